@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// readJSON decodes server payloads, ignoring fields added by newer servers.
+var readJSON = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// session is a seat in a game: what the REST endpoints hand out and the
+// WebSocket needs to connect.
+type session struct {
+	GameID       string
+	PlayerID     string
+	SessionToken string
+	Code         string
+}
+
+// apiClient talks to the server's REST endpoints.
+type apiClient struct {
+	base string
+	http *http.Client
+}
+
+func newAPIClient(base string) *apiClient {
+	return &apiClient{
+		base: strings.TrimRight(base, "/"),
+		http: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// createGame opens a new lobby with name as its host.
+func (c *apiClient) createGame(name string) (*session, error) {
+	var resp catanv1.CreateGameResponse
+	if err := c.post("/api/games", name, &resp); err != nil {
+		return nil, err
+	}
+	return &session{GameID: resp.GameId, PlayerID: resp.PlayerId, SessionToken: resp.SessionToken, Code: resp.Code}, nil
+}
+
+// joinGame takes a seat in the lobby with the given join code.
+func (c *apiClient) joinGame(code, name string) (*session, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	var resp catanv1.JoinGameResponse
+	if err := c.post("/api/games/"+url.PathEscape(code)+"/join", name, &resp); err != nil {
+		return nil, err
+	}
+	return &session{GameID: resp.GameId, PlayerID: resp.PlayerId, SessionToken: resp.SessionToken, Code: code}, nil
+}
+
+func (c *apiClient) post(path, playerName string, out proto.Message) error {
+	body, err := json.Marshal(map[string]string{"playerName": playerName})
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Post(c.base+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return readJSON.Unmarshal(data, out)
+}
+
+// wsURL turns the server's base URL into the WebSocket URL for token.
+func wsURL(base, token string) (string, error) {
+	u, err := url.Parse(strings.TrimRight(base, "/"))
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http", "":
+		u.Scheme = "ws"
+	}
+	u.Path += "/ws"
+	u.RawQuery = url.Values{"token": {token}}.Encode()
+	return u.String(), nil
+}
+
+// conn is a game's WebSocket connection. Messages use the same envelope as
+// the web client: {"message": {"oneofKind": kind, kind: payload}}.
+type conn struct {
+	ws *websocket.Conn
+}
+
+func dial(base, token string) (*conn, error) {
+	target, err := wsURL(base, token)
+	if err != nil {
+		return nil, err
+	}
+	ws, _, err := websocket.DefaultDialer.Dial(target, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{ws: ws}, nil
+}
+
+// send writes a ClientMessage of the given oneof kind.
+func (c *conn) send(kind string, payload proto.Message) error {
+	payloadJSON, err := protojson.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	envelope := map[string]map[string]json.RawMessage{
+		"message": {"oneofKind": json.RawMessage(fmt.Sprintf("%q", kind)), kind: payloadJSON},
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return c.ws.WriteMessage(websocket.TextMessage, data)
+}
+
+// serverMessage is one decoded ServerMessage. Only the field for Kind is set.
+type serverMessage struct {
+	Kind        string
+	State       *catanv1.GameState
+	GameOver    *catanv1.GameOverPayload
+	Error       *catanv1.ErrorPayload
+	Chat        []*catanv1.ChatMessagePayload
+	ChatHistory bool
+}
+
+// read blocks for the next server message. Kinds the CLI does not know are
+// returned with only Kind set.
+func (c *conn) read() (*serverMessage, error) {
+	_, data, err := c.ws.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Message map[string]json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("malformed server message: %w", err)
+	}
+	msg := &serverMessage{}
+	if err := json.Unmarshal(envelope.Message["oneofKind"], &msg.Kind); err != nil {
+		return nil, fmt.Errorf("malformed server message: %w", err)
+	}
+	payload := envelope.Message[msg.Kind]
+	switch msg.Kind {
+	case "gameState":
+		var p catanv1.GameStatePayload
+		err = readJSON.Unmarshal(payload, &p)
+		msg.State = p.State
+	case "gameOver":
+		msg.GameOver = &catanv1.GameOverPayload{}
+		err = readJSON.Unmarshal(payload, msg.GameOver)
+	case "error":
+		msg.Error = &catanv1.ErrorPayload{}
+		err = readJSON.Unmarshal(payload, msg.Error)
+	case "chatMessage":
+		var line catanv1.ChatMessagePayload
+		err = readJSON.Unmarshal(payload, &line)
+		msg.Chat = []*catanv1.ChatMessagePayload{&line}
+	case "chatHistory":
+		var history catanv1.ChatHistoryPayload
+		err = readJSON.Unmarshal(payload, &history)
+		msg.Chat, msg.ChatHistory = history.Messages, true
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed %s payload: %w", msg.Kind, err)
+	}
+	return msg, nil
+}
+
+func (c *conn) close() error {
+	_ = c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return c.ws.Close()
+}
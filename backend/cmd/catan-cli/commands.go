@@ -0,0 +1,410 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+const helpText = `views:
+  board | players | hand | trades | spots <q,r> | help | quit
+lobby:
+  ready | unready | start
+turn:
+  roll | phase trade|build | end
+  build road|settlement|city <v:N or e:N>
+  trade 8 wood for 2 ore [and ...]           bank or port trade
+  offer 1 wood 1 brick for 1 ore [to Bob]    trade with players
+  accept <n> | reject <n>                    answer trade [n] from "trades"
+  robber <q,r> [steal <name>] | steal <name>
+  discard 2 wood 1 ore
+  buy | play knight | play roads | play monopoly <res> | play plenty <res> <res>
+  takeback | approve | deny                  undo your last build or bank trade
+chat:
+  say <text> | whisper <name> <text>`
+
+// outgoing is a ClientMessage to send, by oneof kind.
+type outgoing struct {
+	kind    string
+	payload proto.Message
+}
+
+// command is a parsed input line: messages to send to the server, or a
+// local view to print.
+type command struct {
+	send []outgoing
+	view string
+	hex  *catanv1.HexCoord
+}
+
+func sendOne(kind string, payload proto.Message) *command {
+	return &command{send: []outgoing{{kind, payload}}}
+}
+
+var errUsage = errors.New(`unrecognised command, type "help" for the list`)
+
+// parseCommand turns an input line into a command. state and l resolve
+// player names, trade numbers and vertex and edge labels; state may be nil
+// before the first update arrives.
+func parseCommand(line string, state *catanv1.GameState, l *labels) (*command, error) {
+	fields := strings.Fields(strings.ReplaceAll(line, ",", ", "))
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.Trim(f, ","); f != "" {
+			words = append(words, strings.ToLower(f))
+		}
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+	verb, args := words[0], words[1:]
+
+	switch verb {
+	case "help", "?":
+		return &command{view: "help"}, nil
+	case "board", "players", "hand", "trades":
+		return &command{view: verb}, nil
+	case "quit", "exit":
+		return &command{view: "quit"}, nil
+	case "spots":
+		hex, err := parseHexArg(line)
+		if err != nil {
+			return nil, err
+		}
+		return &command{view: "spots", hex: hex}, nil
+
+	case "ready", "unready":
+		return sendOne("playerReady", &catanv1.PlayerReadyMessage{Ready: verb == "ready"}), nil
+	case "start":
+		return sendOne("startGame", &catanv1.StartGameMessage{}), nil
+	case "roll":
+		return sendOne("rollDice", &catanv1.RollDiceMessage{}), nil
+	case "end":
+		return sendOne("endTurn", &catanv1.EndTurnMessage{}), nil
+	case "buy":
+		return sendOne("buyDevCard", &catanv1.BuyDevCardMessage{}), nil
+	case "takeback":
+		return sendOne("requestTakeback", &catanv1.RequestTakebackMessage{}), nil
+	case "approve", "deny":
+		return sendOne("respondTakeback", &catanv1.RespondTakebackMessage{Approve: verb == "approve"}), nil
+	case "phase":
+		phases := map[string]catanv1.TurnPhase{"trade": catanv1.TurnPhase_TURN_PHASE_TRADE, "build": catanv1.TurnPhase_TURN_PHASE_BUILD}
+		if len(args) != 1 || phases[args[0]] == catanv1.TurnPhase_TURN_PHASE_UNSPECIFIED {
+			return nil, errors.New("usage: phase trade|build")
+		}
+		return sendOne("setTurnPhase", &catanv1.SetTurnPhaseMessage{Phase: phases[args[0]]}), nil
+
+	case "build":
+		return parseBuild(args, l)
+	case "trade":
+		return parseBankTrade(args)
+	case "offer":
+		return parseOffer(args, state)
+	case "accept", "reject":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("usage: %s <trade number>", verb)
+		}
+		trade, err := tradeByNumber(state, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return sendOne("respondTrade", &catanv1.RespondTradeMessage{TradeId: trade.Id, Accept: verb == "accept"}), nil
+	case "robber":
+		return parseRobber(line, args, state)
+	case "steal":
+		if len(args) != 1 {
+			return nil, errors.New("usage: steal <name>")
+		}
+		victim, err := playerIDByName(state, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return sendOne("moveRobber", &catanv1.MoveRobberMessage{VictimId: &victim}), nil
+	case "discard":
+		rc, err := parseResourceCount(args)
+		if err != nil {
+			return nil, err
+		}
+		return sendOne("discardCards", &catanv1.DiscardCardsMessage{Resources: rc}), nil
+	case "play":
+		return parsePlay(args)
+
+	case "say":
+		text := restAfter(line, 1)
+		if text == "" {
+			return nil, errors.New("usage: say <text>")
+		}
+		return sendOne("sendChat", &catanv1.SendChatMessage{Text: text}), nil
+	case "whisper":
+		text := restAfter(line, 2)
+		if text == "" {
+			return nil, errors.New("usage: whisper <name> <text>")
+		}
+		recipient, err := playerIDByName(state, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		return sendOne("sendChat", &catanv1.SendChatMessage{Text: text, RecipientId: &recipient}), nil
+	}
+	return nil, errUsage
+}
+
+func parseBuild(args []string, l *labels) (*command, error) {
+	structures := map[string]catanv1.StructureType{
+		"road":       catanv1.StructureType_STRUCTURE_TYPE_ROAD,
+		"settlement": catanv1.StructureType_STRUCTURE_TYPE_SETTLEMENT,
+		"city":       catanv1.StructureType_STRUCTURE_TYPE_CITY,
+	}
+	if len(args) != 2 || structures[args[0]] == catanv1.StructureType_STRUCTURE_TYPE_UNSPECIFIED {
+		return nil, errors.New("usage: build road|settlement|city <v:N or e:N>")
+	}
+	if l == nil {
+		return nil, errors.New("no board yet")
+	}
+	location, ok := l.resolve(args[1])
+	if !ok {
+		return nil, fmt.Errorf("unknown location %q, see \"board\" or \"spots\"", args[1])
+	}
+	return sendOne("buildStructure", &catanv1.BuildStructureMessage{StructureType: structures[args[0]], Location: location}), nil
+}
+
+// parseBankTrade reads "8 wood for 2 ore", with further lines joined by
+// "and" as in "4 wood for ore and 2 sheep for brick".
+func parseBankTrade(args []string) (*command, error) {
+	const usage = "usage: trade 4 wood for ore [and 2 sheep for 1 brick]"
+	msg := &catanv1.BankTradeMessage{}
+	for len(args) > 0 {
+		var part []string
+		part, args, _ = splitOn(args, "and")
+		give, get, ok := splitOn(part, "for")
+		if !ok || len(give) != 2 || len(get) == 0 || len(get) > 2 {
+			return nil, errors.New(usage)
+		}
+		giveCount, err := strconv.Atoi(give[0])
+		if err != nil || giveCount <= 0 {
+			return nil, errors.New(usage)
+		}
+		getCount := 1
+		if len(get) == 2 {
+			if getCount, err = strconv.Atoi(get[0]); err != nil || getCount <= 0 {
+				return nil, errors.New(usage)
+			}
+		}
+		offering, err := parseResource(give[1])
+		if err != nil {
+			return nil, err
+		}
+		requested, err := parseResource(get[len(get)-1])
+		if err != nil {
+			return nil, err
+		}
+		msg.Lines = append(msg.Lines, &catanv1.BankTradeLine{
+			Offering:       offering,
+			OfferingCount:  int32(giveCount),
+			Requested:      requested,
+			RequestedCount: int32(getCount),
+		})
+	}
+	if len(msg.Lines) == 0 {
+		return nil, errors.New(usage)
+	}
+	return sendOne("bankTrade", msg), nil
+}
+
+// parseOffer reads "1 wood 1 brick for 1 ore [to bob]".
+func parseOffer(args []string, state *catanv1.GameState) (*command, error) {
+	give, rest, ok := splitOn(args, "for")
+	if !ok {
+		return nil, errors.New("usage: offer <cards> for <cards> [to <name>]")
+	}
+	get, target, toPlayer := splitOn(rest, "to")
+	msg := &catanv1.ProposeTradeMessage{}
+	var err error
+	if msg.Offering, err = parseResourceCount(give); err != nil {
+		return nil, err
+	}
+	if msg.Requesting, err = parseResourceCount(get); err != nil {
+		return nil, err
+	}
+	if toPlayer {
+		if len(target) != 1 {
+			return nil, errors.New("usage: offer <cards> for <cards> [to <name>]")
+		}
+		id, err := playerIDByName(state, target[0])
+		if err != nil {
+			return nil, err
+		}
+		msg.TargetId = &id
+	}
+	return sendOne("proposeTrade", msg), nil
+}
+
+// parseRobber reads "robber q,r [steal name]". A steal is a second message
+// because the server expects the move and the steal as separate steps.
+func parseRobber(line string, args []string, state *catanv1.GameState) (*command, error) {
+	hex, err := parseHexArg(line)
+	if err != nil {
+		return nil, err
+	}
+	cmd := sendOne("moveRobber", &catanv1.MoveRobberMessage{Hex: hex})
+	if _, victim, ok := splitOn(args, "steal"); ok {
+		if len(victim) != 1 {
+			return nil, errors.New("usage: robber <q,r> [steal <name>]")
+		}
+		id, err := playerIDByName(state, victim[0])
+		if err != nil {
+			return nil, err
+		}
+		cmd.send = append(cmd.send, outgoing{"moveRobber", &catanv1.MoveRobberMessage{VictimId: &id}})
+	}
+	return cmd, nil
+}
+
+func parsePlay(args []string) (*command, error) {
+	if len(args) == 0 {
+		return nil, errors.New("usage: play knight|roads|monopoly <res>|plenty <res> <res>")
+	}
+	msg := &catanv1.PlayDevCardMessage{}
+	switch args[0] {
+	case "knight":
+		msg.CardType = catanv1.DevCardType_DEV_CARD_TYPE_KNIGHT
+	case "roads", "road":
+		msg.CardType = catanv1.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING
+	case "monopoly":
+		if len(args) != 2 {
+			return nil, errors.New("usage: play monopoly <res>")
+		}
+		r, err := parseResource(args[1])
+		if err != nil {
+			return nil, err
+		}
+		msg.CardType, msg.TargetResource = catanv1.DevCardType_DEV_CARD_TYPE_MONOPOLY, &r
+	case "plenty":
+		if len(args) != 3 {
+			return nil, errors.New("usage: play plenty <res> <res>")
+		}
+		msg.CardType = catanv1.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY
+		for _, name := range args[1:] {
+			r, err := parseResource(name)
+			if err != nil {
+				return nil, err
+			}
+			msg.Resources = append(msg.Resources, r)
+		}
+	default:
+		return nil, fmt.Errorf("unknown card %q", args[0])
+	}
+	return sendOne("playDevCard", msg), nil
+}
+
+// parseResourceCount reads counted resources such as "4 wood 1 ore". A
+// resource without a count counts once.
+func parseResourceCount(words []string) (*catanv1.ResourceCount, error) {
+	if len(words) == 0 {
+		return nil, errors.New("no resources given")
+	}
+	rc := &catanv1.ResourceCount{}
+	for i := 0; i < len(words); i++ {
+		n := int32(1)
+		if v, err := strconv.Atoi(words[i]); err == nil {
+			if v <= 0 || i+1 == len(words) {
+				return nil, fmt.Errorf("expected a resource after %q", words[i])
+			}
+			n = int32(v)
+			i++
+		}
+		r, err := parseResource(words[i])
+		if err != nil {
+			return nil, err
+		}
+		switch r {
+		case catanv1.Resource_RESOURCE_WOOD:
+			rc.Wood += n
+		case catanv1.Resource_RESOURCE_BRICK:
+			rc.Brick += n
+		case catanv1.Resource_RESOURCE_SHEEP:
+			rc.Sheep += n
+		case catanv1.Resource_RESOURCE_WHEAT:
+			rc.Wheat += n
+		case catanv1.Resource_RESOURCE_ORE:
+			rc.Ore += n
+		}
+	}
+	return rc, nil
+}
+
+var resourceNames = map[string]catanv1.Resource{
+	"wood": catanv1.Resource_RESOURCE_WOOD, "lumber": catanv1.Resource_RESOURCE_WOOD,
+	"brick": catanv1.Resource_RESOURCE_BRICK,
+	"sheep": catanv1.Resource_RESOURCE_SHEEP, "wool": catanv1.Resource_RESOURCE_SHEEP,
+	"wheat": catanv1.Resource_RESOURCE_WHEAT, "grain": catanv1.Resource_RESOURCE_WHEAT,
+	"ore": catanv1.Resource_RESOURCE_ORE,
+}
+
+func parseResource(word string) (catanv1.Resource, error) {
+	if r, ok := resourceNames[word]; ok {
+		return r, nil
+	}
+	return catanv1.Resource_RESOURCE_UNSPECIFIED, fmt.Errorf("unknown resource %q", word)
+}
+
+// parseHexArg finds the "q,r" coordinate in line.
+func parseHexArg(line string) (*catanv1.HexCoord, error) {
+	for _, field := range strings.Fields(line) {
+		qs, rs, ok := strings.Cut(field, ",")
+		if !ok {
+			continue
+		}
+		q, errQ := strconv.Atoi(qs)
+		r, errR := strconv.Atoi(rs)
+		if errQ == nil && errR == nil {
+			return &catanv1.HexCoord{Q: int32(q), R: int32(r)}, nil
+		}
+	}
+	return nil, errors.New("expected a hex as q,r, e.g. 1,-1")
+}
+
+func playerIDByName(state *catanv1.GameState, name string) (string, error) {
+	if player := findPlayerByName(state, name); player != nil {
+		return player.Id, nil
+	}
+	return "", fmt.Errorf("no player named %q", name)
+}
+
+func tradeByNumber(state *catanv1.GameState, number string) (*catanv1.TradeOffer, error) {
+	trades := pendingTrades(state)
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 || n > len(trades) {
+		return nil, fmt.Errorf("no trade [%s], see \"trades\"", number)
+	}
+	return trades[n-1], nil
+}
+
+// restAfter returns line without its first n words.
+func restAfter(line string, n int) string {
+	rest := strings.TrimSpace(line)
+	for range n {
+		i := strings.IndexFunc(rest, unicode.IsSpace)
+		if i < 0 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[i:])
+	}
+	return rest
+}
+
+// splitOn splits words around the first occurrence of sep.
+func splitOn(words []string, sep string) (before, after []string, found bool) {
+	for i, w := range words {
+		if w == sep {
+			return words[:i], words[i+1:], true
+		}
+	}
+	return words, nil, false
+}
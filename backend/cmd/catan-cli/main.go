@@ -0,0 +1,209 @@
+// Command catan-cli plays a game from a terminal. It creates or joins a
+// lobby over the REST API, connects to the game's WebSocket and reads
+// commands from stdin; type "help" once connected for the list.
+//
+//	catan-cli -name Alice -create
+//	catan-cli -name Bob -join ABC123
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func main() {
+	server := flag.String("server", "http://localhost:8080", "server base URL")
+	name := flag.String("name", "", "player name")
+	create := flag.Bool("create", false, "create a new game")
+	join := flag.String("join", "", "join the game with this code")
+	noColor := flag.Bool("no-color", os.Getenv("NO_COLOR") != "", "disable ANSI colours")
+	flag.Parse()
+
+	if *name == "" || *create == (*join != "") {
+		fmt.Fprintln(os.Stderr, "usage: catan-cli -name <name> (-create | -join <code>) [-server URL]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	if err := run(*server, *name, *join, palette{enabled: !*noColor}, os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run takes a seat, then relays stdin commands to the game and prints what
+// the server sends back until stdin closes, "quit" or the connection drops.
+func run(server, name, code string, p palette, in io.Reader, out io.Writer) error {
+	api := newAPIClient(server)
+	var seat *session
+	var err error
+	if code == "" {
+		seat, err = api.createGame(name)
+	} else {
+		seat, err = api.joinGame(code, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to take a seat: %w", err)
+	}
+	c, err := dial(server, seat.SessionToken)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.close()
+
+	fmt.Fprintf(out, "seated as %s in game %s, join code %s\n", name, seat.GameID, p.bold(seat.Code))
+	fmt.Fprintln(out, `type "help" for commands`)
+	v := &view{out: out, me: seat.PlayerID, palette: p}
+
+	done := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := c.read()
+			if err != nil {
+				done <- err
+				return
+			}
+			v.show(msg)
+		}
+	}()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			return fmt.Errorf("connection closed: %w", err)
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			quit, err := v.execute(line, c)
+			if err != nil {
+				v.printf("%s\n", p.paint("31", err.Error()))
+			}
+			if quit {
+				return nil
+			}
+		}
+	}
+}
+
+// view holds the latest game state and prints to out. Server messages and
+// stdin commands arrive on different goroutines, so access is locked.
+type view struct {
+	mu      sync.Mutex
+	out     io.Writer
+	me      string
+	palette palette
+	state   *catanv1.GameState
+	labels  *labels
+}
+
+func (v *view) printf(format string, args ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	fmt.Fprintf(v.out, format, args...)
+}
+
+// show prints a server message. The board is drawn whenever the game's
+// status changes and the players are listed on every lobby update;
+// otherwise a state update prints just the status line.
+func (v *view) show(msg *serverMessage) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	p := v.palette
+	switch msg.Kind {
+	case "gameState":
+		if msg.State == nil {
+			return
+		}
+		prev := v.state
+		v.state = msg.State
+		if v.labels == nil || prev == nil || len(prev.GetBoard().GetVertices()) != len(msg.State.GetBoard().GetVertices()) {
+			v.labels = newLabels(msg.State.Board)
+		}
+		waiting := msg.State.Status == catanv1.GameStatus_GAME_STATUS_WAITING
+		statusChanged := prev == nil || prev.Status != msg.State.Status
+		if statusChanged && !waiting {
+			renderBoard(v.out, v.state, v.labels, p)
+		}
+		if statusChanged || waiting {
+			renderPlayers(v.out, v.state, v.me, p)
+		}
+		renderStatus(v.out, v.state, v.me, p)
+	case "error":
+		fmt.Fprintf(v.out, "%s %s\n", p.paint("31", "error:"), msg.Error.GetMessage())
+	case "gameOver":
+		fmt.Fprintf(v.out, "%s %s wins\n", p.bold("game over:"), p.player(v.state, msg.GameOver.WinnerId))
+		for _, s := range msg.GameOver.Scores {
+			name := s.PlayerId
+			if player := findPlayer(v.state, s.PlayerId); player != nil {
+				name = player.Name
+			}
+			fmt.Fprintf(v.out, "  %s %d VP\n", p.playerText(v.state, s.PlayerId, fmt.Sprintf("%-20s", name)), s.Points)
+		}
+	case "chatMessage", "chatHistory":
+		for _, line := range msg.Chat {
+			prefix := p.player(v.state, line.SenderId)
+			if line.RecipientId != nil {
+				prefix = fmt.Sprintf("%s -> %s", prefix, p.player(v.state, *line.RecipientId))
+			}
+			fmt.Fprintf(v.out, "[chat] %s: %s\n", prefix, line.Text)
+		}
+	}
+}
+
+// execute runs one input line, reporting whether the user asked to quit.
+func (v *view) execute(line string, c *conn) (quit bool, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	cmd, err := parseCommand(line, v.state, v.labels)
+	if err != nil || cmd == nil {
+		return false, err
+	}
+	if len(cmd.send) > 0 {
+		for _, msg := range cmd.send {
+			if err := c.send(msg.kind, msg.payload); err != nil {
+				return false, fmt.Errorf("failed to send %s: %w", msg.kind, err)
+			}
+		}
+		return false, nil
+	}
+
+	if cmd.view == "quit" {
+		return true, nil
+	}
+	if cmd.view == "help" {
+		fmt.Fprintln(v.out, helpText)
+		return false, nil
+	}
+	if v.state == nil {
+		return false, errors.New("no game state yet")
+	}
+	switch cmd.view {
+	case "board":
+		renderBoard(v.out, v.state, v.labels, v.palette)
+	case "players":
+		renderPlayers(v.out, v.state, v.me, v.palette)
+	case "hand":
+		renderHand(v.out, v.state, v.me)
+	case "trades":
+		renderTrades(v.out, v.state, v.palette)
+	case "spots":
+		return false, renderSpots(v.out, v.state, cmd.hex, v.labels, v.palette)
+	}
+	return false, nil
+}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// palette wraps text in ANSI colours, or leaves it alone when colour is off.
+type palette struct {
+	enabled bool
+}
+
+func (p palette) paint(code, text string) string {
+	if !p.enabled || code == "" {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+func (p palette) bold(text string) string { return p.paint("1", text) }
+
+func (p palette) player(state *catanv1.GameState, playerID string) string {
+	player := findPlayer(state, playerID)
+	if player == nil {
+		return playerID
+	}
+	return p.paint(playerColorCodes[player.Color], player.Name)
+}
+
+// playerText paints text, such as a padded name, in playerID's colour.
+func (p palette) playerText(state *catanv1.GameState, playerID, text string) string {
+	player := findPlayer(state, playerID)
+	if player == nil {
+		return text
+	}
+	return p.paint(playerColorCodes[player.Color], text)
+}
+
+var playerColorCodes = map[catanv1.PlayerColor]string{
+	catanv1.PlayerColor_PLAYER_COLOR_RED:    "31",
+	catanv1.PlayerColor_PLAYER_COLOR_BLUE:   "34",
+	catanv1.PlayerColor_PLAYER_COLOR_GREEN:  "32",
+	catanv1.PlayerColor_PLAYER_COLOR_ORANGE: "33",
+}
+
+var tiles = map[catanv1.TileResource]struct{ abbr, color string }{
+	catanv1.TileResource_TILE_RESOURCE_WOOD:   {"Wd", "32"},
+	catanv1.TileResource_TILE_RESOURCE_BRICK:  {"Br", "31"},
+	catanv1.TileResource_TILE_RESOURCE_SHEEP:  {"Sh", "92"},
+	catanv1.TileResource_TILE_RESOURCE_WHEAT:  {"Wh", "33"},
+	catanv1.TileResource_TILE_RESOURCE_ORE:    {"Or", "90"},
+	catanv1.TileResource_TILE_RESOURCE_DESERT: {"De", ""},
+}
+
+// labels gives vertices and edges short names players can type: v:N and
+// e:N, numbered in ID order so every client of a game agrees on them.
+type labels struct {
+	byID map[string]string
+	toID map[string]string
+}
+
+func newLabels(board *catanv1.BoardState) *labels {
+	l := &labels{byID: map[string]string{}, toID: map[string]string{}}
+	add := func(prefix string, ids []string) {
+		sort.Strings(ids)
+		for i, id := range ids {
+			label := prefix + strconv.Itoa(i+1)
+			l.byID[id], l.toID[label] = label, id
+		}
+	}
+	var vertices, edges []string
+	for _, v := range board.GetVertices() {
+		vertices = append(vertices, v.Id)
+	}
+	for _, e := range board.GetEdges() {
+		edges = append(edges, e.Id)
+	}
+	add("v:", vertices)
+	add("e:", edges)
+	return l
+}
+
+// label returns the short name for a vertex or edge ID.
+func (l *labels) label(id string) string {
+	if label, ok := l.byID[id]; ok {
+		return label
+	}
+	return id
+}
+
+// resolve turns a short name, or a raw ID, into a vertex or edge ID.
+func (l *labels) resolve(name string) (string, bool) {
+	if id, ok := l.toID[strings.ToLower(name)]; ok {
+		return id, true
+	}
+	_, ok := l.byID[name]
+	return name, ok
+}
+
+// renderBoard draws the hexes as rows of two-line cells, resource and number
+// over the axial coordinate, with the robber marked by *. Structures and
+// ports are listed below it by label.
+func renderBoard(w io.Writer, state *catanv1.GameState, l *labels, p palette) {
+	board := state.GetBoard()
+	rows := map[int32][]*catanv1.Hex{}
+	minX, minR, maxR := int32(math.MaxInt32), int32(math.MaxInt32), int32(math.MinInt32)
+	for _, h := range board.GetHexes() {
+		c := h.GetCoord()
+		rows[c.R] = append(rows[c.R], h)
+		minX = min(minX, 2*c.Q+c.R)
+		minR, maxR = min(minR, c.R), max(maxR, c.R)
+	}
+	robber := board.GetRobberHex()
+	for r := minR; r <= maxR; r++ {
+		row := rows[r]
+		sort.Slice(row, func(i, j int) bool { return row[i].Coord.Q < row[j].Coord.Q })
+		var top, bottom strings.Builder
+		width := 0
+		for _, h := range row {
+			col := int(2*h.Coord.Q+h.Coord.R-minX) * 4
+			pad := strings.Repeat(" ", col-width)
+			tile := tiles[h.Resource]
+			number := "  "
+			if h.Number > 0 {
+				number = fmt.Sprintf("%2d", h.Number)
+			}
+			mark := " "
+			if robber != nil && robber.Q == h.Coord.Q && robber.R == h.Coord.R {
+				mark = "*"
+			}
+			top.WriteString(pad + " " + p.paint(tile.color, fmt.Sprintf("%-2s", tile.abbr)) + " " + number + mark + " ")
+			bottom.WriteString(pad + fmt.Sprintf(" %-7s", fmt.Sprintf("%d,%d", h.Coord.Q, h.Coord.R)))
+			width = col + 8
+		}
+		fmt.Fprintln(w, top.String())
+		fmt.Fprintln(w, bottom.String())
+	}
+
+	for _, player := range state.Players {
+		var settlements, cities, roads []string
+		for _, v := range board.GetVertices() {
+			if b := v.Building; b != nil && b.OwnerId == player.Id {
+				if b.Type == catanv1.BuildingType_BUILDING_TYPE_CITY {
+					cities = append(cities, l.label(v.Id))
+				} else {
+					settlements = append(settlements, l.label(v.Id))
+				}
+			}
+		}
+		for _, e := range board.GetEdges() {
+			if e.Road != nil && e.Road.OwnerId == player.Id {
+				roads = append(roads, l.label(e.Id))
+			}
+		}
+		if len(settlements)+len(cities)+len(roads) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s: settlements %s; cities %s; roads %s\n", p.player(state, player.Id),
+			listOrNone(settlements), listOrNone(cities), listOrNone(roads))
+	}
+	for _, port := range board.GetPorts() {
+		kind := "3:1"
+		if port.Type == catanv1.PortType_PORT_TYPE_SPECIFIC {
+			kind = "2:1 " + resourceName(port.Resource)
+		}
+		at := make([]string, len(port.Location))
+		for i, id := range port.Location {
+			at[i] = l.label(id)
+		}
+		fmt.Fprintf(w, "port %s at %s\n", kind, strings.Join(at, " "))
+	}
+}
+
+// renderSpots lists the corners and sides of the hex at coord with their
+// labels, clockwise from the top, so players can find what to build on.
+func renderSpots(w io.Writer, state *catanv1.GameState, coord *catanv1.HexCoord, l *labels, p palette) error {
+	type corner struct {
+		vertex *catanv1.Vertex
+		angle  float64
+	}
+	var corners []corner
+	for _, v := range state.GetBoard().GetVertices() {
+		for _, h := range v.AdjacentHexes {
+			if h.Q != coord.Q || h.R != coord.R {
+				continue
+			}
+			vq, vr, err := parseVertexID(v.Id)
+			if err != nil {
+				return err
+			}
+			dq, dr := vq-float64(coord.Q), vr-float64(coord.R)
+			x, y := math.Sqrt(3)*(dq+dr/2), 1.5*dr
+			// Clockwise on screen from straight up, where y grows downwards.
+			angle := math.Mod(math.Atan2(x, -y)+2*math.Pi, 2*math.Pi)
+			corners = append(corners, corner{vertex: v, angle: angle})
+		}
+	}
+	if len(corners) == 0 {
+		return fmt.Errorf("no hex at %d,%d", coord.Q, coord.R)
+	}
+	sort.Slice(corners, func(i, j int) bool { return corners[i].angle < corners[j].angle })
+	names := []string{"top", "upper right", "lower right", "bottom", "lower left", "upper left"}
+
+	edges := map[string]*catanv1.Edge{}
+	for _, e := range state.GetBoard().GetEdges() {
+		if len(e.Vertices) == 2 {
+			edges[e.Vertices[0]+" "+e.Vertices[1]] = e
+			edges[e.Vertices[1]+" "+e.Vertices[0]] = e
+		}
+	}
+	for i, c := range corners {
+		name := ""
+		if len(corners) == len(names) {
+			name = names[i]
+		}
+		fmt.Fprintf(w, "%-6s %-12s %s\n", l.label(c.vertex.Id), name, describeBuilding(state, c.vertex.Building, p))
+		next := corners[(i+1)%len(corners)].vertex
+		if e, ok := edges[c.vertex.Id+" "+next.Id]; ok {
+			road := "empty"
+			if e.Road != nil {
+				road = "road of " + p.player(state, e.Road.OwnerId)
+			}
+			fmt.Fprintf(w, "  %-6s %s\n", l.label(e.Id), road)
+		}
+	}
+	return nil
+}
+
+func describeBuilding(state *catanv1.GameState, b *catanv1.Building, p palette) string {
+	switch {
+	case b == nil:
+		return "empty"
+	case b.Type == catanv1.BuildingType_BUILDING_TYPE_CITY:
+		return "city of " + p.player(state, b.OwnerId)
+	default:
+		return "settlement of " + p.player(state, b.OwnerId)
+	}
+}
+
+// parseVertexID reads the axial position a vertex ID encodes, "q,r" with
+// thirds written as decimals.
+func parseVertexID(id string) (q, r float64, err error) {
+	qs, rs, ok := strings.Cut(id, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("malformed vertex ID %q", id)
+	}
+	if q, err = strconv.ParseFloat(qs, 64); err != nil {
+		return 0, 0, fmt.Errorf("malformed vertex ID %q", id)
+	}
+	if r, err = strconv.ParseFloat(rs, 64); err != nil {
+		return 0, 0, fmt.Errorf("malformed vertex ID %q", id)
+	}
+	return q, r, nil
+}
+
+// renderStatus prints whose turn it is and anything the game is waiting on.
+func renderStatus(w io.Writer, state *catanv1.GameState, me string, p palette) {
+	status := strings.TrimPrefix(state.Status.String(), "GAME_STATUS_")
+	line := "game " + strings.ToLower(status)
+	if state.Status == catanv1.GameStatus_GAME_STATUS_SETUP || state.Status == catanv1.GameStatus_GAME_STATUS_PLAYING {
+		current := ""
+		if int(state.CurrentTurn) < len(state.Players) && state.CurrentTurn >= 0 {
+			current = state.Players[state.CurrentTurn].Id
+		}
+		line += ", " + p.player(state, current) + "'s turn"
+		if current == me {
+			line += " " + p.bold("(you)")
+		}
+		if state.Status == catanv1.GameStatus_GAME_STATUS_PLAYING {
+			line += ", phase " + strings.ToLower(strings.TrimPrefix(state.TurnPhase.String(), "TURN_PHASE_"))
+		}
+		if len(state.Dice) == 2 && state.Dice[0] > 0 {
+			line += fmt.Sprintf(", dice %d+%d=%d", state.Dice[0], state.Dice[1], state.Dice[0]+state.Dice[1])
+		}
+	}
+	if state.Paused {
+		line += ", " + p.bold("paused")
+	}
+	fmt.Fprintln(w, line)
+
+	if rp := state.RobberPhase; rp != nil {
+		for _, id := range rp.DiscardPending {
+			fmt.Fprintf(w, "  %s must discard %d cards\n", p.player(state, id), rp.DiscardRequired[id])
+		}
+		if rp.MovePendingPlayerId != nil {
+			fmt.Fprintf(w, "  %s must move the robber\n", p.player(state, *rp.MovePendingPlayerId))
+		}
+		if rp.StealPendingPlayerId != nil {
+			fmt.Fprintf(w, "  %s must choose someone to steal from\n", p.player(state, *rp.StealPendingPlayerId))
+		}
+	}
+	if t := state.PendingTakeback; t != nil {
+		fmt.Fprintf(w, "  %s asks to take back their %s (%d/%d approved)\n", p.player(state, t.RequesterId),
+			t.Action, len(t.ApprovedPlayerIds), len(state.Players)-1)
+	}
+}
+
+// renderPlayers prints one line per player with their public counts.
+func renderPlayers(w io.Writer, state *catanv1.GameState, me string, p palette) {
+	for i, player := range state.Players {
+		marks := []string{}
+		if i == int(state.CurrentTurn) && state.Status != catanv1.GameStatus_GAME_STATUS_WAITING {
+			marks = append(marks, "turn")
+		}
+		if player.IsHost {
+			marks = append(marks, "host")
+		}
+		if state.Status == catanv1.GameStatus_GAME_STATUS_WAITING && player.IsReady {
+			marks = append(marks, "ready")
+		}
+		if state.GetLongestRoadPlayerId() == player.Id {
+			marks = append(marks, "longest road")
+		}
+		if state.GetLargestArmyPlayerId() == player.Id {
+			marks = append(marks, "largest army")
+		}
+		if !player.Connected {
+			marks = append(marks, "offline")
+		}
+		name := player.Name
+		if player.Id == me {
+			name += " (you)"
+		}
+		name = p.playerText(state, player.Id, fmt.Sprintf("%-20s", name))
+		fmt.Fprintf(w, "%s %2d VP  %2d cards  %d dev  %d knights  %s\n", name, player.VictoryPoints,
+			resourceTotal(player.Resources), player.DevCardCount, player.KnightsPlayed, strings.Join(marks, ", "))
+	}
+}
+
+// renderHand prints the resources and development cards of player me.
+func renderHand(w io.Writer, state *catanv1.GameState, me string) {
+	player := findPlayer(state, me)
+	if player == nil {
+		fmt.Fprintln(w, "you have no seat in this game")
+		return
+	}
+	rc := player.GetResources()
+	fmt.Fprintf(w, "hand: %d wood, %d brick, %d sheep, %d wheat, %d ore\n", rc.GetWood(), rc.GetBrick(), rc.GetSheep(), rc.GetWheat(), rc.GetOre())
+	var cards []string
+	for card, count := range player.DevCards {
+		if count > 0 {
+			cards = append(cards, fmt.Sprintf("%d %s", count, devCardName(catanv1.DevCardType(card))))
+		}
+	}
+	sort.Strings(cards)
+	fmt.Fprintf(w, "dev cards: %s\n", listOrNone(cards))
+	if player.RoadBuildingRoadsRemaining > 0 {
+		fmt.Fprintf(w, "free roads to place: %d\n", player.RoadBuildingRoadsRemaining)
+	}
+}
+
+// renderTrades lists pending trade offers, numbered for accept and reject.
+func renderTrades(w io.Writer, state *catanv1.GameState, p palette) {
+	n := 0
+	for _, t := range pendingTrades(state) {
+		n++
+		to := "anyone"
+		if t.TargetId != nil && *t.TargetId != "" {
+			to = p.player(state, *t.TargetId)
+		}
+		fmt.Fprintf(w, "[%d] %s offers %s for %s to %s\n", n, p.player(state, t.ProposerId),
+			formatResources(t.Offering), formatResources(t.Requesting), to)
+	}
+	if n == 0 {
+		fmt.Fprintln(w, "no pending trades")
+	}
+}
+
+func pendingTrades(state *catanv1.GameState) []*catanv1.TradeOffer {
+	var trades []*catanv1.TradeOffer
+	for _, t := range state.GetPendingTrades() {
+		if t.Status == catanv1.TradeStatus_TRADE_STATUS_PENDING {
+			trades = append(trades, t)
+		}
+	}
+	return trades
+}
+
+func formatResources(rc *catanv1.ResourceCount) string {
+	var parts []string
+	for _, r := range []struct {
+		n    int32
+		name string
+	}{{rc.GetWood(), "wood"}, {rc.GetBrick(), "brick"}, {rc.GetSheep(), "sheep"}, {rc.GetWheat(), "wheat"}, {rc.GetOre(), "ore"}} {
+		if r.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", r.n, r.name))
+		}
+	}
+	return listOrNone(parts)
+}
+
+func resourceTotal(rc *catanv1.ResourceCount) int32 {
+	return rc.GetWood() + rc.GetBrick() + rc.GetSheep() + rc.GetWheat() + rc.GetOre()
+}
+
+func resourceName(r catanv1.Resource) string {
+	return strings.ToLower(strings.TrimPrefix(r.String(), "RESOURCE_"))
+}
+
+func devCardName(card catanv1.DevCardType) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(card.String(), "DEV_CARD_TYPE_"), "_", " "))
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+func findPlayer(state *catanv1.GameState, playerID string) *catanv1.PlayerState {
+	for _, p := range state.GetPlayers() {
+		if p.Id == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+func findPlayerByName(state *catanv1.GameState, name string) *catanv1.PlayerState {
+	for _, p := range state.GetPlayers() {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
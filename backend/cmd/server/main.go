@@ -1,36 +1,180 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
+	"github.com/jmoiron/sqlx"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"settlers_from_catan/internal/broker"
+	"settlers_from_catan/internal/config"
 	"settlers_from_catan/internal/db"
 	"settlers_from_catan/internal/handlers"
 	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/notify"
+	"settlers_from_catan/internal/store"
 )
 
 func main() {
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		var err error
+		switch os.Args[1] {
+		case "migrate":
+			err = runMigrate(os.Args[2:])
+		case "upgrade-states":
+			err = runUpgradeStates(os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q (want migrate or upgrade-states)", os.Args[1])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := serve(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// serve runs the server until SIGINT or SIGTERM, then drains it: stop
+// accepting connections, let game commands in flight save, close every
+// WebSocket with a going-away frame and close the stores.
+func serve(cfg *config.Config) error {
+	slog.SetDefault(newLogger(cfg))
+
 	// Initialize database
-	database, err := db.Initialize("./catan.db")
+	database, err := db.Initialize(cfg.DBPath)
 	if err != nil {
-		log.Fatal("Failed to initialize database:", err)
+		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer database.Close()
 
-	// Initialize WebSocket hub
-	h := hub.NewHub()
+	games, err := openGameStore(database, cfg.StorageURL)
+	if err != nil {
+		return fmt.Errorf("failed to open game store: %w", err)
+	}
+	defer games.Close()
+
+	// Initialize WebSocket hub, which reaches the other instances' clients
+	// through the broker when there are peers
+	var peers *broker.Peers
+	var h *hub.Hub
+	if len(cfg.Peers) > 0 {
+		peers = broker.NewPeers(cfg.InstanceURL, cfg.Peers, cfg.BrokerSecret)
+		defer peers.Close()
+		h = hub.NewHubWithBroker(peers)
+		slog.Info("running as one of several instances", "instance", cfg.InstanceURL, "peers", len(cfg.Peers))
+	} else {
+		h = hub.NewHub()
+	}
 	go h.Run()
 
 	// Initialize handlers
-	handler := handlers.NewHandler(database, h)
+	handler := handlers.NewHandlerWithStore(database, games, h)
+	handler.SetAllowedOrigins(cfg.AllowedOrigins)
+	handler.SetAdminToken(cfg.AdminToken)
+	handler.SetChatFilter(cfg.ChatWordFilter)
+	handler.SetStateValidation(handlers.StateValidation(cfg.StateValidation))
+	handler.SetLeaseTTL(cfg.LeaseTTL)
+	if len(cfg.WebhookURLs) > 0 {
+		handler.SetNotifier(notify.Webhooks(cfg.WebhookURLs, cfg.WebhookSecret))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Expire abandoned lobbies and old finished games
+	policy := handlers.RetentionPolicy{LobbyTTL: cfg.LobbyTTL, FinishedRetention: cfg.FinishedGameRetention}
+	slog.Info("retention policy", "lobby_ttl", policy.LobbyTTL.String(), "finished_retention", policy.FinishedRetention.String(), "sweep_interval", cfg.JanitorInterval.String())
+	janitorDone := make(chan struct{})
+	go func() {
+		defer close(janitorDone)
+		handler.RunJanitor(ctx, policy, cfg.JanitorInterval)
+	}()
 
-	// Routes
-	http.HandleFunc("/health", handler.HandleHealth)
-	http.HandleFunc("/ws", handler.HandleWebSocket)
-	http.HandleFunc("/api/games", handler.HandleCreateGame)
-	http.HandleFunc("/api/games/", func(w http.ResponseWriter, r *http.Request) {
+	// Forfeit correspondence turns left past their deadline
+	deadlinesDone := make(chan struct{})
+	go func() {
+		defer close(deadlinesDone)
+		handler.RunDeadlines(ctx, cfg.DeadlineInterval)
+	}()
+
+	// h2c lets gRPC clients reach CatanService over cleartext HTTP/2; TLS
+	// connections negotiate HTTP/2 by themselves.
+	mux := routes(handler, cfg.DevMode)
+	if peers != nil {
+		mux.Handle(broker.PathPrefix, peers)
+	}
+	server := &http.Server{Addr: cfg.Addr, Handler: h2c.NewHandler(mux, &http2.Server{})}
+	server.RegisterOnShutdown(handler.EndSubscriptions)
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "addr", cfg.Addr, "tls", cfg.TLS())
+		if cfg.TLS() {
+			serveErr <- server.ListenAndServeTLS(cfg.TLSCert, cfg.TLSKey)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+	slog.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("http shutdown", "err", err)
+	}
+	if err := handler.Shutdown(shutdownCtx); err != nil {
+		slog.Error("game commands still running at shutdown", "err", err)
+	}
+	<-janitorDone
+	<-deadlinesDone
+	slog.Info("server stopped")
+	return nil
+}
+
+// newLogger writes structured logs to stderr, as JSON unless the config asks
+// for text. log.Printf output from other packages goes through it too.
+func newLogger(cfg *config.Config) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: cfg.LogLevel}
+	if cfg.LogFormat == "text" {
+		return slog.New(slog.NewTextHandler(os.Stderr, opts))
+	}
+	return slog.New(slog.NewJSONHandler(os.Stderr, opts))
+}
+
+func routes(handler *handlers.Handler, devMode bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", handler.HandleHealth)
+	mux.HandleFunc("/metrics", handler.HandleMetrics)
+	mux.HandleFunc("/ws", handler.HandleWebSocket)
+	mux.HandleFunc("/api/games", handler.HandleCreateGame)
+	mux.HandleFunc("/api/games/", func(w http.ResponseWriter, r *http.Request) {
 		// If path ends with /join and is POST, delegate to HandleJoinGame
 		if r.Method == "POST" && len(r.URL.Path) > len("/api/games/") && strings.HasSuffix(r.URL.Path, "/join") {
 			handler.HandleJoinGame(w, r)
@@ -38,17 +182,43 @@ func main() {
 		}
 		handler.HandleGameRoutes(w, r)
 	})
+	mux.HandleFunc("/api/auth/register", handler.HandleRegister)
+	mux.HandleFunc("/api/auth/login", handler.HandleLogin)
+	mux.HandleFunc("/api/auth/logout", handler.HandleLogout)
+	mux.HandleFunc("/api/me/games", handler.HandleMyGames)
+	mux.HandleFunc("/api/leaderboard", handler.HandleLeaderboard)
+	mux.HandleFunc("/api/players/", handler.HandlePlayerStats)
+	mux.HandleFunc("/api/tournaments", handler.HandleTournaments)
+	mux.HandleFunc("/api/tournaments/", handler.HandleTournamentRoutes)
+	mux.HandleFunc("/api/admin/", handler.HandleAdmin)
+	mux.Handle(handler.ConnectHandler())
 
-	// Test endpoints (only available when DEV_MODE=true)
-	if os.Getenv("DEV_MODE") == "true" {
-		log.Println("DEV_MODE enabled - test endpoints available")
-		http.HandleFunc("/test/grant-resources", handler.HandleGrantResources)
-		http.HandleFunc("/test/grant-dev-card", handler.HandleGrantDevCard)
-		http.HandleFunc("/test/force-dice-roll", handler.HandleForceDiceRoll)
-		http.HandleFunc("/test/set-game-state", handler.HandleSetGameState)
+	// Test endpoints (only available in dev mode)
+	if devMode {
+		slog.Warn("DEV_MODE enabled - test endpoints available")
+		mux.HandleFunc("/test/grant-resources", handler.HandleGrantResources)
+		mux.HandleFunc("/test/grant-dev-card", handler.HandleGrantDevCard)
+		mux.HandleFunc("/test/force-dice-roll", handler.HandleForceDiceRoll)
+		mux.HandleFunc("/test/set-game-state", handler.HandleSetGameState)
 	}
+	return mux
+}
 
-	// CORS middleware for development
-	log.Println("Server starting on :8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+// defaultDBPath is the database the maintenance subcommands open unless
+// given -db.
+func defaultDBPath() string {
+	if path := os.Getenv("DB_PATH"); path != "" {
+		return path
+	}
+	return config.Default().DBPath
+}
+
+// openGameStore returns the store games live in: the main database unless
+// storageURL selects another one (sqlite://path, bolt://path or memory://).
+func openGameStore(database *sqlx.DB, storageURL string) (store.GameStore, error) {
+	if storageURL == "" {
+		return store.NewSQLite(database), nil
+	}
+	slog.Info("using game store", "url", storageURL)
+	return store.Open(storageURL)
 }
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"settlers_from_catan/internal/db"
+)
+
+// runMigrate implements `server migrate [-db path] [status|up]`.
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	path := fs.String("db", defaultDBPath(), "database file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: server migrate [-db path] [status|up]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := db.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch cmd := fs.Arg(0); cmd {
+	case "", "status":
+		statuses, err := db.Status(database)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, applied)
+		}
+		return w.Flush()
+	case "up":
+		applied, err := db.Migrate(database)
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+		return nil
+	default:
+		fs.Usage()
+		return errors.New("unknown migrate command " + cmd)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"settlers_from_catan/internal/db"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+// runUpgradeStates implements `server upgrade-states [-db path] [-dry-run]`:
+// it rewrites every stored game to game.CurrentStateVersion and reports the
+// games it could not upgrade.
+func runUpgradeStates(args []string) error {
+	fs := flag.NewFlagSet("upgrade-states", flag.ExitOnError)
+	path := fs.String("db", defaultDBPath(), "database file")
+	dryRun := fs.Bool("dry-run", false, "report what would change without saving")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := db.Initialize(*path)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	games, err := openGameStore(database, os.Getenv("STORAGE_URL"))
+	if err != nil {
+		return err
+	}
+	defer games.Close()
+
+	report, err := store.RewriteGames(games, game.UpgradeState, *dryRun)
+	if err != nil {
+		return err
+	}
+	verb := "upgraded"
+	if *dryRun {
+		verb = "would upgrade"
+	}
+	fmt.Printf("scanned %d games, %s %d to state version %d\n", report.Scanned, verb, report.Rewritten, game.CurrentStateVersion)
+	for _, f := range report.Failures {
+		fmt.Printf("FAILED %s: %v\n", f.GameID, f.Err)
+	}
+	if len(report.Failures) > 0 {
+		return fmt.Errorf("%d games failed to upgrade", len(report.Failures))
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+)
+
+type hexKey struct{ q, r int32 }
+
+func keyOf(c *pb.HexCoord) hexKey { return hexKey{c.GetQ(), c.GetR()} }
+
+// table is one simulated game: its state, lookups over its board and the
+// random source every decision in the game draws from.
+type table struct {
+	state *pb.GameState
+	rng   *rand.Rand
+
+	hexes     map[hexKey]*pb.Hex
+	vertices  map[string]*pb.Vertex
+	edges     []*pb.Edge
+	neighbors map[string][]string     // vertex -> vertices one edge away
+	touching  map[string][]*pb.Edge   // vertex -> edges ending there
+	corners   map[hexKey][]*pb.Vertex // hex -> its vertices
+}
+
+func newTable(state *pb.GameState, rng *rand.Rand) *table {
+	t := &table{
+		state:     state,
+		rng:       rng,
+		hexes:     map[hexKey]*pb.Hex{},
+		vertices:  map[string]*pb.Vertex{},
+		edges:     state.Board.Edges,
+		neighbors: map[string][]string{},
+		touching:  map[string][]*pb.Edge{},
+		corners:   map[hexKey][]*pb.Vertex{},
+	}
+	for _, h := range state.Board.Hexes {
+		t.hexes[keyOf(h.Coord)] = h
+	}
+	for _, v := range state.Board.Vertices {
+		t.vertices[v.Id] = v
+		for _, c := range v.AdjacentHexes {
+			t.corners[keyOf(c)] = append(t.corners[keyOf(c)], v)
+		}
+	}
+	for _, e := range state.Board.Edges {
+		a, b := e.Vertices[0], e.Vertices[1]
+		t.neighbors[a] = append(t.neighbors[a], b)
+		t.neighbors[b] = append(t.neighbors[b], a)
+		t.touching[a] = append(t.touching[a], e)
+		t.touching[b] = append(t.touching[b], e)
+	}
+	return t
+}
+
+// pips is how many of the 36 dice outcomes roll number.
+func pips(number int32) int {
+	if number < 2 || number > 12 || number == 7 {
+		return 0
+	}
+	if number < 7 {
+		return int(number) - 1
+	}
+	return 13 - int(number)
+}
+
+// vertexPips totals the pips of the producing hexes around vertexID,
+// skipping the one the robber sits on.
+func (t *table) vertexPips(vertexID string) int {
+	total := 0
+	robber := keyOf(t.state.Board.RobberHex)
+	for _, c := range t.vertices[vertexID].AdjacentHexes {
+		if keyOf(c) != robber {
+			total += pips(t.hexes[keyOf(c)].GetNumber())
+		}
+	}
+	return total
+}
+
+// productionPips totals vertexPips over every building playerID owns,
+// counting cities twice.
+func (t *table) productionPips(playerID string) int {
+	total := 0
+	for _, v := range t.state.Board.Vertices {
+		if b := v.Building; b != nil && b.OwnerId == playerID {
+			n := t.vertexPips(v.Id)
+			if b.Type == pb.BuildingType_BUILDING_TYPE_CITY {
+				n *= 2
+			}
+			total += n
+		}
+	}
+	return total
+}
+
+// canSettle reports whether playerID could put a settlement on vertexID:
+// it is empty, no neighbour is built on and, outside setup, one of their
+// roads reaches it.
+func (t *table) canSettle(vertexID, playerID string, setup bool) bool {
+	if t.vertices[vertexID].Building != nil {
+		return false
+	}
+	for _, n := range t.neighbors[vertexID] {
+		if t.vertices[n].Building != nil {
+			return false
+		}
+	}
+	if setup {
+		return true
+	}
+	for _, e := range t.touching[vertexID] {
+		if e.Road != nil && e.Road.OwnerId == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// settlementSpots lists the vertices playerID could settle.
+func (t *table) settlementSpots(playerID string, setup bool) []string {
+	var spots []string
+	for _, v := range t.state.Board.Vertices {
+		if t.canSettle(v.Id, playerID, setup) {
+			spots = append(spots, v.Id)
+		}
+	}
+	return spots
+}
+
+// roadSpots lists the empty edges playerID's network reaches. A network
+// does not continue through another player's building.
+func (t *table) roadSpots(playerID string) []*pb.Edge {
+	var spots []*pb.Edge
+	for _, e := range t.edges {
+		if e.Road != nil {
+			continue
+		}
+		for _, id := range e.Vertices {
+			if t.reaches(id, playerID) {
+				spots = append(spots, e)
+				break
+			}
+		}
+	}
+	return spots
+}
+
+func (t *table) reaches(vertexID, playerID string) bool {
+	if b := t.vertices[vertexID].Building; b != nil {
+		return b.OwnerId == playerID
+	}
+	for _, e := range t.touching[vertexID] {
+		if e.Road != nil && e.Road.OwnerId == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// settlements lists the vertices where playerID has a settlement.
+func (t *table) settlements(playerID string) []string {
+	var ids []string
+	for _, v := range t.state.Board.Vertices {
+		if b := v.Building; b != nil && b.OwnerId == playerID && b.Type == pb.BuildingType_BUILDING_TYPE_SETTLEMENT {
+			ids = append(ids, v.Id)
+		}
+	}
+	return ids
+}
+
+func (t *table) player(playerID string) *pb.PlayerState {
+	for _, p := range t.state.Players {
+		if p.Id == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// redNumbersAdjacent reports whether two hexes numbered 6 or 8 touch, which
+// the printed rules avoid.
+func (t *table) redNumbersAdjacent() bool {
+	for _, v := range t.state.Board.Vertices {
+		red := 0
+		for _, c := range v.AdjacentHexes {
+			if n := t.hexes[keyOf(c)].GetNumber(); n == 6 || n == 8 {
+				red++
+			}
+		}
+		if red > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// resourcePips totals the pips on the hexes of each resource.
+func (t *table) resourcePips() map[string]int {
+	totals := map[string]int{}
+	for _, h := range t.state.Board.Hexes {
+		if h.Resource != pb.TileResource_TILE_RESOURCE_DESERT {
+			totals[resourceName(pb.Resource(h.Resource))] += pips(h.Number)
+		}
+	}
+	return totals
+}
+
+// hand returns playerID's resources keyed by tile resource, the shape the
+// game package's cost helpers use.
+func (t *table) hand(playerID string) map[pb.TileResource]int {
+	return game.ResourceCountToMap(t.player(playerID).GetResources())
+}
+
+// resources lists the five tradeable resources. Their values match the
+// corresponding TileResource values.
+var resources = []pb.Resource{
+	pb.Resource_RESOURCE_WOOD,
+	pb.Resource_RESOURCE_BRICK,
+	pb.Resource_RESOURCE_SHEEP,
+	pb.Resource_RESOURCE_WHEAT,
+	pb.Resource_RESOURCE_ORE,
+}
+
+func resourceName(r pb.Resource) string {
+	return strings.ToLower(strings.TrimPrefix(r.String(), "RESOURCE_"))
+}
+
+func resourceCount(r pb.Resource, n int32) *pb.ResourceCount {
+	rc := &pb.ResourceCount{}
+	game.AddResource(rc, r, n)
+	return rc
+}
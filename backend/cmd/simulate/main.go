@@ -0,0 +1,116 @@
+// Command simulate plays complete games between bots with no server or
+// network, straight through the game package, and prints aggregate stats:
+// win rates by seat and policy, game length, how often actions are refused
+// for lack of resources and how fair the generated boards are.
+//
+//	simulate -games 5000 -players 4 -policies greedy,random
+//	simulate -games 1000 -format csv -out run.csv
+//
+// Game i uses seed+i for its board and dice, so a run is reproducible and
+// a suspicious game can be replayed alone with -games 1 -seed <seed>.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"settlers_from_catan/internal/game"
+)
+
+func main() {
+	games := flag.Int("games", 1000, "number of games to play")
+	players := flag.Int("players", 4, "players per game")
+	policyList := flag.String("policies", "greedy", "comma-separated policies, assigned to seats in turn")
+	seed := flag.Int64("seed", 1, "seed of the first game")
+	maxTurns := flag.Int("max-turns", 500, "give up on a game after this many turns")
+	workers := flag.Int("workers", runtime.NumCPU(), "games to play in parallel")
+	format := flag.String("format", "json", "output format: json or csv")
+	outPath := flag.String("out", "", "write the report here instead of stdout")
+	flag.Parse()
+
+	if *players < game.GetMinPlayers() || *players > game.GetMaxPlayers() {
+		log.Fatalf("-players must be between %d and %d", game.GetMinPlayers(), game.GetMaxPlayers())
+	}
+	if *games < 1 || *workers < 1 {
+		log.Fatal("-games and -workers must be positive")
+	}
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("unknown -format %q", *format)
+	}
+	seatNames, err := assignSeats(*policyList, *players)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outcomes := run(*games, *seed, seatNames, *maxTurns, *workers)
+	r := newReport(outcomes, *seed, seatNames)
+
+	var w io.Writer = os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		err = r.writeCSV(w)
+	} else {
+		err = r.writeJSON(w)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// assignSeats deals the listed policies to seats in turn, so "greedy,random"
+// with four players seats greedy, random, greedy, random.
+func assignSeats(list string, players int) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := policies[name]; !ok {
+			return nil, fmt.Errorf("unknown policy %q", name)
+		}
+		names = append(names, name)
+	}
+	seats := make([]string, players)
+	for i := range seats {
+		seats[i] = names[i%len(names)]
+	}
+	return seats, nil
+}
+
+// run plays the games across workers goroutines. Games share no state, so
+// each worker just takes the next seed.
+func run(games int, seed int64, seatNames []string, maxTurns, workers int) []*outcome {
+	outcomes := make([]*outcome, games)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				seats := make([]policy, len(seatNames))
+				for s, name := range seatNames {
+					seats[s] = policies[name]()
+				}
+				outcomes[i] = playGame(seed+int64(i), seats, maxTurns)
+			}
+		}()
+	}
+	for i := range games {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return outcomes
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+)
+
+// maxActionsPerTurn stops a policy that keeps finding things to do, such as
+// trading back and forth, from looping forever. Within a turn an action
+// that failed is not tried again.
+const maxActionsPerTurn = 30
+
+// outcome is what one game contributes to the report.
+type outcome struct {
+	Seed       int64
+	Finished   bool
+	Stuck      string // why the game could not continue, if it stopped early
+	WinnerSeat int    // -1 unless Finished
+	Turns      int32
+	// StartPips is each seat's production after setup.
+	StartPips    []int
+	FinalPoints  []int32
+	RedAdjacent  bool
+	ResourcePips map[string]int
+	Actions      map[actionKind]*actionCounts
+	// Violations counts the states after each step that failed
+	// game.Validate, by rule.
+	Violations map[string]int
+}
+
+// actionCounts tallies what happened when bots tried one kind of action.
+type actionCounts struct {
+	Attempts     int `json:"attempts"`
+	Succeeded    int `json:"succeeded"`
+	Insufficient int `json:"insufficientResources"`
+	Rejected     int `json:"rejected"`
+}
+
+func (c *actionCounts) add(o *actionCounts) {
+	c.Attempts += o.Attempts
+	c.Succeeded += o.Succeeded
+	c.Insufficient += o.Insufficient
+	c.Rejected += o.Rejected
+}
+
+// playGame runs one game from lobby to finish with seats[i] playing seat i.
+// Everything random in the game, including the board, follows from seed.
+func playGame(seed int64, seats []policy, maxTurns int) *outcome {
+	names := make([]string, len(seats))
+	ids := make([]string, len(seats))
+	for i := range seats {
+		names[i] = fmt.Sprintf("bot%d", i+1)
+		ids[i] = fmt.Sprintf("p%d", i+1)
+	}
+	state := game.NewGameStateFromSeed(fmt.Sprintf("sim-%d", seed), "SIMUL8", names, ids, seed)
+	t := newTable(state, rand.New(rand.NewSource(seed)))
+	out := &outcome{
+		Seed:         seed,
+		WinnerSeat:   -1,
+		RedAdjacent:  t.redNumbersAdjacent(),
+		ResourcePips: t.resourcePips(),
+		Actions:      map[actionKind]*actionCounts{},
+		Violations:   map[string]int{},
+	}
+	for _, kind := range actionKinds {
+		out.Actions[kind] = &actionCounts{}
+	}
+	d := &driver{t: t, seats: seats, out: out}
+
+	if err := d.setup(); err != nil {
+		out.Stuck = err.Error()
+		return out
+	}
+	for _, id := range ids {
+		out.StartPips = append(out.StartPips, t.productionPips(id))
+	}
+	for state.Status == pb.GameStatus_GAME_STATUS_PLAYING && int(state.TurnCounter) < maxTurns {
+		if err := d.turn(); err != nil {
+			out.Stuck = err.Error()
+			break
+		}
+	}
+
+	out.Turns = state.TurnCounter
+	for _, p := range state.Players {
+		out.FinalPoints = append(out.FinalPoints, int32(game.CalculatePlayerVictoryPoints(state, p.Id)))
+	}
+	if state.Status == pb.GameStatus_GAME_STATUS_FINISHED {
+		out.Finished = true
+		if winner, ok := game.DetermineWinner(state); ok {
+			for i, p := range state.Players {
+				if p.Id == winner {
+					out.WinnerSeat = i
+				}
+			}
+		}
+	}
+	return out
+}
+
+type driver struct {
+	t     *table
+	seats []policy
+	out   *outcome
+}
+
+// check validates the state after a step and counts what it breaks.
+func (d *driver) check() {
+	for _, v := range game.Validate(d.t.state) {
+		d.out.Violations[v.Rule]++
+	}
+}
+
+func (d *driver) current() (string, policy) {
+	i := d.t.state.CurrentTurn
+	return d.t.state.Players[i].Id, d.seats[i]
+}
+
+// setup readies every seat, starts the game and plays the snake-order
+// placements.
+func (d *driver) setup() error {
+	state := d.t.state
+	for _, p := range state.Players {
+		if err := game.SetPlayerReady(state, p.Id, true); err != nil {
+			return fmt.Errorf("ready: %w", err)
+		}
+	}
+	if err := game.StartGame(state, state.Players[0].Id); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	for state.Status == pb.GameStatus_GAME_STATUS_SETUP {
+		playerID, p := d.current()
+		settlement, err := firstAccepted(p.SetupSettlement(d.t, playerID), func(id string) error {
+			return game.PlaceSetupSettlement(state, playerID, id)
+		})
+		if err != nil {
+			return fmt.Errorf("setup settlement: %w", err)
+		}
+		d.check()
+		if _, err := firstAccepted(p.SetupRoad(d.t, playerID, settlement), func(id string) error {
+			return game.PlaceSetupRoad(state, playerID, id)
+		}); err != nil {
+			return fmt.Errorf("setup road: %w", err)
+		}
+		d.check()
+	}
+	return nil
+}
+
+// firstAccepted tries choices in order and returns the first one place
+// accepts.
+func firstAccepted[T any](choices []T, place func(T) error) (T, error) {
+	err := errors.New("no choices")
+	for _, c := range choices {
+		if err = place(c); err == nil {
+			return c, nil
+		}
+	}
+	var none T
+	return none, err
+}
+
+// turn plays the current seat's turn: roll, resolve any robber, run the
+// policy's plan and end the turn.
+func (d *driver) turn() error {
+	state, rng := d.t.state, d.t.rng
+	playerID, p := d.current()
+	if _, err := game.PerformDiceRollWithValues(state, playerID, rng.Intn(6)+1, rng.Intn(6)+1); err != nil {
+		return fmt.Errorf("roll: %w", err)
+	}
+	d.check()
+	if err := d.robber(playerID, p); err != nil {
+		return err
+	}
+
+	tried := map[string]bool{}
+	for n := 0; n < maxActionsPerTurn && state.Status == pb.GameStatus_GAME_STATUS_PLAYING; n++ {
+		acted := false
+		for _, a := range p.Plan(d.t, playerID) {
+			if tried[a.key()] {
+				continue
+			}
+			if d.try(playerID, a) {
+				acted = true
+				break
+			}
+			tried[a.key()] = true
+		}
+		if !acted {
+			break
+		}
+	}
+	if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+		return nil
+	}
+	if err := game.EndTurn(state, playerID); err != nil {
+		return fmt.Errorf("end turn: %w", err)
+	}
+	d.check()
+	return nil
+}
+
+// robber resolves a 7: every seat over the limit discards, then the roller
+// moves the robber and steals.
+func (d *driver) robber(roller string, p policy) error {
+	state := d.t.state
+	if state.RobberPhase == nil {
+		return nil
+	}
+	for _, id := range append([]string(nil), state.RobberPhase.DiscardPending...) {
+		seat := d.seatOf(id)
+		count := int(state.RobberPhase.DiscardRequired[id])
+		if err := game.DiscardCards(state, id, seat.Discard(d.t, id, count)); err != nil {
+			return fmt.Errorf("discard: %w", err)
+		}
+		d.check()
+	}
+	if _, err := firstAccepted(p.Robber(d.t, roller), func(hex *pb.HexCoord) error {
+		return game.MoveRobber(state, roller, hex)
+	}); err != nil {
+		return fmt.Errorf("move robber: %w", err)
+	}
+	d.check()
+	if state.RobberPhase == nil {
+		return nil
+	}
+	var victims []string
+	for _, v := range state.Board.Vertices {
+		if b := v.Building; b != nil && b.OwnerId != roller && !slices.Contains(victims, b.OwnerId) && touches(v, state.Board.RobberHex) && handSize(d.t.player(b.OwnerId)) > 0 {
+			victims = append(victims, b.OwnerId)
+		}
+	}
+	if len(victims) == 0 {
+		return errors.New("steal pending with nobody to rob")
+	}
+	if _, err := game.StealFromPlayer(state, roller, p.Victim(d.t, roller, victims), d.t.rng.Intn); err != nil {
+		return fmt.Errorf("steal: %w", err)
+	}
+	d.check()
+	if state.RobberPhase != nil {
+		return errors.New("robber phase did not resolve")
+	}
+	return nil
+}
+
+func (d *driver) seatOf(playerID string) policy {
+	for i, p := range d.t.state.Players {
+		if p.Id == playerID {
+			return d.seats[i]
+		}
+	}
+	return nil
+}
+
+// try performs a and records the result, reporting whether it succeeded.
+func (d *driver) try(playerID string, a action) bool {
+	state := d.t.state
+	var err error
+	switch a.kind {
+	case actBankTrade:
+		if err = game.SetTurnPhase(state, playerID, pb.TurnPhase_TURN_PHASE_TRADE); err == nil {
+			err = game.BankTrade(state, playerID, resourceCount(a.give, a.count), a.get)
+		}
+	case actSettlement, actCity, actRoad:
+		if err = game.SetTurnPhase(state, playerID, pb.TurnPhase_TURN_PHASE_BUILD); err != nil {
+			break
+		}
+		switch a.kind {
+		case actSettlement:
+			err = game.PlaceSettlement(state, playerID, a.target)
+		case actCity:
+			err = game.PlaceCity(state, playerID, a.target)
+		default:
+			err = game.PlaceRoad(state, playerID, a.target)
+		}
+	case actBuyDevCard:
+		_, err = game.BuyDevCard(state, playerID)
+	case actPlayCard:
+		err = game.PlayDevCard(state, playerID, a.card, a.monopoly, a.resources)
+	}
+
+	counts := d.out.Actions[a.kind]
+	counts.Attempts++
+	switch {
+	case err == nil:
+		counts.Succeeded++
+		d.check()
+	case errors.Is(err, game.ErrInsufficientResources):
+		counts.Insufficient++
+	default:
+		counts.Rejected++
+	}
+	return err == nil
+}
+
+func touches(v *pb.Vertex, hex *pb.HexCoord) bool {
+	for _, c := range v.AdjacentHexes {
+		if c.Q == hex.Q && c.R == hex.R {
+			return true
+		}
+	}
+	return false
+}
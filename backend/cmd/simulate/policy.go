@@ -0,0 +1,388 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+)
+
+// policy decides for one bot. Each method returns its choices best-first;
+// the driver tries them in order until the game accepts one, so a policy
+// may propose moves the rules turn down.
+type policy interface {
+	SetupSettlement(t *table, playerID string) []string
+	SetupRoad(t *table, playerID, settlementID string) []string
+	// Discard picks count cards to give up after a 7.
+	Discard(t *table, playerID string, count int) *pb.ResourceCount
+	Robber(t *table, playerID string) []*pb.HexCoord
+	Victim(t *table, playerID string, victims []string) string
+	// Plan lists what to try next this turn. The driver calls it again
+	// after every action that succeeds.
+	Plan(t *table, playerID string) []action
+}
+
+// policies maps the names accepted by -policies to their constructors.
+var policies = map[string]func() policy{
+	"greedy": func() policy { return greedy{} },
+	"random": func() policy { return random{} },
+}
+
+type actionKind string
+
+const (
+	actBankTrade  actionKind = "bankTrade"
+	actSettlement actionKind = "settlement"
+	actCity       actionKind = "city"
+	actRoad       actionKind = "road"
+	actBuyDevCard actionKind = "buyDevCard"
+	actPlayCard   actionKind = "playDevCard"
+)
+
+// actionKinds orders the kinds for reporting.
+var actionKinds = []actionKind{actBankTrade, actSettlement, actCity, actRoad, actBuyDevCard, actPlayCard}
+
+// action is one move a policy wants to make during its turn.
+type action struct {
+	kind   actionKind
+	target string // vertex or edge ID for builds
+
+	give  pb.Resource // bank trades
+	count int32
+	get   pb.Resource
+
+	card      pb.DevCardType
+	monopoly  *pb.Resource
+	resources []pb.Resource // year of plenty
+}
+
+// key identifies an action so the driver can skip ones that already failed
+// this turn.
+func (a action) key() string {
+	return fmt.Sprintf("%s/%s/%d/%d/%d/%d", a.kind, a.target, a.give, a.get, a.card, len(a.resources))
+}
+
+// discardMost gives up cards from the largest piles first, which every
+// policy here uses.
+func discardMost(t *table, playerID string, count int) *pb.ResourceCount {
+	hand := t.player(playerID).GetResources()
+	have := map[pb.Resource]int32{}
+	for _, r := range resources {
+		have[r] = game.GetResourceAmount(hand, r)
+	}
+	out := &pb.ResourceCount{}
+	for ; count > 0; count-- {
+		best := resources[0]
+		for _, r := range resources {
+			if have[r] > have[best] {
+				best = r
+			}
+		}
+		have[best]--
+		game.AddResource(out, best, 1)
+	}
+	return out
+}
+
+// greedy builds whatever scores the most points soonest: cities, then
+// settlements on the richest spots, then roads towards them, then dev
+// cards. It trades with the bank to finish its first goal and always
+// robs the leader.
+type greedy struct{}
+
+func (greedy) SetupSettlement(t *table, playerID string) []string {
+	spots := t.settlementSpots(playerID, true)
+	sort.SliceStable(spots, func(i, j int) bool {
+		return spotScore(t, playerID, spots[i]) > spotScore(t, playerID, spots[j])
+	})
+	return spots
+}
+
+// spotScore rates a settlement spot by its pips, with a bonus for
+// resources playerID does not produce yet.
+func spotScore(t *table, playerID, vertexID string) int {
+	produced := map[pb.TileResource]bool{}
+	for _, v := range t.state.Board.Vertices {
+		if b := v.Building; b != nil && b.OwnerId == playerID {
+			for _, c := range v.AdjacentHexes {
+				produced[t.hexes[keyOf(c)].Resource] = true
+			}
+		}
+	}
+	score := t.vertexPips(vertexID) * 2
+	for _, c := range t.vertices[vertexID].AdjacentHexes {
+		h := t.hexes[keyOf(c)]
+		if h.Resource != pb.TileResource_TILE_RESOURCE_DESERT && !produced[h.Resource] {
+			score += 3
+			produced[h.Resource] = true
+		}
+	}
+	return score
+}
+
+// SetupRoad points the road at the best spot still open two steps away.
+func (greedy) SetupRoad(t *table, playerID, settlementID string) []string {
+	edges := t.touching[settlementID]
+	score := func(e *pb.Edge) int {
+		far := e.Vertices[0]
+		if far == settlementID {
+			far = e.Vertices[1]
+		}
+		best := 0
+		for _, n := range t.neighbors[far] {
+			if n != settlementID && t.canSettle(n, playerID, true) {
+				best = max(best, t.vertexPips(n))
+			}
+		}
+		return best
+	}
+	sorted := append([]*pb.Edge(nil), edges...)
+	sort.SliceStable(sorted, func(i, j int) bool { return score(sorted[i]) > score(sorted[j]) })
+	ids := make([]string, len(sorted))
+	for i, e := range sorted {
+		ids[i] = e.Id
+	}
+	return ids
+}
+
+func (greedy) Discard(t *table, playerID string, count int) *pb.ResourceCount {
+	return discardMost(t, playerID, count)
+}
+
+// Robber ranks hexes by how much production they take from opponents,
+// leaving out hexes playerID builds on.
+func (greedy) Robber(t *table, playerID string) []*pb.HexCoord {
+	type scored struct {
+		coord *pb.HexCoord
+		score int
+	}
+	var hexes []scored
+	for _, h := range t.state.Board.Hexes {
+		score := 0
+		for _, v := range t.corners[keyOf(h.Coord)] {
+			b := v.Building
+			if b == nil {
+				continue
+			}
+			weight := 1
+			if b.Type == pb.BuildingType_BUILDING_TYPE_CITY {
+				weight = 2
+			}
+			if b.OwnerId == playerID {
+				weight = -10
+			}
+			score += weight * pips(h.Number)
+		}
+		hexes = append(hexes, scored{h.Coord, score})
+	}
+	sort.SliceStable(hexes, func(i, j int) bool { return hexes[i].score > hexes[j].score })
+	out := make([]*pb.HexCoord, len(hexes))
+	for i, h := range hexes {
+		out[i] = h.coord
+	}
+	return out
+}
+
+// Victim robs whoever has the most victory points, then the most cards.
+func (greedy) Victim(t *table, playerID string, victims []string) string {
+	best := victims[0]
+	for _, id := range victims[1:] {
+		a, b := t.player(id), t.player(best)
+		if a.VictoryPoints > b.VictoryPoints ||
+			(a.VictoryPoints == b.VictoryPoints && handSize(a) > handSize(b)) {
+			best = id
+		}
+	}
+	return best
+}
+
+func handSize(p *pb.PlayerState) int32 {
+	r := p.GetResources()
+	return r.GetWood() + r.GetBrick() + r.GetSheep() + r.GetWheat() + r.GetOre()
+}
+
+func (g greedy) Plan(t *table, playerID string) []action {
+	var plan []action
+	if card, ok := g.card(t, playerID); ok {
+		plan = append(plan, card)
+	}
+
+	type goal struct {
+		building string
+		act      action
+	}
+	var goals []goal
+	if cities := t.settlements(playerID); len(cities) > 0 {
+		sort.SliceStable(cities, func(i, j int) bool { return t.vertexPips(cities[i]) > t.vertexPips(cities[j]) })
+		goals = append(goals, goal{"city", action{kind: actCity, target: cities[0]}})
+	}
+	if spots := t.settlementSpots(playerID, false); len(spots) > 0 {
+		sort.SliceStable(spots, func(i, j int) bool {
+			return spotScore(t, playerID, spots[i]) > spotScore(t, playerID, spots[j])
+		})
+		goals = append(goals, goal{"settlement", action{kind: actSettlement, target: spots[0]}})
+	} else if road, ok := g.road(t, playerID); ok {
+		goals = append(goals, goal{"road", road})
+	}
+	goals = append(goals, goal{"development_card", action{kind: actBuyDevCard}})
+
+	// Trade towards the first goal we cannot already afford, then try every
+	// goal in order; the ones we cannot afford fail cheaply.
+	hand := t.hand(playerID)
+	for _, gl := range goals {
+		if game.CanAfford(hand, gl.building) {
+			continue
+		}
+		if trade, ok := tradeToward(t, playerID, hand, game.GetBuildingCosts(gl.building)); ok {
+			plan = append(plan, trade)
+		}
+		break
+	}
+	for _, gl := range goals {
+		plan = append(plan, gl.act)
+	}
+	return plan
+}
+
+// road picks the free edge whose far end leads to the richest open spot.
+func (greedy) road(t *table, playerID string) (action, bool) {
+	best, bestScore := "", -1
+	for _, e := range t.roadSpots(playerID) {
+		score := 0
+		for _, id := range e.Vertices {
+			if !t.reaches(id, playerID) && t.canSettle(id, playerID, true) {
+				score = max(score, t.vertexPips(id))
+			}
+			for _, n := range t.neighbors[id] {
+				if t.canSettle(n, playerID, true) {
+					score = max(score, t.vertexPips(n)/2)
+				}
+			}
+		}
+		if score > bestScore {
+			best, bestScore = e.Id, score
+		}
+	}
+	return action{kind: actRoad, target: best}, best != ""
+}
+
+// card picks a development card worth playing now, if any.
+func (g greedy) card(t *table, playerID string) (action, bool) {
+	p := t.player(playerID)
+	has := func(c pb.DevCardType) bool {
+		return p.DevCards[int32(c)] > 0 && p.DevCardsPurchasedTurn[int32(c)] != t.state.TurnCounter
+	}
+	switch {
+	case has(pb.DevCardType_DEV_CARD_TYPE_KNIGHT):
+		return action{kind: actPlayCard, card: pb.DevCardType_DEV_CARD_TYPE_KNIGHT}, true
+	case has(pb.DevCardType_DEV_CARD_TYPE_MONOPOLY):
+		best, most := resources[0], int32(0)
+		for _, r := range resources {
+			total := int32(0)
+			for _, other := range t.state.Players {
+				if other.Id != playerID {
+					total += game.GetResourceAmount(other.Resources, r)
+				}
+			}
+			if total > most {
+				best, most = r, total
+			}
+		}
+		if most >= 3 {
+			return action{kind: actPlayCard, card: pb.DevCardType_DEV_CARD_TYPE_MONOPOLY, monopoly: &best}, true
+		}
+	case has(pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY):
+		return action{
+			kind:      actPlayCard,
+			card:      pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY,
+			resources: []pb.Resource{pb.Resource_RESOURCE_WHEAT, pb.Resource_RESOURCE_ORE},
+		}, true
+	case has(pb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING):
+		if _, ok := g.road(t, playerID); ok && p.RoadBuildingRoadsRemaining == 0 {
+			return action{kind: actPlayCard, card: pb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING}, true
+		}
+	}
+	return action{}, false
+}
+
+// tradeToward finds one bank trade that brings hand closer to cost without
+// spending anything cost needs.
+func tradeToward(t *table, playerID string, hand, cost map[pb.TileResource]int) (action, bool) {
+	for _, want := range resources {
+		if hand[pb.TileResource(want)] >= cost[pb.TileResource(want)] {
+			continue
+		}
+		for _, give := range resources {
+			ratio := game.GetBestTradeRatio(playerID, give, t.state.Board)
+			if hand[pb.TileResource(give)]-cost[pb.TileResource(give)] >= ratio {
+				return action{kind: actBankTrade, give: give, count: int32(ratio), get: want}, true
+			}
+		}
+	}
+	return action{}, false
+}
+
+// random picks uniformly among the moves it can see. It is the baseline
+// the other policies should beat.
+type random struct{}
+
+func (random) SetupSettlement(t *table, playerID string) []string {
+	return shuffled(t, t.settlementSpots(playerID, true))
+}
+
+func (random) SetupRoad(t *table, playerID, settlementID string) []string {
+	var ids []string
+	for _, e := range t.touching[settlementID] {
+		ids = append(ids, e.Id)
+	}
+	return shuffled(t, ids)
+}
+
+func (random) Discard(t *table, playerID string, count int) *pb.ResourceCount {
+	return discardMost(t, playerID, count)
+}
+
+func (random) Robber(t *table, playerID string) []*pb.HexCoord {
+	hexes := make([]*pb.HexCoord, len(t.state.Board.Hexes))
+	for i, h := range t.state.Board.Hexes {
+		hexes[i] = h.Coord
+	}
+	t.rng.Shuffle(len(hexes), func(i, j int) { hexes[i], hexes[j] = hexes[j], hexes[i] })
+	return hexes
+}
+
+func (random) Victim(t *table, playerID string, victims []string) string {
+	return victims[t.rng.Intn(len(victims))]
+}
+
+func (random) Plan(t *table, playerID string) []action {
+	var plan []action
+	if spots := t.settlements(playerID); len(spots) > 0 {
+		plan = append(plan, action{kind: actCity, target: spots[t.rng.Intn(len(spots))]})
+	}
+	if spots := t.settlementSpots(playerID, false); len(spots) > 0 {
+		plan = append(plan, action{kind: actSettlement, target: spots[t.rng.Intn(len(spots))]})
+	}
+	if edges := t.roadSpots(playerID); len(edges) > 0 {
+		plan = append(plan, action{kind: actRoad, target: edges[t.rng.Intn(len(edges))].Id})
+	}
+	plan = append(plan, action{kind: actBuyDevCard})
+	hand := t.hand(playerID)
+	for _, give := range resources {
+		if ratio := game.GetBestTradeRatio(playerID, give, t.state.Board); hand[pb.TileResource(give)] >= ratio {
+			get := resources[t.rng.Intn(len(resources))]
+			if get != give {
+				plan = append(plan, action{kind: actBankTrade, give: give, count: int32(ratio), get: get})
+			}
+			break
+		}
+	}
+	t.rng.Shuffle(len(plan), func(i, j int) { plan[i], plan[j] = plan[j], plan[i] })
+	return plan
+}
+
+func shuffled(t *table, ids []string) []string {
+	t.rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// report aggregates every game of a run.
+type report struct {
+	Games     int      `json:"games"`
+	Seed      int64    `json:"seed"`
+	Policies  []string `json:"policies"` // by seat
+	Finished  int      `json:"finished"`
+	TurnLimit int      `json:"hitTurnLimit"`
+	Stuck     int      `json:"stuck"`
+	// StuckReasons counts why games stopped early; each is a bug in the
+	// game package or a policy.
+	StuckReasons map[string]int `json:"stuckReasons,omitempty"`
+	// Violations counts game.Validate failures after each step, by rule.
+	// Anything here is a bug.
+	Violations map[string]int `json:"violations,omitempty"`
+
+	WinsBySeat      []int          `json:"winsBySeat"`
+	WinRateBySeat   []float64      `json:"winRateBySeat"`
+	WinsByPolicy    map[string]int `json:"winsByPolicy"`
+	FirstSeatMargin float64        `json:"firstSeatAdvantage"` // seat 1 win rate over a fair share
+
+	Turns summary `json:"turns"` // finished games only
+
+	Actions map[actionKind]*actionCounts `json:"actions"`
+	// InsufficientRate is the share of each kind's attempts refused with
+	// ErrInsufficientResources.
+	InsufficientRate map[actionKind]float64 `json:"insufficientRate"`
+
+	Boards boardReport `json:"boards"`
+}
+
+type summary struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P10    float64 `json:"p10"`
+	P90    float64 `json:"p90"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+type boardReport struct {
+	// RedAdjacentRate is the share of boards where a 6 and an 8, or two of
+	// either, touch.
+	RedAdjacentRate float64 `json:"redNumbersAdjacentRate"`
+	// MeanResourcePips is the average number of pips on each resource's hexes.
+	MeanResourcePips map[string]float64 `json:"meanResourcePips"`
+	// RichestStartWinRate is how often the seat with the most production
+	// after setup went on to win, among finished games.
+	RichestStartWinRate float64 `json:"richestStartWinRate"`
+	// StartPipsBySeat is the mean production after setup for each seat.
+	StartPipsBySeat []float64 `json:"startPipsBySeat"`
+}
+
+func newReport(games []*outcome, seed int64, seatNames []string) *report {
+	seats := len(seatNames)
+	r := &report{
+		Games:            len(games),
+		Seed:             seed,
+		Policies:         seatNames,
+		StuckReasons:     map[string]int{},
+		Violations:       map[string]int{},
+		WinsBySeat:       make([]int, seats),
+		WinRateBySeat:    make([]float64, seats),
+		WinsByPolicy:     map[string]int{},
+		Actions:          map[actionKind]*actionCounts{},
+		InsufficientRate: map[actionKind]float64{},
+		Boards: boardReport{
+			MeanResourcePips: map[string]float64{},
+			StartPipsBySeat:  make([]float64, seats),
+		},
+	}
+	for _, kind := range actionKinds {
+		r.Actions[kind] = &actionCounts{}
+	}
+
+	var turns []float64
+	var red, richestWins, started int
+	for _, g := range games {
+		for kind, c := range g.Actions {
+			r.Actions[kind].add(c)
+		}
+		for rule, n := range g.Violations {
+			r.Violations[rule] += n
+		}
+		if g.RedAdjacent {
+			red++
+		}
+		for res, n := range g.ResourcePips {
+			r.Boards.MeanResourcePips[res] += float64(n)
+		}
+		if len(g.StartPips) == seats {
+			started++
+			for i, n := range g.StartPips {
+				r.Boards.StartPipsBySeat[i] += float64(n)
+			}
+		}
+
+		switch {
+		case g.Stuck != "":
+			r.Stuck++
+			r.StuckReasons[g.Stuck]++
+		case !g.Finished:
+			r.TurnLimit++
+		default:
+			r.Finished++
+			turns = append(turns, float64(g.Turns))
+			if g.WinnerSeat >= 0 {
+				r.WinsBySeat[g.WinnerSeat]++
+				r.WinsByPolicy[seatNames[g.WinnerSeat]]++
+				if richestSeat(g.StartPips) == g.WinnerSeat {
+					richestWins++
+				}
+			}
+		}
+	}
+
+	for i, wins := range r.WinsBySeat {
+		r.WinRateBySeat[i] = ratio(wins, r.Finished)
+	}
+	if seats > 0 {
+		r.FirstSeatMargin = r.WinRateBySeat[0] - 1/float64(seats)
+	}
+	r.Turns = summarize(turns)
+	for kind, c := range r.Actions {
+		r.InsufficientRate[kind] = ratio(c.Insufficient, c.Attempts)
+	}
+	r.Boards.RedAdjacentRate = ratio(red, len(games))
+	r.Boards.RichestStartWinRate = ratio(richestWins, r.Finished)
+	for res := range r.Boards.MeanResourcePips {
+		r.Boards.MeanResourcePips[res] /= float64(max(len(games), 1))
+	}
+	for i := range r.Boards.StartPipsBySeat {
+		r.Boards.StartPipsBySeat[i] /= float64(max(started, 1))
+	}
+	return r
+}
+
+// richestSeat is the seat with the most pips, or -1 on a tie.
+func richestSeat(pips []int) int {
+	best, seat := -1, -1
+	for i, n := range pips {
+		switch {
+		case n > best:
+			best, seat = n, i
+		case n == best:
+			seat = -1
+		}
+	}
+	return seat
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func summarize(xs []float64) summary {
+	if len(xs) == 0 {
+		return summary{}
+	}
+	sort.Float64s(xs)
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+	at := func(q float64) float64 { return xs[int(q*float64(len(xs)-1))] }
+	return summary{
+		Mean:   total / float64(len(xs)),
+		Median: at(0.5),
+		P10:    at(0.1),
+		P90:    at(0.9),
+		Min:    xs[0],
+		Max:    xs[len(xs)-1],
+	}
+}
+
+func (r *report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// writeCSV flattens the report into metric,value rows so runs can be
+// appended to a spreadsheet and compared.
+func (r *report) writeCSV(w io.Writer) error {
+	rows := [][]string{{"metric", "value"}}
+	add := func(metric string, v any) {
+		var s string
+		switch v := v.(type) {
+		case float64:
+			s = strconv.FormatFloat(v, 'f', 4, 64)
+		default:
+			s = fmt.Sprint(v)
+		}
+		rows = append(rows, []string{metric, s})
+	}
+
+	add("games", r.Games)
+	add("seed", r.Seed)
+	add("finished", r.Finished)
+	add("hit_turn_limit", r.TurnLimit)
+	add("stuck", r.Stuck)
+	rules := make([]string, 0, len(r.Violations))
+	for rule := range r.Violations {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		add("violations_"+rule, r.Violations[rule])
+	}
+	for i, p := range r.Policies {
+		add(fmt.Sprintf("seat%d_policy", i+1), p)
+		add(fmt.Sprintf("seat%d_wins", i+1), r.WinsBySeat[i])
+		add(fmt.Sprintf("seat%d_win_rate", i+1), r.WinRateBySeat[i])
+		add(fmt.Sprintf("seat%d_start_pips", i+1), r.Boards.StartPipsBySeat[i])
+	}
+	add("first_seat_advantage", r.FirstSeatMargin)
+	add("turns_mean", r.Turns.Mean)
+	add("turns_median", r.Turns.Median)
+	add("turns_p10", r.Turns.P10)
+	add("turns_p90", r.Turns.P90)
+	add("turns_min", r.Turns.Min)
+	add("turns_max", r.Turns.Max)
+	for _, kind := range actionKinds {
+		c := r.Actions[kind]
+		add(fmt.Sprintf("%s_attempts", kind), c.Attempts)
+		add(fmt.Sprintf("%s_succeeded", kind), c.Succeeded)
+		add(fmt.Sprintf("%s_insufficient", kind), c.Insufficient)
+		add(fmt.Sprintf("%s_rejected", kind), c.Rejected)
+		add(fmt.Sprintf("%s_insufficient_rate", kind), r.InsufficientRate[kind])
+	}
+	add("board_red_adjacent_rate", r.Boards.RedAdjacentRate)
+	add("board_richest_start_win_rate", r.Boards.RichestStartWinRate)
+	names := make([]string, 0, len(r.Boards.MeanResourcePips))
+	for res := range r.Boards.MeanResourcePips {
+		names = append(names, res)
+	}
+	sort.Strings(names)
+	for _, res := range names {
+		add(fmt.Sprintf("board_%s_pips", res), r.Boards.MeanResourcePips[res])
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: catan/v1/service.proto
+
+package catanv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	v1 "settlers_from_catan/gen/proto/catan/v1"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CatanServiceName is the fully-qualified name of the CatanService service.
+	CatanServiceName = "catan.v1.CatanService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CatanServiceCreateGameProcedure is the fully-qualified name of the CatanService's CreateGame RPC.
+	CatanServiceCreateGameProcedure = "/catan.v1.CatanService/CreateGame"
+	// CatanServiceJoinGameProcedure is the fully-qualified name of the CatanService's JoinGame RPC.
+	CatanServiceJoinGameProcedure = "/catan.v1.CatanService/JoinGame"
+	// CatanServiceGetGameProcedure is the fully-qualified name of the CatanService's GetGame RPC.
+	CatanServiceGetGameProcedure = "/catan.v1.CatanService/GetGame"
+	// CatanServiceGetPendingActionsProcedure is the fully-qualified name of the CatanService's
+	// GetPendingActions RPC.
+	CatanServiceGetPendingActionsProcedure = "/catan.v1.CatanService/GetPendingActions"
+	// CatanServiceSubscribeProcedure is the fully-qualified name of the CatanService's Subscribe RPC.
+	CatanServiceSubscribeProcedure = "/catan.v1.CatanService/Subscribe"
+	// CatanServicePlayerReadyProcedure is the fully-qualified name of the CatanService's PlayerReady
+	// RPC.
+	CatanServicePlayerReadyProcedure = "/catan.v1.CatanService/PlayerReady"
+	// CatanServiceStartGameProcedure is the fully-qualified name of the CatanService's StartGame RPC.
+	CatanServiceStartGameProcedure = "/catan.v1.CatanService/StartGame"
+	// CatanServiceRollDiceProcedure is the fully-qualified name of the CatanService's RollDice RPC.
+	CatanServiceRollDiceProcedure = "/catan.v1.CatanService/RollDice"
+	// CatanServiceBuildStructureProcedure is the fully-qualified name of the CatanService's
+	// BuildStructure RPC.
+	CatanServiceBuildStructureProcedure = "/catan.v1.CatanService/BuildStructure"
+	// CatanServiceProposeTradeProcedure is the fully-qualified name of the CatanService's ProposeTrade
+	// RPC.
+	CatanServiceProposeTradeProcedure = "/catan.v1.CatanService/ProposeTrade"
+	// CatanServiceRespondTradeProcedure is the fully-qualified name of the CatanService's RespondTrade
+	// RPC.
+	CatanServiceRespondTradeProcedure = "/catan.v1.CatanService/RespondTrade"
+	// CatanServiceBankTradeProcedure is the fully-qualified name of the CatanService's BankTrade RPC.
+	CatanServiceBankTradeProcedure = "/catan.v1.CatanService/BankTrade"
+	// CatanServiceMoveRobberProcedure is the fully-qualified name of the CatanService's MoveRobber RPC.
+	CatanServiceMoveRobberProcedure = "/catan.v1.CatanService/MoveRobber"
+	// CatanServiceDiscardCardsProcedure is the fully-qualified name of the CatanService's DiscardCards
+	// RPC.
+	CatanServiceDiscardCardsProcedure = "/catan.v1.CatanService/DiscardCards"
+	// CatanServiceBuyDevCardProcedure is the fully-qualified name of the CatanService's BuyDevCard RPC.
+	CatanServiceBuyDevCardProcedure = "/catan.v1.CatanService/BuyDevCard"
+	// CatanServicePlayDevCardProcedure is the fully-qualified name of the CatanService's PlayDevCard
+	// RPC.
+	CatanServicePlayDevCardProcedure = "/catan.v1.CatanService/PlayDevCard"
+	// CatanServiceSetTurnPhaseProcedure is the fully-qualified name of the CatanService's SetTurnPhase
+	// RPC.
+	CatanServiceSetTurnPhaseProcedure = "/catan.v1.CatanService/SetTurnPhase"
+	// CatanServiceEndTurnProcedure is the fully-qualified name of the CatanService's EndTurn RPC.
+	CatanServiceEndTurnProcedure = "/catan.v1.CatanService/EndTurn"
+	// CatanServiceSendChatProcedure is the fully-qualified name of the CatanService's SendChat RPC.
+	CatanServiceSendChatProcedure = "/catan.v1.CatanService/SendChat"
+	// CatanServiceMuteChatProcedure is the fully-qualified name of the CatanService's MuteChat RPC.
+	CatanServiceMuteChatProcedure = "/catan.v1.CatanService/MuteChat"
+	// CatanServiceSetChatSettingsProcedure is the fully-qualified name of the CatanService's
+	// SetChatSettings RPC.
+	CatanServiceSetChatSettingsProcedure = "/catan.v1.CatanService/SetChatSettings"
+	// CatanServiceRequestTakebackProcedure is the fully-qualified name of the CatanService's
+	// RequestTakeback RPC.
+	CatanServiceRequestTakebackProcedure = "/catan.v1.CatanService/RequestTakeback"
+	// CatanServiceRespondTakebackProcedure is the fully-qualified name of the CatanService's
+	// RespondTakeback RPC.
+	CatanServiceRespondTakebackProcedure = "/catan.v1.CatanService/RespondTakeback"
+	// CatanServiceApproveExportProcedure is the fully-qualified name of the CatanService's
+	// ApproveExport RPC.
+	CatanServiceApproveExportProcedure = "/catan.v1.CatanService/ApproveExport"
+)
+
+// CatanServiceClient is a client for the catan.v1.CatanService service.
+type CatanServiceClient interface {
+	CreateGame(context.Context, *connect.Request[v1.CreateGameRequest]) (*connect.Response[v1.CreateGameResponse], error)
+	JoinGame(context.Context, *connect.Request[v1.JoinGameRequest]) (*connect.Response[v1.JoinGameResponse], error)
+	GetGame(context.Context, *connect.Request[v1.GetGameRequest]) (*connect.Response[v1.GameInfoResponse], error)
+	GetPendingActions(context.Context, *connect.Request[v1.GetPendingActionsRequest]) (*connect.Response[v1.PendingActionsResponse], error)
+	// Subscribe streams the caller's view of the game: its state and chat
+	// history first, then every ServerMessage a WebSocket client would get.
+	Subscribe(context.Context, *connect.Request[v1.SubscribeRequest]) (*connect.ServerStreamForClient[v1.ServerMessage], error)
+	// Each command answers with the caller's view of the game after it.
+	PlayerReady(context.Context, *connect.Request[v1.PlayerReadyMessage]) (*connect.Response[v1.CommandResponse], error)
+	StartGame(context.Context, *connect.Request[v1.StartGameMessage]) (*connect.Response[v1.CommandResponse], error)
+	RollDice(context.Context, *connect.Request[v1.RollDiceMessage]) (*connect.Response[v1.CommandResponse], error)
+	BuildStructure(context.Context, *connect.Request[v1.BuildStructureMessage]) (*connect.Response[v1.CommandResponse], error)
+	ProposeTrade(context.Context, *connect.Request[v1.ProposeTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	RespondTrade(context.Context, *connect.Request[v1.RespondTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	BankTrade(context.Context, *connect.Request[v1.BankTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	MoveRobber(context.Context, *connect.Request[v1.MoveRobberMessage]) (*connect.Response[v1.CommandResponse], error)
+	DiscardCards(context.Context, *connect.Request[v1.DiscardCardsMessage]) (*connect.Response[v1.CommandResponse], error)
+	BuyDevCard(context.Context, *connect.Request[v1.BuyDevCardMessage]) (*connect.Response[v1.CommandResponse], error)
+	PlayDevCard(context.Context, *connect.Request[v1.PlayDevCardMessage]) (*connect.Response[v1.CommandResponse], error)
+	SetTurnPhase(context.Context, *connect.Request[v1.SetTurnPhaseMessage]) (*connect.Response[v1.CommandResponse], error)
+	EndTurn(context.Context, *connect.Request[v1.EndTurnMessage]) (*connect.Response[v1.CommandResponse], error)
+	SendChat(context.Context, *connect.Request[v1.SendChatMessage]) (*connect.Response[v1.CommandResponse], error)
+	MuteChat(context.Context, *connect.Request[v1.MuteChatMessage]) (*connect.Response[v1.CommandResponse], error)
+	SetChatSettings(context.Context, *connect.Request[v1.SetChatSettingsMessage]) (*connect.Response[v1.CommandResponse], error)
+	RequestTakeback(context.Context, *connect.Request[v1.RequestTakebackMessage]) (*connect.Response[v1.CommandResponse], error)
+	RespondTakeback(context.Context, *connect.Request[v1.RespondTakebackMessage]) (*connect.Response[v1.CommandResponse], error)
+	ApproveExport(context.Context, *connect.Request[v1.ApproveExportMessage]) (*connect.Response[v1.CommandResponse], error)
+}
+
+// NewCatanServiceClient constructs a client for the catan.v1.CatanService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCatanServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CatanServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	catanServiceMethods := v1.File_catan_v1_service_proto.Services().ByName("CatanService").Methods()
+	return &catanServiceClient{
+		createGame: connect.NewClient[v1.CreateGameRequest, v1.CreateGameResponse](
+			httpClient,
+			baseURL+CatanServiceCreateGameProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("CreateGame")),
+			connect.WithClientOptions(opts...),
+		),
+		joinGame: connect.NewClient[v1.JoinGameRequest, v1.JoinGameResponse](
+			httpClient,
+			baseURL+CatanServiceJoinGameProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("JoinGame")),
+			connect.WithClientOptions(opts...),
+		),
+		getGame: connect.NewClient[v1.GetGameRequest, v1.GameInfoResponse](
+			httpClient,
+			baseURL+CatanServiceGetGameProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("GetGame")),
+			connect.WithClientOptions(opts...),
+		),
+		getPendingActions: connect.NewClient[v1.GetPendingActionsRequest, v1.PendingActionsResponse](
+			httpClient,
+			baseURL+CatanServiceGetPendingActionsProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("GetPendingActions")),
+			connect.WithClientOptions(opts...),
+		),
+		subscribe: connect.NewClient[v1.SubscribeRequest, v1.ServerMessage](
+			httpClient,
+			baseURL+CatanServiceSubscribeProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("Subscribe")),
+			connect.WithClientOptions(opts...),
+		),
+		playerReady: connect.NewClient[v1.PlayerReadyMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServicePlayerReadyProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("PlayerReady")),
+			connect.WithClientOptions(opts...),
+		),
+		startGame: connect.NewClient[v1.StartGameMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceStartGameProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("StartGame")),
+			connect.WithClientOptions(opts...),
+		),
+		rollDice: connect.NewClient[v1.RollDiceMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceRollDiceProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("RollDice")),
+			connect.WithClientOptions(opts...),
+		),
+		buildStructure: connect.NewClient[v1.BuildStructureMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceBuildStructureProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("BuildStructure")),
+			connect.WithClientOptions(opts...),
+		),
+		proposeTrade: connect.NewClient[v1.ProposeTradeMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceProposeTradeProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("ProposeTrade")),
+			connect.WithClientOptions(opts...),
+		),
+		respondTrade: connect.NewClient[v1.RespondTradeMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceRespondTradeProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("RespondTrade")),
+			connect.WithClientOptions(opts...),
+		),
+		bankTrade: connect.NewClient[v1.BankTradeMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceBankTradeProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("BankTrade")),
+			connect.WithClientOptions(opts...),
+		),
+		moveRobber: connect.NewClient[v1.MoveRobberMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceMoveRobberProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("MoveRobber")),
+			connect.WithClientOptions(opts...),
+		),
+		discardCards: connect.NewClient[v1.DiscardCardsMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceDiscardCardsProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("DiscardCards")),
+			connect.WithClientOptions(opts...),
+		),
+		buyDevCard: connect.NewClient[v1.BuyDevCardMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceBuyDevCardProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("BuyDevCard")),
+			connect.WithClientOptions(opts...),
+		),
+		playDevCard: connect.NewClient[v1.PlayDevCardMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServicePlayDevCardProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("PlayDevCard")),
+			connect.WithClientOptions(opts...),
+		),
+		setTurnPhase: connect.NewClient[v1.SetTurnPhaseMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceSetTurnPhaseProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("SetTurnPhase")),
+			connect.WithClientOptions(opts...),
+		),
+		endTurn: connect.NewClient[v1.EndTurnMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceEndTurnProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("EndTurn")),
+			connect.WithClientOptions(opts...),
+		),
+		sendChat: connect.NewClient[v1.SendChatMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceSendChatProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("SendChat")),
+			connect.WithClientOptions(opts...),
+		),
+		muteChat: connect.NewClient[v1.MuteChatMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceMuteChatProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("MuteChat")),
+			connect.WithClientOptions(opts...),
+		),
+		setChatSettings: connect.NewClient[v1.SetChatSettingsMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceSetChatSettingsProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("SetChatSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		requestTakeback: connect.NewClient[v1.RequestTakebackMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceRequestTakebackProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("RequestTakeback")),
+			connect.WithClientOptions(opts...),
+		),
+		respondTakeback: connect.NewClient[v1.RespondTakebackMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceRespondTakebackProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("RespondTakeback")),
+			connect.WithClientOptions(opts...),
+		),
+		approveExport: connect.NewClient[v1.ApproveExportMessage, v1.CommandResponse](
+			httpClient,
+			baseURL+CatanServiceApproveExportProcedure,
+			connect.WithSchema(catanServiceMethods.ByName("ApproveExport")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// catanServiceClient implements CatanServiceClient.
+type catanServiceClient struct {
+	createGame        *connect.Client[v1.CreateGameRequest, v1.CreateGameResponse]
+	joinGame          *connect.Client[v1.JoinGameRequest, v1.JoinGameResponse]
+	getGame           *connect.Client[v1.GetGameRequest, v1.GameInfoResponse]
+	getPendingActions *connect.Client[v1.GetPendingActionsRequest, v1.PendingActionsResponse]
+	subscribe         *connect.Client[v1.SubscribeRequest, v1.ServerMessage]
+	playerReady       *connect.Client[v1.PlayerReadyMessage, v1.CommandResponse]
+	startGame         *connect.Client[v1.StartGameMessage, v1.CommandResponse]
+	rollDice          *connect.Client[v1.RollDiceMessage, v1.CommandResponse]
+	buildStructure    *connect.Client[v1.BuildStructureMessage, v1.CommandResponse]
+	proposeTrade      *connect.Client[v1.ProposeTradeMessage, v1.CommandResponse]
+	respondTrade      *connect.Client[v1.RespondTradeMessage, v1.CommandResponse]
+	bankTrade         *connect.Client[v1.BankTradeMessage, v1.CommandResponse]
+	moveRobber        *connect.Client[v1.MoveRobberMessage, v1.CommandResponse]
+	discardCards      *connect.Client[v1.DiscardCardsMessage, v1.CommandResponse]
+	buyDevCard        *connect.Client[v1.BuyDevCardMessage, v1.CommandResponse]
+	playDevCard       *connect.Client[v1.PlayDevCardMessage, v1.CommandResponse]
+	setTurnPhase      *connect.Client[v1.SetTurnPhaseMessage, v1.CommandResponse]
+	endTurn           *connect.Client[v1.EndTurnMessage, v1.CommandResponse]
+	sendChat          *connect.Client[v1.SendChatMessage, v1.CommandResponse]
+	muteChat          *connect.Client[v1.MuteChatMessage, v1.CommandResponse]
+	setChatSettings   *connect.Client[v1.SetChatSettingsMessage, v1.CommandResponse]
+	requestTakeback   *connect.Client[v1.RequestTakebackMessage, v1.CommandResponse]
+	respondTakeback   *connect.Client[v1.RespondTakebackMessage, v1.CommandResponse]
+	approveExport     *connect.Client[v1.ApproveExportMessage, v1.CommandResponse]
+}
+
+// CreateGame calls catan.v1.CatanService.CreateGame.
+func (c *catanServiceClient) CreateGame(ctx context.Context, req *connect.Request[v1.CreateGameRequest]) (*connect.Response[v1.CreateGameResponse], error) {
+	return c.createGame.CallUnary(ctx, req)
+}
+
+// JoinGame calls catan.v1.CatanService.JoinGame.
+func (c *catanServiceClient) JoinGame(ctx context.Context, req *connect.Request[v1.JoinGameRequest]) (*connect.Response[v1.JoinGameResponse], error) {
+	return c.joinGame.CallUnary(ctx, req)
+}
+
+// GetGame calls catan.v1.CatanService.GetGame.
+func (c *catanServiceClient) GetGame(ctx context.Context, req *connect.Request[v1.GetGameRequest]) (*connect.Response[v1.GameInfoResponse], error) {
+	return c.getGame.CallUnary(ctx, req)
+}
+
+// GetPendingActions calls catan.v1.CatanService.GetPendingActions.
+func (c *catanServiceClient) GetPendingActions(ctx context.Context, req *connect.Request[v1.GetPendingActionsRequest]) (*connect.Response[v1.PendingActionsResponse], error) {
+	return c.getPendingActions.CallUnary(ctx, req)
+}
+
+// Subscribe calls catan.v1.CatanService.Subscribe.
+func (c *catanServiceClient) Subscribe(ctx context.Context, req *connect.Request[v1.SubscribeRequest]) (*connect.ServerStreamForClient[v1.ServerMessage], error) {
+	return c.subscribe.CallServerStream(ctx, req)
+}
+
+// PlayerReady calls catan.v1.CatanService.PlayerReady.
+func (c *catanServiceClient) PlayerReady(ctx context.Context, req *connect.Request[v1.PlayerReadyMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.playerReady.CallUnary(ctx, req)
+}
+
+// StartGame calls catan.v1.CatanService.StartGame.
+func (c *catanServiceClient) StartGame(ctx context.Context, req *connect.Request[v1.StartGameMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.startGame.CallUnary(ctx, req)
+}
+
+// RollDice calls catan.v1.CatanService.RollDice.
+func (c *catanServiceClient) RollDice(ctx context.Context, req *connect.Request[v1.RollDiceMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.rollDice.CallUnary(ctx, req)
+}
+
+// BuildStructure calls catan.v1.CatanService.BuildStructure.
+func (c *catanServiceClient) BuildStructure(ctx context.Context, req *connect.Request[v1.BuildStructureMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.buildStructure.CallUnary(ctx, req)
+}
+
+// ProposeTrade calls catan.v1.CatanService.ProposeTrade.
+func (c *catanServiceClient) ProposeTrade(ctx context.Context, req *connect.Request[v1.ProposeTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.proposeTrade.CallUnary(ctx, req)
+}
+
+// RespondTrade calls catan.v1.CatanService.RespondTrade.
+func (c *catanServiceClient) RespondTrade(ctx context.Context, req *connect.Request[v1.RespondTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.respondTrade.CallUnary(ctx, req)
+}
+
+// BankTrade calls catan.v1.CatanService.BankTrade.
+func (c *catanServiceClient) BankTrade(ctx context.Context, req *connect.Request[v1.BankTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.bankTrade.CallUnary(ctx, req)
+}
+
+// MoveRobber calls catan.v1.CatanService.MoveRobber.
+func (c *catanServiceClient) MoveRobber(ctx context.Context, req *connect.Request[v1.MoveRobberMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.moveRobber.CallUnary(ctx, req)
+}
+
+// DiscardCards calls catan.v1.CatanService.DiscardCards.
+func (c *catanServiceClient) DiscardCards(ctx context.Context, req *connect.Request[v1.DiscardCardsMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.discardCards.CallUnary(ctx, req)
+}
+
+// BuyDevCard calls catan.v1.CatanService.BuyDevCard.
+func (c *catanServiceClient) BuyDevCard(ctx context.Context, req *connect.Request[v1.BuyDevCardMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.buyDevCard.CallUnary(ctx, req)
+}
+
+// PlayDevCard calls catan.v1.CatanService.PlayDevCard.
+func (c *catanServiceClient) PlayDevCard(ctx context.Context, req *connect.Request[v1.PlayDevCardMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.playDevCard.CallUnary(ctx, req)
+}
+
+// SetTurnPhase calls catan.v1.CatanService.SetTurnPhase.
+func (c *catanServiceClient) SetTurnPhase(ctx context.Context, req *connect.Request[v1.SetTurnPhaseMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.setTurnPhase.CallUnary(ctx, req)
+}
+
+// EndTurn calls catan.v1.CatanService.EndTurn.
+func (c *catanServiceClient) EndTurn(ctx context.Context, req *connect.Request[v1.EndTurnMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.endTurn.CallUnary(ctx, req)
+}
+
+// SendChat calls catan.v1.CatanService.SendChat.
+func (c *catanServiceClient) SendChat(ctx context.Context, req *connect.Request[v1.SendChatMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.sendChat.CallUnary(ctx, req)
+}
+
+// MuteChat calls catan.v1.CatanService.MuteChat.
+func (c *catanServiceClient) MuteChat(ctx context.Context, req *connect.Request[v1.MuteChatMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.muteChat.CallUnary(ctx, req)
+}
+
+// SetChatSettings calls catan.v1.CatanService.SetChatSettings.
+func (c *catanServiceClient) SetChatSettings(ctx context.Context, req *connect.Request[v1.SetChatSettingsMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.setChatSettings.CallUnary(ctx, req)
+}
+
+// RequestTakeback calls catan.v1.CatanService.RequestTakeback.
+func (c *catanServiceClient) RequestTakeback(ctx context.Context, req *connect.Request[v1.RequestTakebackMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.requestTakeback.CallUnary(ctx, req)
+}
+
+// RespondTakeback calls catan.v1.CatanService.RespondTakeback.
+func (c *catanServiceClient) RespondTakeback(ctx context.Context, req *connect.Request[v1.RespondTakebackMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.respondTakeback.CallUnary(ctx, req)
+}
+
+// ApproveExport calls catan.v1.CatanService.ApproveExport.
+func (c *catanServiceClient) ApproveExport(ctx context.Context, req *connect.Request[v1.ApproveExportMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return c.approveExport.CallUnary(ctx, req)
+}
+
+// CatanServiceHandler is an implementation of the catan.v1.CatanService service.
+type CatanServiceHandler interface {
+	CreateGame(context.Context, *connect.Request[v1.CreateGameRequest]) (*connect.Response[v1.CreateGameResponse], error)
+	JoinGame(context.Context, *connect.Request[v1.JoinGameRequest]) (*connect.Response[v1.JoinGameResponse], error)
+	GetGame(context.Context, *connect.Request[v1.GetGameRequest]) (*connect.Response[v1.GameInfoResponse], error)
+	GetPendingActions(context.Context, *connect.Request[v1.GetPendingActionsRequest]) (*connect.Response[v1.PendingActionsResponse], error)
+	// Subscribe streams the caller's view of the game: its state and chat
+	// history first, then every ServerMessage a WebSocket client would get.
+	Subscribe(context.Context, *connect.Request[v1.SubscribeRequest], *connect.ServerStream[v1.ServerMessage]) error
+	// Each command answers with the caller's view of the game after it.
+	PlayerReady(context.Context, *connect.Request[v1.PlayerReadyMessage]) (*connect.Response[v1.CommandResponse], error)
+	StartGame(context.Context, *connect.Request[v1.StartGameMessage]) (*connect.Response[v1.CommandResponse], error)
+	RollDice(context.Context, *connect.Request[v1.RollDiceMessage]) (*connect.Response[v1.CommandResponse], error)
+	BuildStructure(context.Context, *connect.Request[v1.BuildStructureMessage]) (*connect.Response[v1.CommandResponse], error)
+	ProposeTrade(context.Context, *connect.Request[v1.ProposeTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	RespondTrade(context.Context, *connect.Request[v1.RespondTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	BankTrade(context.Context, *connect.Request[v1.BankTradeMessage]) (*connect.Response[v1.CommandResponse], error)
+	MoveRobber(context.Context, *connect.Request[v1.MoveRobberMessage]) (*connect.Response[v1.CommandResponse], error)
+	DiscardCards(context.Context, *connect.Request[v1.DiscardCardsMessage]) (*connect.Response[v1.CommandResponse], error)
+	BuyDevCard(context.Context, *connect.Request[v1.BuyDevCardMessage]) (*connect.Response[v1.CommandResponse], error)
+	PlayDevCard(context.Context, *connect.Request[v1.PlayDevCardMessage]) (*connect.Response[v1.CommandResponse], error)
+	SetTurnPhase(context.Context, *connect.Request[v1.SetTurnPhaseMessage]) (*connect.Response[v1.CommandResponse], error)
+	EndTurn(context.Context, *connect.Request[v1.EndTurnMessage]) (*connect.Response[v1.CommandResponse], error)
+	SendChat(context.Context, *connect.Request[v1.SendChatMessage]) (*connect.Response[v1.CommandResponse], error)
+	MuteChat(context.Context, *connect.Request[v1.MuteChatMessage]) (*connect.Response[v1.CommandResponse], error)
+	SetChatSettings(context.Context, *connect.Request[v1.SetChatSettingsMessage]) (*connect.Response[v1.CommandResponse], error)
+	RequestTakeback(context.Context, *connect.Request[v1.RequestTakebackMessage]) (*connect.Response[v1.CommandResponse], error)
+	RespondTakeback(context.Context, *connect.Request[v1.RespondTakebackMessage]) (*connect.Response[v1.CommandResponse], error)
+	ApproveExport(context.Context, *connect.Request[v1.ApproveExportMessage]) (*connect.Response[v1.CommandResponse], error)
+}
+
+// NewCatanServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCatanServiceHandler(svc CatanServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	catanServiceMethods := v1.File_catan_v1_service_proto.Services().ByName("CatanService").Methods()
+	catanServiceCreateGameHandler := connect.NewUnaryHandler(
+		CatanServiceCreateGameProcedure,
+		svc.CreateGame,
+		connect.WithSchema(catanServiceMethods.ByName("CreateGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceJoinGameHandler := connect.NewUnaryHandler(
+		CatanServiceJoinGameProcedure,
+		svc.JoinGame,
+		connect.WithSchema(catanServiceMethods.ByName("JoinGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceGetGameHandler := connect.NewUnaryHandler(
+		CatanServiceGetGameProcedure,
+		svc.GetGame,
+		connect.WithSchema(catanServiceMethods.ByName("GetGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceGetPendingActionsHandler := connect.NewUnaryHandler(
+		CatanServiceGetPendingActionsProcedure,
+		svc.GetPendingActions,
+		connect.WithSchema(catanServiceMethods.ByName("GetPendingActions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceSubscribeHandler := connect.NewServerStreamHandler(
+		CatanServiceSubscribeProcedure,
+		svc.Subscribe,
+		connect.WithSchema(catanServiceMethods.ByName("Subscribe")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServicePlayerReadyHandler := connect.NewUnaryHandler(
+		CatanServicePlayerReadyProcedure,
+		svc.PlayerReady,
+		connect.WithSchema(catanServiceMethods.ByName("PlayerReady")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceStartGameHandler := connect.NewUnaryHandler(
+		CatanServiceStartGameProcedure,
+		svc.StartGame,
+		connect.WithSchema(catanServiceMethods.ByName("StartGame")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceRollDiceHandler := connect.NewUnaryHandler(
+		CatanServiceRollDiceProcedure,
+		svc.RollDice,
+		connect.WithSchema(catanServiceMethods.ByName("RollDice")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceBuildStructureHandler := connect.NewUnaryHandler(
+		CatanServiceBuildStructureProcedure,
+		svc.BuildStructure,
+		connect.WithSchema(catanServiceMethods.ByName("BuildStructure")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceProposeTradeHandler := connect.NewUnaryHandler(
+		CatanServiceProposeTradeProcedure,
+		svc.ProposeTrade,
+		connect.WithSchema(catanServiceMethods.ByName("ProposeTrade")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceRespondTradeHandler := connect.NewUnaryHandler(
+		CatanServiceRespondTradeProcedure,
+		svc.RespondTrade,
+		connect.WithSchema(catanServiceMethods.ByName("RespondTrade")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceBankTradeHandler := connect.NewUnaryHandler(
+		CatanServiceBankTradeProcedure,
+		svc.BankTrade,
+		connect.WithSchema(catanServiceMethods.ByName("BankTrade")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceMoveRobberHandler := connect.NewUnaryHandler(
+		CatanServiceMoveRobberProcedure,
+		svc.MoveRobber,
+		connect.WithSchema(catanServiceMethods.ByName("MoveRobber")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceDiscardCardsHandler := connect.NewUnaryHandler(
+		CatanServiceDiscardCardsProcedure,
+		svc.DiscardCards,
+		connect.WithSchema(catanServiceMethods.ByName("DiscardCards")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceBuyDevCardHandler := connect.NewUnaryHandler(
+		CatanServiceBuyDevCardProcedure,
+		svc.BuyDevCard,
+		connect.WithSchema(catanServiceMethods.ByName("BuyDevCard")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServicePlayDevCardHandler := connect.NewUnaryHandler(
+		CatanServicePlayDevCardProcedure,
+		svc.PlayDevCard,
+		connect.WithSchema(catanServiceMethods.ByName("PlayDevCard")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceSetTurnPhaseHandler := connect.NewUnaryHandler(
+		CatanServiceSetTurnPhaseProcedure,
+		svc.SetTurnPhase,
+		connect.WithSchema(catanServiceMethods.ByName("SetTurnPhase")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceEndTurnHandler := connect.NewUnaryHandler(
+		CatanServiceEndTurnProcedure,
+		svc.EndTurn,
+		connect.WithSchema(catanServiceMethods.ByName("EndTurn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceSendChatHandler := connect.NewUnaryHandler(
+		CatanServiceSendChatProcedure,
+		svc.SendChat,
+		connect.WithSchema(catanServiceMethods.ByName("SendChat")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceMuteChatHandler := connect.NewUnaryHandler(
+		CatanServiceMuteChatProcedure,
+		svc.MuteChat,
+		connect.WithSchema(catanServiceMethods.ByName("MuteChat")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceSetChatSettingsHandler := connect.NewUnaryHandler(
+		CatanServiceSetChatSettingsProcedure,
+		svc.SetChatSettings,
+		connect.WithSchema(catanServiceMethods.ByName("SetChatSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceRequestTakebackHandler := connect.NewUnaryHandler(
+		CatanServiceRequestTakebackProcedure,
+		svc.RequestTakeback,
+		connect.WithSchema(catanServiceMethods.ByName("RequestTakeback")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceRespondTakebackHandler := connect.NewUnaryHandler(
+		CatanServiceRespondTakebackProcedure,
+		svc.RespondTakeback,
+		connect.WithSchema(catanServiceMethods.ByName("RespondTakeback")),
+		connect.WithHandlerOptions(opts...),
+	)
+	catanServiceApproveExportHandler := connect.NewUnaryHandler(
+		CatanServiceApproveExportProcedure,
+		svc.ApproveExport,
+		connect.WithSchema(catanServiceMethods.ByName("ApproveExport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/catan.v1.CatanService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CatanServiceCreateGameProcedure:
+			catanServiceCreateGameHandler.ServeHTTP(w, r)
+		case CatanServiceJoinGameProcedure:
+			catanServiceJoinGameHandler.ServeHTTP(w, r)
+		case CatanServiceGetGameProcedure:
+			catanServiceGetGameHandler.ServeHTTP(w, r)
+		case CatanServiceGetPendingActionsProcedure:
+			catanServiceGetPendingActionsHandler.ServeHTTP(w, r)
+		case CatanServiceSubscribeProcedure:
+			catanServiceSubscribeHandler.ServeHTTP(w, r)
+		case CatanServicePlayerReadyProcedure:
+			catanServicePlayerReadyHandler.ServeHTTP(w, r)
+		case CatanServiceStartGameProcedure:
+			catanServiceStartGameHandler.ServeHTTP(w, r)
+		case CatanServiceRollDiceProcedure:
+			catanServiceRollDiceHandler.ServeHTTP(w, r)
+		case CatanServiceBuildStructureProcedure:
+			catanServiceBuildStructureHandler.ServeHTTP(w, r)
+		case CatanServiceProposeTradeProcedure:
+			catanServiceProposeTradeHandler.ServeHTTP(w, r)
+		case CatanServiceRespondTradeProcedure:
+			catanServiceRespondTradeHandler.ServeHTTP(w, r)
+		case CatanServiceBankTradeProcedure:
+			catanServiceBankTradeHandler.ServeHTTP(w, r)
+		case CatanServiceMoveRobberProcedure:
+			catanServiceMoveRobberHandler.ServeHTTP(w, r)
+		case CatanServiceDiscardCardsProcedure:
+			catanServiceDiscardCardsHandler.ServeHTTP(w, r)
+		case CatanServiceBuyDevCardProcedure:
+			catanServiceBuyDevCardHandler.ServeHTTP(w, r)
+		case CatanServicePlayDevCardProcedure:
+			catanServicePlayDevCardHandler.ServeHTTP(w, r)
+		case CatanServiceSetTurnPhaseProcedure:
+			catanServiceSetTurnPhaseHandler.ServeHTTP(w, r)
+		case CatanServiceEndTurnProcedure:
+			catanServiceEndTurnHandler.ServeHTTP(w, r)
+		case CatanServiceSendChatProcedure:
+			catanServiceSendChatHandler.ServeHTTP(w, r)
+		case CatanServiceMuteChatProcedure:
+			catanServiceMuteChatHandler.ServeHTTP(w, r)
+		case CatanServiceSetChatSettingsProcedure:
+			catanServiceSetChatSettingsHandler.ServeHTTP(w, r)
+		case CatanServiceRequestTakebackProcedure:
+			catanServiceRequestTakebackHandler.ServeHTTP(w, r)
+		case CatanServiceRespondTakebackProcedure:
+			catanServiceRespondTakebackHandler.ServeHTTP(w, r)
+		case CatanServiceApproveExportProcedure:
+			catanServiceApproveExportHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCatanServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCatanServiceHandler struct{}
+
+func (UnimplementedCatanServiceHandler) CreateGame(context.Context, *connect.Request[v1.CreateGameRequest]) (*connect.Response[v1.CreateGameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.CreateGame is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) JoinGame(context.Context, *connect.Request[v1.JoinGameRequest]) (*connect.Response[v1.JoinGameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.JoinGame is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) GetGame(context.Context, *connect.Request[v1.GetGameRequest]) (*connect.Response[v1.GameInfoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.GetGame is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) GetPendingActions(context.Context, *connect.Request[v1.GetPendingActionsRequest]) (*connect.Response[v1.PendingActionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.GetPendingActions is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) Subscribe(context.Context, *connect.Request[v1.SubscribeRequest], *connect.ServerStream[v1.ServerMessage]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.Subscribe is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) PlayerReady(context.Context, *connect.Request[v1.PlayerReadyMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.PlayerReady is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) StartGame(context.Context, *connect.Request[v1.StartGameMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.StartGame is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) RollDice(context.Context, *connect.Request[v1.RollDiceMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.RollDice is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) BuildStructure(context.Context, *connect.Request[v1.BuildStructureMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.BuildStructure is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) ProposeTrade(context.Context, *connect.Request[v1.ProposeTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.ProposeTrade is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) RespondTrade(context.Context, *connect.Request[v1.RespondTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.RespondTrade is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) BankTrade(context.Context, *connect.Request[v1.BankTradeMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.BankTrade is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) MoveRobber(context.Context, *connect.Request[v1.MoveRobberMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.MoveRobber is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) DiscardCards(context.Context, *connect.Request[v1.DiscardCardsMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.DiscardCards is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) BuyDevCard(context.Context, *connect.Request[v1.BuyDevCardMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.BuyDevCard is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) PlayDevCard(context.Context, *connect.Request[v1.PlayDevCardMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.PlayDevCard is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) SetTurnPhase(context.Context, *connect.Request[v1.SetTurnPhaseMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.SetTurnPhase is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) EndTurn(context.Context, *connect.Request[v1.EndTurnMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.EndTurn is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) SendChat(context.Context, *connect.Request[v1.SendChatMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.SendChat is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) MuteChat(context.Context, *connect.Request[v1.MuteChatMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.MuteChat is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) SetChatSettings(context.Context, *connect.Request[v1.SetChatSettingsMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.SetChatSettings is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) RequestTakeback(context.Context, *connect.Request[v1.RequestTakebackMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.RequestTakeback is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) RespondTakeback(context.Context, *connect.Request[v1.RespondTakebackMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.RespondTakeback is not implemented"))
+}
+
+func (UnimplementedCatanServiceHandler) ApproveExport(context.Context, *connect.Request[v1.ApproveExportMessage]) (*connect.Response[v1.CommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("catan.v1.CatanService.ApproveExport is not implemented"))
+}
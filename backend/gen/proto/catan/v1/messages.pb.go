@@ -25,8 +25,11 @@ type BankTradeMessage struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Offering          *ResourceCount         `protobuf:"bytes,1,opt,name=offering,proto3" json:"offering,omitempty"`
 	ResourceRequested Resource               `protobuf:"varint,2,opt,name=resource_requested,json=resourceRequested,proto3,enum=catan.v1.Resource" json:"resource_requested,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// When set, the trade is made of these lines instead of offering and
+	// resource_requested, and is applied all or nothing.
+	Lines         []*BankTradeLine `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BankTradeMessage) Reset() {
@@ -73,6 +76,83 @@ func (x *BankTradeMessage) GetResourceRequested() Resource {
 	return Resource_RESOURCE_UNSPECIFIED
 }
 
+func (x *BankTradeMessage) GetLines() []*BankTradeLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// BankTradeLine gives offering_count cards of offering for requested_count
+// cards of requested, at the player's best ratio for offering.
+type BankTradeLine struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Offering       Resource               `protobuf:"varint,1,opt,name=offering,proto3,enum=catan.v1.Resource" json:"offering,omitempty"`
+	OfferingCount  int32                  `protobuf:"varint,2,opt,name=offering_count,json=offeringCount,proto3" json:"offering_count,omitempty"`
+	Requested      Resource               `protobuf:"varint,3,opt,name=requested,proto3,enum=catan.v1.Resource" json:"requested,omitempty"`
+	RequestedCount int32                  `protobuf:"varint,4,opt,name=requested_count,json=requestedCount,proto3" json:"requested_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BankTradeLine) Reset() {
+	*x = BankTradeLine{}
+	mi := &file_catan_v1_messages_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BankTradeLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankTradeLine) ProtoMessage() {}
+
+func (x *BankTradeLine) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BankTradeLine.ProtoReflect.Descriptor instead.
+func (*BankTradeLine) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BankTradeLine) GetOffering() Resource {
+	if x != nil {
+		return x.Offering
+	}
+	return Resource_RESOURCE_UNSPECIFIED
+}
+
+func (x *BankTradeLine) GetOfferingCount() int32 {
+	if x != nil {
+		return x.OfferingCount
+	}
+	return 0
+}
+
+func (x *BankTradeLine) GetRequested() Resource {
+	if x != nil {
+		return x.Requested
+	}
+	return Resource_RESOURCE_UNSPECIFIED
+}
+
+func (x *BankTradeLine) GetRequestedCount() int32 {
+	if x != nil {
+		return x.RequestedCount
+	}
+	return 0
+}
+
 type SetTurnPhaseMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Phase         TurnPhase              `protobuf:"varint,1,opt,name=phase,proto3,enum=catan.v1.TurnPhase" json:"phase,omitempty"`
@@ -82,7 +162,7 @@ type SetTurnPhaseMessage struct {
 
 func (x *SetTurnPhaseMessage) Reset() {
 	*x = SetTurnPhaseMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[1]
+	mi := &file_catan_v1_messages_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +174,7 @@ func (x *SetTurnPhaseMessage) String() string {
 func (*SetTurnPhaseMessage) ProtoMessage() {}
 
 func (x *SetTurnPhaseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[1]
+	mi := &file_catan_v1_messages_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +187,7 @@ func (x *SetTurnPhaseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetTurnPhaseMessage.ProtoReflect.Descriptor instead.
 func (*SetTurnPhaseMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{1}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SetTurnPhaseMessage) GetPhase() TurnPhase {
@@ -126,7 +206,7 @@ type JoinGameMessage struct {
 
 func (x *JoinGameMessage) Reset() {
 	*x = JoinGameMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[2]
+	mi := &file_catan_v1_messages_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -138,7 +218,7 @@ func (x *JoinGameMessage) String() string {
 func (*JoinGameMessage) ProtoMessage() {}
 
 func (x *JoinGameMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[2]
+	mi := &file_catan_v1_messages_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -151,7 +231,7 @@ func (x *JoinGameMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinGameMessage.ProtoReflect.Descriptor instead.
 func (*JoinGameMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{2}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *JoinGameMessage) GetGameId() string {
@@ -169,7 +249,7 @@ type StartGameMessage struct {
 
 func (x *StartGameMessage) Reset() {
 	*x = StartGameMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[3]
+	mi := &file_catan_v1_messages_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -181,7 +261,7 @@ func (x *StartGameMessage) String() string {
 func (*StartGameMessage) ProtoMessage() {}
 
 func (x *StartGameMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[3]
+	mi := &file_catan_v1_messages_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +274,7 @@ func (x *StartGameMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartGameMessage.ProtoReflect.Descriptor instead.
 func (*StartGameMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{3}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{4}
 }
 
 type RollDiceMessage struct {
@@ -205,7 +285,7 @@ type RollDiceMessage struct {
 
 func (x *RollDiceMessage) Reset() {
 	*x = RollDiceMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[4]
+	mi := &file_catan_v1_messages_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -217,7 +297,7 @@ func (x *RollDiceMessage) String() string {
 func (*RollDiceMessage) ProtoMessage() {}
 
 func (x *RollDiceMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[4]
+	mi := &file_catan_v1_messages_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,7 +310,7 @@ func (x *RollDiceMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RollDiceMessage.ProtoReflect.Descriptor instead.
 func (*RollDiceMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{4}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{5}
 }
 
 type BuildStructureMessage struct {
@@ -243,7 +323,7 @@ type BuildStructureMessage struct {
 
 func (x *BuildStructureMessage) Reset() {
 	*x = BuildStructureMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[5]
+	mi := &file_catan_v1_messages_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -255,7 +335,7 @@ func (x *BuildStructureMessage) String() string {
 func (*BuildStructureMessage) ProtoMessage() {}
 
 func (x *BuildStructureMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[5]
+	mi := &file_catan_v1_messages_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -268,7 +348,7 @@ func (x *BuildStructureMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildStructureMessage.ProtoReflect.Descriptor instead.
 func (*BuildStructureMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{5}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BuildStructureMessage) GetStructureType() StructureType {
@@ -296,7 +376,7 @@ type ProposeTradeMessage struct {
 
 func (x *ProposeTradeMessage) Reset() {
 	*x = ProposeTradeMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[6]
+	mi := &file_catan_v1_messages_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -308,7 +388,7 @@ func (x *ProposeTradeMessage) String() string {
 func (*ProposeTradeMessage) ProtoMessage() {}
 
 func (x *ProposeTradeMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[6]
+	mi := &file_catan_v1_messages_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,7 +401,7 @@ func (x *ProposeTradeMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProposeTradeMessage.ProtoReflect.Descriptor instead.
 func (*ProposeTradeMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{6}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ProposeTradeMessage) GetTargetId() string {
@@ -355,7 +435,7 @@ type RespondTradeMessage struct {
 
 func (x *RespondTradeMessage) Reset() {
 	*x = RespondTradeMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[7]
+	mi := &file_catan_v1_messages_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -367,7 +447,7 @@ func (x *RespondTradeMessage) String() string {
 func (*RespondTradeMessage) ProtoMessage() {}
 
 func (x *RespondTradeMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[7]
+	mi := &file_catan_v1_messages_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,7 +460,7 @@ func (x *RespondTradeMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RespondTradeMessage.ProtoReflect.Descriptor instead.
 func (*RespondTradeMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{7}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RespondTradeMessage) GetTradeId() string {
@@ -407,7 +487,7 @@ type MoveRobberMessage struct {
 
 func (x *MoveRobberMessage) Reset() {
 	*x = MoveRobberMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[8]
+	mi := &file_catan_v1_messages_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -419,7 +499,7 @@ func (x *MoveRobberMessage) String() string {
 func (*MoveRobberMessage) ProtoMessage() {}
 
 func (x *MoveRobberMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[8]
+	mi := &file_catan_v1_messages_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -432,7 +512,7 @@ func (x *MoveRobberMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveRobberMessage.ProtoReflect.Descriptor instead.
 func (*MoveRobberMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{8}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *MoveRobberMessage) GetHex() *HexCoord {
@@ -457,7 +537,7 @@ type EndTurnMessage struct {
 
 func (x *EndTurnMessage) Reset() {
 	*x = EndTurnMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[9]
+	mi := &file_catan_v1_messages_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +549,7 @@ func (x *EndTurnMessage) String() string {
 func (*EndTurnMessage) ProtoMessage() {}
 
 func (x *EndTurnMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[9]
+	mi := &file_catan_v1_messages_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +562,7 @@ func (x *EndTurnMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndTurnMessage.ProtoReflect.Descriptor instead.
 func (*EndTurnMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{9}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{10}
 }
 
 type PlayerReadyMessage struct {
@@ -494,7 +574,7 @@ type PlayerReadyMessage struct {
 
 func (x *PlayerReadyMessage) Reset() {
 	*x = PlayerReadyMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[10]
+	mi := &file_catan_v1_messages_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -506,7 +586,7 @@ func (x *PlayerReadyMessage) String() string {
 func (*PlayerReadyMessage) ProtoMessage() {}
 
 func (x *PlayerReadyMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[10]
+	mi := &file_catan_v1_messages_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +599,7 @@ func (x *PlayerReadyMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerReadyMessage.ProtoReflect.Descriptor instead.
 func (*PlayerReadyMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{10}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PlayerReadyMessage) GetReady() bool {
@@ -537,7 +617,7 @@ type BuyDevCardMessage struct {
 
 func (x *BuyDevCardMessage) Reset() {
 	*x = BuyDevCardMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[11]
+	mi := &file_catan_v1_messages_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -549,7 +629,7 @@ func (x *BuyDevCardMessage) String() string {
 func (*BuyDevCardMessage) ProtoMessage() {}
 
 func (x *BuyDevCardMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[11]
+	mi := &file_catan_v1_messages_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +642,7 @@ func (x *BuyDevCardMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuyDevCardMessage.ProtoReflect.Descriptor instead.
 func (*BuyDevCardMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{11}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{12}
 }
 
 type PlayDevCardMessage struct {
@@ -577,7 +657,7 @@ type PlayDevCardMessage struct {
 
 func (x *PlayDevCardMessage) Reset() {
 	*x = PlayDevCardMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[12]
+	mi := &file_catan_v1_messages_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -589,7 +669,7 @@ func (x *PlayDevCardMessage) String() string {
 func (*PlayDevCardMessage) ProtoMessage() {}
 
 func (x *PlayDevCardMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[12]
+	mi := &file_catan_v1_messages_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -602,7 +682,7 @@ func (x *PlayDevCardMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayDevCardMessage.ProtoReflect.Descriptor instead.
 func (*PlayDevCardMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{12}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PlayDevCardMessage) GetCardType() DevCardType {
@@ -636,7 +716,7 @@ type DiscardCardsMessage struct {
 
 func (x *DiscardCardsMessage) Reset() {
 	*x = DiscardCardsMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[13]
+	mi := &file_catan_v1_messages_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -648,7 +728,7 @@ func (x *DiscardCardsMessage) String() string {
 func (*DiscardCardsMessage) ProtoMessage() {}
 
 func (x *DiscardCardsMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[13]
+	mi := &file_catan_v1_messages_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,7 +741,7 @@ func (x *DiscardCardsMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscardCardsMessage.ProtoReflect.Descriptor instead.
 func (*DiscardCardsMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{13}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DiscardCardsMessage) GetResources() *ResourceCount {
@@ -671,6 +751,277 @@ func (x *DiscardCardsMessage) GetResources() *ResourceCount {
 	return nil
 }
 
+// Agrees to export or fork the unfinished game, which reveals every hidden
+// card and the deck order.
+type ApproveExportMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveExportMessage) Reset() {
+	*x = ApproveExportMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveExportMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveExportMessage) ProtoMessage() {}
+
+func (x *ApproveExportMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveExportMessage.ProtoReflect.Descriptor instead.
+func (*ApproveExportMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{15}
+}
+
+// Sends a chat line to the whole game, or only to recipient_id as a whisper.
+type SendChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	RecipientId   *string                `protobuf:"bytes,2,opt,name=recipient_id,json=recipientId,proto3,oneof" json:"recipient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendChatMessage) Reset() {
+	*x = SendChatMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendChatMessage) ProtoMessage() {}
+
+func (x *SendChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendChatMessage.ProtoReflect.Descriptor instead.
+func (*SendChatMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SendChatMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendChatMessage) GetRecipientId() string {
+	if x != nil && x.RecipientId != nil {
+		return *x.RecipientId
+	}
+	return ""
+}
+
+// Host only: mutes or unmutes a player in chat.
+type MuteChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Muted         bool                   `protobuf:"varint,2,opt,name=muted,proto3" json:"muted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteChatMessage) Reset() {
+	*x = MuteChatMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteChatMessage) ProtoMessage() {}
+
+func (x *MuteChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteChatMessage.ProtoReflect.Descriptor instead.
+func (*MuteChatMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MuteChatMessage) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MuteChatMessage) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+// Host only: changes the game's chat settings.
+type SetChatSettingsMessage struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SpectatorChatDisabled bool                   `protobuf:"varint,1,opt,name=spectator_chat_disabled,json=spectatorChatDisabled,proto3" json:"spectator_chat_disabled,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SetChatSettingsMessage) Reset() {
+	*x = SetChatSettingsMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetChatSettingsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetChatSettingsMessage) ProtoMessage() {}
+
+func (x *SetChatSettingsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetChatSettingsMessage.ProtoReflect.Descriptor instead.
+func (*SetChatSettingsMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetChatSettingsMessage) GetSpectatorChatDisabled() bool {
+	if x != nil {
+		return x.SpectatorChatDisabled
+	}
+	return false
+}
+
+// Asks the other players to undo your last build or bank trade this turn.
+type RequestTakebackMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTakebackMessage) Reset() {
+	*x = RequestTakebackMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTakebackMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTakebackMessage) ProtoMessage() {}
+
+func (x *RequestTakebackMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTakebackMessage.ProtoReflect.Descriptor instead.
+func (*RequestTakebackMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{19}
+}
+
+// Votes on the pending takeback; a single rejection cancels it.
+type RespondTakebackMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approve       bool                   `protobuf:"varint,1,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondTakebackMessage) Reset() {
+	*x = RespondTakebackMessage{}
+	mi := &file_catan_v1_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondTakebackMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondTakebackMessage) ProtoMessage() {}
+
+func (x *RespondTakebackMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondTakebackMessage.ProtoReflect.Descriptor instead.
+func (*RespondTakebackMessage) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RespondTakebackMessage) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
 // Wrapper for all client messages
 type ClientMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -690,6 +1041,12 @@ type ClientMessage struct {
 	//	*ClientMessage_BankTrade
 	//	*ClientMessage_SetTurnPhase
 	//	*ClientMessage_BuyDevCard
+	//	*ClientMessage_ApproveExport
+	//	*ClientMessage_SendChat
+	//	*ClientMessage_MuteChat
+	//	*ClientMessage_SetChatSettings
+	//	*ClientMessage_RequestTakeback
+	//	*ClientMessage_RespondTakeback
 	Message       isClientMessage_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -697,7 +1054,7 @@ type ClientMessage struct {
 
 func (x *ClientMessage) Reset() {
 	*x = ClientMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[14]
+	mi := &file_catan_v1_messages_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -709,7 +1066,7 @@ func (x *ClientMessage) String() string {
 func (*ClientMessage) ProtoMessage() {}
 
 func (x *ClientMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[14]
+	mi := &file_catan_v1_messages_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +1079,7 @@ func (x *ClientMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
 func (*ClientMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{14}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ClientMessage) GetMessage() isClientMessage_Message {
@@ -858,6 +1215,60 @@ func (x *ClientMessage) GetBuyDevCard() *BuyDevCardMessage {
 	return nil
 }
 
+func (x *ClientMessage) GetApproveExport() *ApproveExportMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_ApproveExport); ok {
+			return x.ApproveExport
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetSendChat() *SendChatMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_SendChat); ok {
+			return x.SendChat
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetMuteChat() *MuteChatMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_MuteChat); ok {
+			return x.MuteChat
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetSetChatSettings() *SetChatSettingsMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_SetChatSettings); ok {
+			return x.SetChatSettings
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRequestTakeback() *RequestTakebackMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_RequestTakeback); ok {
+			return x.RequestTakeback
+		}
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRespondTakeback() *RespondTakebackMessage {
+	if x != nil {
+		if x, ok := x.Message.(*ClientMessage_RespondTakeback); ok {
+			return x.RespondTakeback
+		}
+	}
+	return nil
+}
+
 type isClientMessage_Message interface {
 	isClientMessage_Message()
 }
@@ -918,6 +1329,30 @@ type ClientMessage_BuyDevCard struct {
 	BuyDevCard *BuyDevCardMessage `protobuf:"bytes,14,opt,name=buy_dev_card,json=buyDevCard,proto3,oneof"`
 }
 
+type ClientMessage_ApproveExport struct {
+	ApproveExport *ApproveExportMessage `protobuf:"bytes,15,opt,name=approve_export,json=approveExport,proto3,oneof"`
+}
+
+type ClientMessage_SendChat struct {
+	SendChat *SendChatMessage `protobuf:"bytes,16,opt,name=send_chat,json=sendChat,proto3,oneof"`
+}
+
+type ClientMessage_MuteChat struct {
+	MuteChat *MuteChatMessage `protobuf:"bytes,17,opt,name=mute_chat,json=muteChat,proto3,oneof"`
+}
+
+type ClientMessage_SetChatSettings struct {
+	SetChatSettings *SetChatSettingsMessage `protobuf:"bytes,18,opt,name=set_chat_settings,json=setChatSettings,proto3,oneof"`
+}
+
+type ClientMessage_RequestTakeback struct {
+	RequestTakeback *RequestTakebackMessage `protobuf:"bytes,19,opt,name=request_takeback,json=requestTakeback,proto3,oneof"`
+}
+
+type ClientMessage_RespondTakeback struct {
+	RespondTakeback *RespondTakebackMessage `protobuf:"bytes,20,opt,name=respond_takeback,json=respondTakeback,proto3,oneof"`
+}
+
 func (*ClientMessage_JoinGame) isClientMessage_Message() {}
 
 func (*ClientMessage_StartGame) isClientMessage_Message() {}
@@ -946,6 +1381,18 @@ func (*ClientMessage_SetTurnPhase) isClientMessage_Message() {}
 
 func (*ClientMessage_BuyDevCard) isClientMessage_Message() {}
 
+func (*ClientMessage_ApproveExport) isClientMessage_Message() {}
+
+func (*ClientMessage_SendChat) isClientMessage_Message() {}
+
+func (*ClientMessage_MuteChat) isClientMessage_Message() {}
+
+func (*ClientMessage_SetChatSettings) isClientMessage_Message() {}
+
+func (*ClientMessage_RequestTakeback) isClientMessage_Message() {}
+
+func (*ClientMessage_RespondTakeback) isClientMessage_Message() {}
+
 type GameStatePayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	State         *GameState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // Board ports reflected in state.board.ports
@@ -955,7 +1402,7 @@ type GameStatePayload struct {
 
 func (x *GameStatePayload) Reset() {
 	*x = GameStatePayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[15]
+	mi := &file_catan_v1_messages_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -967,7 +1414,7 @@ func (x *GameStatePayload) String() string {
 func (*GameStatePayload) ProtoMessage() {}
 
 func (x *GameStatePayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[15]
+	mi := &file_catan_v1_messages_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -980,7 +1427,7 @@ func (x *GameStatePayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameStatePayload.ProtoReflect.Descriptor instead.
 func (*GameStatePayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{15}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GameStatePayload) GetState() *GameState {
@@ -999,7 +1446,7 @@ type PlayerJoinedPayload struct {
 
 func (x *PlayerJoinedPayload) Reset() {
 	*x = PlayerJoinedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[16]
+	mi := &file_catan_v1_messages_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1011,7 +1458,7 @@ func (x *PlayerJoinedPayload) String() string {
 func (*PlayerJoinedPayload) ProtoMessage() {}
 
 func (x *PlayerJoinedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[16]
+	mi := &file_catan_v1_messages_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1024,7 +1471,7 @@ func (x *PlayerJoinedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerJoinedPayload.ProtoReflect.Descriptor instead.
 func (*PlayerJoinedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{16}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PlayerJoinedPayload) GetPlayer() *PlayerState {
@@ -1043,7 +1490,7 @@ type PlayerLeftPayload struct {
 
 func (x *PlayerLeftPayload) Reset() {
 	*x = PlayerLeftPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[17]
+	mi := &file_catan_v1_messages_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1055,7 +1502,7 @@ func (x *PlayerLeftPayload) String() string {
 func (*PlayerLeftPayload) ProtoMessage() {}
 
 func (x *PlayerLeftPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[17]
+	mi := &file_catan_v1_messages_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1515,7 @@ func (x *PlayerLeftPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerLeftPayload.ProtoReflect.Descriptor instead.
 func (*PlayerLeftPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{17}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PlayerLeftPayload) GetPlayerId() string {
@@ -1088,7 +1535,7 @@ type ResourceDistribution struct {
 
 func (x *ResourceDistribution) Reset() {
 	*x = ResourceDistribution{}
-	mi := &file_catan_v1_messages_proto_msgTypes[18]
+	mi := &file_catan_v1_messages_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1100,7 +1547,7 @@ func (x *ResourceDistribution) String() string {
 func (*ResourceDistribution) ProtoMessage() {}
 
 func (x *ResourceDistribution) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[18]
+	mi := &file_catan_v1_messages_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1113,7 +1560,7 @@ func (x *ResourceDistribution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceDistribution.ProtoReflect.Descriptor instead.
 func (*ResourceDistribution) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{18}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ResourceDistribution) GetPlayerId() string {
@@ -1141,7 +1588,7 @@ type DiceRolledPayload struct {
 
 func (x *DiceRolledPayload) Reset() {
 	*x = DiceRolledPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[19]
+	mi := &file_catan_v1_messages_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1153,7 +1600,7 @@ func (x *DiceRolledPayload) String() string {
 func (*DiceRolledPayload) ProtoMessage() {}
 
 func (x *DiceRolledPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[19]
+	mi := &file_catan_v1_messages_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1166,7 +1613,7 @@ func (x *DiceRolledPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiceRolledPayload.ProtoReflect.Descriptor instead.
 func (*DiceRolledPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{19}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DiceRolledPayload) GetPlayerId() string {
@@ -1201,7 +1648,7 @@ type BuildingPlacedPayload struct {
 
 func (x *BuildingPlacedPayload) Reset() {
 	*x = BuildingPlacedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[20]
+	mi := &file_catan_v1_messages_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1213,7 +1660,7 @@ func (x *BuildingPlacedPayload) String() string {
 func (*BuildingPlacedPayload) ProtoMessage() {}
 
 func (x *BuildingPlacedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[20]
+	mi := &file_catan_v1_messages_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,7 +1673,7 @@ func (x *BuildingPlacedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BuildingPlacedPayload.ProtoReflect.Descriptor instead.
 func (*BuildingPlacedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{20}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *BuildingPlacedPayload) GetPlayerId() string {
@@ -1260,7 +1707,7 @@ type RoadPlacedPayload struct {
 
 func (x *RoadPlacedPayload) Reset() {
 	*x = RoadPlacedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[21]
+	mi := &file_catan_v1_messages_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1272,7 +1719,7 @@ func (x *RoadPlacedPayload) String() string {
 func (*RoadPlacedPayload) ProtoMessage() {}
 
 func (x *RoadPlacedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[21]
+	mi := &file_catan_v1_messages_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,7 +1732,7 @@ func (x *RoadPlacedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoadPlacedPayload.ProtoReflect.Descriptor instead.
 func (*RoadPlacedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{21}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RoadPlacedPayload) GetPlayerId() string {
@@ -1311,7 +1758,7 @@ type TradeProposedPayload struct {
 
 func (x *TradeProposedPayload) Reset() {
 	*x = TradeProposedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[22]
+	mi := &file_catan_v1_messages_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1323,7 +1770,7 @@ func (x *TradeProposedPayload) String() string {
 func (*TradeProposedPayload) ProtoMessage() {}
 
 func (x *TradeProposedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[22]
+	mi := &file_catan_v1_messages_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,7 +1783,7 @@ func (x *TradeProposedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TradeProposedPayload.ProtoReflect.Descriptor instead.
 func (*TradeProposedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{22}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *TradeProposedPayload) GetTrade() *TradeOffer {
@@ -1357,7 +1804,7 @@ type TradeResolvedPayload struct {
 
 func (x *TradeResolvedPayload) Reset() {
 	*x = TradeResolvedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[23]
+	mi := &file_catan_v1_messages_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1369,7 +1816,7 @@ func (x *TradeResolvedPayload) String() string {
 func (*TradeResolvedPayload) ProtoMessage() {}
 
 func (x *TradeResolvedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[23]
+	mi := &file_catan_v1_messages_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,7 +1829,7 @@ func (x *TradeResolvedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TradeResolvedPayload.ProtoReflect.Descriptor instead.
 func (*TradeResolvedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{23}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *TradeResolvedPayload) GetTradeId() string {
@@ -1418,7 +1865,7 @@ type RobberMovedPayload struct {
 
 func (x *RobberMovedPayload) Reset() {
 	*x = RobberMovedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[24]
+	mi := &file_catan_v1_messages_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1430,7 +1877,7 @@ func (x *RobberMovedPayload) String() string {
 func (*RobberMovedPayload) ProtoMessage() {}
 
 func (x *RobberMovedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[24]
+	mi := &file_catan_v1_messages_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1443,7 +1890,7 @@ func (x *RobberMovedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RobberMovedPayload.ProtoReflect.Descriptor instead.
 func (*RobberMovedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{24}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *RobberMovedPayload) GetPlayerId() string {
@@ -1484,7 +1931,7 @@ type TurnChangedPayload struct {
 
 func (x *TurnChangedPayload) Reset() {
 	*x = TurnChangedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[25]
+	mi := &file_catan_v1_messages_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1496,7 +1943,7 @@ func (x *TurnChangedPayload) String() string {
 func (*TurnChangedPayload) ProtoMessage() {}
 
 func (x *TurnChangedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[25]
+	mi := &file_catan_v1_messages_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1509,7 +1956,7 @@ func (x *TurnChangedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TurnChangedPayload.ProtoReflect.Descriptor instead.
 func (*TurnChangedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{25}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *TurnChangedPayload) GetActivePlayerId() string {
@@ -1535,7 +1982,7 @@ type GameStartedPayload struct {
 
 func (x *GameStartedPayload) Reset() {
 	*x = GameStartedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[26]
+	mi := &file_catan_v1_messages_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1547,7 +1994,7 @@ func (x *GameStartedPayload) String() string {
 func (*GameStartedPayload) ProtoMessage() {}
 
 func (x *GameStartedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[26]
+	mi := &file_catan_v1_messages_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1560,7 +2007,7 @@ func (x *GameStartedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameStartedPayload.ProtoReflect.Descriptor instead.
 func (*GameStartedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{26}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GameStartedPayload) GetState() *GameState {
@@ -1580,7 +2027,7 @@ type PlayerReadyChangedPayload struct {
 
 func (x *PlayerReadyChangedPayload) Reset() {
 	*x = PlayerReadyChangedPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[27]
+	mi := &file_catan_v1_messages_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1592,7 +2039,7 @@ func (x *PlayerReadyChangedPayload) String() string {
 func (*PlayerReadyChangedPayload) ProtoMessage() {}
 
 func (x *PlayerReadyChangedPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[27]
+	mi := &file_catan_v1_messages_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1605,7 +2052,7 @@ func (x *PlayerReadyChangedPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerReadyChangedPayload.ProtoReflect.Descriptor instead.
 func (*PlayerReadyChangedPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{27}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *PlayerReadyChangedPayload) GetPlayerId() string {
@@ -1632,7 +2079,7 @@ type PlayerScore struct {
 
 func (x *PlayerScore) Reset() {
 	*x = PlayerScore{}
-	mi := &file_catan_v1_messages_proto_msgTypes[28]
+	mi := &file_catan_v1_messages_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1644,7 +2091,7 @@ func (x *PlayerScore) String() string {
 func (*PlayerScore) ProtoMessage() {}
 
 func (x *PlayerScore) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[28]
+	mi := &file_catan_v1_messages_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1657,7 +2104,7 @@ func (x *PlayerScore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerScore.ProtoReflect.Descriptor instead.
 func (*PlayerScore) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{28}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *PlayerScore) GetPlayerId() string {
@@ -1678,13 +2125,14 @@ type GameOverPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WinnerId      string                 `protobuf:"bytes,1,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
 	Scores        []*PlayerScore         `protobuf:"bytes,2,rep,name=scores,proto3" json:"scores,omitempty"`
+	Series        *Series                `protobuf:"bytes,3,opt,name=series,proto3" json:"series,omitempty"` // Present for rematches, counting this game
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameOverPayload) Reset() {
 	*x = GameOverPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[29]
+	mi := &file_catan_v1_messages_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1696,7 +2144,7 @@ func (x *GameOverPayload) String() string {
 func (*GameOverPayload) ProtoMessage() {}
 
 func (x *GameOverPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[29]
+	mi := &file_catan_v1_messages_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1709,7 +2157,7 @@ func (x *GameOverPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameOverPayload.ProtoReflect.Descriptor instead.
 func (*GameOverPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{29}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GameOverPayload) GetWinnerId() string {
@@ -1726,17 +2174,84 @@ func (x *GameOverPayload) GetScores() []*PlayerScore {
 	return nil
 }
 
-type ErrorPayload struct {
+func (x *GameOverPayload) GetSeries() *Series {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// Sent to a finished game's players once someone starts its rematch. Each
+// player claims their new seat with POST /api/games/{code}/rematch.
+type RematchPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchPayload) Reset() {
+	*x = RematchPayload{}
+	mi := &file_catan_v1_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchPayload) ProtoMessage() {}
+
+func (x *RematchPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchPayload.ProtoReflect.Descriptor instead.
+func (*RematchPayload) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RematchPayload) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *RematchPayload) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ErrorPayload struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Code      string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message   string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=catan.v1.ErrorCode" json:"error_code,omitempty"`
+	// Set for ERROR_CODE_INSUFFICIENT_RESOURCES: the cards the command needed
+	// and the cards the player had. For ERROR_CODE_BANK_EMPTY, held is what
+	// the bank had.
+	Required      *ResourceCount `protobuf:"bytes,4,opt,name=required,proto3" json:"required,omitempty"`
+	Held          *ResourceCount `protobuf:"bytes,5,opt,name=held,proto3" json:"held,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ErrorPayload) Reset() {
 	*x = ErrorPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[30]
+	mi := &file_catan_v1_messages_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1748,7 +2263,7 @@ func (x *ErrorPayload) String() string {
 func (*ErrorPayload) ProtoMessage() {}
 
 func (x *ErrorPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[30]
+	mi := &file_catan_v1_messages_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1761,7 +2276,7 @@ func (x *ErrorPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorPayload.ProtoReflect.Descriptor instead.
 func (*ErrorPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{30}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ErrorPayload) GetCode() string {
@@ -1778,6 +2293,27 @@ func (x *ErrorPayload) GetMessage() string {
 	return ""
 }
 
+func (x *ErrorPayload) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *ErrorPayload) GetRequired() *ResourceCount {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *ErrorPayload) GetHeld() *ResourceCount {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
 // Server confirms that a player has discarded cards (could be broadcasted).
 type DiscardedCardsPayload struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1789,7 +2325,7 @@ type DiscardedCardsPayload struct {
 
 func (x *DiscardedCardsPayload) Reset() {
 	*x = DiscardedCardsPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[31]
+	mi := &file_catan_v1_messages_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1801,7 +2337,7 @@ func (x *DiscardedCardsPayload) String() string {
 func (*DiscardedCardsPayload) ProtoMessage() {}
 
 func (x *DiscardedCardsPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[31]
+	mi := &file_catan_v1_messages_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1814,7 +2350,7 @@ func (x *DiscardedCardsPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiscardedCardsPayload.ProtoReflect.Descriptor instead.
 func (*DiscardedCardsPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{31}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *DiscardedCardsPayload) GetPlayerId() string {
@@ -1842,7 +2378,7 @@ type DevCardBoughtPayload struct {
 
 func (x *DevCardBoughtPayload) Reset() {
 	*x = DevCardBoughtPayload{}
-	mi := &file_catan_v1_messages_proto_msgTypes[32]
+	mi := &file_catan_v1_messages_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1854,7 +2390,7 @@ func (x *DevCardBoughtPayload) String() string {
 func (*DevCardBoughtPayload) ProtoMessage() {}
 
 func (x *DevCardBoughtPayload) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[32]
+	mi := &file_catan_v1_messages_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1867,7 +2403,7 @@ func (x *DevCardBoughtPayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DevCardBoughtPayload.ProtoReflect.Descriptor instead.
 func (*DevCardBoughtPayload) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{32}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *DevCardBoughtPayload) GetPlayerId() string {
@@ -1884,6 +2420,128 @@ func (x *DevCardBoughtPayload) GetCardType() DevCardType {
 	return DevCardType_DEV_CARD_TYPE_UNSPECIFIED
 }
 
+// A chat line as delivered to clients.
+type ChatMessagePayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	SenderId      string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	RecipientId   *string                `protobuf:"bytes,3,opt,name=recipient_id,json=recipientId,proto3,oneof" json:"recipient_id,omitempty"` // Set for whispers
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	SentAt        int64                  `protobuf:"varint,5,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessagePayload) Reset() {
+	*x = ChatMessagePayload{}
+	mi := &file_catan_v1_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessagePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessagePayload) ProtoMessage() {}
+
+func (x *ChatMessagePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessagePayload.ProtoReflect.Descriptor instead.
+func (*ChatMessagePayload) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ChatMessagePayload) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChatMessagePayload) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *ChatMessagePayload) GetRecipientId() string {
+	if x != nil && x.RecipientId != nil {
+		return *x.RecipientId
+	}
+	return ""
+}
+
+func (x *ChatMessagePayload) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChatMessagePayload) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
+}
+
+// The chat lines a player can see, oldest first. Sent on connect.
+type ChatHistoryPayload struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*ChatMessagePayload  `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatHistoryPayload) Reset() {
+	*x = ChatHistoryPayload{}
+	mi := &file_catan_v1_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatHistoryPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatHistoryPayload) ProtoMessage() {}
+
+func (x *ChatHistoryPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatHistoryPayload.ProtoReflect.Descriptor instead.
+func (*ChatHistoryPayload) Descriptor() ([]byte, []int) {
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ChatHistoryPayload) GetMessages() []*ChatMessagePayload {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
 // Wrapper for all server messages
 type ServerMessage struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1905,6 +2563,9 @@ type ServerMessage struct {
 	//	*ServerMessage_PlayerReadyChanged
 	//	*ServerMessage_DiscardedCards
 	//	*ServerMessage_DevCardBought
+	//	*ServerMessage_ChatMessage
+	//	*ServerMessage_ChatHistory
+	//	*ServerMessage_Rematch
 	Message       isServerMessage_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1912,7 +2573,7 @@ type ServerMessage struct {
 
 func (x *ServerMessage) Reset() {
 	*x = ServerMessage{}
-	mi := &file_catan_v1_messages_proto_msgTypes[33]
+	mi := &file_catan_v1_messages_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1924,7 +2585,7 @@ func (x *ServerMessage) String() string {
 func (*ServerMessage) ProtoMessage() {}
 
 func (x *ServerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_messages_proto_msgTypes[33]
+	mi := &file_catan_v1_messages_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1937,7 +2598,7 @@ func (x *ServerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
 func (*ServerMessage) Descriptor() ([]byte, []int) {
-	return file_catan_v1_messages_proto_rawDescGZIP(), []int{33}
+	return file_catan_v1_messages_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ServerMessage) GetMessage() isServerMessage_Message {
@@ -2091,6 +2752,33 @@ func (x *ServerMessage) GetDevCardBought() *DevCardBoughtPayload {
 	return nil
 }
 
+func (x *ServerMessage) GetChatMessage() *ChatMessagePayload {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_ChatMessage); ok {
+			return x.ChatMessage
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetChatHistory() *ChatHistoryPayload {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_ChatHistory); ok {
+			return x.ChatHistory
+		}
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetRematch() *RematchPayload {
+	if x != nil {
+		if x, ok := x.Message.(*ServerMessage_Rematch); ok {
+			return x.Rematch
+		}
+	}
+	return nil
+}
+
 type isServerMessage_Message interface {
 	isServerMessage_Message()
 }
@@ -2159,6 +2847,18 @@ type ServerMessage_DevCardBought struct {
 	DevCardBought *DevCardBoughtPayload `protobuf:"bytes,16,opt,name=dev_card_bought,json=devCardBought,proto3,oneof"`
 }
 
+type ServerMessage_ChatMessage struct {
+	ChatMessage *ChatMessagePayload `protobuf:"bytes,17,opt,name=chat_message,json=chatMessage,proto3,oneof"`
+}
+
+type ServerMessage_ChatHistory struct {
+	ChatHistory *ChatHistoryPayload `protobuf:"bytes,18,opt,name=chat_history,json=chatHistory,proto3,oneof"`
+}
+
+type ServerMessage_Rematch struct {
+	Rematch *RematchPayload `protobuf:"bytes,19,opt,name=rematch,proto3,oneof"`
+}
+
 func (*ServerMessage_GameState) isServerMessage_Message() {}
 
 func (*ServerMessage_PlayerJoined) isServerMessage_Message() {}
@@ -2191,14 +2891,26 @@ func (*ServerMessage_DiscardedCards) isServerMessage_Message() {}
 
 func (*ServerMessage_DevCardBought) isServerMessage_Message() {}
 
+func (*ServerMessage_ChatMessage) isServerMessage_Message() {}
+
+func (*ServerMessage_ChatHistory) isServerMessage_Message() {}
+
+func (*ServerMessage_Rematch) isServerMessage_Message() {}
+
 var File_catan_v1_messages_proto protoreflect.FileDescriptor
 
 const file_catan_v1_messages_proto_rawDesc = "" +
 	"\n" +
-	"\x17catan/v1/messages.proto\x12\bcatan.v1\x1a\x14catan/v1/types.proto\"\x8a\x01\n" +
+	"\x17catan/v1/messages.proto\x12\bcatan.v1\x1a\x14catan/v1/types.proto\"\xb9\x01\n" +
 	"\x10BankTradeMessage\x123\n" +
 	"\boffering\x18\x01 \x01(\v2\x17.catan.v1.ResourceCountR\boffering\x12A\n" +
-	"\x12resource_requested\x18\x02 \x01(\x0e2\x12.catan.v1.ResourceR\x11resourceRequested\"@\n" +
+	"\x12resource_requested\x18\x02 \x01(\x0e2\x12.catan.v1.ResourceR\x11resourceRequested\x12-\n" +
+	"\x05lines\x18\x03 \x03(\v2\x17.catan.v1.BankTradeLineR\x05lines\"\xc1\x01\n" +
+	"\rBankTradeLine\x12.\n" +
+	"\boffering\x18\x01 \x01(\x0e2\x12.catan.v1.ResourceR\boffering\x12%\n" +
+	"\x0eoffering_count\x18\x02 \x01(\x05R\rofferingCount\x120\n" +
+	"\trequested\x18\x03 \x01(\x0e2\x12.catan.v1.ResourceR\trequested\x12'\n" +
+	"\x0frequested_count\x18\x04 \x01(\x05R\x0erequestedCount\"@\n" +
 	"\x13SetTurnPhaseMessage\x12)\n" +
 	"\x05phase\x18\x01 \x01(\x0e2\x13.catan.v1.TurnPhaseR\x05phase\"*\n" +
 	"\x0fJoinGameMessage\x12\x17\n" +
@@ -2234,7 +2946,21 @@ const file_catan_v1_messages_proto_rawDesc = "" +
 	"\tresources\x18\x03 \x03(\x0e2\x12.catan.v1.ResourceR\tresourcesB\x12\n" +
 	"\x10_target_resource\"L\n" +
 	"\x13DiscardCardsMessage\x125\n" +
-	"\tresources\x18\x01 \x01(\v2\x17.catan.v1.ResourceCountR\tresources\"\xac\a\n" +
+	"\tresources\x18\x01 \x01(\v2\x17.catan.v1.ResourceCountR\tresources\"\x16\n" +
+	"\x14ApproveExportMessage\"^\n" +
+	"\x0fSendChatMessage\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12&\n" +
+	"\frecipient_id\x18\x02 \x01(\tH\x00R\vrecipientId\x88\x01\x01B\x0f\n" +
+	"\r_recipient_id\"D\n" +
+	"\x0fMuteChatMessage\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05muted\x18\x02 \x01(\bR\x05muted\"P\n" +
+	"\x16SetChatSettingsMessage\x126\n" +
+	"\x17spectator_chat_disabled\x18\x01 \x01(\bR\x15spectatorChatDisabled\"\x18\n" +
+	"\x16RequestTakebackMessage\"2\n" +
+	"\x16RespondTakebackMessage\x12\x18\n" +
+	"\aapprove\x18\x01 \x01(\bR\aapprove\"\xd7\n" +
+	"\n" +
 	"\rClientMessage\x128\n" +
 	"\tjoin_game\x18\x01 \x01(\v2\x19.catan.v1.JoinGameMessageH\x00R\bjoinGame\x12;\n" +
 	"\n" +
@@ -2254,7 +2980,13 @@ const file_catan_v1_messages_proto_rawDesc = "" +
 	"bank_trade\x18\f \x01(\v2\x1a.catan.v1.BankTradeMessageH\x00R\tbankTrade\x12E\n" +
 	"\x0eset_turn_phase\x18\r \x01(\v2\x1d.catan.v1.SetTurnPhaseMessageH\x00R\fsetTurnPhase\x12?\n" +
 	"\fbuy_dev_card\x18\x0e \x01(\v2\x1b.catan.v1.BuyDevCardMessageH\x00R\n" +
-	"buyDevCardB\t\n" +
+	"buyDevCard\x12G\n" +
+	"\x0eapprove_export\x18\x0f \x01(\v2\x1e.catan.v1.ApproveExportMessageH\x00R\rapproveExport\x128\n" +
+	"\tsend_chat\x18\x10 \x01(\v2\x19.catan.v1.SendChatMessageH\x00R\bsendChat\x128\n" +
+	"\tmute_chat\x18\x11 \x01(\v2\x19.catan.v1.MuteChatMessageH\x00R\bmuteChat\x12N\n" +
+	"\x11set_chat_settings\x18\x12 \x01(\v2 .catan.v1.SetChatSettingsMessageH\x00R\x0fsetChatSettings\x12M\n" +
+	"\x10request_takeback\x18\x13 \x01(\v2 .catan.v1.RequestTakebackMessageH\x00R\x0frequestTakeback\x12M\n" +
+	"\x10respond_takeback\x18\x14 \x01(\v2 .catan.v1.RespondTakebackMessageH\x00R\x0frespondTakebackB\t\n" +
 	"\amessage\"=\n" +
 	"\x10GameStatePayload\x12)\n" +
 	"\x05state\x18\x01 \x01(\v2\x13.catan.v1.GameStateR\x05state\"D\n" +
@@ -2302,19 +3034,37 @@ const file_catan_v1_messages_proto_rawDesc = "" +
 	"\bis_ready\x18\x02 \x01(\bR\aisReady\"B\n" +
 	"\vPlayerScore\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x16\n" +
-	"\x06points\x18\x02 \x01(\x05R\x06points\"]\n" +
+	"\x06points\x18\x02 \x01(\x05R\x06points\"\x87\x01\n" +
 	"\x0fGameOverPayload\x12\x1b\n" +
 	"\twinner_id\x18\x01 \x01(\tR\bwinnerId\x12-\n" +
-	"\x06scores\x18\x02 \x03(\v2\x15.catan.v1.PlayerScoreR\x06scores\"<\n" +
+	"\x06scores\x18\x02 \x03(\v2\x15.catan.v1.PlayerScoreR\x06scores\x12(\n" +
+	"\x06series\x18\x03 \x01(\v2\x10.catan.v1.SeriesR\x06series\"=\n" +
+	"\x0eRematchPayload\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xd2\x01\n" +
 	"\fErrorPayload\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"k\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\x0e2\x13.catan.v1.ErrorCodeR\terrorCode\x123\n" +
+	"\brequired\x18\x04 \x01(\v2\x17.catan.v1.ResourceCountR\brequired\x12+\n" +
+	"\x04held\x18\x05 \x01(\v2\x17.catan.v1.ResourceCountR\x04held\"k\n" +
 	"\x15DiscardedCardsPayload\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x125\n" +
 	"\tresources\x18\x02 \x01(\v2\x17.catan.v1.ResourceCountR\tresources\"g\n" +
 	"\x14DevCardBoughtPayload\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x122\n" +
-	"\tcard_type\x18\x02 \x01(\x0e2\x15.catan.v1.DevCardTypeR\bcardType\"\xdd\b\n" +
+	"\tcard_type\x18\x02 \x01(\x0e2\x15.catan.v1.DevCardTypeR\bcardType\"\xa9\x01\n" +
+	"\x12ChatMessagePayload\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12&\n" +
+	"\frecipient_id\x18\x03 \x01(\tH\x00R\vrecipientId\x88\x01\x01\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x17\n" +
+	"\asent_at\x18\x05 \x01(\x03R\x06sentAtB\x0f\n" +
+	"\r_recipient_id\"N\n" +
+	"\x12ChatHistoryPayload\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.catan.v1.ChatMessagePayloadR\bmessages\"\x99\n" +
+	"\n" +
 	"\rServerMessage\x12;\n" +
 	"\n" +
 	"game_state\x18\x01 \x01(\v2\x1a.catan.v1.GameStatePayloadH\x00R\tgameState\x12D\n" +
@@ -2336,7 +3086,10 @@ const file_catan_v1_messages_proto_rawDesc = "" +
 	"\x05error\x18\r \x01(\v2\x16.catan.v1.ErrorPayloadH\x00R\x05error\x12W\n" +
 	"\x14player_ready_changed\x18\x0e \x01(\v2#.catan.v1.PlayerReadyChangedPayloadH\x00R\x12playerReadyChanged\x12J\n" +
 	"\x0fdiscarded_cards\x18\x0f \x01(\v2\x1f.catan.v1.DiscardedCardsPayloadH\x00R\x0ediscardedCards\x12H\n" +
-	"\x0fdev_card_bought\x18\x10 \x01(\v2\x1e.catan.v1.DevCardBoughtPayloadH\x00R\rdevCardBoughtB\t\n" +
+	"\x0fdev_card_bought\x18\x10 \x01(\v2\x1e.catan.v1.DevCardBoughtPayloadH\x00R\rdevCardBought\x12A\n" +
+	"\fchat_message\x18\x11 \x01(\v2\x1c.catan.v1.ChatMessagePayloadH\x00R\vchatMessage\x12A\n" +
+	"\fchat_history\x18\x12 \x01(\v2\x1c.catan.v1.ChatHistoryPayloadH\x00R\vchatHistory\x124\n" +
+	"\arematch\x18\x13 \x01(\v2\x18.catan.v1.RematchPayloadH\x00R\arematchB\t\n" +
 	"\amessageB\x8e\x01\n" +
 	"\fcom.catan.v1B\rMessagesProtoP\x01Z.settlers_from_catan/gen/proto/catan/v1;catanv1\xa2\x02\x03CXX\xaa\x02\bCatan.V1\xca\x02\bCatan\\V1\xe2\x02\x14Catan\\V1\\GPBMetadata\xea\x02\tCatan::V1b\x06proto3"
 
@@ -2352,113 +3105,142 @@ func file_catan_v1_messages_proto_rawDescGZIP() []byte {
 	return file_catan_v1_messages_proto_rawDescData
 }
 
-var file_catan_v1_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_catan_v1_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_catan_v1_messages_proto_goTypes = []any{
 	(*BankTradeMessage)(nil),          // 0: catan.v1.BankTradeMessage
-	(*SetTurnPhaseMessage)(nil),       // 1: catan.v1.SetTurnPhaseMessage
-	(*JoinGameMessage)(nil),           // 2: catan.v1.JoinGameMessage
-	(*StartGameMessage)(nil),          // 3: catan.v1.StartGameMessage
-	(*RollDiceMessage)(nil),           // 4: catan.v1.RollDiceMessage
-	(*BuildStructureMessage)(nil),     // 5: catan.v1.BuildStructureMessage
-	(*ProposeTradeMessage)(nil),       // 6: catan.v1.ProposeTradeMessage
-	(*RespondTradeMessage)(nil),       // 7: catan.v1.RespondTradeMessage
-	(*MoveRobberMessage)(nil),         // 8: catan.v1.MoveRobberMessage
-	(*EndTurnMessage)(nil),            // 9: catan.v1.EndTurnMessage
-	(*PlayerReadyMessage)(nil),        // 10: catan.v1.PlayerReadyMessage
-	(*BuyDevCardMessage)(nil),         // 11: catan.v1.BuyDevCardMessage
-	(*PlayDevCardMessage)(nil),        // 12: catan.v1.PlayDevCardMessage
-	(*DiscardCardsMessage)(nil),       // 13: catan.v1.DiscardCardsMessage
-	(*ClientMessage)(nil),             // 14: catan.v1.ClientMessage
-	(*GameStatePayload)(nil),          // 15: catan.v1.GameStatePayload
-	(*PlayerJoinedPayload)(nil),       // 16: catan.v1.PlayerJoinedPayload
-	(*PlayerLeftPayload)(nil),         // 17: catan.v1.PlayerLeftPayload
-	(*ResourceDistribution)(nil),      // 18: catan.v1.ResourceDistribution
-	(*DiceRolledPayload)(nil),         // 19: catan.v1.DiceRolledPayload
-	(*BuildingPlacedPayload)(nil),     // 20: catan.v1.BuildingPlacedPayload
-	(*RoadPlacedPayload)(nil),         // 21: catan.v1.RoadPlacedPayload
-	(*TradeProposedPayload)(nil),      // 22: catan.v1.TradeProposedPayload
-	(*TradeResolvedPayload)(nil),      // 23: catan.v1.TradeResolvedPayload
-	(*RobberMovedPayload)(nil),        // 24: catan.v1.RobberMovedPayload
-	(*TurnChangedPayload)(nil),        // 25: catan.v1.TurnChangedPayload
-	(*GameStartedPayload)(nil),        // 26: catan.v1.GameStartedPayload
-	(*PlayerReadyChangedPayload)(nil), // 27: catan.v1.PlayerReadyChangedPayload
-	(*PlayerScore)(nil),               // 28: catan.v1.PlayerScore
-	(*GameOverPayload)(nil),           // 29: catan.v1.GameOverPayload
-	(*ErrorPayload)(nil),              // 30: catan.v1.ErrorPayload
-	(*DiscardedCardsPayload)(nil),     // 31: catan.v1.DiscardedCardsPayload
-	(*DevCardBoughtPayload)(nil),      // 32: catan.v1.DevCardBoughtPayload
-	(*ServerMessage)(nil),             // 33: catan.v1.ServerMessage
-	(*ResourceCount)(nil),             // 34: catan.v1.ResourceCount
-	(Resource)(0),                     // 35: catan.v1.Resource
-	(TurnPhase)(0),                    // 36: catan.v1.TurnPhase
-	(StructureType)(0),                // 37: catan.v1.StructureType
-	(*HexCoord)(nil),                  // 38: catan.v1.HexCoord
-	(DevCardType)(0),                  // 39: catan.v1.DevCardType
-	(*GameState)(nil),                 // 40: catan.v1.GameState
-	(*PlayerState)(nil),               // 41: catan.v1.PlayerState
-	(BuildingType)(0),                 // 42: catan.v1.BuildingType
-	(*TradeOffer)(nil),                // 43: catan.v1.TradeOffer
+	(*BankTradeLine)(nil),             // 1: catan.v1.BankTradeLine
+	(*SetTurnPhaseMessage)(nil),       // 2: catan.v1.SetTurnPhaseMessage
+	(*JoinGameMessage)(nil),           // 3: catan.v1.JoinGameMessage
+	(*StartGameMessage)(nil),          // 4: catan.v1.StartGameMessage
+	(*RollDiceMessage)(nil),           // 5: catan.v1.RollDiceMessage
+	(*BuildStructureMessage)(nil),     // 6: catan.v1.BuildStructureMessage
+	(*ProposeTradeMessage)(nil),       // 7: catan.v1.ProposeTradeMessage
+	(*RespondTradeMessage)(nil),       // 8: catan.v1.RespondTradeMessage
+	(*MoveRobberMessage)(nil),         // 9: catan.v1.MoveRobberMessage
+	(*EndTurnMessage)(nil),            // 10: catan.v1.EndTurnMessage
+	(*PlayerReadyMessage)(nil),        // 11: catan.v1.PlayerReadyMessage
+	(*BuyDevCardMessage)(nil),         // 12: catan.v1.BuyDevCardMessage
+	(*PlayDevCardMessage)(nil),        // 13: catan.v1.PlayDevCardMessage
+	(*DiscardCardsMessage)(nil),       // 14: catan.v1.DiscardCardsMessage
+	(*ApproveExportMessage)(nil),      // 15: catan.v1.ApproveExportMessage
+	(*SendChatMessage)(nil),           // 16: catan.v1.SendChatMessage
+	(*MuteChatMessage)(nil),           // 17: catan.v1.MuteChatMessage
+	(*SetChatSettingsMessage)(nil),    // 18: catan.v1.SetChatSettingsMessage
+	(*RequestTakebackMessage)(nil),    // 19: catan.v1.RequestTakebackMessage
+	(*RespondTakebackMessage)(nil),    // 20: catan.v1.RespondTakebackMessage
+	(*ClientMessage)(nil),             // 21: catan.v1.ClientMessage
+	(*GameStatePayload)(nil),          // 22: catan.v1.GameStatePayload
+	(*PlayerJoinedPayload)(nil),       // 23: catan.v1.PlayerJoinedPayload
+	(*PlayerLeftPayload)(nil),         // 24: catan.v1.PlayerLeftPayload
+	(*ResourceDistribution)(nil),      // 25: catan.v1.ResourceDistribution
+	(*DiceRolledPayload)(nil),         // 26: catan.v1.DiceRolledPayload
+	(*BuildingPlacedPayload)(nil),     // 27: catan.v1.BuildingPlacedPayload
+	(*RoadPlacedPayload)(nil),         // 28: catan.v1.RoadPlacedPayload
+	(*TradeProposedPayload)(nil),      // 29: catan.v1.TradeProposedPayload
+	(*TradeResolvedPayload)(nil),      // 30: catan.v1.TradeResolvedPayload
+	(*RobberMovedPayload)(nil),        // 31: catan.v1.RobberMovedPayload
+	(*TurnChangedPayload)(nil),        // 32: catan.v1.TurnChangedPayload
+	(*GameStartedPayload)(nil),        // 33: catan.v1.GameStartedPayload
+	(*PlayerReadyChangedPayload)(nil), // 34: catan.v1.PlayerReadyChangedPayload
+	(*PlayerScore)(nil),               // 35: catan.v1.PlayerScore
+	(*GameOverPayload)(nil),           // 36: catan.v1.GameOverPayload
+	(*RematchPayload)(nil),            // 37: catan.v1.RematchPayload
+	(*ErrorPayload)(nil),              // 38: catan.v1.ErrorPayload
+	(*DiscardedCardsPayload)(nil),     // 39: catan.v1.DiscardedCardsPayload
+	(*DevCardBoughtPayload)(nil),      // 40: catan.v1.DevCardBoughtPayload
+	(*ChatMessagePayload)(nil),        // 41: catan.v1.ChatMessagePayload
+	(*ChatHistoryPayload)(nil),        // 42: catan.v1.ChatHistoryPayload
+	(*ServerMessage)(nil),             // 43: catan.v1.ServerMessage
+	(*ResourceCount)(nil),             // 44: catan.v1.ResourceCount
+	(Resource)(0),                     // 45: catan.v1.Resource
+	(TurnPhase)(0),                    // 46: catan.v1.TurnPhase
+	(StructureType)(0),                // 47: catan.v1.StructureType
+	(*HexCoord)(nil),                  // 48: catan.v1.HexCoord
+	(DevCardType)(0),                  // 49: catan.v1.DevCardType
+	(*GameState)(nil),                 // 50: catan.v1.GameState
+	(*PlayerState)(nil),               // 51: catan.v1.PlayerState
+	(BuildingType)(0),                 // 52: catan.v1.BuildingType
+	(*TradeOffer)(nil),                // 53: catan.v1.TradeOffer
+	(*Series)(nil),                    // 54: catan.v1.Series
+	(ErrorCode)(0),                    // 55: catan.v1.ErrorCode
 }
 var file_catan_v1_messages_proto_depIdxs = []int32{
-	34, // 0: catan.v1.BankTradeMessage.offering:type_name -> catan.v1.ResourceCount
-	35, // 1: catan.v1.BankTradeMessage.resource_requested:type_name -> catan.v1.Resource
-	36, // 2: catan.v1.SetTurnPhaseMessage.phase:type_name -> catan.v1.TurnPhase
-	37, // 3: catan.v1.BuildStructureMessage.structure_type:type_name -> catan.v1.StructureType
-	34, // 4: catan.v1.ProposeTradeMessage.offering:type_name -> catan.v1.ResourceCount
-	34, // 5: catan.v1.ProposeTradeMessage.requesting:type_name -> catan.v1.ResourceCount
-	38, // 6: catan.v1.MoveRobberMessage.hex:type_name -> catan.v1.HexCoord
-	39, // 7: catan.v1.PlayDevCardMessage.card_type:type_name -> catan.v1.DevCardType
-	35, // 8: catan.v1.PlayDevCardMessage.target_resource:type_name -> catan.v1.Resource
-	35, // 9: catan.v1.PlayDevCardMessage.resources:type_name -> catan.v1.Resource
-	34, // 10: catan.v1.DiscardCardsMessage.resources:type_name -> catan.v1.ResourceCount
-	2,  // 11: catan.v1.ClientMessage.join_game:type_name -> catan.v1.JoinGameMessage
-	3,  // 12: catan.v1.ClientMessage.start_game:type_name -> catan.v1.StartGameMessage
-	4,  // 13: catan.v1.ClientMessage.roll_dice:type_name -> catan.v1.RollDiceMessage
-	5,  // 14: catan.v1.ClientMessage.build_structure:type_name -> catan.v1.BuildStructureMessage
-	6,  // 15: catan.v1.ClientMessage.propose_trade:type_name -> catan.v1.ProposeTradeMessage
-	7,  // 16: catan.v1.ClientMessage.respond_trade:type_name -> catan.v1.RespondTradeMessage
-	8,  // 17: catan.v1.ClientMessage.move_robber:type_name -> catan.v1.MoveRobberMessage
-	9,  // 18: catan.v1.ClientMessage.end_turn:type_name -> catan.v1.EndTurnMessage
-	12, // 19: catan.v1.ClientMessage.play_dev_card:type_name -> catan.v1.PlayDevCardMessage
-	10, // 20: catan.v1.ClientMessage.player_ready:type_name -> catan.v1.PlayerReadyMessage
-	13, // 21: catan.v1.ClientMessage.discard_cards:type_name -> catan.v1.DiscardCardsMessage
-	0,  // 22: catan.v1.ClientMessage.bank_trade:type_name -> catan.v1.BankTradeMessage
-	1,  // 23: catan.v1.ClientMessage.set_turn_phase:type_name -> catan.v1.SetTurnPhaseMessage
-	11, // 24: catan.v1.ClientMessage.buy_dev_card:type_name -> catan.v1.BuyDevCardMessage
-	40, // 25: catan.v1.GameStatePayload.state:type_name -> catan.v1.GameState
-	41, // 26: catan.v1.PlayerJoinedPayload.player:type_name -> catan.v1.PlayerState
-	34, // 27: catan.v1.ResourceDistribution.resources:type_name -> catan.v1.ResourceCount
-	18, // 28: catan.v1.DiceRolledPayload.resources_distributed:type_name -> catan.v1.ResourceDistribution
-	42, // 29: catan.v1.BuildingPlacedPayload.building_type:type_name -> catan.v1.BuildingType
-	43, // 30: catan.v1.TradeProposedPayload.trade:type_name -> catan.v1.TradeOffer
-	38, // 31: catan.v1.RobberMovedPayload.hex:type_name -> catan.v1.HexCoord
-	35, // 32: catan.v1.RobberMovedPayload.stolen_resource:type_name -> catan.v1.Resource
-	36, // 33: catan.v1.TurnChangedPayload.phase:type_name -> catan.v1.TurnPhase
-	40, // 34: catan.v1.GameStartedPayload.state:type_name -> catan.v1.GameState
-	28, // 35: catan.v1.GameOverPayload.scores:type_name -> catan.v1.PlayerScore
-	34, // 36: catan.v1.DiscardedCardsPayload.resources:type_name -> catan.v1.ResourceCount
-	39, // 37: catan.v1.DevCardBoughtPayload.card_type:type_name -> catan.v1.DevCardType
-	15, // 38: catan.v1.ServerMessage.game_state:type_name -> catan.v1.GameStatePayload
-	16, // 39: catan.v1.ServerMessage.player_joined:type_name -> catan.v1.PlayerJoinedPayload
-	17, // 40: catan.v1.ServerMessage.player_left:type_name -> catan.v1.PlayerLeftPayload
-	19, // 41: catan.v1.ServerMessage.dice_rolled:type_name -> catan.v1.DiceRolledPayload
-	20, // 42: catan.v1.ServerMessage.building_placed:type_name -> catan.v1.BuildingPlacedPayload
-	21, // 43: catan.v1.ServerMessage.road_placed:type_name -> catan.v1.RoadPlacedPayload
-	22, // 44: catan.v1.ServerMessage.trade_proposed:type_name -> catan.v1.TradeProposedPayload
-	23, // 45: catan.v1.ServerMessage.trade_resolved:type_name -> catan.v1.TradeResolvedPayload
-	24, // 46: catan.v1.ServerMessage.robber_moved:type_name -> catan.v1.RobberMovedPayload
-	25, // 47: catan.v1.ServerMessage.turn_changed:type_name -> catan.v1.TurnChangedPayload
-	26, // 48: catan.v1.ServerMessage.game_started:type_name -> catan.v1.GameStartedPayload
-	29, // 49: catan.v1.ServerMessage.game_over:type_name -> catan.v1.GameOverPayload
-	30, // 50: catan.v1.ServerMessage.error:type_name -> catan.v1.ErrorPayload
-	27, // 51: catan.v1.ServerMessage.player_ready_changed:type_name -> catan.v1.PlayerReadyChangedPayload
-	31, // 52: catan.v1.ServerMessage.discarded_cards:type_name -> catan.v1.DiscardedCardsPayload
-	32, // 53: catan.v1.ServerMessage.dev_card_bought:type_name -> catan.v1.DevCardBoughtPayload
-	54, // [54:54] is the sub-list for method output_type
-	54, // [54:54] is the sub-list for method input_type
-	54, // [54:54] is the sub-list for extension type_name
-	54, // [54:54] is the sub-list for extension extendee
-	0,  // [0:54] is the sub-list for field type_name
+	44, // 0: catan.v1.BankTradeMessage.offering:type_name -> catan.v1.ResourceCount
+	45, // 1: catan.v1.BankTradeMessage.resource_requested:type_name -> catan.v1.Resource
+	1,  // 2: catan.v1.BankTradeMessage.lines:type_name -> catan.v1.BankTradeLine
+	45, // 3: catan.v1.BankTradeLine.offering:type_name -> catan.v1.Resource
+	45, // 4: catan.v1.BankTradeLine.requested:type_name -> catan.v1.Resource
+	46, // 5: catan.v1.SetTurnPhaseMessage.phase:type_name -> catan.v1.TurnPhase
+	47, // 6: catan.v1.BuildStructureMessage.structure_type:type_name -> catan.v1.StructureType
+	44, // 7: catan.v1.ProposeTradeMessage.offering:type_name -> catan.v1.ResourceCount
+	44, // 8: catan.v1.ProposeTradeMessage.requesting:type_name -> catan.v1.ResourceCount
+	48, // 9: catan.v1.MoveRobberMessage.hex:type_name -> catan.v1.HexCoord
+	49, // 10: catan.v1.PlayDevCardMessage.card_type:type_name -> catan.v1.DevCardType
+	45, // 11: catan.v1.PlayDevCardMessage.target_resource:type_name -> catan.v1.Resource
+	45, // 12: catan.v1.PlayDevCardMessage.resources:type_name -> catan.v1.Resource
+	44, // 13: catan.v1.DiscardCardsMessage.resources:type_name -> catan.v1.ResourceCount
+	3,  // 14: catan.v1.ClientMessage.join_game:type_name -> catan.v1.JoinGameMessage
+	4,  // 15: catan.v1.ClientMessage.start_game:type_name -> catan.v1.StartGameMessage
+	5,  // 16: catan.v1.ClientMessage.roll_dice:type_name -> catan.v1.RollDiceMessage
+	6,  // 17: catan.v1.ClientMessage.build_structure:type_name -> catan.v1.BuildStructureMessage
+	7,  // 18: catan.v1.ClientMessage.propose_trade:type_name -> catan.v1.ProposeTradeMessage
+	8,  // 19: catan.v1.ClientMessage.respond_trade:type_name -> catan.v1.RespondTradeMessage
+	9,  // 20: catan.v1.ClientMessage.move_robber:type_name -> catan.v1.MoveRobberMessage
+	10, // 21: catan.v1.ClientMessage.end_turn:type_name -> catan.v1.EndTurnMessage
+	13, // 22: catan.v1.ClientMessage.play_dev_card:type_name -> catan.v1.PlayDevCardMessage
+	11, // 23: catan.v1.ClientMessage.player_ready:type_name -> catan.v1.PlayerReadyMessage
+	14, // 24: catan.v1.ClientMessage.discard_cards:type_name -> catan.v1.DiscardCardsMessage
+	0,  // 25: catan.v1.ClientMessage.bank_trade:type_name -> catan.v1.BankTradeMessage
+	2,  // 26: catan.v1.ClientMessage.set_turn_phase:type_name -> catan.v1.SetTurnPhaseMessage
+	12, // 27: catan.v1.ClientMessage.buy_dev_card:type_name -> catan.v1.BuyDevCardMessage
+	15, // 28: catan.v1.ClientMessage.approve_export:type_name -> catan.v1.ApproveExportMessage
+	16, // 29: catan.v1.ClientMessage.send_chat:type_name -> catan.v1.SendChatMessage
+	17, // 30: catan.v1.ClientMessage.mute_chat:type_name -> catan.v1.MuteChatMessage
+	18, // 31: catan.v1.ClientMessage.set_chat_settings:type_name -> catan.v1.SetChatSettingsMessage
+	19, // 32: catan.v1.ClientMessage.request_takeback:type_name -> catan.v1.RequestTakebackMessage
+	20, // 33: catan.v1.ClientMessage.respond_takeback:type_name -> catan.v1.RespondTakebackMessage
+	50, // 34: catan.v1.GameStatePayload.state:type_name -> catan.v1.GameState
+	51, // 35: catan.v1.PlayerJoinedPayload.player:type_name -> catan.v1.PlayerState
+	44, // 36: catan.v1.ResourceDistribution.resources:type_name -> catan.v1.ResourceCount
+	25, // 37: catan.v1.DiceRolledPayload.resources_distributed:type_name -> catan.v1.ResourceDistribution
+	52, // 38: catan.v1.BuildingPlacedPayload.building_type:type_name -> catan.v1.BuildingType
+	53, // 39: catan.v1.TradeProposedPayload.trade:type_name -> catan.v1.TradeOffer
+	48, // 40: catan.v1.RobberMovedPayload.hex:type_name -> catan.v1.HexCoord
+	45, // 41: catan.v1.RobberMovedPayload.stolen_resource:type_name -> catan.v1.Resource
+	46, // 42: catan.v1.TurnChangedPayload.phase:type_name -> catan.v1.TurnPhase
+	50, // 43: catan.v1.GameStartedPayload.state:type_name -> catan.v1.GameState
+	35, // 44: catan.v1.GameOverPayload.scores:type_name -> catan.v1.PlayerScore
+	54, // 45: catan.v1.GameOverPayload.series:type_name -> catan.v1.Series
+	55, // 46: catan.v1.ErrorPayload.error_code:type_name -> catan.v1.ErrorCode
+	44, // 47: catan.v1.ErrorPayload.required:type_name -> catan.v1.ResourceCount
+	44, // 48: catan.v1.ErrorPayload.held:type_name -> catan.v1.ResourceCount
+	44, // 49: catan.v1.DiscardedCardsPayload.resources:type_name -> catan.v1.ResourceCount
+	49, // 50: catan.v1.DevCardBoughtPayload.card_type:type_name -> catan.v1.DevCardType
+	41, // 51: catan.v1.ChatHistoryPayload.messages:type_name -> catan.v1.ChatMessagePayload
+	22, // 52: catan.v1.ServerMessage.game_state:type_name -> catan.v1.GameStatePayload
+	23, // 53: catan.v1.ServerMessage.player_joined:type_name -> catan.v1.PlayerJoinedPayload
+	24, // 54: catan.v1.ServerMessage.player_left:type_name -> catan.v1.PlayerLeftPayload
+	26, // 55: catan.v1.ServerMessage.dice_rolled:type_name -> catan.v1.DiceRolledPayload
+	27, // 56: catan.v1.ServerMessage.building_placed:type_name -> catan.v1.BuildingPlacedPayload
+	28, // 57: catan.v1.ServerMessage.road_placed:type_name -> catan.v1.RoadPlacedPayload
+	29, // 58: catan.v1.ServerMessage.trade_proposed:type_name -> catan.v1.TradeProposedPayload
+	30, // 59: catan.v1.ServerMessage.trade_resolved:type_name -> catan.v1.TradeResolvedPayload
+	31, // 60: catan.v1.ServerMessage.robber_moved:type_name -> catan.v1.RobberMovedPayload
+	32, // 61: catan.v1.ServerMessage.turn_changed:type_name -> catan.v1.TurnChangedPayload
+	33, // 62: catan.v1.ServerMessage.game_started:type_name -> catan.v1.GameStartedPayload
+	36, // 63: catan.v1.ServerMessage.game_over:type_name -> catan.v1.GameOverPayload
+	38, // 64: catan.v1.ServerMessage.error:type_name -> catan.v1.ErrorPayload
+	34, // 65: catan.v1.ServerMessage.player_ready_changed:type_name -> catan.v1.PlayerReadyChangedPayload
+	39, // 66: catan.v1.ServerMessage.discarded_cards:type_name -> catan.v1.DiscardedCardsPayload
+	40, // 67: catan.v1.ServerMessage.dev_card_bought:type_name -> catan.v1.DevCardBoughtPayload
+	41, // 68: catan.v1.ServerMessage.chat_message:type_name -> catan.v1.ChatMessagePayload
+	42, // 69: catan.v1.ServerMessage.chat_history:type_name -> catan.v1.ChatHistoryPayload
+	37, // 70: catan.v1.ServerMessage.rematch:type_name -> catan.v1.RematchPayload
+	71, // [71:71] is the sub-list for method output_type
+	71, // [71:71] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
 }
 
 func init() { file_catan_v1_messages_proto_init() }
@@ -2467,10 +3249,11 @@ func file_catan_v1_messages_proto_init() {
 		return
 	}
 	file_catan_v1_types_proto_init()
-	file_catan_v1_messages_proto_msgTypes[6].OneofWrappers = []any{}
-	file_catan_v1_messages_proto_msgTypes[8].OneofWrappers = []any{}
-	file_catan_v1_messages_proto_msgTypes[12].OneofWrappers = []any{}
-	file_catan_v1_messages_proto_msgTypes[14].OneofWrappers = []any{
+	file_catan_v1_messages_proto_msgTypes[7].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[9].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[13].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[16].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[21].OneofWrappers = []any{
 		(*ClientMessage_JoinGame)(nil),
 		(*ClientMessage_StartGame)(nil),
 		(*ClientMessage_RollDice)(nil),
@@ -2485,10 +3268,17 @@ func file_catan_v1_messages_proto_init() {
 		(*ClientMessage_BankTrade)(nil),
 		(*ClientMessage_SetTurnPhase)(nil),
 		(*ClientMessage_BuyDevCard)(nil),
-	}
-	file_catan_v1_messages_proto_msgTypes[23].OneofWrappers = []any{}
-	file_catan_v1_messages_proto_msgTypes[24].OneofWrappers = []any{}
-	file_catan_v1_messages_proto_msgTypes[33].OneofWrappers = []any{
+		(*ClientMessage_ApproveExport)(nil),
+		(*ClientMessage_SendChat)(nil),
+		(*ClientMessage_MuteChat)(nil),
+		(*ClientMessage_SetChatSettings)(nil),
+		(*ClientMessage_RequestTakeback)(nil),
+		(*ClientMessage_RespondTakeback)(nil),
+	}
+	file_catan_v1_messages_proto_msgTypes[30].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[31].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[41].OneofWrappers = []any{}
+	file_catan_v1_messages_proto_msgTypes[43].OneofWrappers = []any{
 		(*ServerMessage_GameState)(nil),
 		(*ServerMessage_PlayerJoined)(nil),
 		(*ServerMessage_PlayerLeft)(nil),
@@ -2505,6 +3295,9 @@ func file_catan_v1_messages_proto_init() {
 		(*ServerMessage_PlayerReadyChanged)(nil),
 		(*ServerMessage_DiscardedCards)(nil),
 		(*ServerMessage_DevCardBought)(nil),
+		(*ServerMessage_ChatMessage)(nil),
+		(*ServerMessage_ChatHistory)(nil),
+		(*ServerMessage_Rematch)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2512,7 +3305,7 @@ func file_catan_v1_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catan_v1_messages_proto_rawDesc), len(file_catan_v1_messages_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
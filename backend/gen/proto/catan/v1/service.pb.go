@@ -0,0 +1,352 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: catan/v1/service.proto
+
+package catanv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameRequest) Reset() {
+	*x = GetGameRequest{}
+	mi := &file_catan_v1_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameRequest) ProtoMessage() {}
+
+func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
+func (*GetGameRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetGameRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type GetPendingActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingActionsRequest) Reset() {
+	*x = GetPendingActionsRequest{}
+	mi := &file_catan_v1_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingActionsRequest) ProtoMessage() {}
+
+func (x *GetPendingActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingActionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingActionsRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_service_proto_rawDescGZIP(), []int{1}
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_catan_v1_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+type CommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *GameState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResponse) Reset() {
+	*x = CommandResponse{}
+	mi := &file_catan_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResponse) ProtoMessage() {}
+
+func (x *CommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResponse.ProtoReflect.Descriptor instead.
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommandResponse) GetState() *GameState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+var File_catan_v1_service_proto protoreflect.FileDescriptor
+
+const file_catan_v1_service_proto_rawDesc = "" +
+	"\n" +
+	"\x16catan/v1/service.proto\x12\bcatan.v1\x1a\x17catan/v1/messages.proto\x1a\x14catan/v1/types.proto\"$\n" +
+	"\x0eGetGameRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x1a\n" +
+	"\x18GetPendingActionsRequest\"\x12\n" +
+	"\x10SubscribeRequest\"<\n" +
+	"\x0fCommandResponse\x12)\n" +
+	"\x05state\x18\x01 \x01(\v2\x13.catan.v1.GameStateR\x05state2\xd6\r\n" +
+	"\fCatanService\x12G\n" +
+	"\n" +
+	"CreateGame\x12\x1b.catan.v1.CreateGameRequest\x1a\x1c.catan.v1.CreateGameResponse\x12A\n" +
+	"\bJoinGame\x12\x19.catan.v1.JoinGameRequest\x1a\x1a.catan.v1.JoinGameResponse\x12?\n" +
+	"\aGetGame\x12\x18.catan.v1.GetGameRequest\x1a\x1a.catan.v1.GameInfoResponse\x12Y\n" +
+	"\x11GetPendingActions\x12\".catan.v1.GetPendingActionsRequest\x1a .catan.v1.PendingActionsResponse\x12B\n" +
+	"\tSubscribe\x12\x1a.catan.v1.SubscribeRequest\x1a\x17.catan.v1.ServerMessage0\x01\x12F\n" +
+	"\vPlayerReady\x12\x1c.catan.v1.PlayerReadyMessage\x1a\x19.catan.v1.CommandResponse\x12B\n" +
+	"\tStartGame\x12\x1a.catan.v1.StartGameMessage\x1a\x19.catan.v1.CommandResponse\x12@\n" +
+	"\bRollDice\x12\x19.catan.v1.RollDiceMessage\x1a\x19.catan.v1.CommandResponse\x12L\n" +
+	"\x0eBuildStructure\x12\x1f.catan.v1.BuildStructureMessage\x1a\x19.catan.v1.CommandResponse\x12H\n" +
+	"\fProposeTrade\x12\x1d.catan.v1.ProposeTradeMessage\x1a\x19.catan.v1.CommandResponse\x12H\n" +
+	"\fRespondTrade\x12\x1d.catan.v1.RespondTradeMessage\x1a\x19.catan.v1.CommandResponse\x12B\n" +
+	"\tBankTrade\x12\x1a.catan.v1.BankTradeMessage\x1a\x19.catan.v1.CommandResponse\x12D\n" +
+	"\n" +
+	"MoveRobber\x12\x1b.catan.v1.MoveRobberMessage\x1a\x19.catan.v1.CommandResponse\x12H\n" +
+	"\fDiscardCards\x12\x1d.catan.v1.DiscardCardsMessage\x1a\x19.catan.v1.CommandResponse\x12D\n" +
+	"\n" +
+	"BuyDevCard\x12\x1b.catan.v1.BuyDevCardMessage\x1a\x19.catan.v1.CommandResponse\x12F\n" +
+	"\vPlayDevCard\x12\x1c.catan.v1.PlayDevCardMessage\x1a\x19.catan.v1.CommandResponse\x12H\n" +
+	"\fSetTurnPhase\x12\x1d.catan.v1.SetTurnPhaseMessage\x1a\x19.catan.v1.CommandResponse\x12>\n" +
+	"\aEndTurn\x12\x18.catan.v1.EndTurnMessage\x1a\x19.catan.v1.CommandResponse\x12@\n" +
+	"\bSendChat\x12\x19.catan.v1.SendChatMessage\x1a\x19.catan.v1.CommandResponse\x12@\n" +
+	"\bMuteChat\x12\x19.catan.v1.MuteChatMessage\x1a\x19.catan.v1.CommandResponse\x12N\n" +
+	"\x0fSetChatSettings\x12 .catan.v1.SetChatSettingsMessage\x1a\x19.catan.v1.CommandResponse\x12N\n" +
+	"\x0fRequestTakeback\x12 .catan.v1.RequestTakebackMessage\x1a\x19.catan.v1.CommandResponse\x12N\n" +
+	"\x0fRespondTakeback\x12 .catan.v1.RespondTakebackMessage\x1a\x19.catan.v1.CommandResponse\x12J\n" +
+	"\rApproveExport\x12\x1e.catan.v1.ApproveExportMessage\x1a\x19.catan.v1.CommandResponseB\x8d\x01\n" +
+	"\fcom.catan.v1B\fServiceProtoP\x01Z.settlers_from_catan/gen/proto/catan/v1;catanv1\xa2\x02\x03CXX\xaa\x02\bCatan.V1\xca\x02\bCatan\\V1\xe2\x02\x14Catan\\V1\\GPBMetadata\xea\x02\tCatan::V1b\x06proto3"
+
+var (
+	file_catan_v1_service_proto_rawDescOnce sync.Once
+	file_catan_v1_service_proto_rawDescData []byte
+)
+
+func file_catan_v1_service_proto_rawDescGZIP() []byte {
+	file_catan_v1_service_proto_rawDescOnce.Do(func() {
+		file_catan_v1_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catan_v1_service_proto_rawDesc), len(file_catan_v1_service_proto_rawDesc)))
+	})
+	return file_catan_v1_service_proto_rawDescData
+}
+
+var file_catan_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_catan_v1_service_proto_goTypes = []any{
+	(*GetGameRequest)(nil),           // 0: catan.v1.GetGameRequest
+	(*GetPendingActionsRequest)(nil), // 1: catan.v1.GetPendingActionsRequest
+	(*SubscribeRequest)(nil),         // 2: catan.v1.SubscribeRequest
+	(*CommandResponse)(nil),          // 3: catan.v1.CommandResponse
+	(*GameState)(nil),                // 4: catan.v1.GameState
+	(*CreateGameRequest)(nil),        // 5: catan.v1.CreateGameRequest
+	(*JoinGameRequest)(nil),          // 6: catan.v1.JoinGameRequest
+	(*PlayerReadyMessage)(nil),       // 7: catan.v1.PlayerReadyMessage
+	(*StartGameMessage)(nil),         // 8: catan.v1.StartGameMessage
+	(*RollDiceMessage)(nil),          // 9: catan.v1.RollDiceMessage
+	(*BuildStructureMessage)(nil),    // 10: catan.v1.BuildStructureMessage
+	(*ProposeTradeMessage)(nil),      // 11: catan.v1.ProposeTradeMessage
+	(*RespondTradeMessage)(nil),      // 12: catan.v1.RespondTradeMessage
+	(*BankTradeMessage)(nil),         // 13: catan.v1.BankTradeMessage
+	(*MoveRobberMessage)(nil),        // 14: catan.v1.MoveRobberMessage
+	(*DiscardCardsMessage)(nil),      // 15: catan.v1.DiscardCardsMessage
+	(*BuyDevCardMessage)(nil),        // 16: catan.v1.BuyDevCardMessage
+	(*PlayDevCardMessage)(nil),       // 17: catan.v1.PlayDevCardMessage
+	(*SetTurnPhaseMessage)(nil),      // 18: catan.v1.SetTurnPhaseMessage
+	(*EndTurnMessage)(nil),           // 19: catan.v1.EndTurnMessage
+	(*SendChatMessage)(nil),          // 20: catan.v1.SendChatMessage
+	(*MuteChatMessage)(nil),          // 21: catan.v1.MuteChatMessage
+	(*SetChatSettingsMessage)(nil),   // 22: catan.v1.SetChatSettingsMessage
+	(*RequestTakebackMessage)(nil),   // 23: catan.v1.RequestTakebackMessage
+	(*RespondTakebackMessage)(nil),   // 24: catan.v1.RespondTakebackMessage
+	(*ApproveExportMessage)(nil),     // 25: catan.v1.ApproveExportMessage
+	(*CreateGameResponse)(nil),       // 26: catan.v1.CreateGameResponse
+	(*JoinGameResponse)(nil),         // 27: catan.v1.JoinGameResponse
+	(*GameInfoResponse)(nil),         // 28: catan.v1.GameInfoResponse
+	(*PendingActionsResponse)(nil),   // 29: catan.v1.PendingActionsResponse
+	(*ServerMessage)(nil),            // 30: catan.v1.ServerMessage
+}
+var file_catan_v1_service_proto_depIdxs = []int32{
+	4,  // 0: catan.v1.CommandResponse.state:type_name -> catan.v1.GameState
+	5,  // 1: catan.v1.CatanService.CreateGame:input_type -> catan.v1.CreateGameRequest
+	6,  // 2: catan.v1.CatanService.JoinGame:input_type -> catan.v1.JoinGameRequest
+	0,  // 3: catan.v1.CatanService.GetGame:input_type -> catan.v1.GetGameRequest
+	1,  // 4: catan.v1.CatanService.GetPendingActions:input_type -> catan.v1.GetPendingActionsRequest
+	2,  // 5: catan.v1.CatanService.Subscribe:input_type -> catan.v1.SubscribeRequest
+	7,  // 6: catan.v1.CatanService.PlayerReady:input_type -> catan.v1.PlayerReadyMessage
+	8,  // 7: catan.v1.CatanService.StartGame:input_type -> catan.v1.StartGameMessage
+	9,  // 8: catan.v1.CatanService.RollDice:input_type -> catan.v1.RollDiceMessage
+	10, // 9: catan.v1.CatanService.BuildStructure:input_type -> catan.v1.BuildStructureMessage
+	11, // 10: catan.v1.CatanService.ProposeTrade:input_type -> catan.v1.ProposeTradeMessage
+	12, // 11: catan.v1.CatanService.RespondTrade:input_type -> catan.v1.RespondTradeMessage
+	13, // 12: catan.v1.CatanService.BankTrade:input_type -> catan.v1.BankTradeMessage
+	14, // 13: catan.v1.CatanService.MoveRobber:input_type -> catan.v1.MoveRobberMessage
+	15, // 14: catan.v1.CatanService.DiscardCards:input_type -> catan.v1.DiscardCardsMessage
+	16, // 15: catan.v1.CatanService.BuyDevCard:input_type -> catan.v1.BuyDevCardMessage
+	17, // 16: catan.v1.CatanService.PlayDevCard:input_type -> catan.v1.PlayDevCardMessage
+	18, // 17: catan.v1.CatanService.SetTurnPhase:input_type -> catan.v1.SetTurnPhaseMessage
+	19, // 18: catan.v1.CatanService.EndTurn:input_type -> catan.v1.EndTurnMessage
+	20, // 19: catan.v1.CatanService.SendChat:input_type -> catan.v1.SendChatMessage
+	21, // 20: catan.v1.CatanService.MuteChat:input_type -> catan.v1.MuteChatMessage
+	22, // 21: catan.v1.CatanService.SetChatSettings:input_type -> catan.v1.SetChatSettingsMessage
+	23, // 22: catan.v1.CatanService.RequestTakeback:input_type -> catan.v1.RequestTakebackMessage
+	24, // 23: catan.v1.CatanService.RespondTakeback:input_type -> catan.v1.RespondTakebackMessage
+	25, // 24: catan.v1.CatanService.ApproveExport:input_type -> catan.v1.ApproveExportMessage
+	26, // 25: catan.v1.CatanService.CreateGame:output_type -> catan.v1.CreateGameResponse
+	27, // 26: catan.v1.CatanService.JoinGame:output_type -> catan.v1.JoinGameResponse
+	28, // 27: catan.v1.CatanService.GetGame:output_type -> catan.v1.GameInfoResponse
+	29, // 28: catan.v1.CatanService.GetPendingActions:output_type -> catan.v1.PendingActionsResponse
+	30, // 29: catan.v1.CatanService.Subscribe:output_type -> catan.v1.ServerMessage
+	3,  // 30: catan.v1.CatanService.PlayerReady:output_type -> catan.v1.CommandResponse
+	3,  // 31: catan.v1.CatanService.StartGame:output_type -> catan.v1.CommandResponse
+	3,  // 32: catan.v1.CatanService.RollDice:output_type -> catan.v1.CommandResponse
+	3,  // 33: catan.v1.CatanService.BuildStructure:output_type -> catan.v1.CommandResponse
+	3,  // 34: catan.v1.CatanService.ProposeTrade:output_type -> catan.v1.CommandResponse
+	3,  // 35: catan.v1.CatanService.RespondTrade:output_type -> catan.v1.CommandResponse
+	3,  // 36: catan.v1.CatanService.BankTrade:output_type -> catan.v1.CommandResponse
+	3,  // 37: catan.v1.CatanService.MoveRobber:output_type -> catan.v1.CommandResponse
+	3,  // 38: catan.v1.CatanService.DiscardCards:output_type -> catan.v1.CommandResponse
+	3,  // 39: catan.v1.CatanService.BuyDevCard:output_type -> catan.v1.CommandResponse
+	3,  // 40: catan.v1.CatanService.PlayDevCard:output_type -> catan.v1.CommandResponse
+	3,  // 41: catan.v1.CatanService.SetTurnPhase:output_type -> catan.v1.CommandResponse
+	3,  // 42: catan.v1.CatanService.EndTurn:output_type -> catan.v1.CommandResponse
+	3,  // 43: catan.v1.CatanService.SendChat:output_type -> catan.v1.CommandResponse
+	3,  // 44: catan.v1.CatanService.MuteChat:output_type -> catan.v1.CommandResponse
+	3,  // 45: catan.v1.CatanService.SetChatSettings:output_type -> catan.v1.CommandResponse
+	3,  // 46: catan.v1.CatanService.RequestTakeback:output_type -> catan.v1.CommandResponse
+	3,  // 47: catan.v1.CatanService.RespondTakeback:output_type -> catan.v1.CommandResponse
+	3,  // 48: catan.v1.CatanService.ApproveExport:output_type -> catan.v1.CommandResponse
+	25, // [25:49] is the sub-list for method output_type
+	1,  // [1:25] is the sub-list for method input_type
+	1,  // [1:1] is the sub-list for extension type_name
+	1,  // [1:1] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_catan_v1_service_proto_init() }
+func file_catan_v1_service_proto_init() {
+	if File_catan_v1_service_proto != nil {
+		return
+	}
+	file_catan_v1_messages_proto_init()
+	file_catan_v1_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catan_v1_service_proto_rawDesc), len(file_catan_v1_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catan_v1_service_proto_goTypes,
+		DependencyIndexes: file_catan_v1_service_proto_depIdxs,
+		MessageInfos:      file_catan_v1_service_proto_msgTypes,
+	}.Build()
+	File_catan_v1_service_proto = out.File
+	file_catan_v1_service_proto_goTypes = nil
+	file_catan_v1_service_proto_depIdxs = nil
+}
@@ -565,6 +565,400 @@ func (TradeStatus) EnumDescriptor() ([]byte, []int) {
 	return file_catan_v1_types_proto_rawDescGZIP(), []int{9}
 }
 
+// Why the server refused a command. The string code on ErrorPayload names
+// the broad kind; this names the rule, so clients can localize the message
+// and react without parsing text.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED              ErrorCode = 0
+	ErrorCode_ERROR_CODE_INVALID_ACTION           ErrorCode = 1
+	ErrorCode_ERROR_CODE_BAD_REQUEST              ErrorCode = 2
+	ErrorCode_ERROR_CODE_RATE_LIMITED             ErrorCode = 3
+	ErrorCode_ERROR_CODE_GAME_PAUSED              ErrorCode = 4
+	ErrorCode_ERROR_CODE_GAME_EXPIRED             ErrorCode = 5
+	ErrorCode_ERROR_CODE_INVALID_STATE            ErrorCode = 6
+	ErrorCode_ERROR_CODE_SERVER_ERROR             ErrorCode = 7
+	ErrorCode_ERROR_CODE_SHUTTING_DOWN            ErrorCode = 8
+	ErrorCode_ERROR_CODE_REMOVED                  ErrorCode = 9
+	ErrorCode_ERROR_CODE_NOT_YOUR_TURN            ErrorCode = 20
+	ErrorCode_ERROR_CODE_WRONG_PHASE              ErrorCode = 21
+	ErrorCode_ERROR_CODE_INVALID_TURN_PHASE       ErrorCode = 22
+	ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND         ErrorCode = 23
+	ErrorCode_ERROR_CODE_NOT_HOST                 ErrorCode = 24
+	ErrorCode_ERROR_CODE_PLAYERS_NOT_READY        ErrorCode = 25
+	ErrorCode_ERROR_CODE_NOT_ENOUGH_PLAYERS       ErrorCode = 26
+	ErrorCode_ERROR_CODE_LAST_PLAYER              ErrorCode = 27
+	ErrorCode_ERROR_CODE_INVALID_VERTEX           ErrorCode = 40
+	ErrorCode_ERROR_CODE_INVALID_EDGE             ErrorCode = 41
+	ErrorCode_ERROR_CODE_VERTEX_OCCUPIED          ErrorCode = 42
+	ErrorCode_ERROR_CODE_EDGE_OCCUPIED            ErrorCode = 43
+	ErrorCode_ERROR_CODE_DISTANCE_RULE            ErrorCode = 44
+	ErrorCode_ERROR_CODE_NOT_CONNECTED            ErrorCode = 45
+	ErrorCode_ERROR_CODE_CANNOT_UPGRADE           ErrorCode = 46
+	ErrorCode_ERROR_CODE_SETUP_SETTLEMENT_FIRST   ErrorCode = 47
+	ErrorCode_ERROR_CODE_SETUP_ROAD_NOT_CONNECTED ErrorCode = 48
+	ErrorCode_ERROR_CODE_MAX_SETTLEMENTS          ErrorCode = 49
+	ErrorCode_ERROR_CODE_MAX_ROADS                ErrorCode = 50
+	ErrorCode_ERROR_CODE_MAX_CITIES               ErrorCode = 51
+	ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES   ErrorCode = 60
+	ErrorCode_ERROR_CODE_BANK_EMPTY               ErrorCode = 61
+	ErrorCode_ERROR_CODE_INVALID_TRADE            ErrorCode = 62
+	ErrorCode_ERROR_CODE_TRADE_ALREADY_PENDING    ErrorCode = 63
+	ErrorCode_ERROR_CODE_TRADE_NOT_FOUND          ErrorCode = 64
+	ErrorCode_ERROR_CODE_NOT_TRADE_PARTICIPANT    ErrorCode = 65
+	ErrorCode_ERROR_CODE_DEV_DECK_EMPTY           ErrorCode = 80
+	ErrorCode_ERROR_CODE_DEV_CARD_NOT_HELD        ErrorCode = 81
+	ErrorCode_ERROR_CODE_DEV_CARD_TOO_NEW         ErrorCode = 82
+	ErrorCode_ERROR_CODE_INVALID_DEV_CARD_CHOICE  ErrorCode = 83
+	ErrorCode_ERROR_CODE_NO_ROBBER_PHASE          ErrorCode = 100
+	ErrorCode_ERROR_CODE_DISCARD_NOT_REQUIRED     ErrorCode = 101
+	ErrorCode_ERROR_CODE_WRONG_DISCARD            ErrorCode = 102
+	ErrorCode_ERROR_CODE_DISCARDS_PENDING         ErrorCode = 103
+	ErrorCode_ERROR_CODE_NOT_ROBBER               ErrorCode = 104
+	ErrorCode_ERROR_CODE_INVALID_HEX              ErrorCode = 105
+	ErrorCode_ERROR_CODE_ROBBER_ALREADY_THERE     ErrorCode = 106
+	ErrorCode_ERROR_CODE_INVALID_VICTIM           ErrorCode = 107
+	ErrorCode_ERROR_CODE_TAKEBACK_NOT_ALLOWED     ErrorCode = 120
+	ErrorCode_ERROR_CODE_TAKEBACK_PENDING         ErrorCode = 121
+	ErrorCode_ERROR_CODE_NO_TAKEBACK_PENDING      ErrorCode = 122
+	ErrorCode_ERROR_CODE_OWN_TAKEBACK             ErrorCode = 123
+	ErrorCode_ERROR_CODE_ALREADY_VOTED            ErrorCode = 124
+	ErrorCode_ERROR_CODE_TAKEBACK_STALE           ErrorCode = 125
+	ErrorCode_ERROR_CODE_CHAT_TOO_LONG            ErrorCode = 140
+	ErrorCode_ERROR_CODE_CHAT_RATE_LIMITED        ErrorCode = 141
+	ErrorCode_ERROR_CODE_NOT_CHAT_MODERATOR       ErrorCode = 142
+	ErrorCode_ERROR_CODE_CHAT_MUTED               ErrorCode = 143
+	ErrorCode_ERROR_CODE_SPECTATOR_CHAT_DISABLED  ErrorCode = 144
+	ErrorCode_ERROR_CODE_INVALID_WHISPER          ErrorCode = 145
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:   "ERROR_CODE_UNSPECIFIED",
+		1:   "ERROR_CODE_INVALID_ACTION",
+		2:   "ERROR_CODE_BAD_REQUEST",
+		3:   "ERROR_CODE_RATE_LIMITED",
+		4:   "ERROR_CODE_GAME_PAUSED",
+		5:   "ERROR_CODE_GAME_EXPIRED",
+		6:   "ERROR_CODE_INVALID_STATE",
+		7:   "ERROR_CODE_SERVER_ERROR",
+		8:   "ERROR_CODE_SHUTTING_DOWN",
+		9:   "ERROR_CODE_REMOVED",
+		20:  "ERROR_CODE_NOT_YOUR_TURN",
+		21:  "ERROR_CODE_WRONG_PHASE",
+		22:  "ERROR_CODE_INVALID_TURN_PHASE",
+		23:  "ERROR_CODE_PLAYER_NOT_FOUND",
+		24:  "ERROR_CODE_NOT_HOST",
+		25:  "ERROR_CODE_PLAYERS_NOT_READY",
+		26:  "ERROR_CODE_NOT_ENOUGH_PLAYERS",
+		27:  "ERROR_CODE_LAST_PLAYER",
+		40:  "ERROR_CODE_INVALID_VERTEX",
+		41:  "ERROR_CODE_INVALID_EDGE",
+		42:  "ERROR_CODE_VERTEX_OCCUPIED",
+		43:  "ERROR_CODE_EDGE_OCCUPIED",
+		44:  "ERROR_CODE_DISTANCE_RULE",
+		45:  "ERROR_CODE_NOT_CONNECTED",
+		46:  "ERROR_CODE_CANNOT_UPGRADE",
+		47:  "ERROR_CODE_SETUP_SETTLEMENT_FIRST",
+		48:  "ERROR_CODE_SETUP_ROAD_NOT_CONNECTED",
+		49:  "ERROR_CODE_MAX_SETTLEMENTS",
+		50:  "ERROR_CODE_MAX_ROADS",
+		51:  "ERROR_CODE_MAX_CITIES",
+		60:  "ERROR_CODE_INSUFFICIENT_RESOURCES",
+		61:  "ERROR_CODE_BANK_EMPTY",
+		62:  "ERROR_CODE_INVALID_TRADE",
+		63:  "ERROR_CODE_TRADE_ALREADY_PENDING",
+		64:  "ERROR_CODE_TRADE_NOT_FOUND",
+		65:  "ERROR_CODE_NOT_TRADE_PARTICIPANT",
+		80:  "ERROR_CODE_DEV_DECK_EMPTY",
+		81:  "ERROR_CODE_DEV_CARD_NOT_HELD",
+		82:  "ERROR_CODE_DEV_CARD_TOO_NEW",
+		83:  "ERROR_CODE_INVALID_DEV_CARD_CHOICE",
+		100: "ERROR_CODE_NO_ROBBER_PHASE",
+		101: "ERROR_CODE_DISCARD_NOT_REQUIRED",
+		102: "ERROR_CODE_WRONG_DISCARD",
+		103: "ERROR_CODE_DISCARDS_PENDING",
+		104: "ERROR_CODE_NOT_ROBBER",
+		105: "ERROR_CODE_INVALID_HEX",
+		106: "ERROR_CODE_ROBBER_ALREADY_THERE",
+		107: "ERROR_CODE_INVALID_VICTIM",
+		120: "ERROR_CODE_TAKEBACK_NOT_ALLOWED",
+		121: "ERROR_CODE_TAKEBACK_PENDING",
+		122: "ERROR_CODE_NO_TAKEBACK_PENDING",
+		123: "ERROR_CODE_OWN_TAKEBACK",
+		124: "ERROR_CODE_ALREADY_VOTED",
+		125: "ERROR_CODE_TAKEBACK_STALE",
+		140: "ERROR_CODE_CHAT_TOO_LONG",
+		141: "ERROR_CODE_CHAT_RATE_LIMITED",
+		142: "ERROR_CODE_NOT_CHAT_MODERATOR",
+		143: "ERROR_CODE_CHAT_MUTED",
+		144: "ERROR_CODE_SPECTATOR_CHAT_DISABLED",
+		145: "ERROR_CODE_INVALID_WHISPER",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":              0,
+		"ERROR_CODE_INVALID_ACTION":           1,
+		"ERROR_CODE_BAD_REQUEST":              2,
+		"ERROR_CODE_RATE_LIMITED":             3,
+		"ERROR_CODE_GAME_PAUSED":              4,
+		"ERROR_CODE_GAME_EXPIRED":             5,
+		"ERROR_CODE_INVALID_STATE":            6,
+		"ERROR_CODE_SERVER_ERROR":             7,
+		"ERROR_CODE_SHUTTING_DOWN":            8,
+		"ERROR_CODE_REMOVED":                  9,
+		"ERROR_CODE_NOT_YOUR_TURN":            20,
+		"ERROR_CODE_WRONG_PHASE":              21,
+		"ERROR_CODE_INVALID_TURN_PHASE":       22,
+		"ERROR_CODE_PLAYER_NOT_FOUND":         23,
+		"ERROR_CODE_NOT_HOST":                 24,
+		"ERROR_CODE_PLAYERS_NOT_READY":        25,
+		"ERROR_CODE_NOT_ENOUGH_PLAYERS":       26,
+		"ERROR_CODE_LAST_PLAYER":              27,
+		"ERROR_CODE_INVALID_VERTEX":           40,
+		"ERROR_CODE_INVALID_EDGE":             41,
+		"ERROR_CODE_VERTEX_OCCUPIED":          42,
+		"ERROR_CODE_EDGE_OCCUPIED":            43,
+		"ERROR_CODE_DISTANCE_RULE":            44,
+		"ERROR_CODE_NOT_CONNECTED":            45,
+		"ERROR_CODE_CANNOT_UPGRADE":           46,
+		"ERROR_CODE_SETUP_SETTLEMENT_FIRST":   47,
+		"ERROR_CODE_SETUP_ROAD_NOT_CONNECTED": 48,
+		"ERROR_CODE_MAX_SETTLEMENTS":          49,
+		"ERROR_CODE_MAX_ROADS":                50,
+		"ERROR_CODE_MAX_CITIES":               51,
+		"ERROR_CODE_INSUFFICIENT_RESOURCES":   60,
+		"ERROR_CODE_BANK_EMPTY":               61,
+		"ERROR_CODE_INVALID_TRADE":            62,
+		"ERROR_CODE_TRADE_ALREADY_PENDING":    63,
+		"ERROR_CODE_TRADE_NOT_FOUND":          64,
+		"ERROR_CODE_NOT_TRADE_PARTICIPANT":    65,
+		"ERROR_CODE_DEV_DECK_EMPTY":           80,
+		"ERROR_CODE_DEV_CARD_NOT_HELD":        81,
+		"ERROR_CODE_DEV_CARD_TOO_NEW":         82,
+		"ERROR_CODE_INVALID_DEV_CARD_CHOICE":  83,
+		"ERROR_CODE_NO_ROBBER_PHASE":          100,
+		"ERROR_CODE_DISCARD_NOT_REQUIRED":     101,
+		"ERROR_CODE_WRONG_DISCARD":            102,
+		"ERROR_CODE_DISCARDS_PENDING":         103,
+		"ERROR_CODE_NOT_ROBBER":               104,
+		"ERROR_CODE_INVALID_HEX":              105,
+		"ERROR_CODE_ROBBER_ALREADY_THERE":     106,
+		"ERROR_CODE_INVALID_VICTIM":           107,
+		"ERROR_CODE_TAKEBACK_NOT_ALLOWED":     120,
+		"ERROR_CODE_TAKEBACK_PENDING":         121,
+		"ERROR_CODE_NO_TAKEBACK_PENDING":      122,
+		"ERROR_CODE_OWN_TAKEBACK":             123,
+		"ERROR_CODE_ALREADY_VOTED":            124,
+		"ERROR_CODE_TAKEBACK_STALE":           125,
+		"ERROR_CODE_CHAT_TOO_LONG":            140,
+		"ERROR_CODE_CHAT_RATE_LIMITED":        141,
+		"ERROR_CODE_NOT_CHAT_MODERATOR":       142,
+		"ERROR_CODE_CHAT_MUTED":               143,
+		"ERROR_CODE_SPECTATOR_CHAT_DISABLED":  144,
+		"ERROR_CODE_INVALID_WHISPER":          145,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_catan_v1_types_proto_enumTypes[10].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_catan_v1_types_proto_enumTypes[10]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{10}
+}
+
+type TournamentStatus int32
+
+const (
+	TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED  TournamentStatus = 0
+	TournamentStatus_TOURNAMENT_STATUS_REGISTRATION TournamentStatus = 1
+	TournamentStatus_TOURNAMENT_STATUS_RUNNING      TournamentStatus = 2
+	TournamentStatus_TOURNAMENT_STATUS_FINISHED     TournamentStatus = 3
+)
+
+// Enum value maps for TournamentStatus.
+var (
+	TournamentStatus_name = map[int32]string{
+		0: "TOURNAMENT_STATUS_UNSPECIFIED",
+		1: "TOURNAMENT_STATUS_REGISTRATION",
+		2: "TOURNAMENT_STATUS_RUNNING",
+		3: "TOURNAMENT_STATUS_FINISHED",
+	}
+	TournamentStatus_value = map[string]int32{
+		"TOURNAMENT_STATUS_UNSPECIFIED":  0,
+		"TOURNAMENT_STATUS_REGISTRATION": 1,
+		"TOURNAMENT_STATUS_RUNNING":      2,
+		"TOURNAMENT_STATUS_FINISHED":     3,
+	}
+)
+
+func (x TournamentStatus) Enum() *TournamentStatus {
+	p := new(TournamentStatus)
+	*p = x
+	return p
+}
+
+func (x TournamentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_catan_v1_types_proto_enumTypes[11].Descriptor()
+}
+
+func (TournamentStatus) Type() protoreflect.EnumType {
+	return &file_catan_v1_types_proto_enumTypes[11]
+}
+
+func (x TournamentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentStatus.Descriptor instead.
+func (TournamentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{11}
+}
+
+// How entrants go through to the next round. PLACEMENT takes the top
+// advance_per_table finishers of each table; POINTS takes as many entrants
+// in total from the top of the tournament points standings.
+type TournamentAdvancement int32
+
+const (
+	TournamentAdvancement_TOURNAMENT_ADVANCEMENT_UNSPECIFIED TournamentAdvancement = 0
+	TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT   TournamentAdvancement = 1
+	TournamentAdvancement_TOURNAMENT_ADVANCEMENT_POINTS      TournamentAdvancement = 2
+)
+
+// Enum value maps for TournamentAdvancement.
+var (
+	TournamentAdvancement_name = map[int32]string{
+		0: "TOURNAMENT_ADVANCEMENT_UNSPECIFIED",
+		1: "TOURNAMENT_ADVANCEMENT_PLACEMENT",
+		2: "TOURNAMENT_ADVANCEMENT_POINTS",
+	}
+	TournamentAdvancement_value = map[string]int32{
+		"TOURNAMENT_ADVANCEMENT_UNSPECIFIED": 0,
+		"TOURNAMENT_ADVANCEMENT_PLACEMENT":   1,
+		"TOURNAMENT_ADVANCEMENT_POINTS":      2,
+	}
+)
+
+func (x TournamentAdvancement) Enum() *TournamentAdvancement {
+	p := new(TournamentAdvancement)
+	*p = x
+	return p
+}
+
+func (x TournamentAdvancement) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentAdvancement) Descriptor() protoreflect.EnumDescriptor {
+	return file_catan_v1_types_proto_enumTypes[12].Descriptor()
+}
+
+func (TournamentAdvancement) Type() protoreflect.EnumType {
+	return &file_catan_v1_types_proto_enumTypes[12]
+}
+
+func (x TournamentAdvancement) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentAdvancement.Descriptor instead.
+func (TournamentAdvancement) EnumDescriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{12}
+}
+
+type PendingActionKind int32
+
+const (
+	PendingActionKind_PENDING_ACTION_KIND_UNSPECIFIED      PendingActionKind = 0
+	PendingActionKind_PENDING_ACTION_KIND_PLACE_SETTLEMENT PendingActionKind = 1
+	PendingActionKind_PENDING_ACTION_KIND_PLACE_ROAD       PendingActionKind = 2
+	PendingActionKind_PENDING_ACTION_KIND_ROLL             PendingActionKind = 3
+	PendingActionKind_PENDING_ACTION_KIND_PLAY_TURN        PendingActionKind = 4
+	PendingActionKind_PENDING_ACTION_KIND_DISCARD          PendingActionKind = 5
+	PendingActionKind_PENDING_ACTION_KIND_MOVE_ROBBER      PendingActionKind = 6
+	PendingActionKind_PENDING_ACTION_KIND_STEAL            PendingActionKind = 7
+	PendingActionKind_PENDING_ACTION_KIND_RESPOND_TRADE    PendingActionKind = 8
+)
+
+// Enum value maps for PendingActionKind.
+var (
+	PendingActionKind_name = map[int32]string{
+		0: "PENDING_ACTION_KIND_UNSPECIFIED",
+		1: "PENDING_ACTION_KIND_PLACE_SETTLEMENT",
+		2: "PENDING_ACTION_KIND_PLACE_ROAD",
+		3: "PENDING_ACTION_KIND_ROLL",
+		4: "PENDING_ACTION_KIND_PLAY_TURN",
+		5: "PENDING_ACTION_KIND_DISCARD",
+		6: "PENDING_ACTION_KIND_MOVE_ROBBER",
+		7: "PENDING_ACTION_KIND_STEAL",
+		8: "PENDING_ACTION_KIND_RESPOND_TRADE",
+	}
+	PendingActionKind_value = map[string]int32{
+		"PENDING_ACTION_KIND_UNSPECIFIED":      0,
+		"PENDING_ACTION_KIND_PLACE_SETTLEMENT": 1,
+		"PENDING_ACTION_KIND_PLACE_ROAD":       2,
+		"PENDING_ACTION_KIND_ROLL":             3,
+		"PENDING_ACTION_KIND_PLAY_TURN":        4,
+		"PENDING_ACTION_KIND_DISCARD":          5,
+		"PENDING_ACTION_KIND_MOVE_ROBBER":      6,
+		"PENDING_ACTION_KIND_STEAL":            7,
+		"PENDING_ACTION_KIND_RESPOND_TRADE":    8,
+	}
+)
+
+func (x PendingActionKind) Enum() *PendingActionKind {
+	p := new(PendingActionKind)
+	*p = x
+	return p
+}
+
+func (x PendingActionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PendingActionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_catan_v1_types_proto_enumTypes[13].Descriptor()
+}
+
+func (PendingActionKind) Type() protoreflect.EnumType {
+	return &file_catan_v1_types_proto_enumTypes[13]
+}
+
+func (x PendingActionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PendingActionKind.Descriptor instead.
+func (PendingActionKind) EnumDescriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{13}
+}
+
 // Axial coordinates for hex grid
 type HexCoord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1266,24 +1660,38 @@ func (x *BoardState) GetPorts() []*Port {
 
 // Complete game state
 type GameState struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code                string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // 6-character join code
-	Board               *BoardState            `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
-	Players             []*PlayerState         `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
-	CurrentTurn         int32                  `protobuf:"varint,5,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"` // Index of current player
-	TurnPhase           TurnPhase              `protobuf:"varint,6,opt,name=turn_phase,json=turnPhase,proto3,enum=catan.v1.TurnPhase" json:"turn_phase,omitempty"`
-	Dice                []int32                `protobuf:"varint,7,rep,packed,name=dice,proto3" json:"dice,omitempty"` // Always 2 values
-	Status              GameStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=catan.v1.GameStatus" json:"status,omitempty"`
-	LongestRoadPlayerId *string                `protobuf:"bytes,9,opt,name=longest_road_player_id,json=longestRoadPlayerId,proto3,oneof" json:"longest_road_player_id,omitempty"`
-	LargestArmyPlayerId *string                `protobuf:"bytes,10,opt,name=largest_army_player_id,json=largestArmyPlayerId,proto3,oneof" json:"largest_army_player_id,omitempty"`
-	SetupPhase          *SetupPhase            `protobuf:"bytes,11,opt,name=setup_phase,json=setupPhase,proto3,oneof" json:"setup_phase,omitempty"`    // Present during setup status
-	RobberPhase         *RobberPhase           `protobuf:"bytes,12,opt,name=robber_phase,json=robberPhase,proto3,oneof" json:"robber_phase,omitempty"` // Present during robber actions
-	PendingTrades       []*TradeOffer          `protobuf:"bytes,13,rep,name=pending_trades,json=pendingTrades,proto3" json:"pending_trades,omitempty"`
-	DevCardDeck         []DevCardType          `protobuf:"varint,14,rep,packed,name=dev_card_deck,json=devCardDeck,proto3,enum=catan.v1.DevCardType" json:"dev_card_deck,omitempty"` // Remaining cards in deck (shuffled)
-	TurnCounter         int32                  `protobuf:"varint,15,opt,name=turn_counter,json=turnCounter,proto3" json:"turn_counter,omitempty"`                                    // Global turn counter (incremented each turn)
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code                    string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // 6-character join code
+	Board                   *BoardState            `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	Players                 []*PlayerState         `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	CurrentTurn             int32                  `protobuf:"varint,5,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"` // Index of current player
+	TurnPhase               TurnPhase              `protobuf:"varint,6,opt,name=turn_phase,json=turnPhase,proto3,enum=catan.v1.TurnPhase" json:"turn_phase,omitempty"`
+	Dice                    []int32                `protobuf:"varint,7,rep,packed,name=dice,proto3" json:"dice,omitempty"` // Always 2 values
+	Status                  GameStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=catan.v1.GameStatus" json:"status,omitempty"`
+	LongestRoadPlayerId     *string                `protobuf:"bytes,9,opt,name=longest_road_player_id,json=longestRoadPlayerId,proto3,oneof" json:"longest_road_player_id,omitempty"`
+	LargestArmyPlayerId     *string                `protobuf:"bytes,10,opt,name=largest_army_player_id,json=largestArmyPlayerId,proto3,oneof" json:"largest_army_player_id,omitempty"`
+	SetupPhase              *SetupPhase            `protobuf:"bytes,11,opt,name=setup_phase,json=setupPhase,proto3,oneof" json:"setup_phase,omitempty"`    // Present during setup status
+	RobberPhase             *RobberPhase           `protobuf:"bytes,12,opt,name=robber_phase,json=robberPhase,proto3,oneof" json:"robber_phase,omitempty"` // Present during robber actions
+	PendingTrades           []*TradeOffer          `protobuf:"bytes,13,rep,name=pending_trades,json=pendingTrades,proto3" json:"pending_trades,omitempty"`
+	DevCardDeck             []DevCardType          `protobuf:"varint,14,rep,packed,name=dev_card_deck,json=devCardDeck,proto3,enum=catan.v1.DevCardType" json:"dev_card_deck,omitempty"`                                                        // Remaining cards in deck (shuffled)
+	TurnCounter             int32                  `protobuf:"varint,15,opt,name=turn_counter,json=turnCounter,proto3" json:"turn_counter,omitempty"`                                                                                           // Global turn counter (incremented each turn)
+	Seed                    int64                  `protobuf:"varint,16,opt,name=seed,proto3" json:"seed,omitempty"`                                                                                                                            // Seed the board and dev card deck were generated from
+	ExportApprovedPlayerIds []string               `protobuf:"bytes,17,rep,name=export_approved_player_ids,json=exportApprovedPlayerIds,proto3" json:"export_approved_player_ids,omitempty"`                                                    // Players agreeing to export this unfinished game; cleared by the next command
+	StateVersion            int32                  `protobuf:"varint,18,opt,name=state_version,json=stateVersion,proto3" json:"state_version,omitempty"`                                                                                        // Stored schema version, see game.CurrentStateVersion
+	Paused                  bool                   `protobuf:"varint,19,opt,name=paused,proto3" json:"paused,omitempty"`                                                                                                                        // Set by an administrator; commands are refused while paused
+	EndedByAdmin            bool                   `protobuf:"varint,20,opt,name=ended_by_admin,json=endedByAdmin,proto3" json:"ended_by_admin,omitempty"`                                                                                      // Finished by an administrator rather than won; never archived or rated
+	ChatMutedPlayerIds      []string               `protobuf:"bytes,21,rep,name=chat_muted_player_ids,json=chatMutedPlayerIds,proto3" json:"chat_muted_player_ids,omitempty"`                                                                   // Players the host has muted in chat
+	SpectatorChatDisabled   bool                   `protobuf:"varint,22,opt,name=spectator_chat_disabled,json=spectatorChatDisabled,proto3" json:"spectator_chat_disabled,omitempty"`                                                           // Set by the host; connections without a seat may not chat
+	PendingTakeback         *TakebackRequest       `protobuf:"bytes,23,opt,name=pending_takeback,json=pendingTakeback,proto3" json:"pending_takeback,omitempty"`                                                                                // Present while a takeback awaits votes
+	TakenBackSeq            int64                  `protobuf:"varint,24,opt,name=taken_back_seq,json=takenBackSeq,proto3" json:"taken_back_seq,omitempty"`                                                                                      // Logged event the last approved takeback undid
+	Series                  *Series                `protobuf:"bytes,25,opt,name=series,proto3" json:"series,omitempty"`                                                                                                                         // Present in rematches: the tally of the games before this one
+	RematchGameId           string                 `protobuf:"bytes,26,opt,name=rematch_game_id,json=rematchGameId,proto3" json:"rematch_game_id,omitempty"`                                                                                    // Set on a finished game once its rematch was created
+	RematchPlayerIds        map[string]string      `protobuf:"bytes,27,rep,name=rematch_player_ids,json=rematchPlayerIds,proto3" json:"rematch_player_ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Player ID here -> their seat in the rematch
+	TournamentId            string                 `protobuf:"bytes,28,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`                                                                                         // Set on tournament tables
+	Correspondence          *Correspondence        `protobuf:"bytes,29,opt,name=correspondence,proto3" json:"correspondence,omitempty"`                                                                                                         // Present for correspondence games
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *GameState) Reset() {
@@ -1421,107 +1829,133 @@ func (x *GameState) GetTurnCounter() int32 {
 	return 0
 }
 
-// Tracks the Robber phase state, including pending discards and steps.
-type RobberPhase struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Player IDs still required to discard (if >7 cards when 7 is rolled).
-	DiscardPending []string `protobuf:"bytes,1,rep,name=discard_pending,json=discardPending,proto3" json:"discard_pending,omitempty"`
-	// How many cards each player must discard.
-	DiscardRequired map[string]int32 `protobuf:"bytes,2,rep,name=discard_required,json=discardRequired,proto3" json:"discard_required,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	// Player ID currently expected to move the robber (usually the roller).
-	MovePendingPlayerId *string `protobuf:"bytes,3,opt,name=move_pending_player_id,json=movePendingPlayerId,proto3,oneof" json:"move_pending_player_id,omitempty"`
-	// Player ID currently expected to perform a steal (if any).
-	StealPendingPlayerId *string `protobuf:"bytes,4,opt,name=steal_pending_player_id,json=stealPendingPlayerId,proto3,oneof" json:"steal_pending_player_id,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+func (x *GameState) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
 }
 
-func (x *RobberPhase) Reset() {
-	*x = RobberPhase{}
-	mi := &file_catan_v1_types_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GameState) GetExportApprovedPlayerIds() []string {
+	if x != nil {
+		return x.ExportApprovedPlayerIds
+	}
+	return nil
 }
 
-func (x *RobberPhase) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GameState) GetStateVersion() int32 {
+	if x != nil {
+		return x.StateVersion
+	}
+	return 0
 }
 
-func (*RobberPhase) ProtoMessage() {}
+func (x *GameState) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
 
-func (x *RobberPhase) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[11]
+func (x *GameState) GetEndedByAdmin() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EndedByAdmin
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use RobberPhase.ProtoReflect.Descriptor instead.
-func (*RobberPhase) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{11}
+func (x *GameState) GetChatMutedPlayerIds() []string {
+	if x != nil {
+		return x.ChatMutedPlayerIds
+	}
+	return nil
 }
 
-func (x *RobberPhase) GetDiscardPending() []string {
+func (x *GameState) GetSpectatorChatDisabled() bool {
 	if x != nil {
-		return x.DiscardPending
+		return x.SpectatorChatDisabled
+	}
+	return false
+}
+
+func (x *GameState) GetPendingTakeback() *TakebackRequest {
+	if x != nil {
+		return x.PendingTakeback
 	}
 	return nil
 }
 
-func (x *RobberPhase) GetDiscardRequired() map[string]int32 {
+func (x *GameState) GetTakenBackSeq() int64 {
 	if x != nil {
-		return x.DiscardRequired
+		return x.TakenBackSeq
+	}
+	return 0
+}
+
+func (x *GameState) GetSeries() *Series {
+	if x != nil {
+		return x.Series
 	}
 	return nil
 }
 
-func (x *RobberPhase) GetMovePendingPlayerId() string {
-	if x != nil && x.MovePendingPlayerId != nil {
-		return *x.MovePendingPlayerId
+func (x *GameState) GetRematchGameId() string {
+	if x != nil {
+		return x.RematchGameId
 	}
 	return ""
 }
 
-func (x *RobberPhase) GetStealPendingPlayerId() string {
-	if x != nil && x.StealPendingPlayerId != nil {
-		return *x.StealPendingPlayerId
+func (x *GameState) GetRematchPlayerIds() map[string]string {
+	if x != nil {
+		return x.RematchPlayerIds
+	}
+	return nil
+}
+
+func (x *GameState) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
 	}
 	return ""
 }
 
-// A trade offer between players
-type TradeOffer struct {
+func (x *GameState) GetCorrespondence() *Correspondence {
+	if x != nil {
+		return x.Correspondence
+	}
+	return nil
+}
+
+// Rematches between the same players, optionally as a best-of-N series.
+// Tallies are keyed by player ID in the game that carries them.
+type Series struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProposerId    string                 `protobuf:"bytes,2,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
-	TargetId      *string                `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3,oneof" json:"target_id,omitempty"` // Empty = open to all
-	Offering      *ResourceCount         `protobuf:"bytes,4,opt,name=offering,proto3" json:"offering,omitempty"`
-	Requesting    *ResourceCount         `protobuf:"bytes,5,opt,name=requesting,proto3" json:"requesting,omitempty"`
-	Status        TradeStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=catan.v1.TradeStatus" json:"status,omitempty"`
+	BestOf        int32                  `protobuf:"varint,2,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`                                                             // 0 for open-ended rematches
+	GameNumber    int32                  `protobuf:"varint,3,opt,name=game_number,json=gameNumber,proto3" json:"game_number,omitempty"`                                                 // 1 for the first game of the series
+	Wins          map[string]int32       `protobuf:"bytes,4,rep,name=wins,proto3" json:"wins,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`     // Games won
+	Points        map[string]int32       `protobuf:"bytes,5,rep,name=points,proto3" json:"points,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Victory points summed over finished games
+	WinnerId      string                 `protobuf:"bytes,6,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`                                                        // Set once a player has won a majority of best_of games
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TradeOffer) Reset() {
-	*x = TradeOffer{}
-	mi := &file_catan_v1_types_proto_msgTypes[12]
+func (x *Series) Reset() {
+	*x = Series{}
+	mi := &file_catan_v1_types_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TradeOffer) String() string {
+func (x *Series) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TradeOffer) ProtoMessage() {}
+func (*Series) ProtoMessage() {}
 
-func (x *TradeOffer) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[12]
+func (x *Series) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1532,77 +1966,80 @@ func (x *TradeOffer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TradeOffer.ProtoReflect.Descriptor instead.
-func (*TradeOffer) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use Series.ProtoReflect.Descriptor instead.
+func (*Series) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *TradeOffer) GetId() string {
+func (x *Series) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *TradeOffer) GetProposerId() string {
+func (x *Series) GetBestOf() int32 {
 	if x != nil {
-		return x.ProposerId
+		return x.BestOf
 	}
-	return ""
+	return 0
 }
 
-func (x *TradeOffer) GetTargetId() string {
-	if x != nil && x.TargetId != nil {
-		return *x.TargetId
+func (x *Series) GetGameNumber() int32 {
+	if x != nil {
+		return x.GameNumber
 	}
-	return ""
+	return 0
 }
 
-func (x *TradeOffer) GetOffering() *ResourceCount {
+func (x *Series) GetWins() map[string]int32 {
 	if x != nil {
-		return x.Offering
+		return x.Wins
 	}
 	return nil
 }
 
-func (x *TradeOffer) GetRequesting() *ResourceCount {
+func (x *Series) GetPoints() map[string]int32 {
 	if x != nil {
-		return x.Requesting
+		return x.Points
 	}
 	return nil
 }
 
-func (x *TradeOffer) GetStatus() TradeStatus {
+func (x *Series) GetWinnerId() string {
 	if x != nil {
-		return x.Status
+		return x.WinnerId
 	}
-	return TradeStatus_TRADE_STATUS_UNSPECIFIED
+	return ""
 }
 
-// Setup phase tracking for initial placement
-type SetupPhase struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Round            int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`                                                 // 1 or 2
-	PlacementsInTurn int32                  `protobuf:"varint,2,opt,name=placements_in_turn,json=placementsInTurn,proto3" json:"placements_in_turn,omitempty"` // 0 = need settlement, 1 = need road
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// A request by the current player to undo their last action, awaiting the
+// other players' votes.
+type TakebackRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequesterId       string                 `protobuf:"bytes,1,opt,name=requester_id,json=requesterId,proto3" json:"requester_id,omitempty"`
+	EventSeq          int64                  `protobuf:"varint,2,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"` // Logged event the takeback would undo
+	Action            string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                      // Client message kind of that event, e.g. "buildStructure"
+	ApprovedPlayerIds []string               `protobuf:"bytes,4,rep,name=approved_player_ids,json=approvedPlayerIds,proto3" json:"approved_player_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *SetupPhase) Reset() {
-	*x = SetupPhase{}
-	mi := &file_catan_v1_types_proto_msgTypes[13]
+func (x *TakebackRequest) Reset() {
+	*x = TakebackRequest{}
+	mi := &file_catan_v1_types_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetupPhase) String() string {
+func (x *TakebackRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetupPhase) ProtoMessage() {}
+func (*TakebackRequest) ProtoMessage() {}
 
-func (x *SetupPhase) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[13]
+func (x *TakebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,47 +2050,973 @@ func (x *SetupPhase) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetupPhase.ProtoReflect.Descriptor instead.
-func (*SetupPhase) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use TakebackRequest.ProtoReflect.Descriptor instead.
+func (*TakebackRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SetupPhase) GetRound() int32 {
+func (x *TakebackRequest) GetRequesterId() string {
 	if x != nil {
-		return x.Round
+		return x.RequesterId
 	}
-	return 0
+	return ""
 }
 
-func (x *SetupPhase) GetPlacementsInTurn() int32 {
+func (x *TakebackRequest) GetEventSeq() int64 {
 	if x != nil {
-		return x.PlacementsInTurn
+		return x.EventSeq
 	}
 	return 0
 }
 
-type CreateGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerName    string                 `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TakebackRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
 }
 
-func (x *CreateGameRequest) Reset() {
-	*x = CreateGameRequest{}
+func (x *TakebackRequest) GetApprovedPlayerIds() []string {
+	if x != nil {
+		return x.ApprovedPlayerIds
+	}
+	return nil
+}
+
+// Tracks the Robber phase state, including pending discards and steps.
+type RobberPhase struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Player IDs still required to discard (if >7 cards when 7 is rolled).
+	DiscardPending []string `protobuf:"bytes,1,rep,name=discard_pending,json=discardPending,proto3" json:"discard_pending,omitempty"`
+	// How many cards each player must discard.
+	DiscardRequired map[string]int32 `protobuf:"bytes,2,rep,name=discard_required,json=discardRequired,proto3" json:"discard_required,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Player ID currently expected to move the robber (usually the roller).
+	MovePendingPlayerId *string `protobuf:"bytes,3,opt,name=move_pending_player_id,json=movePendingPlayerId,proto3,oneof" json:"move_pending_player_id,omitempty"`
+	// Player ID currently expected to perform a steal (if any).
+	StealPendingPlayerId *string `protobuf:"bytes,4,opt,name=steal_pending_player_id,json=stealPendingPlayerId,proto3,oneof" json:"steal_pending_player_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RobberPhase) Reset() {
+	*x = RobberPhase{}
+	mi := &file_catan_v1_types_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RobberPhase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RobberPhase) ProtoMessage() {}
+
+func (x *RobberPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RobberPhase.ProtoReflect.Descriptor instead.
+func (*RobberPhase) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RobberPhase) GetDiscardPending() []string {
+	if x != nil {
+		return x.DiscardPending
+	}
+	return nil
+}
+
+func (x *RobberPhase) GetDiscardRequired() map[string]int32 {
+	if x != nil {
+		return x.DiscardRequired
+	}
+	return nil
+}
+
+func (x *RobberPhase) GetMovePendingPlayerId() string {
+	if x != nil && x.MovePendingPlayerId != nil {
+		return *x.MovePendingPlayerId
+	}
+	return ""
+}
+
+func (x *RobberPhase) GetStealPendingPlayerId() string {
+	if x != nil && x.StealPendingPlayerId != nil {
+		return *x.StealPendingPlayerId
+	}
+	return ""
+}
+
+// A trade offer between players
+type TradeOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProposerId    string                 `protobuf:"bytes,2,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	TargetId      *string                `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3,oneof" json:"target_id,omitempty"` // Empty = open to all
+	Offering      *ResourceCount         `protobuf:"bytes,4,opt,name=offering,proto3" json:"offering,omitempty"`
+	Requesting    *ResourceCount         `protobuf:"bytes,5,opt,name=requesting,proto3" json:"requesting,omitempty"`
+	Status        TradeStatus            `protobuf:"varint,6,opt,name=status,proto3,enum=catan.v1.TradeStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeOffer) Reset() {
+	*x = TradeOffer{}
+	mi := &file_catan_v1_types_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeOffer) ProtoMessage() {}
+
+func (x *TradeOffer) ProtoReflect() protoreflect.Message {
 	mi := &file_catan_v1_types_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeOffer.ProtoReflect.Descriptor instead.
+func (*TradeOffer) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TradeOffer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TradeOffer) GetProposerId() string {
+	if x != nil {
+		return x.ProposerId
+	}
+	return ""
+}
+
+func (x *TradeOffer) GetTargetId() string {
+	if x != nil && x.TargetId != nil {
+		return *x.TargetId
+	}
+	return ""
+}
+
+func (x *TradeOffer) GetOffering() *ResourceCount {
+	if x != nil {
+		return x.Offering
+	}
+	return nil
+}
+
+func (x *TradeOffer) GetRequesting() *ResourceCount {
+	if x != nil {
+		return x.Requesting
+	}
+	return nil
+}
+
+func (x *TradeOffer) GetStatus() TradeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TradeStatus_TRADE_STATUS_UNSPECIFIED
+}
+
+// Setup phase tracking for initial placement
+type SetupPhase struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Round            int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`                                                 // 1 or 2
+	PlacementsInTurn int32                  `protobuf:"varint,2,opt,name=placements_in_turn,json=placementsInTurn,proto3" json:"placements_in_turn,omitempty"` // 0 = need settlement, 1 = need road
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetupPhase) Reset() {
+	*x = SetupPhase{}
+	mi := &file_catan_v1_types_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupPhase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupPhase) ProtoMessage() {}
+
+func (x *SetupPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetupPhase.ProtoReflect.Descriptor instead.
+func (*SetupPhase) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetupPhase) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *SetupPhase) GetPlacementsInTurn() int32 {
+	if x != nil {
+		return x.PlacementsInTurn
+	}
+	return 0
+}
+
+type CreateGameRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerName     string                 `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Correspondence *Correspondence        `protobuf:"bytes,2,opt,name=correspondence,proto3" json:"correspondence,omitempty"` // Only turn_hours is read
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_catan_v1_types_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateGameRequest) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *CreateGameRequest) GetCorrespondence() *Correspondence {
+	if x != nil {
+		return x.Correspondence
+	}
+	return nil
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	SessionToken  string                 `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,4,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateGameResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *CreateGameResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreateGameResponse) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *CreateGameResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type JoinGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerName    string                 `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // CatanService only; the HTTP route takes it from the path
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameRequest) Reset() {
+	*x = JoinGameRequest{}
+	mi := &file_catan_v1_types_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameRequest) ProtoMessage() {}
+
+func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
+func (*JoinGameRequest) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *JoinGameRequest) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type JoinGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	SessionToken  string                 `protobuf:"bytes,2,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Players       []*PlayerInfo          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinGameResponse) Reset() {
+	*x = JoinGameResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameResponse) ProtoMessage() {}
+
+func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
+func (*JoinGameResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *JoinGameResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *JoinGameResponse) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *JoinGameResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *JoinGameResponse) GetPlayers() []*PlayerInfo {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type PlayerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color         PlayerColor            `protobuf:"varint,3,opt,name=color,proto3,enum=catan.v1.PlayerColor" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerInfo) Reset() {
+	*x = PlayerInfo{}
+	mi := &file_catan_v1_types_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerInfo) ProtoMessage() {}
+
+func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
+func (*PlayerInfo) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PlayerInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetColor() PlayerColor {
+	if x != nil {
+		return x.Color
+	}
+	return PlayerColor_PLAYER_COLOR_UNSPECIFIED
+}
+
+type GameInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Status        GameStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=catan.v1.GameStatus" json:"status,omitempty"`
+	PlayerCount   int32                  `protobuf:"varint,3,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
+	Players       []*PlayerInfo          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameInfoResponse) Reset() {
+	*x = GameInfoResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameInfoResponse) ProtoMessage() {}
+
+func (x *GameInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameInfoResponse.ProtoReflect.Descriptor instead.
+func (*GameInfoResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GameInfoResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GameInfoResponse) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *GameInfoResponse) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *GameInfoResponse) GetPlayers() []*PlayerInfo {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+// A tournament seats its entrants at tables of 3-4 players, one game per
+// table, and cuts the field after each round until one table remains. The
+// winner of that final table wins the tournament.
+type Tournament struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status          TournamentStatus       `protobuf:"varint,3,opt,name=status,proto3,enum=catan.v1.TournamentStatus" json:"status,omitempty"`
+	Advancement     TournamentAdvancement  `protobuf:"varint,4,opt,name=advancement,proto3,enum=catan.v1.TournamentAdvancement" json:"advancement,omitempty"`
+	AdvancePerTable int32                  `protobuf:"varint,5,opt,name=advance_per_table,json=advancePerTable,proto3" json:"advance_per_table,omitempty"`
+	Seed            int64                  `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`                                          // Seeds the first round's seating
+	OrganizerToken  string                 `protobuf:"bytes,7,opt,name=organizer_token,json=organizerToken,proto3" json:"organizer_token,omitempty"` // Secret; stripped from API responses
+	Entrants        []*TournamentEntrant   `protobuf:"bytes,8,rep,name=entrants,proto3" json:"entrants,omitempty"`
+	Rounds          []*TournamentRound     `protobuf:"bytes,9,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	Standings       []*TournamentStanding  `protobuf:"bytes,10,rep,name=standings,proto3" json:"standings,omitempty"`               // Best first
+	WinnerId        string                 `protobuf:"bytes,11,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // Entrant ID, set when the tournament finishes
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Tournament) Reset() {
+	*x = Tournament{}
+	mi := &file_catan_v1_types_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tournament) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tournament) ProtoMessage() {}
+
+func (x *Tournament) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tournament.ProtoReflect.Descriptor instead.
+func (*Tournament) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Tournament) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tournament) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tournament) GetStatus() TournamentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Tournament) GetAdvancement() TournamentAdvancement {
+	if x != nil {
+		return x.Advancement
+	}
+	return TournamentAdvancement_TOURNAMENT_ADVANCEMENT_UNSPECIFIED
+}
+
+func (x *Tournament) GetAdvancePerTable() int32 {
+	if x != nil {
+		return x.AdvancePerTable
+	}
+	return 0
+}
+
+func (x *Tournament) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Tournament) GetOrganizerToken() string {
+	if x != nil {
+		return x.OrganizerToken
+	}
+	return ""
+}
+
+func (x *Tournament) GetEntrants() []*TournamentEntrant {
+	if x != nil {
+		return x.Entrants
+	}
+	return nil
+}
+
+func (x *Tournament) GetRounds() []*TournamentRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Tournament) GetStandings() []*TournamentStanding {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+func (x *Tournament) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+type TournamentEntrant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                 // Secret; stripped from API responses
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for anonymous entrants; stripped from API responses
+	Eliminated    bool                   `protobuf:"varint,5,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentEntrant) Reset() {
+	*x = TournamentEntrant{}
+	mi := &file_catan_v1_types_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentEntrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentEntrant) ProtoMessage() {}
+
+func (x *TournamentEntrant) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentEntrant.ProtoReflect.Descriptor instead.
+func (*TournamentEntrant) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TournamentEntrant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TournamentEntrant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TournamentEntrant) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TournamentEntrant) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TournamentEntrant) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+type TournamentRound struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"` // 1 for the first round
+	Tables        []*TournamentTable     `protobuf:"bytes,2,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentRound) Reset() {
+	*x = TournamentRound{}
+	mi := &file_catan_v1_types_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRound) ProtoMessage() {}
+
+func (x *TournamentRound) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRound.ProtoReflect.Descriptor instead.
+func (*TournamentRound) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TournamentRound) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TournamentRound) GetTables() []*TournamentTable {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type TournamentTable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Seats         []*TournamentSeat      `protobuf:"bytes,3,rep,name=seats,proto3" json:"seats,omitempty"` // In seat order
+	Finished      bool                   `protobuf:"varint,4,opt,name=finished,proto3" json:"finished,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,5,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // Entrant ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentTable) Reset() {
+	*x = TournamentTable{}
+	mi := &file_catan_v1_types_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentTable) ProtoMessage() {}
+
+func (x *TournamentTable) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentTable.ProtoReflect.Descriptor instead.
+func (*TournamentTable) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TournamentTable) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *TournamentTable) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TournamentTable) GetSeats() []*TournamentSeat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *TournamentTable) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *TournamentTable) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+type TournamentSeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntrantId     string                 `protobuf:"bytes,1,opt,name=entrant_id,json=entrantId,proto3" json:"entrant_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Points        int32                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"` // Final victory points, set once the table finishes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentSeat) Reset() {
+	*x = TournamentSeat{}
+	mi := &file_catan_v1_types_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameRequest) String() string {
+func (x *TournamentSeat) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameRequest) ProtoMessage() {}
+func (*TournamentSeat) ProtoMessage() {}
 
-func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[14]
+func (x *TournamentSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1664,43 +3027,58 @@ func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
-func (*CreateGameRequest) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use TournamentSeat.ProtoReflect.Descriptor instead.
+func (*TournamentSeat) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *CreateGameRequest) GetPlayerName() string {
+func (x *TournamentSeat) GetEntrantId() string {
 	if x != nil {
-		return x.PlayerName
+		return x.EntrantId
 	}
 	return ""
 }
 
-type CreateGameResponse struct {
+func (x *TournamentSeat) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *TournamentSeat) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type TournamentStanding struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
-	SessionToken  string                 `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
-	PlayerId      string                 `protobuf:"bytes,4,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	EntrantId     string                 `protobuf:"bytes,1,opt,name=entrant_id,json=entrantId,proto3" json:"entrant_id,omitempty"`
+	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Points        int32                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"` // Victory points over all finished tables
+	Wins          int32                  `protobuf:"varint,4,opt,name=wins,proto3" json:"wins,omitempty"`
+	Games         int32                  `protobuf:"varint,5,opt,name=games,proto3" json:"games,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGameResponse) Reset() {
-	*x = CreateGameResponse{}
-	mi := &file_catan_v1_types_proto_msgTypes[15]
+func (x *TournamentStanding) Reset() {
+	*x = TournamentStanding{}
+	mi := &file_catan_v1_types_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameResponse) String() string {
+func (x *TournamentStanding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameResponse) ProtoMessage() {}
+func (*TournamentStanding) ProtoMessage() {}
 
-func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[15]
+func (x *TournamentStanding) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,61 +3089,121 @@ func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
-func (*CreateGameResponse) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use TournamentStanding.ProtoReflect.Descriptor instead.
+func (*TournamentStanding) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *CreateGameResponse) GetGameId() string {
+func (x *TournamentStanding) GetEntrantId() string {
 	if x != nil {
-		return x.GameId
+		return x.EntrantId
 	}
 	return ""
 }
 
-func (x *CreateGameResponse) GetCode() string {
+func (x *TournamentStanding) GetRank() int32 {
 	if x != nil {
-		return x.Code
+		return x.Rank
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateGameResponse) GetSessionToken() string {
+func (x *TournamentStanding) GetPoints() int32 {
 	if x != nil {
-		return x.SessionToken
+		return x.Points
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateGameResponse) GetPlayerId() string {
+func (x *TournamentStanding) GetWins() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+type CreateTournamentResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tournament     *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	OrganizerToken string                 `protobuf:"bytes,2,opt,name=organizer_token,json=organizerToken,proto3" json:"organizer_token,omitempty"` // Starts the tournament; keep it private
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateTournamentResponse) Reset() {
+	*x = CreateTournamentResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentResponse) ProtoMessage() {}
+
+func (x *CreateTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentResponse.ProtoReflect.Descriptor instead.
+func (*CreateTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+func (x *CreateTournamentResponse) GetOrganizerToken() string {
+	if x != nil {
+		return x.OrganizerToken
 	}
 	return ""
 }
 
-type JoinGameRequest struct {
+type RegisterTournamentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerName    string                 `protobuf:"bytes,1,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	EntrantId     string                 `protobuf:"bytes,1,opt,name=entrant_id,json=entrantId,proto3" json:"entrant_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Claims the entrant's seat each round
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinGameRequest) Reset() {
-	*x = JoinGameRequest{}
-	mi := &file_catan_v1_types_proto_msgTypes[16]
+func (x *RegisterTournamentResponse) Reset() {
+	*x = RegisterTournamentResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinGameRequest) String() string {
+func (x *RegisterTournamentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinGameRequest) ProtoMessage() {}
+func (*RegisterTournamentResponse) ProtoMessage() {}
 
-func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[16]
+func (x *RegisterTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1776,43 +3214,47 @@ func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
-func (*JoinGameRequest) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use RegisterTournamentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *JoinGameRequest) GetPlayerName() string {
+func (x *RegisterTournamentResponse) GetEntrantId() string {
 	if x != nil {
-		return x.PlayerName
+		return x.EntrantId
 	}
 	return ""
 }
 
-type JoinGameResponse struct {
+func (x *RegisterTournamentResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListTournamentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	SessionToken  string                 `protobuf:"bytes,2,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
-	PlayerId      string                 `protobuf:"bytes,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	Players       []*PlayerInfo          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
+	Tournaments   []*Tournament          `protobuf:"bytes,1,rep,name=tournaments,proto3" json:"tournaments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinGameResponse) Reset() {
-	*x = JoinGameResponse{}
-	mi := &file_catan_v1_types_proto_msgTypes[17]
+func (x *ListTournamentsResponse) Reset() {
+	*x = ListTournamentsResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinGameResponse) String() string {
+func (x *ListTournamentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinGameResponse) ProtoMessage() {}
+func (*ListTournamentsResponse) ProtoMessage() {}
 
-func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[17]
+func (x *ListTournamentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1823,63 +3265,107 @@ func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
-func (*JoinGameResponse) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ListTournamentsResponse.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *JoinGameResponse) GetGameId() string {
+func (x *ListTournamentsResponse) GetTournaments() []*Tournament {
 	if x != nil {
-		return x.GameId
+		return x.Tournaments
 	}
-	return ""
+	return nil
 }
 
-func (x *JoinGameResponse) GetSessionToken() string {
+// Correspondence games are played over days: each turn has a long deadline,
+// players are notified when the game is waiting on them, and a turn left
+// past its deadline is forfeited where the rules allow it.
+type Correspondence struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TurnHours       int32                  `protobuf:"varint,1,opt,name=turn_hours,json=turnHours,proto3" json:"turn_hours,omitempty"`
+	TurnDeadline    int64                  `protobuf:"varint,2,opt,name=turn_deadline,json=turnDeadline,proto3" json:"turn_deadline,omitempty"`          // Unix milliseconds; 0 before the game starts
+	OverdueNotified bool                   `protobuf:"varint,3,opt,name=overdue_notified,json=overdueNotified,proto3" json:"overdue_notified,omitempty"` // Set once the current turn's players were told it is overdue
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Correspondence) Reset() {
+	*x = Correspondence{}
+	mi := &file_catan_v1_types_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Correspondence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Correspondence) ProtoMessage() {}
+
+func (x *Correspondence) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[31]
 	if x != nil {
-		return x.SessionToken
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *JoinGameResponse) GetPlayerId() string {
+// Deprecated: Use Correspondence.ProtoReflect.Descriptor instead.
+func (*Correspondence) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Correspondence) GetTurnHours() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.TurnHours
 	}
-	return ""
+	return 0
 }
 
-func (x *JoinGameResponse) GetPlayers() []*PlayerInfo {
+func (x *Correspondence) GetTurnDeadline() int64 {
 	if x != nil {
-		return x.Players
+		return x.TurnDeadline
 	}
-	return nil
+	return 0
 }
 
-type PlayerInfo struct {
+func (x *Correspondence) GetOverdueNotified() bool {
+	if x != nil {
+		return x.OverdueNotified
+	}
+	return false
+}
+
+// Something the game is waiting for a player to do. PLAY_TURN means the
+// player may build, trade or end their turn.
+type PendingAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Color         PlayerColor            `protobuf:"varint,3,opt,name=color,proto3,enum=catan.v1.PlayerColor" json:"color,omitempty"`
+	Kind          PendingActionKind      `protobuf:"varint,1,opt,name=kind,proto3,enum=catan.v1.PendingActionKind" json:"kind,omitempty"`
+	TradeId       string                 `protobuf:"bytes,2,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`                 // RESPOND_TRADE only
+	DiscardCount  int32                  `protobuf:"varint,3,opt,name=discard_count,json=discardCount,proto3" json:"discard_count,omitempty"` // DISCARD only
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerInfo) Reset() {
-	*x = PlayerInfo{}
-	mi := &file_catan_v1_types_proto_msgTypes[18]
+func (x *PendingAction) Reset() {
+	*x = PendingAction{}
+	mi := &file_catan_v1_types_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerInfo) String() string {
+func (x *PendingAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInfo) ProtoMessage() {}
+func (*PendingAction) ProtoMessage() {}
 
-func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[18]
+func (x *PendingAction) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1890,57 +3376,59 @@ func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
-func (*PlayerInfo) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PendingAction.ProtoReflect.Descriptor instead.
+func (*PendingAction) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *PlayerInfo) GetId() string {
+func (x *PendingAction) GetKind() PendingActionKind {
 	if x != nil {
-		return x.Id
+		return x.Kind
 	}
-	return ""
+	return PendingActionKind_PENDING_ACTION_KIND_UNSPECIFIED
 }
 
-func (x *PlayerInfo) GetName() string {
+func (x *PendingAction) GetTradeId() string {
 	if x != nil {
-		return x.Name
+		return x.TradeId
 	}
 	return ""
 }
 
-func (x *PlayerInfo) GetColor() PlayerColor {
+func (x *PendingAction) GetDiscardCount() int32 {
 	if x != nil {
-		return x.Color
+		return x.DiscardCount
 	}
-	return PlayerColor_PLAYER_COLOR_UNSPECIFIED
+	return 0
 }
 
-type GameInfoResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Status        GameStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=catan.v1.GameStatus" json:"status,omitempty"`
-	PlayerCount   int32                  `protobuf:"varint,3,opt,name=player_count,json=playerCount,proto3" json:"player_count,omitempty"`
-	Players       []*PlayerInfo          `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type PendingActionsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	GameId          string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId        string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Status          GameStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=catan.v1.GameStatus" json:"status,omitempty"`
+	CurrentPlayerId string                 `protobuf:"bytes,4,opt,name=current_player_id,json=currentPlayerId,proto3" json:"current_player_id,omitempty"`
+	Actions         []*PendingAction       `protobuf:"bytes,5,rep,name=actions,proto3" json:"actions,omitempty"`
+	TurnDeadline    int64                  `protobuf:"varint,6,opt,name=turn_deadline,json=turnDeadline,proto3" json:"turn_deadline,omitempty"` // Unix milliseconds; 0 outside correspondence games
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GameInfoResponse) Reset() {
-	*x = GameInfoResponse{}
-	mi := &file_catan_v1_types_proto_msgTypes[19]
+func (x *PendingActionsResponse) Reset() {
+	*x = PendingActionsResponse{}
+	mi := &file_catan_v1_types_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameInfoResponse) String() string {
+func (x *PendingActionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameInfoResponse) ProtoMessage() {}
+func (*PendingActionsResponse) ProtoMessage() {}
 
-func (x *GameInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catan_v1_types_proto_msgTypes[19]
+func (x *PendingActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catan_v1_types_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1951,39 +3439,53 @@ func (x *GameInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameInfoResponse.ProtoReflect.Descriptor instead.
-func (*GameInfoResponse) Descriptor() ([]byte, []int) {
-	return file_catan_v1_types_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PendingActionsResponse.ProtoReflect.Descriptor instead.
+func (*PendingActionsResponse) Descriptor() ([]byte, []int) {
+	return file_catan_v1_types_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *GameInfoResponse) GetCode() string {
+func (x *PendingActionsResponse) GetGameId() string {
 	if x != nil {
-		return x.Code
+		return x.GameId
 	}
 	return ""
 }
 
-func (x *GameInfoResponse) GetStatus() GameStatus {
+func (x *PendingActionsResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PendingActionsResponse) GetStatus() GameStatus {
 	if x != nil {
 		return x.Status
 	}
 	return GameStatus_GAME_STATUS_UNSPECIFIED
 }
 
-func (x *GameInfoResponse) GetPlayerCount() int32 {
+func (x *PendingActionsResponse) GetCurrentPlayerId() string {
 	if x != nil {
-		return x.PlayerCount
+		return x.CurrentPlayerId
 	}
-	return 0
+	return ""
 }
 
-func (x *GameInfoResponse) GetPlayers() []*PlayerInfo {
+func (x *PendingActionsResponse) GetActions() []*PendingAction {
 	if x != nil {
-		return x.Players
+		return x.Actions
 	}
 	return nil
 }
 
+func (x *PendingActionsResponse) GetTurnDeadline() int64 {
+	if x != nil {
+		return x.TurnDeadline
+	}
+	return 0
+}
+
 var File_catan_v1_types_proto protoreflect.FileDescriptor
 
 const file_catan_v1_types_proto_rawDesc = "" +
@@ -2050,7 +3552,7 @@ const file_catan_v1_types_proto_rawDesc = "" +
 	"\x05edges\x18\x03 \x03(\v2\x0e.catan.v1.EdgeR\x05edges\x121\n" +
 	"\n" +
 	"robber_hex\x18\x04 \x01(\v2\x12.catan.v1.HexCoordR\trobberHex\x12$\n" +
-	"\x05ports\x18\x05 \x03(\v2\x0e.catan.v1.PortR\x05ports\"\x86\x06\n" +
+	"\x05ports\x18\x05 \x03(\v2\x0e.catan.v1.PortR\x05ports\"\xe8\v\n" +
 	"\tGameState\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12*\n" +
@@ -2069,11 +3571,47 @@ const file_catan_v1_types_proto_rawDesc = "" +
 	"\frobber_phase\x18\f \x01(\v2\x15.catan.v1.RobberPhaseH\x03R\vrobberPhase\x88\x01\x01\x12;\n" +
 	"\x0epending_trades\x18\r \x03(\v2\x14.catan.v1.TradeOfferR\rpendingTrades\x129\n" +
 	"\rdev_card_deck\x18\x0e \x03(\x0e2\x15.catan.v1.DevCardTypeR\vdevCardDeck\x12!\n" +
-	"\fturn_counter\x18\x0f \x01(\x05R\vturnCounterB\x19\n" +
+	"\fturn_counter\x18\x0f \x01(\x05R\vturnCounter\x12\x12\n" +
+	"\x04seed\x18\x10 \x01(\x03R\x04seed\x12;\n" +
+	"\x1aexport_approved_player_ids\x18\x11 \x03(\tR\x17exportApprovedPlayerIds\x12#\n" +
+	"\rstate_version\x18\x12 \x01(\x05R\fstateVersion\x12\x16\n" +
+	"\x06paused\x18\x13 \x01(\bR\x06paused\x12$\n" +
+	"\x0eended_by_admin\x18\x14 \x01(\bR\fendedByAdmin\x121\n" +
+	"\x15chat_muted_player_ids\x18\x15 \x03(\tR\x12chatMutedPlayerIds\x126\n" +
+	"\x17spectator_chat_disabled\x18\x16 \x01(\bR\x15spectatorChatDisabled\x12D\n" +
+	"\x10pending_takeback\x18\x17 \x01(\v2\x19.catan.v1.TakebackRequestR\x0fpendingTakeback\x12$\n" +
+	"\x0etaken_back_seq\x18\x18 \x01(\x03R\ftakenBackSeq\x12(\n" +
+	"\x06series\x18\x19 \x01(\v2\x10.catan.v1.SeriesR\x06series\x12&\n" +
+	"\x0frematch_game_id\x18\x1a \x01(\tR\rrematchGameId\x12W\n" +
+	"\x12rematch_player_ids\x18\x1b \x03(\v2).catan.v1.GameState.RematchPlayerIdsEntryR\x10rematchPlayerIds\x12#\n" +
+	"\rtournament_id\x18\x1c \x01(\tR\ftournamentId\x12@\n" +
+	"\x0ecorrespondence\x18\x1d \x01(\v2\x18.catan.v1.CorrespondenceR\x0ecorrespondence\x1aC\n" +
+	"\x15RematchPlayerIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x19\n" +
 	"\x17_longest_road_player_idB\x19\n" +
 	"\x17_largest_army_player_idB\x0e\n" +
 	"\f_setup_phaseB\x0f\n" +
-	"\r_robber_phase\"\xfe\x02\n" +
+	"\r_robber_phase\"\xc9\x02\n" +
+	"\x06Series\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\abest_of\x18\x02 \x01(\x05R\x06bestOf\x12\x1f\n" +
+	"\vgame_number\x18\x03 \x01(\x05R\n" +
+	"gameNumber\x12.\n" +
+	"\x04wins\x18\x04 \x03(\v2\x1a.catan.v1.Series.WinsEntryR\x04wins\x124\n" +
+	"\x06points\x18\x05 \x03(\v2\x1c.catan.v1.Series.PointsEntryR\x06points\x12\x1b\n" +
+	"\twinner_id\x18\x06 \x01(\tR\bwinnerId\x1a7\n" +
+	"\tWinsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a9\n" +
+	"\vPointsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x99\x01\n" +
+	"\x0fTakebackRequest\x12!\n" +
+	"\frequester_id\x18\x01 \x01(\tR\vrequesterId\x12\x1b\n" +
+	"\tevent_seq\x18\x02 \x01(\x03R\beventSeq\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12.\n" +
+	"\x13approved_player_ids\x18\x04 \x03(\tR\x11approvedPlayerIds\"\xfe\x02\n" +
 	"\vRobberPhase\x12'\n" +
 	"\x0fdiscard_pending\x18\x01 \x03(\tR\x0ediscardPending\x12U\n" +
 	"\x10discard_required\x18\x02 \x03(\v2*.catan.v1.RobberPhase.DiscardRequiredEntryR\x0fdiscardRequired\x128\n" +
@@ -2100,18 +3638,20 @@ const file_catan_v1_types_proto_rawDesc = "" +
 	"\n" +
 	"SetupPhase\x12\x14\n" +
 	"\x05round\x18\x01 \x01(\x05R\x05round\x12,\n" +
-	"\x12placements_in_turn\x18\x02 \x01(\x05R\x10placementsInTurn\"4\n" +
+	"\x12placements_in_turn\x18\x02 \x01(\x05R\x10placementsInTurn\"v\n" +
 	"\x11CreateGameRequest\x12\x1f\n" +
 	"\vplayer_name\x18\x01 \x01(\tR\n" +
-	"playerName\"\x83\x01\n" +
+	"playerName\x12@\n" +
+	"\x0ecorrespondence\x18\x02 \x01(\v2\x18.catan.v1.CorrespondenceR\x0ecorrespondence\"\x83\x01\n" +
 	"\x12CreateGameResponse\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12#\n" +
 	"\rsession_token\x18\x03 \x01(\tR\fsessionToken\x12\x1b\n" +
-	"\tplayer_id\x18\x04 \x01(\tR\bplayerId\"2\n" +
+	"\tplayer_id\x18\x04 \x01(\tR\bplayerId\"F\n" +
 	"\x0fJoinGameRequest\x12\x1f\n" +
 	"\vplayer_name\x18\x01 \x01(\tR\n" +
-	"playerName\"\x9d\x01\n" +
+	"playerName\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x9d\x01\n" +
 	"\x10JoinGameResponse\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12#\n" +
 	"\rsession_token\x18\x02 \x01(\tR\fsessionToken\x12\x1b\n" +
@@ -2126,7 +3666,77 @@ const file_catan_v1_types_proto_rawDesc = "" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12,\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x14.catan.v1.GameStatusR\x06status\x12!\n" +
 	"\fplayer_count\x18\x03 \x01(\x05R\vplayerCount\x12.\n" +
-	"\aplayers\x18\x04 \x03(\v2\x14.catan.v1.PlayerInfoR\aplayers*T\n" +
+	"\aplayers\x18\x04 \x03(\v2\x14.catan.v1.PlayerInfoR\aplayers\"\xd5\x03\n" +
+	"\n" +
+	"Tournament\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.catan.v1.TournamentStatusR\x06status\x12A\n" +
+	"\vadvancement\x18\x04 \x01(\x0e2\x1f.catan.v1.TournamentAdvancementR\vadvancement\x12*\n" +
+	"\x11advance_per_table\x18\x05 \x01(\x05R\x0fadvancePerTable\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\x12'\n" +
+	"\x0forganizer_token\x18\a \x01(\tR\x0eorganizerToken\x127\n" +
+	"\bentrants\x18\b \x03(\v2\x1b.catan.v1.TournamentEntrantR\bentrants\x121\n" +
+	"\x06rounds\x18\t \x03(\v2\x19.catan.v1.TournamentRoundR\x06rounds\x12:\n" +
+	"\tstandings\x18\n" +
+	" \x03(\v2\x1c.catan.v1.TournamentStandingR\tstandings\x12\x1b\n" +
+	"\twinner_id\x18\v \x01(\tR\bwinnerId\"\x86\x01\n" +
+	"\x11TournamentEntrant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x05 \x01(\bR\n" +
+	"eliminated\"\\\n" +
+	"\x0fTournamentRound\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x121\n" +
+	"\x06tables\x18\x02 \x03(\v2\x19.catan.v1.TournamentTableR\x06tables\"\xa7\x01\n" +
+	"\x0fTournamentTable\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12.\n" +
+	"\x05seats\x18\x03 \x03(\v2\x18.catan.v1.TournamentSeatR\x05seats\x12\x1a\n" +
+	"\bfinished\x18\x04 \x01(\bR\bfinished\x12\x1b\n" +
+	"\twinner_id\x18\x05 \x01(\tR\bwinnerId\"d\n" +
+	"\x0eTournamentSeat\x12\x1d\n" +
+	"\n" +
+	"entrant_id\x18\x01 \x01(\tR\tentrantId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\x05R\x06points\"\x89\x01\n" +
+	"\x12TournamentStanding\x12\x1d\n" +
+	"\n" +
+	"entrant_id\x18\x01 \x01(\tR\tentrantId\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\x05R\x06points\x12\x12\n" +
+	"\x04wins\x18\x04 \x01(\x05R\x04wins\x12\x14\n" +
+	"\x05games\x18\x05 \x01(\x05R\x05games\"y\n" +
+	"\x18CreateTournamentResponse\x124\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x14.catan.v1.TournamentR\n" +
+	"tournament\x12'\n" +
+	"\x0forganizer_token\x18\x02 \x01(\tR\x0eorganizerToken\"Q\n" +
+	"\x1aRegisterTournamentResponse\x12\x1d\n" +
+	"\n" +
+	"entrant_id\x18\x01 \x01(\tR\tentrantId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"Q\n" +
+	"\x17ListTournamentsResponse\x126\n" +
+	"\vtournaments\x18\x01 \x03(\v2\x14.catan.v1.TournamentR\vtournaments\"\x7f\n" +
+	"\x0eCorrespondence\x12\x1d\n" +
+	"\n" +
+	"turn_hours\x18\x01 \x01(\x05R\tturnHours\x12#\n" +
+	"\rturn_deadline\x18\x02 \x01(\x03R\fturnDeadline\x12)\n" +
+	"\x10overdue_notified\x18\x03 \x01(\bR\x0foverdueNotified\"\x80\x01\n" +
+	"\rPendingAction\x12/\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1b.catan.v1.PendingActionKindR\x04kind\x12\x19\n" +
+	"\btrade_id\x18\x02 \x01(\tR\atradeId\x12#\n" +
+	"\rdiscard_count\x18\x03 \x01(\x05R\fdiscardCount\"\x80\x02\n" +
+	"\x16PendingActionsResponse\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.catan.v1.GameStatusR\x06status\x12*\n" +
+	"\x11current_player_id\x18\x04 \x01(\tR\x0fcurrentPlayerId\x121\n" +
+	"\aactions\x18\x05 \x03(\v2\x17.catan.v1.PendingActionR\aactions\x12#\n" +
+	"\rturn_deadline\x18\x06 \x01(\x03R\fturnDeadline*T\n" +
 	"\bPortType\x12\x19\n" +
 	"\x15PORT_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11PORT_TYPE_GENERIC\x10\x01\x12\x16\n" +
@@ -2185,7 +3795,87 @@ const file_catan_v1_types_proto_rawDesc = "" +
 	"\x14TRADE_STATUS_PENDING\x10\x01\x12\x19\n" +
 	"\x15TRADE_STATUS_ACCEPTED\x10\x02\x12\x19\n" +
 	"\x15TRADE_STATUS_REJECTED\x10\x03\x12\x1a\n" +
-	"\x16TRADE_STATUS_CANCELLED\x10\x04B\x8b\x01\n" +
+	"\x16TRADE_STATUS_CANCELLED\x10\x04*\x80\x0f\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ERROR_CODE_INVALID_ACTION\x10\x01\x12\x1a\n" +
+	"\x16ERROR_CODE_BAD_REQUEST\x10\x02\x12\x1b\n" +
+	"\x17ERROR_CODE_RATE_LIMITED\x10\x03\x12\x1a\n" +
+	"\x16ERROR_CODE_GAME_PAUSED\x10\x04\x12\x1b\n" +
+	"\x17ERROR_CODE_GAME_EXPIRED\x10\x05\x12\x1c\n" +
+	"\x18ERROR_CODE_INVALID_STATE\x10\x06\x12\x1b\n" +
+	"\x17ERROR_CODE_SERVER_ERROR\x10\a\x12\x1c\n" +
+	"\x18ERROR_CODE_SHUTTING_DOWN\x10\b\x12\x16\n" +
+	"\x12ERROR_CODE_REMOVED\x10\t\x12\x1c\n" +
+	"\x18ERROR_CODE_NOT_YOUR_TURN\x10\x14\x12\x1a\n" +
+	"\x16ERROR_CODE_WRONG_PHASE\x10\x15\x12!\n" +
+	"\x1dERROR_CODE_INVALID_TURN_PHASE\x10\x16\x12\x1f\n" +
+	"\x1bERROR_CODE_PLAYER_NOT_FOUND\x10\x17\x12\x17\n" +
+	"\x13ERROR_CODE_NOT_HOST\x10\x18\x12 \n" +
+	"\x1cERROR_CODE_PLAYERS_NOT_READY\x10\x19\x12!\n" +
+	"\x1dERROR_CODE_NOT_ENOUGH_PLAYERS\x10\x1a\x12\x1a\n" +
+	"\x16ERROR_CODE_LAST_PLAYER\x10\x1b\x12\x1d\n" +
+	"\x19ERROR_CODE_INVALID_VERTEX\x10(\x12\x1b\n" +
+	"\x17ERROR_CODE_INVALID_EDGE\x10)\x12\x1e\n" +
+	"\x1aERROR_CODE_VERTEX_OCCUPIED\x10*\x12\x1c\n" +
+	"\x18ERROR_CODE_EDGE_OCCUPIED\x10+\x12\x1c\n" +
+	"\x18ERROR_CODE_DISTANCE_RULE\x10,\x12\x1c\n" +
+	"\x18ERROR_CODE_NOT_CONNECTED\x10-\x12\x1d\n" +
+	"\x19ERROR_CODE_CANNOT_UPGRADE\x10.\x12%\n" +
+	"!ERROR_CODE_SETUP_SETTLEMENT_FIRST\x10/\x12'\n" +
+	"#ERROR_CODE_SETUP_ROAD_NOT_CONNECTED\x100\x12\x1e\n" +
+	"\x1aERROR_CODE_MAX_SETTLEMENTS\x101\x12\x18\n" +
+	"\x14ERROR_CODE_MAX_ROADS\x102\x12\x19\n" +
+	"\x15ERROR_CODE_MAX_CITIES\x103\x12%\n" +
+	"!ERROR_CODE_INSUFFICIENT_RESOURCES\x10<\x12\x19\n" +
+	"\x15ERROR_CODE_BANK_EMPTY\x10=\x12\x1c\n" +
+	"\x18ERROR_CODE_INVALID_TRADE\x10>\x12$\n" +
+	" ERROR_CODE_TRADE_ALREADY_PENDING\x10?\x12\x1e\n" +
+	"\x1aERROR_CODE_TRADE_NOT_FOUND\x10@\x12$\n" +
+	" ERROR_CODE_NOT_TRADE_PARTICIPANT\x10A\x12\x1d\n" +
+	"\x19ERROR_CODE_DEV_DECK_EMPTY\x10P\x12 \n" +
+	"\x1cERROR_CODE_DEV_CARD_NOT_HELD\x10Q\x12\x1f\n" +
+	"\x1bERROR_CODE_DEV_CARD_TOO_NEW\x10R\x12&\n" +
+	"\"ERROR_CODE_INVALID_DEV_CARD_CHOICE\x10S\x12\x1e\n" +
+	"\x1aERROR_CODE_NO_ROBBER_PHASE\x10d\x12#\n" +
+	"\x1fERROR_CODE_DISCARD_NOT_REQUIRED\x10e\x12\x1c\n" +
+	"\x18ERROR_CODE_WRONG_DISCARD\x10f\x12\x1f\n" +
+	"\x1bERROR_CODE_DISCARDS_PENDING\x10g\x12\x19\n" +
+	"\x15ERROR_CODE_NOT_ROBBER\x10h\x12\x1a\n" +
+	"\x16ERROR_CODE_INVALID_HEX\x10i\x12#\n" +
+	"\x1fERROR_CODE_ROBBER_ALREADY_THERE\x10j\x12\x1d\n" +
+	"\x19ERROR_CODE_INVALID_VICTIM\x10k\x12#\n" +
+	"\x1fERROR_CODE_TAKEBACK_NOT_ALLOWED\x10x\x12\x1f\n" +
+	"\x1bERROR_CODE_TAKEBACK_PENDING\x10y\x12\"\n" +
+	"\x1eERROR_CODE_NO_TAKEBACK_PENDING\x10z\x12\x1b\n" +
+	"\x17ERROR_CODE_OWN_TAKEBACK\x10{\x12\x1c\n" +
+	"\x18ERROR_CODE_ALREADY_VOTED\x10|\x12\x1d\n" +
+	"\x19ERROR_CODE_TAKEBACK_STALE\x10}\x12\x1d\n" +
+	"\x18ERROR_CODE_CHAT_TOO_LONG\x10\x8c\x01\x12!\n" +
+	"\x1cERROR_CODE_CHAT_RATE_LIMITED\x10\x8d\x01\x12\"\n" +
+	"\x1dERROR_CODE_NOT_CHAT_MODERATOR\x10\x8e\x01\x12\x1a\n" +
+	"\x15ERROR_CODE_CHAT_MUTED\x10\x8f\x01\x12'\n" +
+	"\"ERROR_CODE_SPECTATOR_CHAT_DISABLED\x10\x90\x01\x12\x1f\n" +
+	"\x1aERROR_CODE_INVALID_WHISPER\x10\x91\x01*\x98\x01\n" +
+	"\x10TournamentStatus\x12!\n" +
+	"\x1dTOURNAMENT_STATUS_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eTOURNAMENT_STATUS_REGISTRATION\x10\x01\x12\x1d\n" +
+	"\x19TOURNAMENT_STATUS_RUNNING\x10\x02\x12\x1e\n" +
+	"\x1aTOURNAMENT_STATUS_FINISHED\x10\x03*\x88\x01\n" +
+	"\x15TournamentAdvancement\x12&\n" +
+	"\"TOURNAMENT_ADVANCEMENT_UNSPECIFIED\x10\x00\x12$\n" +
+	" TOURNAMENT_ADVANCEMENT_PLACEMENT\x10\x01\x12!\n" +
+	"\x1dTOURNAMENT_ADVANCEMENT_POINTS\x10\x02*\xd3\x02\n" +
+	"\x11PendingActionKind\x12#\n" +
+	"\x1fPENDING_ACTION_KIND_UNSPECIFIED\x10\x00\x12(\n" +
+	"$PENDING_ACTION_KIND_PLACE_SETTLEMENT\x10\x01\x12\"\n" +
+	"\x1ePENDING_ACTION_KIND_PLACE_ROAD\x10\x02\x12\x1c\n" +
+	"\x18PENDING_ACTION_KIND_ROLL\x10\x03\x12!\n" +
+	"\x1dPENDING_ACTION_KIND_PLAY_TURN\x10\x04\x12\x1f\n" +
+	"\x1bPENDING_ACTION_KIND_DISCARD\x10\x05\x12#\n" +
+	"\x1fPENDING_ACTION_KIND_MOVE_ROBBER\x10\x06\x12\x1d\n" +
+	"\x19PENDING_ACTION_KIND_STEAL\x10\a\x12%\n" +
+	"!PENDING_ACTION_KIND_RESPOND_TRADE\x10\bB\x8b\x01\n" +
 	"\fcom.catan.v1B\n" +
 	"TypesProtoP\x01Z.settlers_from_catan/gen/proto/catan/v1;catanv1\xa2\x02\x03CXX\xaa\x02\bCatan.V1\xca\x02\bCatan\\V1\xe2\x02\x14Catan\\V1\\GPBMetadata\xea\x02\tCatan::V1b\x06proto3"
 
@@ -2201,82 +3891,122 @@ func file_catan_v1_types_proto_rawDescGZIP() []byte {
 	return file_catan_v1_types_proto_rawDescData
 }
 
-var file_catan_v1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_catan_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_catan_v1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 14)
+var file_catan_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_catan_v1_types_proto_goTypes = []any{
-	(PortType)(0),              // 0: catan.v1.PortType
-	(Resource)(0),              // 1: catan.v1.Resource
-	(TileResource)(0),          // 2: catan.v1.TileResource
-	(BuildingType)(0),          // 3: catan.v1.BuildingType
-	(StructureType)(0),         // 4: catan.v1.StructureType
-	(GameStatus)(0),            // 5: catan.v1.GameStatus
-	(TurnPhase)(0),             // 6: catan.v1.TurnPhase
-	(PlayerColor)(0),           // 7: catan.v1.PlayerColor
-	(DevCardType)(0),           // 8: catan.v1.DevCardType
-	(TradeStatus)(0),           // 9: catan.v1.TradeStatus
-	(*HexCoord)(nil),           // 10: catan.v1.HexCoord
-	(*Hex)(nil),                // 11: catan.v1.Hex
-	(*Building)(nil),           // 12: catan.v1.Building
-	(*Road)(nil),               // 13: catan.v1.Road
-	(*Vertex)(nil),             // 14: catan.v1.Vertex
-	(*Edge)(nil),               // 15: catan.v1.Edge
-	(*ResourceCount)(nil),      // 16: catan.v1.ResourceCount
-	(*PlayerState)(nil),        // 17: catan.v1.PlayerState
-	(*Port)(nil),               // 18: catan.v1.Port
-	(*BoardState)(nil),         // 19: catan.v1.BoardState
-	(*GameState)(nil),          // 20: catan.v1.GameState
-	(*RobberPhase)(nil),        // 21: catan.v1.RobberPhase
-	(*TradeOffer)(nil),         // 22: catan.v1.TradeOffer
-	(*SetupPhase)(nil),         // 23: catan.v1.SetupPhase
-	(*CreateGameRequest)(nil),  // 24: catan.v1.CreateGameRequest
-	(*CreateGameResponse)(nil), // 25: catan.v1.CreateGameResponse
-	(*JoinGameRequest)(nil),    // 26: catan.v1.JoinGameRequest
-	(*JoinGameResponse)(nil),   // 27: catan.v1.JoinGameResponse
-	(*PlayerInfo)(nil),         // 28: catan.v1.PlayerInfo
-	(*GameInfoResponse)(nil),   // 29: catan.v1.GameInfoResponse
-	nil,                        // 30: catan.v1.PlayerState.DevCardsEntry
-	nil,                        // 31: catan.v1.PlayerState.DevCardsPurchasedTurnEntry
-	nil,                        // 32: catan.v1.RobberPhase.DiscardRequiredEntry
+	(PortType)(0),                      // 0: catan.v1.PortType
+	(Resource)(0),                      // 1: catan.v1.Resource
+	(TileResource)(0),                  // 2: catan.v1.TileResource
+	(BuildingType)(0),                  // 3: catan.v1.BuildingType
+	(StructureType)(0),                 // 4: catan.v1.StructureType
+	(GameStatus)(0),                    // 5: catan.v1.GameStatus
+	(TurnPhase)(0),                     // 6: catan.v1.TurnPhase
+	(PlayerColor)(0),                   // 7: catan.v1.PlayerColor
+	(DevCardType)(0),                   // 8: catan.v1.DevCardType
+	(TradeStatus)(0),                   // 9: catan.v1.TradeStatus
+	(ErrorCode)(0),                     // 10: catan.v1.ErrorCode
+	(TournamentStatus)(0),              // 11: catan.v1.TournamentStatus
+	(TournamentAdvancement)(0),         // 12: catan.v1.TournamentAdvancement
+	(PendingActionKind)(0),             // 13: catan.v1.PendingActionKind
+	(*HexCoord)(nil),                   // 14: catan.v1.HexCoord
+	(*Hex)(nil),                        // 15: catan.v1.Hex
+	(*Building)(nil),                   // 16: catan.v1.Building
+	(*Road)(nil),                       // 17: catan.v1.Road
+	(*Vertex)(nil),                     // 18: catan.v1.Vertex
+	(*Edge)(nil),                       // 19: catan.v1.Edge
+	(*ResourceCount)(nil),              // 20: catan.v1.ResourceCount
+	(*PlayerState)(nil),                // 21: catan.v1.PlayerState
+	(*Port)(nil),                       // 22: catan.v1.Port
+	(*BoardState)(nil),                 // 23: catan.v1.BoardState
+	(*GameState)(nil),                  // 24: catan.v1.GameState
+	(*Series)(nil),                     // 25: catan.v1.Series
+	(*TakebackRequest)(nil),            // 26: catan.v1.TakebackRequest
+	(*RobberPhase)(nil),                // 27: catan.v1.RobberPhase
+	(*TradeOffer)(nil),                 // 28: catan.v1.TradeOffer
+	(*SetupPhase)(nil),                 // 29: catan.v1.SetupPhase
+	(*CreateGameRequest)(nil),          // 30: catan.v1.CreateGameRequest
+	(*CreateGameResponse)(nil),         // 31: catan.v1.CreateGameResponse
+	(*JoinGameRequest)(nil),            // 32: catan.v1.JoinGameRequest
+	(*JoinGameResponse)(nil),           // 33: catan.v1.JoinGameResponse
+	(*PlayerInfo)(nil),                 // 34: catan.v1.PlayerInfo
+	(*GameInfoResponse)(nil),           // 35: catan.v1.GameInfoResponse
+	(*Tournament)(nil),                 // 36: catan.v1.Tournament
+	(*TournamentEntrant)(nil),          // 37: catan.v1.TournamentEntrant
+	(*TournamentRound)(nil),            // 38: catan.v1.TournamentRound
+	(*TournamentTable)(nil),            // 39: catan.v1.TournamentTable
+	(*TournamentSeat)(nil),             // 40: catan.v1.TournamentSeat
+	(*TournamentStanding)(nil),         // 41: catan.v1.TournamentStanding
+	(*CreateTournamentResponse)(nil),   // 42: catan.v1.CreateTournamentResponse
+	(*RegisterTournamentResponse)(nil), // 43: catan.v1.RegisterTournamentResponse
+	(*ListTournamentsResponse)(nil),    // 44: catan.v1.ListTournamentsResponse
+	(*Correspondence)(nil),             // 45: catan.v1.Correspondence
+	(*PendingAction)(nil),              // 46: catan.v1.PendingAction
+	(*PendingActionsResponse)(nil),     // 47: catan.v1.PendingActionsResponse
+	nil,                                // 48: catan.v1.PlayerState.DevCardsEntry
+	nil,                                // 49: catan.v1.PlayerState.DevCardsPurchasedTurnEntry
+	nil,                                // 50: catan.v1.GameState.RematchPlayerIdsEntry
+	nil,                                // 51: catan.v1.Series.WinsEntry
+	nil,                                // 52: catan.v1.Series.PointsEntry
+	nil,                                // 53: catan.v1.RobberPhase.DiscardRequiredEntry
 }
 var file_catan_v1_types_proto_depIdxs = []int32{
-	10, // 0: catan.v1.Hex.coord:type_name -> catan.v1.HexCoord
+	14, // 0: catan.v1.Hex.coord:type_name -> catan.v1.HexCoord
 	2,  // 1: catan.v1.Hex.resource:type_name -> catan.v1.TileResource
 	3,  // 2: catan.v1.Building.type:type_name -> catan.v1.BuildingType
-	10, // 3: catan.v1.Vertex.adjacent_hexes:type_name -> catan.v1.HexCoord
-	12, // 4: catan.v1.Vertex.building:type_name -> catan.v1.Building
-	13, // 5: catan.v1.Edge.road:type_name -> catan.v1.Road
+	14, // 3: catan.v1.Vertex.adjacent_hexes:type_name -> catan.v1.HexCoord
+	16, // 4: catan.v1.Vertex.building:type_name -> catan.v1.Building
+	17, // 5: catan.v1.Edge.road:type_name -> catan.v1.Road
 	7,  // 6: catan.v1.PlayerState.color:type_name -> catan.v1.PlayerColor
-	16, // 7: catan.v1.PlayerState.resources:type_name -> catan.v1.ResourceCount
-	30, // 8: catan.v1.PlayerState.dev_cards:type_name -> catan.v1.PlayerState.DevCardsEntry
-	31, // 9: catan.v1.PlayerState.dev_cards_purchased_turn:type_name -> catan.v1.PlayerState.DevCardsPurchasedTurnEntry
+	20, // 7: catan.v1.PlayerState.resources:type_name -> catan.v1.ResourceCount
+	48, // 8: catan.v1.PlayerState.dev_cards:type_name -> catan.v1.PlayerState.DevCardsEntry
+	49, // 9: catan.v1.PlayerState.dev_cards_purchased_turn:type_name -> catan.v1.PlayerState.DevCardsPurchasedTurnEntry
 	0,  // 10: catan.v1.Port.type:type_name -> catan.v1.PortType
 	1,  // 11: catan.v1.Port.resource:type_name -> catan.v1.Resource
-	11, // 12: catan.v1.BoardState.hexes:type_name -> catan.v1.Hex
-	14, // 13: catan.v1.BoardState.vertices:type_name -> catan.v1.Vertex
-	15, // 14: catan.v1.BoardState.edges:type_name -> catan.v1.Edge
-	10, // 15: catan.v1.BoardState.robber_hex:type_name -> catan.v1.HexCoord
-	18, // 16: catan.v1.BoardState.ports:type_name -> catan.v1.Port
-	19, // 17: catan.v1.GameState.board:type_name -> catan.v1.BoardState
-	17, // 18: catan.v1.GameState.players:type_name -> catan.v1.PlayerState
+	15, // 12: catan.v1.BoardState.hexes:type_name -> catan.v1.Hex
+	18, // 13: catan.v1.BoardState.vertices:type_name -> catan.v1.Vertex
+	19, // 14: catan.v1.BoardState.edges:type_name -> catan.v1.Edge
+	14, // 15: catan.v1.BoardState.robber_hex:type_name -> catan.v1.HexCoord
+	22, // 16: catan.v1.BoardState.ports:type_name -> catan.v1.Port
+	23, // 17: catan.v1.GameState.board:type_name -> catan.v1.BoardState
+	21, // 18: catan.v1.GameState.players:type_name -> catan.v1.PlayerState
 	6,  // 19: catan.v1.GameState.turn_phase:type_name -> catan.v1.TurnPhase
 	5,  // 20: catan.v1.GameState.status:type_name -> catan.v1.GameStatus
-	23, // 21: catan.v1.GameState.setup_phase:type_name -> catan.v1.SetupPhase
-	21, // 22: catan.v1.GameState.robber_phase:type_name -> catan.v1.RobberPhase
-	22, // 23: catan.v1.GameState.pending_trades:type_name -> catan.v1.TradeOffer
+	29, // 21: catan.v1.GameState.setup_phase:type_name -> catan.v1.SetupPhase
+	27, // 22: catan.v1.GameState.robber_phase:type_name -> catan.v1.RobberPhase
+	28, // 23: catan.v1.GameState.pending_trades:type_name -> catan.v1.TradeOffer
 	8,  // 24: catan.v1.GameState.dev_card_deck:type_name -> catan.v1.DevCardType
-	32, // 25: catan.v1.RobberPhase.discard_required:type_name -> catan.v1.RobberPhase.DiscardRequiredEntry
-	16, // 26: catan.v1.TradeOffer.offering:type_name -> catan.v1.ResourceCount
-	16, // 27: catan.v1.TradeOffer.requesting:type_name -> catan.v1.ResourceCount
-	9,  // 28: catan.v1.TradeOffer.status:type_name -> catan.v1.TradeStatus
-	28, // 29: catan.v1.JoinGameResponse.players:type_name -> catan.v1.PlayerInfo
-	7,  // 30: catan.v1.PlayerInfo.color:type_name -> catan.v1.PlayerColor
-	5,  // 31: catan.v1.GameInfoResponse.status:type_name -> catan.v1.GameStatus
-	28, // 32: catan.v1.GameInfoResponse.players:type_name -> catan.v1.PlayerInfo
-	33, // [33:33] is the sub-list for method output_type
-	33, // [33:33] is the sub-list for method input_type
-	33, // [33:33] is the sub-list for extension type_name
-	33, // [33:33] is the sub-list for extension extendee
-	0,  // [0:33] is the sub-list for field type_name
+	26, // 25: catan.v1.GameState.pending_takeback:type_name -> catan.v1.TakebackRequest
+	25, // 26: catan.v1.GameState.series:type_name -> catan.v1.Series
+	50, // 27: catan.v1.GameState.rematch_player_ids:type_name -> catan.v1.GameState.RematchPlayerIdsEntry
+	45, // 28: catan.v1.GameState.correspondence:type_name -> catan.v1.Correspondence
+	51, // 29: catan.v1.Series.wins:type_name -> catan.v1.Series.WinsEntry
+	52, // 30: catan.v1.Series.points:type_name -> catan.v1.Series.PointsEntry
+	53, // 31: catan.v1.RobberPhase.discard_required:type_name -> catan.v1.RobberPhase.DiscardRequiredEntry
+	20, // 32: catan.v1.TradeOffer.offering:type_name -> catan.v1.ResourceCount
+	20, // 33: catan.v1.TradeOffer.requesting:type_name -> catan.v1.ResourceCount
+	9,  // 34: catan.v1.TradeOffer.status:type_name -> catan.v1.TradeStatus
+	45, // 35: catan.v1.CreateGameRequest.correspondence:type_name -> catan.v1.Correspondence
+	34, // 36: catan.v1.JoinGameResponse.players:type_name -> catan.v1.PlayerInfo
+	7,  // 37: catan.v1.PlayerInfo.color:type_name -> catan.v1.PlayerColor
+	5,  // 38: catan.v1.GameInfoResponse.status:type_name -> catan.v1.GameStatus
+	34, // 39: catan.v1.GameInfoResponse.players:type_name -> catan.v1.PlayerInfo
+	11, // 40: catan.v1.Tournament.status:type_name -> catan.v1.TournamentStatus
+	12, // 41: catan.v1.Tournament.advancement:type_name -> catan.v1.TournamentAdvancement
+	37, // 42: catan.v1.Tournament.entrants:type_name -> catan.v1.TournamentEntrant
+	38, // 43: catan.v1.Tournament.rounds:type_name -> catan.v1.TournamentRound
+	41, // 44: catan.v1.Tournament.standings:type_name -> catan.v1.TournamentStanding
+	39, // 45: catan.v1.TournamentRound.tables:type_name -> catan.v1.TournamentTable
+	40, // 46: catan.v1.TournamentTable.seats:type_name -> catan.v1.TournamentSeat
+	36, // 47: catan.v1.CreateTournamentResponse.tournament:type_name -> catan.v1.Tournament
+	36, // 48: catan.v1.ListTournamentsResponse.tournaments:type_name -> catan.v1.Tournament
+	13, // 49: catan.v1.PendingAction.kind:type_name -> catan.v1.PendingActionKind
+	5,  // 50: catan.v1.PendingActionsResponse.status:type_name -> catan.v1.GameStatus
+	46, // 51: catan.v1.PendingActionsResponse.actions:type_name -> catan.v1.PendingAction
+	52, // [52:52] is the sub-list for method output_type
+	52, // [52:52] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_catan_v1_types_proto_init() }
@@ -2287,15 +4017,15 @@ func file_catan_v1_types_proto_init() {
 	file_catan_v1_types_proto_msgTypes[4].OneofWrappers = []any{}
 	file_catan_v1_types_proto_msgTypes[5].OneofWrappers = []any{}
 	file_catan_v1_types_proto_msgTypes[10].OneofWrappers = []any{}
-	file_catan_v1_types_proto_msgTypes[11].OneofWrappers = []any{}
-	file_catan_v1_types_proto_msgTypes[12].OneofWrappers = []any{}
+	file_catan_v1_types_proto_msgTypes[13].OneofWrappers = []any{}
+	file_catan_v1_types_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catan_v1_types_proto_rawDesc), len(file_catan_v1_types_proto_rawDesc)),
-			NumEnums:      10,
-			NumMessages:   23,
+			NumEnums:      14,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,105 @@
+// Package broker carries messages between the server instances that share
+// a game store, so players connected to different instances behind a load
+// balancer see the same games. Brokers are pluggable; the server ships
+// Local for a single instance and Peers, which POSTs to the other instances.
+package broker
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Message is one message for a game's clients.
+type Message struct {
+	// Kind tells subscribers what Data holds.
+	Kind     string `json:"kind"`
+	GameID   string `json:"gameId"`
+	PlayerID string `json:"playerId,omitempty"`
+	Data     []byte `json:"data,omitempty"`
+}
+
+// CallHandler answers a call from another instance.
+type CallHandler func(ctx context.Context, req []byte) ([]byte, error)
+
+// Broker fans published messages out to the subscribers on every instance
+// and carries calls from one instance to another.
+type Broker interface {
+	// Instance names this instance to the others.
+	Instance() string
+	// Publish delivers msg to every subscriber on every instance, this one
+	// included. Each instance sees one publisher's messages in the order
+	// they were published.
+	Publish(msg *Message) error
+	// Subscribe calls fn with every message published on any instance until
+	// cancel is called. fn runs on the delivering goroutine.
+	Subscribe(fn func(*Message)) (cancel func())
+	// Call sends req to the call handler of instance and returns its reply.
+	Call(ctx context.Context, instance string, req []byte) ([]byte, error)
+	// HandleCalls sets the handler answering calls to this instance.
+	HandleCalls(fn CallHandler)
+	Close() error
+}
+
+var (
+	// ErrUnknownInstance is returned by Call for an instance the broker
+	// cannot reach.
+	ErrUnknownInstance = errors.New("unknown instance")
+	// ErrNoCallHandler is returned by Call when the instance has no call
+	// handler set.
+	ErrNoCallHandler = errors.New("instance takes no calls")
+)
+
+// fanout keeps an instance's subscribers and call handler.
+type fanout struct {
+	mu     sync.RWMutex
+	subs   map[int]func(*Message)
+	nextID int
+	calls  CallHandler
+}
+
+func (f *fanout) Subscribe(fn func(*Message)) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[int]func(*Message))
+	}
+	id := f.nextID
+	f.nextID++
+	f.subs[id] = fn
+	return func() {
+		f.mu.Lock()
+		delete(f.subs, id)
+		f.mu.Unlock()
+	}
+}
+
+func (f *fanout) HandleCalls(fn CallHandler) {
+	f.mu.Lock()
+	f.calls = fn
+	f.mu.Unlock()
+}
+
+// deliver hands msg to every subscriber of this instance.
+func (f *fanout) deliver(msg *Message) {
+	f.mu.RLock()
+	subs := make([]func(*Message), 0, len(f.subs))
+	for _, fn := range f.subs {
+		subs = append(subs, fn)
+	}
+	f.mu.RUnlock()
+	for _, fn := range subs {
+		fn(msg)
+	}
+}
+
+// call answers req with this instance's call handler.
+func (f *fanout) call(ctx context.Context, req []byte) ([]byte, error) {
+	f.mu.RLock()
+	fn := f.calls
+	f.mu.RUnlock()
+	if fn == nil {
+		return nil, ErrNoCallHandler
+	}
+	return fn(ctx, req)
+}
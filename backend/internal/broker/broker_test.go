@@ -0,0 +1,199 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the messages a subscriber receives.
+type recorder struct {
+	mu   sync.Mutex
+	msgs []*Message
+}
+
+func (r *recorder) add(msg *Message) {
+	r.mu.Lock()
+	r.msgs = append(r.msgs, msg)
+	r.mu.Unlock()
+}
+
+func (r *recorder) snapshot() []*Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Message(nil), r.msgs...)
+}
+
+// waitFor polls until r has n messages.
+func (r *recorder) waitFor(t *testing.T, n int) []*Message {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		msgs := r.snapshot()
+		if len(msgs) >= n {
+			return msgs
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d messages, got %d", n, len(msgs))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func echo(ctx context.Context, req []byte) ([]byte, error) {
+	return append([]byte("echo "), req...), nil
+}
+
+func TestLocal(t *testing.T) {
+	b := NewLocal()
+	var got recorder
+	cancel := b.Subscribe(got.add)
+	if err := b.Publish(&Message{Kind: "game", GameID: "g1", Data: []byte("hello")}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if msgs := got.snapshot(); len(msgs) != 1 || string(msgs[0].Data) != "hello" {
+		t.Fatalf("expected the message delivered at once, got %v", msgs)
+	}
+	cancel()
+	_ = b.Publish(&Message{Kind: "game", GameID: "g1"})
+	if msgs := got.snapshot(); len(msgs) != 1 {
+		t.Errorf("expected nothing after cancel, got %d messages", len(msgs))
+	}
+
+	if _, err := b.Call(context.Background(), LocalInstance, nil); !errors.Is(err, ErrNoCallHandler) {
+		t.Errorf("expected ErrNoCallHandler, got %v", err)
+	}
+	b.HandleCalls(echo)
+	if reply, err := b.Call(context.Background(), b.Instance(), []byte("hi")); err != nil || string(reply) != "echo hi" {
+		t.Errorf("expected the call answered, got %q, %v", reply, err)
+	}
+	if _, err := b.Call(context.Background(), "http://elsewhere", nil); !errors.Is(err, ErrUnknownInstance) {
+		t.Errorf("expected ErrUnknownInstance, got %v", err)
+	}
+}
+
+// startPeers starts n instances that know each other.
+func startPeers(t *testing.T, n int, secret string) []*Peers {
+	t.Helper()
+	handlers := make([]http.Handler, n)
+	urls := make([]string, n)
+	for i := range n {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlers[i].ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+		urls[i] = server.URL
+	}
+	brokers := make([]*Peers, n)
+	for i := range n {
+		brokers[i] = NewPeers(urls[i], urls, secret)
+		handlers[i] = brokers[i]
+		t.Cleanup(func() { _ = brokers[i].Close() })
+	}
+	return brokers
+}
+
+func TestPeers_PublishReachesEveryInstanceInOrder(t *testing.T) {
+	brokers := startPeers(t, 3, "s3cret")
+	recorders := make([]*recorder, len(brokers))
+	for i, b := range brokers {
+		recorders[i] = &recorder{}
+		b.Subscribe(recorders[i].add)
+	}
+
+	const count = 200
+	for i := range count {
+		if err := brokers[0].Publish(&Message{Kind: "game", GameID: "g1", Data: []byte(fmt.Sprint(i))}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	for i, r := range recorders {
+		msgs := r.waitFor(t, count)
+		for j, msg := range msgs {
+			if string(msg.Data) != fmt.Sprint(j) || msg.GameID != "g1" {
+				t.Fatalf("instance %d: expected message %d in order, got %q for %s", i, j, msg.Data, msg.GameID)
+			}
+		}
+	}
+	if drops := brokers[0].Drops(); drops != 0 {
+		t.Errorf("expected no drops, got %d", drops)
+	}
+}
+
+func TestPeers_Call(t *testing.T) {
+	brokers := startPeers(t, 2, "s3cret")
+	ctx := context.Background()
+	if _, err := brokers[0].Call(ctx, brokers[1].Instance(), []byte("hi")); err == nil || !strings.Contains(err.Error(), ErrNoCallHandler.Error()) {
+		t.Errorf("expected the missing handler reported, got %v", err)
+	}
+	brokers[1].HandleCalls(echo)
+	if reply, err := brokers[0].Call(ctx, brokers[1].Instance()+"/", []byte("hi")); err != nil || string(reply) != "echo hi" {
+		t.Errorf("expected the peer's reply, got %q, %v", reply, err)
+	}
+	brokers[0].HandleCalls(echo)
+	if reply, err := brokers[0].Call(ctx, brokers[0].Instance(), []byte("me")); err != nil || string(reply) != "echo me" {
+		t.Errorf("expected a call to itself answered directly, got %q, %v", reply, err)
+	}
+	if _, err := brokers[0].Call(ctx, "http://127.0.0.1:1", nil); !errors.Is(err, ErrUnknownInstance) {
+		t.Errorf("expected ErrUnknownInstance for a stranger, got %v", err)
+	}
+}
+
+func TestPeers_RefusesRequestsWithoutTheSecret(t *testing.T) {
+	brokers := startPeers(t, 1, "s3cret")
+	var got recorder
+	brokers[0].Subscribe(got.add)
+	brokers[0].HandleCalls(echo)
+	server := httptest.NewServer(brokers[0])
+	defer server.Close()
+
+	for _, path := range []string{"publish", "call"} {
+		for _, auth := range []string{"", "Bearer wrong"} {
+			req, _ := http.NewRequest(http.MethodPost, server.URL+PathPrefix+path, strings.NewReader(`[{"kind":"game","gameId":"g1"}]`))
+			if auth != "" {
+				req.Header.Set("Authorization", auth)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to post: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("%s with %q: expected 401, got %d", path, auth, resp.StatusCode)
+			}
+		}
+	}
+	if msgs := got.snapshot(); len(msgs) != 0 {
+		t.Errorf("expected nothing delivered, got %v", msgs)
+	}
+}
+
+func TestPeers_DropsWhatAnUnreachablePeerMisses(t *testing.T) {
+	gone := httptest.NewServer(http.NotFoundHandler())
+	gone.Close()
+	b := NewPeers("http://self.invalid", []string{"http://self.invalid", gone.URL}, "s3cret")
+	var got recorder
+	b.Subscribe(got.add)
+	if err := b.Publish(&Message{Kind: "game", GameID: "g1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if msgs := got.snapshot(); len(msgs) != 1 {
+		t.Fatalf("expected local delivery regardless, got %d messages", len(msgs))
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for b.Drops() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the message to be dropped after retries")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
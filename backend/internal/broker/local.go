@@ -0,0 +1,37 @@
+package broker
+
+import "context"
+
+// LocalInstance is the instance name of a Local broker.
+const LocalInstance = "local"
+
+// Local is the Broker of a server running as a single instance: published
+// messages go straight to this process's subscribers.
+type Local struct {
+	fanout
+}
+
+// NewLocal returns an in-process broker.
+func NewLocal() *Local {
+	return &Local{}
+}
+
+func (l *Local) Instance() string {
+	return LocalInstance
+}
+
+func (l *Local) Publish(msg *Message) error {
+	l.deliver(msg)
+	return nil
+}
+
+func (l *Local) Call(ctx context.Context, instance string, req []byte) ([]byte, error) {
+	if instance != LocalInstance {
+		return nil, ErrUnknownInstance
+	}
+	return l.call(ctx, req)
+}
+
+func (l *Local) Close() error {
+	return nil
+}
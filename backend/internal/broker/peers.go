@@ -0,0 +1,248 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PathPrefix is where Peers serves the other instances; mount the broker
+// there on every instance.
+const PathPrefix = "/internal/broker/"
+
+const (
+	// peerQueueSize bounds the messages waiting for one peer; more are
+	// dropped while it is unreachable.
+	peerQueueSize = 1024
+	// maxBatch is how many queued messages go out in one request.
+	maxBatch = 64
+	// sendAttempts is how often a batch is tried before it is dropped.
+	sendAttempts = 3
+	retryBackoff = 100 * time.Millisecond
+
+	maxPublishBytes = 16 << 20
+	maxCallBytes    = 1 << 20
+)
+
+// Peers is a Broker for several server instances that POST messages and
+// calls to each other over HTTP. Each instance is named by the base URL
+// the others reach it at, and serves Peers under PathPrefix. Requests
+// between instances carry the shared secret as a bearer token.
+type Peers struct {
+	fanout
+	self    string
+	secret  string
+	client  *http.Client
+	peers   map[string]*peer
+	done    chan struct{}
+	closing sync.Once
+	wg      sync.WaitGroup
+	drops   atomic.Uint64
+}
+
+type peer struct {
+	url   string
+	queue chan *Message
+}
+
+// NewPeers returns the broker of the instance at self among urls, which
+// may list self too. It starts sending to the others right away.
+func NewPeers(self string, urls []string, secret string) *Peers {
+	p := &Peers{
+		self:   strings.TrimSuffix(self, "/"),
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+		peers:  make(map[string]*peer),
+		done:   make(chan struct{}),
+	}
+	for _, u := range urls {
+		u = strings.TrimSuffix(u, "/")
+		if u == p.self || p.peers[u] != nil {
+			continue
+		}
+		pr := &peer{url: u, queue: make(chan *Message, peerQueueSize)}
+		p.peers[u] = pr
+		p.wg.Add(1)
+		go p.send(pr)
+	}
+	return p
+}
+
+func (p *Peers) Instance() string {
+	return p.self
+}
+
+// Publish delivers msg here at once and queues it for every peer. A peer
+// whose queue is full misses it.
+func (p *Peers) Publish(msg *Message) error {
+	p.deliver(msg)
+	for _, pr := range p.peers {
+		select {
+		case pr.queue <- msg:
+		default:
+			p.drops.Add(1)
+			slog.Warn("broker peer backed up, message dropped", "peer", pr.url, "kind", msg.Kind, "game_id", msg.GameID)
+		}
+	}
+	return nil
+}
+
+func (p *Peers) Call(ctx context.Context, instance string, req []byte) ([]byte, error) {
+	instance = strings.TrimSuffix(instance, "/")
+	if instance == p.self {
+		return p.call(ctx, req)
+	}
+	if p.peers[instance] == nil {
+		return nil, ErrUnknownInstance
+	}
+	resp, err := p.post(ctx, instance+PathPrefix+"call", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCallBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("instance %s answered %s: %s", instance, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// Drops returns how many messages peers missed because they were
+// unreachable or backed up.
+func (p *Peers) Drops() uint64 {
+	return p.drops.Load()
+}
+
+// Close stops sending after one last try at the messages already queued.
+func (p *Peers) Close() error {
+	p.closing.Do(func() { close(p.done) })
+	p.wg.Wait()
+	return nil
+}
+
+// ServeHTTP takes messages and calls from the other instances.
+func (p *Peers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !p.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	switch strings.TrimPrefix(r.URL.Path, PathPrefix) {
+	case "publish":
+		var batch []*Message
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPublishBytes)).Decode(&batch); err != nil {
+			http.Error(w, "invalid messages", http.StatusBadRequest)
+			return
+		}
+		for _, msg := range batch {
+			p.deliver(msg)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "call":
+		req, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallBytes))
+		if err != nil {
+			http.Error(w, "invalid call", http.StatusBadRequest)
+			return
+		}
+		reply, err := p.call(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(reply)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *Peers) authorized(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	return ok && p.secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(p.secret)) == 1
+}
+
+func (p *Peers) post(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.secret)
+	return p.client.Do(req)
+}
+
+// send forwards pr's queue in batches until Close.
+func (p *Peers) send(pr *peer) {
+	defer p.wg.Done()
+	for {
+		select {
+		case msg := <-pr.queue:
+			p.sendBatch(pr, p.batch(pr, msg), sendAttempts)
+		case <-p.done:
+			for {
+				select {
+				case msg := <-pr.queue:
+					p.sendBatch(pr, p.batch(pr, msg), 1)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// batch adds up to maxBatch already queued messages after first.
+func (p *Peers) batch(pr *peer, first *Message) []*Message {
+	batch := []*Message{first}
+	for len(batch) < maxBatch {
+		select {
+		case msg := <-pr.queue:
+			batch = append(batch, msg)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (p *Peers) sendBatch(pr *peer, batch []*Message, attempts int) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		slog.Error("failed to encode broker messages", "err", err)
+		return
+	}
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(retryBackoff << (attempt - 1)):
+			case <-p.done:
+			}
+		}
+		resp, err := p.post(context.Background(), pr.url+PathPrefix+"publish", body)
+		if err == nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode/100 == 2 {
+				return
+			}
+			err = fmt.Errorf("answered %s", resp.Status)
+		}
+		slog.Warn("failed to publish to broker peer", "peer", pr.url, "attempt", attempt+1, "err", err)
+	}
+	p.drops.Add(uint64(len(batch)))
+}
@@ -0,0 +1,320 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the server configuration. Each setting can come from a JSON
+// config file, an environment variable or a command line flag; later sources
+// win in that order.
+type Config struct {
+	Addr       string
+	DBPath     string
+	StorageURL string
+	TLSCert    string
+	TLSKey     string
+	// AllowedOrigins lists the Origin headers WebSocket upgrades accept.
+	// Requests without one are always accepted. Empty accepts any origin.
+	AllowedOrigins []string
+	LogLevel       slog.Level
+	// LogFormat is "json" or "text".
+	LogFormat string
+	DevMode   bool
+	// AdminToken enables the admin API for bearer requests carrying it.
+	AdminToken string
+	// ChatWordFilter lists words masked out of chat messages.
+	ChatWordFilter []string
+	// StateValidation is "off", "log" or "reject": what to do when a
+	// command leaves a game breaking its invariants.
+	StateValidation string
+
+	// WebhookURLs receive "your turn" notifications for correspondence
+	// games; WebhookSecret signs them.
+	WebhookURLs   []string
+	WebhookSecret string
+
+	LobbyTTL              time.Duration
+	FinishedGameRetention time.Duration
+	JanitorInterval       time.Duration
+	// DeadlineInterval is how often correspondence turn deadlines are
+	// enforced.
+	DeadlineInterval time.Duration
+	// ShutdownTimeout bounds how long shutdown waits for requests and game
+	// commands in flight.
+	ShutdownTimeout time.Duration
+
+	// Peers lists the base URLs of every server instance sharing the game
+	// store; InstanceURL is this one's. Instances authenticate to each other
+	// with BrokerSecret. Without peers the server runs as a single instance.
+	Peers        []string
+	InstanceURL  string
+	BrokerSecret string
+	// LeaseTTL is how long an instance keeps running a game's commands
+	// after it last renewed the game's lease.
+	LeaseTTL time.Duration
+}
+
+// Default returns the configuration used when nothing is set.
+func Default() *Config {
+	return &Config{
+		Addr:                  ":8080",
+		DBPath:                "./catan.db",
+		LogLevel:              slog.LevelInfo,
+		LogFormat:             "json",
+		StateValidation:       "log",
+		LobbyTTL:              24 * time.Hour,
+		FinishedGameRetention: 30 * 24 * time.Hour,
+		JanitorInterval:       10 * time.Minute,
+		DeadlineInterval:      time.Minute,
+		ShutdownTimeout:       15 * time.Second,
+		LeaseTTL:              30 * time.Second,
+	}
+}
+
+// TLS reports whether the server should serve HTTPS.
+func (c *Config) TLS() bool {
+	return c.TLSCert != ""
+}
+
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{"addr", "LISTEN_ADDR", "address to listen on", func(c *Config, v string) error {
+		c.Addr = v
+		return nil
+	}},
+	{"db", "DB_PATH", "SQLite database path", func(c *Config, v string) error {
+		c.DBPath = v
+		return nil
+	}},
+	{"storage", "STORAGE_URL", "game store URL (sqlite://path, bolt://path or memory://); defaults to the main database", func(c *Config, v string) error {
+		c.StorageURL = v
+		return nil
+	}},
+	{"tls-cert", "TLS_CERT_FILE", "TLS certificate file; serves HTTPS when set", func(c *Config, v string) error {
+		c.TLSCert = v
+		return nil
+	}},
+	{"tls-key", "TLS_KEY_FILE", "TLS private key file", func(c *Config, v string) error {
+		c.TLSKey = v
+		return nil
+	}},
+	{"allowed-origins", "ALLOWED_ORIGINS", "comma separated origins allowed to open WebSockets; empty allows any", func(c *Config, v string) error {
+		c.AllowedOrigins = splitList(v)
+		return nil
+	}},
+	{"log-level", "LOG_LEVEL", "debug, info, warn or error", func(c *Config, v string) error {
+		return c.LogLevel.UnmarshalText([]byte(v))
+	}},
+	{"log-format", "LOG_FORMAT", "json or text", func(c *Config, v string) error {
+		if v != "json" && v != "text" {
+			return errors.New("want json or text")
+		}
+		c.LogFormat = v
+		return nil
+	}},
+	{"dev", "DEV_MODE", "enable the /test endpoints", func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		c.DevMode = b
+		return err
+	}},
+	{"admin-token", "ADMIN_TOKEN", "bearer token for /api/admin; empty disables the admin API", func(c *Config, v string) error {
+		c.AdminToken = v
+		return nil
+	}},
+	{"chat-word-filter", "CHAT_WORD_FILTER", "comma separated words masked out of chat", func(c *Config, v string) error {
+		c.ChatWordFilter = splitList(v)
+		return nil
+	}},
+	{"state-validation", "STATE_VALIDATION", "off, log or reject commands that leave a game state invalid", func(c *Config, v string) error {
+		if v != "off" && v != "log" && v != "reject" {
+			return errors.New("want off, log or reject")
+		}
+		c.StateValidation = v
+		return nil
+	}},
+	{"webhook-urls", "WEBHOOK_URLS", "comma separated URLs notified when a correspondence game waits on a player", func(c *Config, v string) error {
+		c.WebhookURLs = splitList(v)
+		return nil
+	}},
+	{"webhook-secret", "WEBHOOK_SECRET", "key for the HMAC-SHA256 signature sent with each webhook", func(c *Config, v string) error {
+		c.WebhookSecret = v
+		return nil
+	}},
+	{"lobby-ttl", "LOBBY_TTL", "expire waiting lobbies idle this long; 0 keeps them", durationSetter(func(c *Config) *time.Duration { return &c.LobbyTTL })},
+	{"finished-retention", "FINISHED_GAME_RETENTION", "delete finished games this long after they end; 0 keeps them", durationSetter(func(c *Config) *time.Duration { return &c.FinishedGameRetention })},
+	{"janitor-interval", "JANITOR_INTERVAL", "how often to look for expired games", durationSetter(func(c *Config) *time.Duration { return &c.JanitorInterval })},
+	{"deadline-interval", "DEADLINE_INTERVAL", "how often to enforce correspondence turn deadlines; 0 disables them", durationSetter(func(c *Config) *time.Duration { return &c.DeadlineInterval })},
+	{"shutdown-timeout", "SHUTDOWN_TIMEOUT", "how long to wait for in-flight work on shutdown", durationSetter(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{"peers", "PEERS", "comma separated base URLs of every server instance sharing the game store", func(c *Config, v string) error {
+		c.Peers = splitList(v)
+		return nil
+	}},
+	{"instance-url", "INSTANCE_URL", "base URL the other instances reach this one at", func(c *Config, v string) error {
+		c.InstanceURL = v
+		return nil
+	}},
+	{"broker-secret", "BROKER_SECRET", "shared secret instances authenticate to each other with", func(c *Config, v string) error {
+		c.BrokerSecret = v
+		return nil
+	}},
+	{"lease-ttl", "LEASE_TTL", "how long an instance keeps a game after last renewing its lease", durationSetter(func(c *Config) *time.Duration { return &c.LeaseTTL })},
+}
+
+func durationSetter(field func(c *Config) *time.Duration) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		if d < 0 {
+			return errors.New("must not be negative")
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Load builds the configuration from defaults, the config file named by
+// -config or CONFIG_FILE, the environment and then args.
+func Load(args []string, getenv func(string) string) (*Config, error) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	configPath := fs.String("config", getenv("CONFIG_FILE"), "JSON config file (env CONFIG_FILE)")
+	var flagValues [][2]string
+	for _, s := range settings {
+		name := s.flag
+		fs.Func(name, fmt.Sprintf("%s (env %s)", s.usage, s.env), func(v string) error {
+			flagValues = append(flagValues, [2]string{name, v})
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	cfg := Default()
+	if *configPath != "" {
+		if err := cfg.loadFile(*configPath); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range settings {
+		if v := getenv(s.env); v != "" {
+			if err := s.set(cfg, v); err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", s.env, v, err)
+			}
+		}
+	}
+	for _, fv := range flagValues {
+		s, _ := lookup(fv[0])
+		if err := s.set(cfg, fv[1]); err != nil {
+			return nil, fmt.Errorf("invalid -%s %q: %w", fv[0], fv[1], err)
+		}
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func lookup(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.flag == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// loadFile reads a JSON object keyed by flag name, for example
+// {"addr": ":443", "allowed-origins": ["https://catan.example"]}.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	for name, raw := range values {
+		s, ok := lookup(name)
+		if !ok {
+			return fmt.Errorf("config file %s: unknown setting %q", path, name)
+		}
+		v, err := fileValue(raw)
+		if err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, name, err)
+		}
+		if err := s.set(c, v); err != nil {
+			return fmt.Errorf("config file %s: invalid %s %q: %w", path, name, v, err)
+		}
+	}
+	return nil
+}
+
+// fileValue turns a JSON string, list of strings, bool or number into the
+// text form a flag would take.
+func fileValue(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case len(raw) > 0 && raw[0] == '"':
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case len(raw) > 0 && raw[0] == '[':
+		var list []string
+		err := json.Unmarshal(raw, &list)
+		return strings.Join(list, ","), err
+	case bytes.Equal(raw, []byte("null")):
+		return "", errors.New("null is not a value")
+	default:
+		return string(raw), nil
+	}
+}
+
+func (c *Config) validate() error {
+	if c.Addr == "" {
+		return errors.New("listen address must not be empty")
+	}
+	if c.DBPath == "" {
+		return errors.New("database path must not be empty")
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		return errors.New("TLS needs both a certificate and a key")
+	}
+	if len(c.Peers) > 0 && (c.InstanceURL == "" || c.BrokerSecret == "") {
+		return errors.New("peers need an instance URL and a broker secret")
+	}
+	if c.LeaseTTL <= 0 {
+		return errors.New("lease TTL must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(name string) string { return values[name] }
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("expected defaults, got %+v", cfg)
+	}
+	if cfg.TLS() {
+		t.Fatal("expected TLS to be off by default")
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catan.json")
+	file := `{
+		"addr": ":9000",
+		"db": "/var/lib/catan/file.db",
+		"allowed-origins": ["https://a.example", "https://b.example"],
+		"dev": true,
+		"lobby-ttl": "2h",
+		"log-level": "warn"
+	}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load([]string{"-config", path, "-addr", ":9100", "-lobby-ttl", "0", "-log-format", "text"}, env(map[string]string{
+		"LISTEN_ADDR":      ":9050",
+		"DB_PATH":          "/tmp/env.db",
+		"LOG_LEVEL":        "debug",
+		"CHAT_WORD_FILTER": "darn, heck",
+		"STATE_VALIDATION": "reject",
+		"WEBHOOK_URLS":     "https://hooks.example/a,https://hooks.example/b",
+		"PEERS":            "http://catan-1:8080, http://catan-2:8080",
+		"INSTANCE_URL":     "http://catan-1:8080",
+		"BROKER_SECRET":    "s3cret",
+	}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"flag beats env and file", cfg.Addr, ":9100"},
+		{"env beats file", cfg.DBPath, "/tmp/env.db"},
+		{"env log level", cfg.LogLevel, slog.LevelDebug},
+		{"env list", cfg.ChatWordFilter, []string{"darn", "heck"}},
+		{"flag log format", cfg.LogFormat, "text"},
+		{"env state validation", cfg.StateValidation, "reject"},
+		{"env webhooks", cfg.WebhookURLs, []string{"https://hooks.example/a", "https://hooks.example/b"}},
+		{"env peers", cfg.Peers, []string{"http://catan-1:8080", "http://catan-2:8080"}},
+		{"file list", cfg.AllowedOrigins, []string{"https://a.example", "https://b.example"}},
+		{"file bool", cfg.DevMode, true},
+		{"flag zero duration", cfg.LobbyTTL, time.Duration(0)},
+		{"untouched default", cfg.JanitorInterval, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catan.json")
+	if err := os.WriteFile(path, []byte(`{"allowed-origins": "https://a.example, https://b.example"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(nil, env(map[string]string{"CONFIG_FILE": path}))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := []string{"https://a.example", "https://b.example"}; !reflect.DeepEqual(cfg.AllowedOrigins, want) {
+		t.Fatalf("got %v, want %v", cfg.AllowedOrigins, want)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	unknown := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknown, []byte(`{"port": 8080}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{"bad duration flag", []string{"-janitor-interval", "soon"}, nil, "-janitor-interval"},
+		{"negative duration", []string{"-lobby-ttl", "-1h"}, nil, "negative"},
+		{"bad env bool", nil, map[string]string{"DEV_MODE": "yes please"}, "DEV_MODE"},
+		{"bad log level", []string{"-log-level", "loud"}, nil, "-log-level"},
+		{"bad log format", nil, map[string]string{"LOG_FORMAT": "xml"}, "LOG_FORMAT"},
+		{"bad state validation", []string{"-state-validation", "panic"}, nil, "-state-validation"},
+		{"cert without key", []string{"-tls-cert", "cert.pem"}, nil, "certificate and a key"},
+		{"peers without secret", []string{"-peers", "http://a:8080,http://b:8080", "-instance-url", "http://a:8080"}, nil, "broker secret"},
+		{"zero lease ttl", nil, map[string]string{"LEASE_TTL": "0s"}, "lease TTL"},
+		{"unknown file setting", []string{"-config", unknown}, nil, `unknown setting "port"`},
+		{"missing file", []string{"-config", filepath.Join(dir, "missing.json")}, nil, "missing.json"},
+		{"stray argument", []string{"serve"}, nil, "unexpected arguments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.args, env(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
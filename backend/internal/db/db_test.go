@@ -3,6 +3,9 @@ package db
 import (
 	"os"
 	"testing"
+	"testing/fstest"
+
+	"github.com/jmoiron/sqlx"
 )
 
 func TestInitialize(t *testing.T) {
@@ -54,3 +57,179 @@ func TestPlayersTableHasIsHost(t *testing.T) {
 		t.Errorf("Expected is_host to be 1, got %d", isHost)
 	}
 }
+
+func TestInitializeAddsUserIDToExistingPlayersTable(t *testing.T) {
+	tmpFile := t.TempDir() + "/old.db"
+
+	old, err := sqlx.Connect("sqlite", tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	_, err = old.Exec(`CREATE TABLE players (id TEXT PRIMARY KEY, game_id TEXT, name TEXT NOT NULL, session_token TEXT UNIQUE)`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy players table: %v", err)
+	}
+	old.Close()
+
+	db, err := Initialize(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM pragma_table_info('players') WHERE name = 'user_id'"); err != nil {
+		t.Fatalf("Failed to query columns: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected players.user_id to be added, got %d", count)
+	}
+}
+
+func TestMigrate_FromBaselineFixture(t *testing.T) {
+	tmpFile := t.TempDir() + "/baseline.db"
+	fixture, err := os.ReadFile("testdata/baseline.sql")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	old, err := sqlx.Connect("sqlite", tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if _, err := old.Exec(string(fixture)); err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	old.Close()
+
+	db, err := Initialize(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to migrate fixture: %v", err)
+	}
+	defer db.Close()
+
+	statuses, err := Status(db)
+	if err != nil {
+		t.Fatalf("Failed to read migration status: %v", err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("Expected migration %04d_%s to be applied", s.Version, s.Name)
+		}
+	}
+
+	var players int
+	if err := db.Get(&players, "SELECT COUNT(*) FROM players WHERE game_id = 'game1' AND user_id IS NULL"); err != nil {
+		t.Fatalf("Failed to query players: %v", err)
+	}
+	if players != 2 {
+		t.Errorf("Expected fixture players to survive with no user, got %d", players)
+	}
+	for _, table := range []string{"users", "auth_tokens", "game_events", "game_stats", "game_history", "player_game_stats", "player_ratings", "chat_messages", "tournaments", "game_leases"} {
+		var count int
+		if err := db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table); err != nil || count != 1 {
+			t.Errorf("Expected table %s to exist (err=%v)", table, err)
+		}
+	}
+	if _, err := db.Exec("INSERT INTO game_events (game_id, seq, kind, state) VALUES ('game1', 1, 'createGame', '{}')"); err != nil {
+		t.Errorf("Expected to log events for fixture game: %v", err)
+	}
+}
+
+func TestMigrate_AppliesEachMigrationOnce(t *testing.T) {
+	db, err := Initialize(t.TempDir() + "/fresh.db")
+	if err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+	var recorded int
+	if err := db.Get(&recorded, "SELECT COUNT(*) FROM schema_migrations"); err != nil {
+		t.Fatalf("Failed to query schema_migrations: %v", err)
+	}
+	if recorded != len(migrations) {
+		t.Errorf("Expected %d recorded migrations, got %d", len(migrations), recorded)
+	}
+
+	applied, err := Migrate(db)
+	if err != nil {
+		t.Fatalf("Failed to re-run migrations: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected no pending migrations, applied %d", len(applied))
+	}
+}
+
+func TestMigrate_FailedMigrationRollsBack(t *testing.T) {
+	db, err := Open(t.TempDir() + "/broken.db")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := ensureMigrationsTable(db); err != nil {
+		t.Fatalf("Failed to create schema_migrations: %v", err)
+	}
+
+	err = applyMigration(db, Migration{Version: 99, Name: "broken", SQL: "CREATE TABLE half (id TEXT); INSERT INTO missing VALUES (1);"})
+	if err == nil {
+		t.Fatal("Expected broken migration to fail")
+	}
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE name = 'half'"); err != nil || count != 0 {
+		t.Errorf("Expected partial migration to be rolled back, found %d tables (err=%v)", count, err)
+	}
+	if err := db.Get(&count, "SELECT COUNT(*) FROM schema_migrations"); err != nil || count != 0 {
+		t.Errorf("Expected failed migration not to be recorded, got %d", count)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int
+		wantErr bool
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"m/0010_later.sql": {Data: []byte("SELECT 1;")},
+				"m/0002_first.sql": {Data: []byte("SELECT 1;")},
+				"m/README.md":      {Data: []byte("ignored")},
+			},
+			want: []int{2, 10},
+		},
+		{
+			name:    "missing version",
+			files:   fstest.MapFS{"m/init.sql": {Data: []byte("SELECT 1;")}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"m/0001_a.sql": {Data: []byte("SELECT 1;")},
+				"m/1_b.sql":    {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadMigrations(tt.files, "m")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d migrations, got %d", len(tt.want), len(got))
+			}
+			for i, m := range got {
+				if m.Version != tt.want[i] {
+					t.Errorf("migration %d: expected version %d, got %d", i, tt.want[i], m.Version)
+				}
+			}
+		})
+	}
+}
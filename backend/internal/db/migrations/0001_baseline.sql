@@ -0,0 +1,28 @@
+-- Schema as created by db.Initialize before versioned migrations. Tables use
+-- IF NOT EXISTS so databases created back then adopt this version as is.
+CREATE TABLE IF NOT EXISTS games (
+	id TEXT PRIMARY KEY,
+	code TEXT UNIQUE NOT NULL,
+	state TEXT NOT NULL,
+	status TEXT DEFAULT 'waiting',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_code ON games(code);
+CREATE INDEX IF NOT EXISTS idx_games_status ON games(status);
+
+CREATE TABLE IF NOT EXISTS players (
+	id TEXT PRIMARY KEY,
+	game_id TEXT REFERENCES games(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	color TEXT,
+	session_token TEXT UNIQUE,
+	is_host INTEGER DEFAULT 0,
+	connected INTEGER DEFAULT 0,
+	last_seen TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_players_game_id ON players(game_id);
+CREATE INDEX IF NOT EXISTS idx_players_session_token ON players(session_token);
@@ -0,0 +1,41 @@
+-- Running per-game stats, archived results and ratings. Games may live in
+-- another store (STORAGE_URL), so running stats do not reference them.
+CREATE TABLE game_stats (
+	game_id TEXT PRIMARY KEY,
+	stats TEXT NOT NULL,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE game_history (
+	game_id TEXT PRIMARY KEY,
+	code TEXT NOT NULL,
+	winner_id TEXT,
+	player_count INTEGER NOT NULL,
+	turns INTEGER NOT NULL,
+	dice_distribution TEXT,
+	finished_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE player_game_stats (
+	game_id TEXT NOT NULL REFERENCES game_history(game_id) ON DELETE CASCADE,
+	player_id TEXT NOT NULL,
+	player_name TEXT NOT NULL COLLATE NOCASE,
+	place INTEGER NOT NULL,
+	points INTEGER NOT NULL,
+	is_winner INTEGER DEFAULT 0,
+	stats TEXT NOT NULL,
+	rating_before REAL,
+	rating_after REAL,
+	PRIMARY KEY (game_id, player_id)
+);
+
+CREATE INDEX idx_player_game_stats_player_id ON player_game_stats(player_id);
+CREATE INDEX idx_player_game_stats_player_name ON player_game_stats(player_name);
+
+CREATE TABLE player_ratings (
+	player_name TEXT PRIMARY KEY COLLATE NOCASE,
+	rating REAL NOT NULL,
+	games_played INTEGER DEFAULT 0,
+	wins INTEGER DEFAULT 0,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,11 @@
+-- Per-game event log with the state after each event.
+CREATE TABLE game_events (
+	game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	seq INTEGER NOT NULL,
+	kind TEXT NOT NULL,
+	player_id TEXT,
+	payload TEXT,
+	state TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (game_id, seq)
+);
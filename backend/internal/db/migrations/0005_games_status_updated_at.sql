@@ -0,0 +1,2 @@
+-- Lets the janitor find games idle in a status without scanning every game.
+CREATE INDEX IF NOT EXISTS idx_games_status_updated_at ON games(status, updated_at);
@@ -0,0 +1,13 @@
+-- Every action taken through the admin API.
+CREATE TABLE admin_audit (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	action TEXT NOT NULL,
+	game_id TEXT,
+	target TEXT,
+	details TEXT,
+	actor TEXT,
+	remote_addr TEXT,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_audit_game_id ON admin_audit(game_id);
@@ -0,0 +1,10 @@
+-- In-game chat. Whispers have a recipient; everything else goes to the whole game.
+CREATE TABLE chat_messages (
+	game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	seq INTEGER NOT NULL,
+	sender_id TEXT NOT NULL,
+	recipient_id TEXT,
+	text TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (game_id, seq)
+);
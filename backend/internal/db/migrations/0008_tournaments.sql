@@ -0,0 +1,9 @@
+-- Tournaments and their rounds; each table's game records the tournament in its state.
+CREATE TABLE tournaments (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	state TEXT NOT NULL,
+	status TEXT NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
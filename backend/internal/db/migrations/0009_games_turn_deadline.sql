@@ -0,0 +1,9 @@
+-- Correspondence turn deadlines (Unix milliseconds) mirrored out of the game
+-- state, so the deadline sweep finds overdue games without decoding every
+-- state. NULL while no turn clock is running.
+ALTER TABLE games ADD COLUMN turn_deadline INTEGER;
+
+UPDATE games SET turn_deadline = CAST(json_extract(state, '$.correspondence.turnDeadline') AS INTEGER)
+	WHERE json_extract(state, '$.correspondence.turnDeadline') IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_games_turn_deadline ON games(turn_deadline);
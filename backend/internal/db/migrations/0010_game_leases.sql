@@ -0,0 +1,6 @@
+-- Which server instance runs each game's commands, and until when.
+CREATE TABLE game_leases (
+	game_id TEXT PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+	owner TEXT NOT NULL,
+	expires_at INTEGER NOT NULL
+);
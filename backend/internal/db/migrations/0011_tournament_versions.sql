@@ -0,0 +1,3 @@
+-- Bumped on every save, so concurrent changes to a tournament from different
+-- server instances cannot overwrite each other.
+ALTER TABLE tournaments ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,31 @@
+-- A catan.db as created by the server before versioned migrations.
+CREATE TABLE games (
+	id TEXT PRIMARY KEY,
+	code TEXT UNIQUE NOT NULL,
+	state TEXT NOT NULL,
+	status TEXT DEFAULT 'waiting',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_games_code ON games(code);
+CREATE INDEX idx_games_status ON games(status);
+
+CREATE TABLE players (
+	id TEXT PRIMARY KEY,
+	game_id TEXT REFERENCES games(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	color TEXT,
+	session_token TEXT UNIQUE,
+	is_host INTEGER DEFAULT 0,
+	connected INTEGER DEFAULT 0,
+	last_seen TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_players_game_id ON players(game_id);
+CREATE INDEX idx_players_session_token ON players(session_token);
+
+INSERT INTO games (id, code, state, status) VALUES ('game1', 'ABCDEF', '{"id":"game1","code":"ABCDEF"}', 'playing');
+INSERT INTO players (id, game_id, name, color, session_token, is_host) VALUES ('p1', 'game1', 'Alice', '1', 'token-alice', 1);
+INSERT INTO players (id, game_id, name, color, session_token, is_host) VALUES ('p2', 'game1', 'Bob', '2', 'token-bob', 0);
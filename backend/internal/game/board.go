@@ -54,17 +54,27 @@ var hexCoords = []struct{ q, r int32 }{
 
 // GenerateBoard creates a randomized standard Catan board
 func GenerateBoard() *pb.BoardState {
+	return generateBoard(rand.New(rand.NewSource(rand.Int63())))
+}
+
+// GenerateBoardFromSeed creates the standard Catan board layout for seed.
+// The same seed always produces the same board.
+func GenerateBoardFromSeed(seed int64) *pb.BoardState {
+	return generateBoard(rand.New(rand.NewSource(seed)))
+}
+
+func generateBoard(rng *rand.Rand) *pb.BoardState {
 	// Shuffle resources
 	resources := make([]pb.TileResource, len(standardResources))
 	copy(resources, standardResources)
-	rand.Shuffle(len(resources), func(i, j int) {
+	rng.Shuffle(len(resources), func(i, j int) {
 		resources[i], resources[j] = resources[j], resources[i]
 	})
 
 	// Shuffle numbers
 	numbers := make([]int32, len(standardNumbers))
 	copy(numbers, standardNumbers)
-	rand.Shuffle(len(numbers), func(i, j int) {
+	rng.Shuffle(len(numbers), func(i, j int) {
 		numbers[i], numbers[j] = numbers[j], numbers[i]
 	})
 
@@ -106,7 +116,7 @@ func GenerateBoard() *pb.BoardState {
 	}
 
 	// Add standard ports (maritime trading) - needs vertices to be generated first
-	board.Ports = GeneratePortsForBoard(board)
+	board.Ports = generatePorts(board, rng.Perm)
 	return board
 }
 
@@ -134,6 +144,9 @@ func generateVertices(hexes []*pb.Hex) []*pb.Vertex {
 		r int
 	}
 	vertexMap := make(map[vertexKey]*pb.Vertex)
+	// Vertices are kept in the order first seen so a seed always yields the
+	// same board, down to the order of its slices.
+	var vertices []*pb.Vertex
 
 	for _, hex := range hexes {
 		if hex.Coord == nil {
@@ -149,6 +162,7 @@ func generateVertices(hexes []*pb.Hex) []*pb.Vertex {
 					Id:            formatVertexID(vq, vr),
 					AdjacentHexes: []*pb.HexCoord{},
 				}
+				vertices = append(vertices, vertexMap[key])
 			}
 
 			// Add this hex as adjacent to the vertex
@@ -156,17 +170,12 @@ func generateVertices(hexes []*pb.Hex) []*pb.Vertex {
 		}
 	}
 
-	// Convert map to slice
-	vertices := make([]*pb.Vertex, 0, len(vertexMap))
-	for _, v := range vertexMap {
-		vertices = append(vertices, v)
-	}
-
 	return vertices
 }
 
 func generateEdges(hexes []*pb.Hex) []*pb.Edge {
 	edgeMap := make(map[string]*pb.Edge)
+	var edges []*pb.Edge
 
 	for _, hex := range hexes {
 		if hex.Coord == nil {
@@ -186,15 +195,11 @@ func generateEdges(hexes []*pb.Hex) []*pb.Edge {
 					Id:       edgeID,
 					Vertices: vertices,
 				}
+				edges = append(edges, edgeMap[edgeID])
 			}
 		}
 	}
 
-	edges := make([]*pb.Edge, 0, len(edgeMap))
-	for _, e := range edgeMap {
-		edges = append(edges, e)
-	}
-
 	return edges
 }
 
@@ -221,6 +226,13 @@ func orderedEdge(v1, v2 string) (string, []string) {
 
 // NewGameState creates a new game state with the given players
 func NewGameState(gameID, code string, playerNames []string, playerIDs []string) *pb.GameState {
+	return NewGameStateFromSeed(gameID, code, playerNames, playerIDs, rand.Int63())
+}
+
+// NewGameStateFromSeed creates a new game state whose board and dev card deck
+// are generated from seed. The seed is stored in the state.
+func NewGameStateFromSeed(gameID, code string, playerNames []string, playerIDs []string, seed int64) *pb.GameState {
+	rng := rand.New(rand.NewSource(seed))
 	colors := []pb.PlayerColor{
 		pb.PlayerColor_PLAYER_COLOR_RED,
 		pb.PlayerColor_PLAYER_COLOR_BLUE,
@@ -245,14 +257,16 @@ func NewGameState(gameID, code string, playerNames []string, playerIDs []string)
 	}
 
 	return &pb.GameState{
-		Id:          gameID,
-		Code:        code,
-		Board:       GenerateBoard(),
-		Players:     players,
-		CurrentTurn: 0,
-		TurnPhase:   pb.TurnPhase_TURN_PHASE_ROLL,
-		Dice:        []int32{0, 0},
-		Status:      pb.GameStatus_GAME_STATUS_WAITING,
-		DevCardDeck: InitDevCardDeck(),
+		Id:           gameID,
+		Code:         code,
+		Board:        generateBoard(rng),
+		Players:      players,
+		CurrentTurn:  0,
+		TurnPhase:    pb.TurnPhase_TURN_PHASE_ROLL,
+		Dice:         []int32{0, 0},
+		Status:       pb.GameStatus_GAME_STATUS_WAITING,
+		DevCardDeck:  initDevCardDeck(rng),
+		Seed:         seed,
+		StateVersion: CurrentStateVersion,
 	}
 }
@@ -3,6 +3,8 @@ package game
 import (
 	"testing"
 
+	"google.golang.org/protobuf/proto"
+
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
 
@@ -187,3 +189,37 @@ func TestPlayerStartsWithNoResources(t *testing.T) {
 		t.Error("Players should start with 0 resources")
 	}
 }
+
+func TestNewGameStateFromSeed_IsDeterministic(t *testing.T) {
+	a := NewGameStateFromSeed("g1", "AAAAAA", []string{"Alice"}, []string{"p1"}, 42)
+	b := NewGameStateFromSeed("g2", "BBBBBB", []string{"Bob"}, []string{"p2"}, 42)
+
+	if a.Seed != 42 {
+		t.Errorf("expected seed to be stored, got %d", a.Seed)
+	}
+	for i := range a.Board.Hexes {
+		if a.Board.Hexes[i].Resource != b.Board.Hexes[i].Resource || a.Board.Hexes[i].Number != b.Board.Hexes[i].Number {
+			t.Fatalf("hex %d differs between boards with the same seed", i)
+		}
+	}
+	for i := range a.DevCardDeck {
+		if a.DevCardDeck[i] != b.DevCardDeck[i] {
+			t.Fatalf("dev card %d differs between decks with the same seed", i)
+		}
+	}
+	for i := range a.Board.Vertices {
+		if a.Board.Vertices[i].Id != b.Board.Vertices[i].Id {
+			t.Fatalf("vertex %d differs between boards with the same seed", i)
+		}
+	}
+	for i := range a.Board.Edges {
+		if a.Board.Edges[i].Id != b.Board.Edges[i].Id {
+			t.Fatalf("edge %d differs between boards with the same seed", i)
+		}
+	}
+	for i := range a.Board.Ports {
+		if !proto.Equal(a.Board.Ports[i], b.Board.Ports[i]) {
+			t.Fatalf("port %d differs between boards with the same seed", i)
+		}
+	}
+}
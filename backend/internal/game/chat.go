@@ -0,0 +1,69 @@
+package game
+
+import (
+	"slices"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// Chat lines are kept outside the game state; the state only records who may
+// speak. Connections without a seat in the game are spectators.
+var (
+	ErrNotChatModerator      = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_CHAT_MODERATOR, "only the host can moderate chat")
+	ErrChatMuted             = newRuleError(pb.ErrorCode_ERROR_CODE_CHAT_MUTED, "you are muted in this game's chat")
+	ErrSpectatorChatDisabled = newRuleError(pb.ErrorCode_ERROR_CODE_SPECTATOR_CHAT_DISABLED, "chat is disabled for spectators")
+	ErrInvalidWhisper        = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_WHISPER, "whispers must go to another player in the game")
+)
+
+// CanChat reports whether senderID may send a chat line to recipientID, or
+// to the whole game when recipientID is empty.
+func CanChat(state *pb.GameState, senderID, recipientID string) error {
+	if slices.Contains(state.ChatMutedPlayerIds, senderID) {
+		return ErrChatMuted
+	}
+	if state.SpectatorChatDisabled && getPlayerByID(state, senderID) == nil {
+		return ErrSpectatorChatDisabled
+	}
+	if recipientID != "" && (recipientID == senderID || getPlayerByID(state, recipientID) == nil) {
+		return ErrInvalidWhisper
+	}
+	return nil
+}
+
+// SetChatMuted lets the host mute or unmute a player in chat.
+func SetChatMuted(state *pb.GameState, hostID, playerID string, muted bool) error {
+	if err := requireChatModerator(state, hostID); err != nil {
+		return err
+	}
+	if getPlayerByID(state, playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	idx := slices.Index(state.ChatMutedPlayerIds, playerID)
+	switch {
+	case muted && idx < 0:
+		state.ChatMutedPlayerIds = append(state.ChatMutedPlayerIds, playerID)
+	case !muted && idx >= 0:
+		state.ChatMutedPlayerIds = slices.Delete(state.ChatMutedPlayerIds, idx, idx+1)
+	}
+	return nil
+}
+
+// SetSpectatorChatDisabled lets the host stop or allow chat from spectators.
+func SetSpectatorChatDisabled(state *pb.GameState, hostID string, disabled bool) error {
+	if err := requireChatModerator(state, hostID); err != nil {
+		return err
+	}
+	state.SpectatorChatDisabled = disabled
+	return nil
+}
+
+func requireChatModerator(state *pb.GameState, playerID string) error {
+	player := getPlayerByID(state, playerID)
+	if player == nil {
+		return ErrPlayerNotFound
+	}
+	if !player.IsHost {
+		return ErrNotChatModerator
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanChat(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+	state.ChatMutedPlayerIds = []string{"p3"}
+
+	tests := []struct {
+		name              string
+		sender, recipient string
+		spectatorsOff     bool
+		wantErr           error
+	}{
+		{"game-wide", "p1", "", false, nil},
+		{"whisper", "p1", "p2", false, nil},
+		{"muted player", "p3", "", false, ErrChatMuted},
+		{"whisper to self", "p1", "p1", false, ErrInvalidWhisper},
+		{"whisper to stranger", "p1", "nobody", false, ErrInvalidWhisper},
+		{"spectator allowed", "watcher", "", false, nil},
+		{"spectator disabled", "watcher", "", true, ErrSpectatorChatDisabled},
+		{"player unaffected by spectator setting", "p2", "", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state.SpectatorChatDisabled = tt.spectatorsOff
+			if err := CanChat(state, tt.sender, tt.recipient); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestChatModeration(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	state.Players[0].IsHost = true
+
+	if err := SetChatMuted(state, "p2", "p1", true); !errors.Is(err, ErrNotChatModerator) {
+		t.Fatalf("expected ErrNotChatModerator, got %v", err)
+	}
+	if err := SetChatMuted(state, "p1", "nobody", true); !errors.Is(err, ErrPlayerNotFound) {
+		t.Fatalf("expected ErrPlayerNotFound, got %v", err)
+	}
+	for range 2 {
+		if err := SetChatMuted(state, "p1", "p2", true); err != nil {
+			t.Fatalf("SetChatMuted: %v", err)
+		}
+	}
+	if len(state.ChatMutedPlayerIds) != 1 || state.ChatMutedPlayerIds[0] != "p2" {
+		t.Fatalf("expected p2 muted once, got %v", state.ChatMutedPlayerIds)
+	}
+	if err := SetChatMuted(state, "p1", "p2", false); err != nil || len(state.ChatMutedPlayerIds) != 0 {
+		t.Fatalf("expected p2 unmuted, got %v, %v", state.ChatMutedPlayerIds, err)
+	}
+
+	if err := SetSpectatorChatDisabled(state, "p2", true); !errors.Is(err, ErrNotChatModerator) {
+		t.Fatalf("expected ErrNotChatModerator, got %v", err)
+	}
+	if err := SetSpectatorChatDisabled(state, "p1", true); err != nil || !state.SpectatorChatDisabled {
+		t.Fatalf("expected spectator chat disabled, got %v", err)
+	}
+}
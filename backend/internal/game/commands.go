@@ -11,8 +11,8 @@ func PlaceSettlement(state *pb.GameState, playerID, vertexID string) error {
 		return ErrWrongPhase
 	}
 
-	// Verify it's build phase
-	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD {
+	// Verify it's build phase and the robber is settled
+	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD || state.RobberPhase != nil {
 		return ErrWrongPhase
 	}
 
@@ -34,7 +34,7 @@ func PlaceSettlement(state *pb.GameState, playerID, vertexID string) error {
 
 	// Check resources
 	if !CanAfford(ResourceCountToMap(player.Resources), "settlement") {
-		return ErrInsufficientResources
+		return insufficientResources(buildingCost("settlement"), player.Resources)
 	}
 
 	// Find the vertex
@@ -94,8 +94,8 @@ func PlaceCity(state *pb.GameState, playerID, vertexID string) error {
 		return ErrWrongPhase
 	}
 
-	// Verify it's build phase
-	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD {
+	// Verify it's build phase and the robber is settled
+	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD || state.RobberPhase != nil {
 		return ErrWrongPhase
 	}
 
@@ -111,7 +111,7 @@ func PlaceCity(state *pb.GameState, playerID, vertexID string) error {
 
 	// Check resources
 	if !CanAfford(ResourceCountToMap(player.Resources), "city") {
-		return ErrInsufficientResources
+		return insufficientResources(buildingCost("city"), player.Resources)
 	}
 
 	// Find the vertex
@@ -136,6 +136,9 @@ func PlaceCity(state *pb.GameState, playerID, vertexID string) error {
 	if targetVertex.Building.Type != pb.BuildingType_BUILDING_TYPE_SETTLEMENT {
 		return ErrCannotUpgrade // Already a city
 	}
+	if _, cities := countPlayerBuildings(state, playerID); cities >= GetMaxCities() {
+		return ErrMaxCitiesReached
+	}
 
 	// Deduct resources
 	DeductResources(player.Resources, "city")
@@ -161,8 +164,8 @@ func PlaceRoad(state *pb.GameState, playerID, edgeID string) error {
 		return ErrWrongPhase
 	}
 
-	// Verify it's build phase
-	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD {
+	// Verify it's build phase and the robber is settled
+	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_BUILD || state.RobberPhase != nil {
 		return ErrWrongPhase
 	}
 
@@ -185,7 +188,7 @@ func PlaceRoad(state *pb.GameState, playerID, edgeID string) error {
 	// Check resources (unless road building card is active)
 	if player.RoadBuildingRoadsRemaining == 0 {
 		if !CanAfford(ResourceCountToMap(player.Resources), "road") {
-			return ErrInsufficientResources
+			return insufficientResources(buildingCost("road"), player.Resources)
 		}
 	}
 
@@ -369,6 +369,29 @@ func TestBuildCity_OnlyOnOwnSettlement(t *testing.T) {
 	}
 }
 
+func TestBuildCity_MaxCities(t *testing.T) {
+	state := createPlayingGameState(2)
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	state.Players[0].Resources = &pb.ResourceCount{Wheat: 2, Ore: 3}
+
+	var spots []*pb.Vertex
+	for _, v := range state.Board.Vertices {
+		if len(spots) < GetMaxCities()+1 && !violatesDistanceRule(state.Board, v.Id) {
+			v.Building = &pb.Building{Type: pb.BuildingType_BUILDING_TYPE_CITY, OwnerId: "p1"}
+			spots = append(spots, v)
+		}
+	}
+	last := spots[len(spots)-1]
+	last.Building.Type = pb.BuildingType_BUILDING_TYPE_SETTLEMENT
+
+	if err := PlaceCity(state, "p1", last.Id); err != ErrMaxCitiesReached {
+		t.Fatalf("expected ErrMaxCitiesReached, got %v", err)
+	}
+	if last.Building.Type != pb.BuildingType_BUILDING_TYPE_SETTLEMENT {
+		t.Error("settlement should not have been upgraded")
+	}
+}
+
 func TestBuildCity_CannotOnEmptyVertex(t *testing.T) {
 	state := NewGameState("g1", "CODE", []string{"Alice", "Bob"}, []string{"p1", "p2"})
 	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
@@ -544,6 +567,37 @@ func TestBuild_NotYourTurn(t *testing.T) {
 	}
 }
 
+// Rolling a 7 moves straight to the build phase, but nothing may be built
+// until the robber is settled or players could spend the cards they owe.
+func TestBuild_WaitsForRobber(t *testing.T) {
+	state := createPlayingGameState(2)
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	state.Players[0].Resources = &pb.ResourceCount{Wood: 9, Brick: 9, Sheep: 9, Wheat: 9, Ore: 9}
+	state.RobberPhase = &pb.RobberPhase{
+		DiscardPending:      []string{"p1"},
+		DiscardRequired:     map[string]int32{"p1": 22},
+		MovePendingPlayerId: ptr("p1"),
+	}
+
+	settlement := state.Board.Vertices[0].Id
+	state.Board.Vertices[0].Building = &pb.Building{Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT, OwnerId: "p1"}
+	var road string
+	for _, e := range state.Board.Edges {
+		if e.Road == nil && (e.Vertices[0] == settlement || e.Vertices[1] == settlement) {
+			road = e.Id
+		}
+	}
+	if err := PlaceRoad(state, "p1", road); err != ErrWrongPhase {
+		t.Errorf("PlaceRoad: got %v, want ErrWrongPhase", err)
+	}
+	if err := PlaceCity(state, "p1", settlement); err != ErrWrongPhase {
+		t.Errorf("PlaceCity: got %v, want ErrWrongPhase", err)
+	}
+	if _, err := BuyDevCard(state, "p1"); err != ErrWrongPhase {
+		t.Errorf("BuyDevCard: got %v, want ErrWrongPhase", err)
+	}
+}
+
 func TestMaxSettlementsPerPlayer(t *testing.T) {
 	// This test verifies the max settlements constant and count function
 	state := NewGameState("g1", "CODE", []string{"Alice"}, []string{"p1"})
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+const (
+	// DefaultTurnHours is a correspondence game's turn length when the host
+	// does not pick one.
+	DefaultTurnHours = 48
+	// MaxTurnHours is the longest turn a correspondence game can have.
+	MaxTurnHours = 14 * 24
+)
+
+var ErrInvalidTurnHours = fmt.Errorf("turn hours must be between 1 and %d", MaxTurnHours)
+
+// NewCorrespondence returns the settings for a correspondence game with
+// turns of turnHours, or DefaultTurnHours when it is 0.
+func NewCorrespondence(turnHours int32) (*pb.Correspondence, error) {
+	if turnHours == 0 {
+		turnHours = DefaultTurnHours
+	}
+	if turnHours < 1 || turnHours > MaxTurnHours {
+		return nil, ErrInvalidTurnHours
+	}
+	return &pb.Correspondence{TurnHours: turnHours}, nil
+}
+
+// PendingActions lists what the game is waiting for playerID to do: their
+// setup placement, roll or turn, a discard or robber move, and any trade
+// offered to them.
+func PendingActions(state *pb.GameState, playerID string) []*pb.PendingAction {
+	var actions []*pb.PendingAction
+	current := ""
+	if state.CurrentTurn >= 0 && int(state.CurrentTurn) < len(state.Players) {
+		current = state.Players[state.CurrentTurn].Id
+	}
+	add := func(kind pb.PendingActionKind) {
+		actions = append(actions, &pb.PendingAction{Kind: kind})
+	}
+
+	switch state.GetStatus() {
+	case pb.GameStatus_GAME_STATUS_SETUP:
+		if playerID == current {
+			if state.GetSetupPhase().GetPlacementsInTurn() == 0 {
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_PLACE_SETTLEMENT)
+			} else {
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_PLACE_ROAD)
+			}
+		}
+	case pb.GameStatus_GAME_STATUS_PLAYING:
+		if rp := state.RobberPhase; rp != nil {
+			switch {
+			case slices.Contains(rp.DiscardPending, playerID):
+				actions = append(actions, &pb.PendingAction{
+					Kind:         pb.PendingActionKind_PENDING_ACTION_KIND_DISCARD,
+					DiscardCount: rp.DiscardRequired[playerID],
+				})
+			case len(rp.DiscardPending) > 0:
+			case rp.GetMovePendingPlayerId() == playerID:
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_MOVE_ROBBER)
+			case rp.GetStealPendingPlayerId() == playerID:
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_STEAL)
+			}
+		} else if playerID == current {
+			if state.TurnPhase == pb.TurnPhase_TURN_PHASE_ROLL {
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_ROLL)
+			} else {
+				add(pb.PendingActionKind_PENDING_ACTION_KIND_PLAY_TURN)
+			}
+		}
+		for _, t := range state.PendingTrades {
+			if t.Status != pb.TradeStatus_TRADE_STATUS_PENDING || t.ProposerId == playerID {
+				continue
+			}
+			if t.TargetId == nil || *t.TargetId == playerID {
+				actions = append(actions, &pb.PendingAction{
+					Kind:    pb.PendingActionKind_PENDING_ACTION_KIND_RESPOND_TRADE,
+					TradeId: t.Id,
+				})
+			}
+		}
+	}
+	return actions
+}
+
+// WaitingOn returns the players the game is waiting on, in seat order.
+func WaitingOn(state *pb.GameState) []string {
+	var ids []string
+	for _, p := range state.GetPlayers() {
+		if len(PendingActions(state, p.Id)) > 0 {
+			ids = append(ids, p.Id)
+		}
+	}
+	return ids
+}
+
+// NewlyPending returns the actions playerID owes in after that they did not
+// owe in before, such as the start of their turn or a trade offered to them.
+func NewlyPending(before, after *pb.GameState, playerID string) []*pb.PendingAction {
+	had := map[string]bool{}
+	for _, a := range PendingActions(before, playerID) {
+		had[pendingKey(a)] = true
+	}
+	var fresh []*pb.PendingAction
+	for _, a := range PendingActions(after, playerID) {
+		if !had[pendingKey(a)] {
+			fresh = append(fresh, a)
+		}
+	}
+	return fresh
+}
+
+func pendingKey(a *pb.PendingAction) string {
+	return a.Kind.String() + "/" + a.TradeId
+}
+
+// UpdateTurnDeadline restarts the turn clock of a correspondence game when
+// after is on a different turn from before, counting from now. It does
+// nothing for other games.
+func UpdateTurnDeadline(before, after *pb.GameState, now time.Time) {
+	c := after.GetCorrespondence()
+	if c == nil {
+		return
+	}
+	switch after.GetStatus() {
+	case pb.GameStatus_GAME_STATUS_SETUP, pb.GameStatus_GAME_STATUS_PLAYING:
+	default:
+		c.TurnDeadline = 0
+		c.OverdueNotified = false
+		return
+	}
+	if c.TurnDeadline != 0 && turnKey(before) == turnKey(after) {
+		return
+	}
+	c.TurnDeadline = now.Add(time.Duration(c.TurnHours) * time.Hour).UnixMilli()
+	c.OverdueNotified = false
+}
+
+// TurnOverdue reports whether a correspondence game's current turn ran past
+// its deadline by now.
+func TurnOverdue(state *pb.GameState, now time.Time) bool {
+	c := state.GetCorrespondence()
+	return c != nil && c.TurnDeadline != 0 && now.UnixMilli() >= c.TurnDeadline
+}
+
+// turnKey identifies whose turn it is. Setup's second round starts with
+// the same player who ended the first, so the round is part of the key.
+func turnKey(state *pb.GameState) string {
+	return fmt.Sprintf("%v/%d/%d/%d", state.GetStatus(), state.GetCurrentTurn(), state.GetTurnCounter(), state.GetSetupPhase().GetRound())
+}
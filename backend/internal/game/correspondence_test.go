@@ -0,0 +1,187 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func kinds(actions []*pb.PendingAction) []pb.PendingActionKind {
+	var out []pb.PendingActionKind
+	for _, a := range actions {
+		out = append(out, a.Kind)
+	}
+	return out
+}
+
+func TestPendingActions(t *testing.T) {
+	state := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+	if got := WaitingOn(state); len(got) != 0 {
+		t.Errorf("expected a lobby to wait on nobody, got %v", got)
+	}
+
+	state.Status = pb.GameStatus_GAME_STATUS_SETUP
+	state.SetupPhase = &pb.SetupPhase{Round: 1, PlacementsInTurn: 1}
+	if got := kinds(PendingActions(state, "p1")); len(got) != 1 || got[0] != pb.PendingActionKind_PENDING_ACTION_KIND_PLACE_ROAD {
+		t.Errorf("expected p1 to owe a setup road, got %v", got)
+	}
+
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	state.SetupPhase = nil
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
+	if got := kinds(PendingActions(state, "p1")); len(got) != 1 || got[0] != pb.PendingActionKind_PENDING_ACTION_KIND_ROLL {
+		t.Errorf("expected p1 to owe a roll, got %v", got)
+	}
+	if got := PendingActions(state, "p2"); len(got) != 0 {
+		t.Errorf("expected p2 to owe nothing, got %v", got)
+	}
+
+	roller := "p1"
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	state.RobberPhase = &pb.RobberPhase{
+		DiscardPending:      []string{"p3"},
+		DiscardRequired:     map[string]int32{"p3": 4},
+		MovePendingPlayerId: &roller,
+	}
+	if got := PendingActions(state, "p3"); len(got) != 1 || got[0].Kind != pb.PendingActionKind_PENDING_ACTION_KIND_DISCARD || got[0].DiscardCount != 4 {
+		t.Errorf("expected p3 to owe a discard of 4, got %v", got)
+	}
+	if got := PendingActions(state, "p1"); len(got) != 0 {
+		t.Errorf("expected the robber to wait for discards, got %v", got)
+	}
+	state.RobberPhase.DiscardPending = nil
+	if got := kinds(PendingActions(state, "p1")); len(got) != 1 || got[0] != pb.PendingActionKind_PENDING_ACTION_KIND_MOVE_ROBBER {
+		t.Errorf("expected p1 to owe a robber move, got %v", got)
+	}
+
+	state.RobberPhase = nil
+	target := "p2"
+	state.PendingTrades = []*pb.TradeOffer{
+		{Id: "open", ProposerId: "p1", Status: pb.TradeStatus_TRADE_STATUS_PENDING},
+		{Id: "direct", ProposerId: "p1", TargetId: &target, Status: pb.TradeStatus_TRADE_STATUS_PENDING},
+		{Id: "done", ProposerId: "p1", Status: pb.TradeStatus_TRADE_STATUS_REJECTED},
+	}
+	if got := PendingActions(state, "p2"); len(got) != 2 || got[0].TradeId != "open" || got[1].TradeId != "direct" {
+		t.Errorf("expected p2 to owe responses to both live offers, got %v", got)
+	}
+	if got := PendingActions(state, "p3"); len(got) != 1 || got[0].TradeId != "open" {
+		t.Errorf("expected p3 to owe a response to the open offer only, got %v", got)
+	}
+	if got := kinds(PendingActions(state, "p1")); len(got) != 1 || got[0] != pb.PendingActionKind_PENDING_ACTION_KIND_PLAY_TURN {
+		t.Errorf("expected p1 to be playing their turn, got %v", got)
+	}
+	if got := WaitingOn(state); len(got) != 3 {
+		t.Errorf("expected the game to wait on everyone, got %v", got)
+	}
+}
+
+func TestNewlyPending_TurnChange(t *testing.T) {
+	before := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	before.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	before.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	after := proto.Clone(before).(*pb.GameState)
+	if err := EndTurn(after, "p1"); err != nil {
+		t.Fatalf("EndTurn: %v", err)
+	}
+	if got := kinds(NewlyPending(before, after, "p2")); len(got) != 1 || got[0] != pb.PendingActionKind_PENDING_ACTION_KIND_ROLL {
+		t.Errorf("expected p2 to be newly owed a roll, got %v", got)
+	}
+	if got := NewlyPending(before, after, "p1"); len(got) != 0 {
+		t.Errorf("expected nothing new for p1, got %v", got)
+	}
+}
+
+func TestUpdateTurnDeadline(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c, err := NewCorrespondence(0)
+	if err != nil || c.TurnHours != DefaultTurnHours {
+		t.Fatalf("expected the default turn length, got %+v, %v", c, err)
+	}
+	for _, hours := range []int32{-1, MaxTurnHours + 1} {
+		if _, err := NewCorrespondence(hours); err != ErrInvalidTurnHours {
+			t.Errorf("%d hours: expected ErrInvalidTurnHours, got %v", hours, err)
+		}
+	}
+
+	before := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	before.Correspondence = c
+	after := proto.Clone(before).(*pb.GameState)
+	UpdateTurnDeadline(before, after, now)
+	if after.Correspondence.TurnDeadline != 0 {
+		t.Errorf("expected no deadline in the lobby, got %d", after.Correspondence.TurnDeadline)
+	}
+
+	after.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	after.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
+	UpdateTurnDeadline(before, after, now)
+	deadline := now.Add(DefaultTurnHours * time.Hour)
+	if after.Correspondence.TurnDeadline != deadline.UnixMilli() {
+		t.Fatalf("expected the clock to start, got %d", after.Correspondence.TurnDeadline)
+	}
+
+	// Acting within the turn keeps the clock; a new turn restarts it.
+	rolled := proto.Clone(after).(*pb.GameState)
+	rolled.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE
+	UpdateTurnDeadline(after, rolled, now.Add(time.Hour))
+	if rolled.Correspondence.TurnDeadline != deadline.UnixMilli() {
+		t.Errorf("expected the deadline to hold within a turn, got %d", rolled.Correspondence.TurnDeadline)
+	}
+	if TurnOverdue(rolled, deadline.Add(-time.Second)) || !TurnOverdue(rolled, deadline) {
+		t.Errorf("expected the turn to fall overdue exactly at the deadline")
+	}
+	rolled.Correspondence.OverdueNotified = true
+	next := proto.Clone(rolled).(*pb.GameState)
+	if err := ForfeitTurn(next); err != nil {
+		t.Fatalf("ForfeitTurn: %v", err)
+	}
+	UpdateTurnDeadline(rolled, next, deadline)
+	if next.Correspondence.TurnDeadline != deadline.Add(DefaultTurnHours*time.Hour).UnixMilli() || next.Correspondence.OverdueNotified {
+		t.Errorf("expected a fresh clock for the next turn, got %+v", next.Correspondence)
+	}
+}
+
+func TestForfeitTurn(t *testing.T) {
+	state := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	if err := ForfeitTurn(state); err != ErrWrongPhase {
+		t.Errorf("expected a lobby turn not to be forfeitable, got %v", err)
+	}
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
+	state.PendingTrades = []*pb.TradeOffer{{Id: "t1", ProposerId: "p1", Status: pb.TradeStatus_TRADE_STATUS_PENDING}}
+	if err := ForfeitTurn(state); err != nil {
+		t.Fatalf("ForfeitTurn: %v", err)
+	}
+	if state.CurrentTurn != 1 || state.TurnCounter != 1 || len(state.PendingTrades) != 0 {
+		t.Errorf("expected an unrolled turn to pass to p2, got turn %d counter %d", state.CurrentTurn, state.TurnCounter)
+	}
+
+	// An open robber step is settled: discards are taken at random and the
+	// robber neither moves nor steals.
+	roller := "p2"
+	robberHex := state.Board.RobberHex
+	state.Players[0].Resources = &pb.ResourceCount{Wood: 4, Ore: 5}
+	state.Players[1].Resources = &pb.ResourceCount{Brick: 2}
+	state.RobberPhase = &pb.RobberPhase{
+		DiscardPending:      []string{"p1"},
+		DiscardRequired:     map[string]int32{"p1": 4},
+		MovePendingPlayerId: &roller,
+	}
+	if err := ForfeitTurn(state); err != nil {
+		t.Fatalf("ForfeitTurn: %v", err)
+	}
+	if state.RobberPhase != nil || state.CurrentTurn != 0 {
+		t.Errorf("expected the robber settled and the turn passed to p1, got %v turn %d", state.RobberPhase, state.CurrentTurn)
+	}
+	if got := countTotalResources(state.Players[0].Resources); got != 5 {
+		t.Errorf("expected p1 to lose the 4 cards they owed, got %d left", got)
+	}
+	if got := countTotalResources(state.Players[1].Resources); got != 2 {
+		t.Errorf("expected p2 to steal nothing, got %d cards", got)
+	}
+	if state.Board.RobberHex != robberHex {
+		t.Errorf("expected the robber to stay put, got %v", state.Board.RobberHex)
+	}
+}
@@ -1,36 +1,45 @@
 package game
 
 import (
-	"errors"
 	"math/rand"
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
 
+var (
+	ErrDevDeckEmpty       = newRuleError(pb.ErrorCode_ERROR_CODE_DEV_DECK_EMPTY, "dev card deck is empty")
+	ErrDevCardNotHeld     = newRuleError(pb.ErrorCode_ERROR_CODE_DEV_CARD_NOT_HELD, "you don't have that card")
+	ErrDevCardTooNew      = newRuleError(pb.ErrorCode_ERROR_CODE_DEV_CARD_TOO_NEW, "cannot play development card purchased this turn")
+	ErrYearOfPlentyChoice = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_DEV_CARD_CHOICE, "year of plenty requires exactly 2 resources")
+	ErrMonopolyChoice     = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_DEV_CARD_CHOICE, "monopoly requires a target resource")
+	ErrUnknownDevCard     = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_DEV_CARD_CHOICE, "unknown card type")
+)
+
 // InitDevCardDeck creates a shuffled deck of 25 development cards
 func InitDevCardDeck() []pb.DevCardType {
+	return initDevCardDeck(rand.New(rand.NewSource(rand.Int63())))
+}
+
+// devCardCounts is how many of each development card the game has.
+var devCardCounts = []struct {
+	card  pb.DevCardType
+	count int
+}{
+	{pb.DevCardType_DEV_CARD_TYPE_KNIGHT, 14},
+	{pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT, 5},
+	{pb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING, 2},
+	{pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY, 2},
+	{pb.DevCardType_DEV_CARD_TYPE_MONOPOLY, 2},
+}
+
+func initDevCardDeck(rng *rand.Rand) []pb.DevCardType {
 	deck := make([]pb.DevCardType, 0, 25)
-	// 14 Knights
-	for i := 0; i < 14; i++ {
-		deck = append(deck, pb.DevCardType_DEV_CARD_TYPE_KNIGHT)
-	}
-	// 5 Victory Points
-	for i := 0; i < 5; i++ {
-		deck = append(deck, pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT)
-	}
-	// 2 Road Building
-	for i := 0; i < 2; i++ {
-		deck = append(deck, pb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING)
-	}
-	// 2 Year of Plenty
-	for i := 0; i < 2; i++ {
-		deck = append(deck, pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY)
-	}
-	// 2 Monopoly
-	for i := 0; i < 2; i++ {
-		deck = append(deck, pb.DevCardType_DEV_CARD_TYPE_MONOPOLY)
+	for _, c := range devCardCounts {
+		for i := 0; i < c.count; i++ {
+			deck = append(deck, c.card)
+		}
 	}
 	// Shuffle
-	rand.Shuffle(len(deck), func(i, j int) {
+	rng.Shuffle(len(deck), func(i, j int) {
 		deck[i], deck[j] = deck[j], deck[i]
 	})
 	return deck
@@ -47,17 +56,23 @@ func BuyDevCard(state *pb.GameState, playerID string) (pb.DevCardType, error) {
 		}
 	}
 	if p == nil {
-		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, errors.New("player not found")
+		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, ErrPlayerNotFound
+	}
+	if !isCurrentPlayer(state, playerID) {
+		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, ErrNotYourTurn
+	}
+	if state.RobberPhase != nil {
+		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, ErrWrongPhase
 	}
 
 	// Check deck not empty
 	if len(state.DevCardDeck) == 0 {
-		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, errors.New("dev card deck is empty")
+		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, ErrDevDeckEmpty
 	}
 
 	// Deduct resources (ore, wheat, sheep)
 	if !CanAfford(ResourceCountToMap(p.Resources), "development_card") {
-		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, errors.New("insufficient resources")
+		return pb.DevCardType_DEV_CARD_TYPE_UNSPECIFIED, insufficientResources(buildingCost("development_card"), p.Resources)
 	}
 	DeductResources(p.Resources, "development_card")
 
@@ -89,6 +104,12 @@ func BuyDevCard(state *pb.GameState, playerID string) (pb.DevCardType, error) {
 	return cardType, nil
 }
 
+// isCurrentPlayer reports whether it is playerID's turn.
+func isCurrentPlayer(state *pb.GameState, playerID string) bool {
+	i := int(state.CurrentTurn)
+	return i >= 0 && i < len(state.Players) && state.Players[i].Id == playerID
+}
+
 // PlayDevCard plays a card from hand, applying its effect
 func PlayDevCard(state *pb.GameState, playerID string, cardType pb.DevCardType, targetResource *pb.Resource, resources []pb.Resource) error {
 	var p *pb.PlayerState
@@ -99,18 +120,24 @@ func PlayDevCard(state *pb.GameState, playerID string, cardType pb.DevCardType,
 		}
 	}
 	if p == nil {
-		return errors.New("player not found")
+		return ErrPlayerNotFound
+	}
+	if !isCurrentPlayer(state, playerID) {
+		return ErrNotYourTurn
+	}
+	if state.RobberPhase != nil {
+		return ErrWrongPhase
 	}
 
 	// Check player has the card
 	if p.DevCards == nil || p.DevCards[int32(cardType)] == 0 {
-		return errors.New("you don't have that card")
+		return ErrDevCardNotHeld
 	}
 
 	// Check timing rules - cards purchased this turn cannot be played (except Victory Point cards)
 	if cardType != pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT {
 		if p.DevCardsPurchasedTurn != nil && p.DevCardsPurchasedTurn[int32(cardType)] == state.TurnCounter {
-			return errors.New("cannot play development card purchased this turn")
+			return ErrDevCardTooNew
 		}
 	}
 
@@ -132,7 +159,11 @@ func PlayDevCard(state *pb.GameState, playerID string, cardType pb.DevCardType,
 	case pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY:
 		// Year of Plenty: take 2 resources from bank
 		if len(resources) != 2 {
-			return errors.New("year of plenty requires exactly 2 resources")
+			return ErrYearOfPlentyChoice
+		}
+		if resources[0] == resources[1] && bankStock(state, resources[0]) < 2 ||
+			bankStock(state, resources[0]) < 1 || bankStock(state, resources[1]) < 1 {
+			return bankEmpty(state, resources...)
 		}
 		for _, res := range resources {
 			AddResource(p.Resources, res, 1)
@@ -141,7 +172,7 @@ func PlayDevCard(state *pb.GameState, playerID string, cardType pb.DevCardType,
 	case pb.DevCardType_DEV_CARD_TYPE_MONOPOLY:
 		// Monopoly: collect all of one resource from all players
 		if targetResource == nil {
-			return errors.New("monopoly requires a target resource")
+			return ErrMonopolyChoice
 		}
 		totalCollected := int32(0)
 		for _, pl := range state.Players {
@@ -157,7 +188,7 @@ func PlayDevCard(state *pb.GameState, playerID string, cardType pb.DevCardType,
 		AddResource(p.Resources, *targetResource, totalCollected)
 
 	default:
-		return errors.New("unknown card type")
+		return ErrUnknownDevCard
 	}
 
 	// Remove card from hand
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	pbb "settlers_from_catan/gen/proto/catan/v1"
 	"testing"
 )
@@ -38,8 +39,8 @@ func TestBuyDevCardInsufficientResources(t *testing.T) {
 		Players:     []*pbb.PlayerState{{Id: "P2", Resources: &pbb.ResourceCount{Ore: 0, Wheat: 1, Sheep: 1}}},
 	}
 	_, err := BuyDevCard(state, "P2")
-	if err == nil {
-		t.Fatalf("expected error for insufficient resources, got nil")
+	if !errors.Is(err, ErrInsufficientResources) {
+		t.Fatalf("expected ErrInsufficientResources, got %v", err)
 	}
 }
 
@@ -80,6 +81,26 @@ func TestPlayDevCardNoCardError(t *testing.T) {
 	}
 }
 
+func TestPlayDevCardNotYourTurn(t *testing.T) {
+	state := &pbb.GameState{
+		CurrentTurn: 0,
+		Players: []*pbb.PlayerState{
+			{Id: "P1"},
+			{
+				Id:           "P2",
+				DevCardCount: 1,
+				DevCards:     map[int32]int32{int32(pbb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING): 1},
+			},
+		},
+	}
+	if err := PlayDevCard(state, "P2", pbb.DevCardType_DEV_CARD_TYPE_ROAD_BUILDING, nil, nil); err != ErrNotYourTurn {
+		t.Fatalf("expected ErrNotYourTurn, got %v", err)
+	}
+	if state.Players[1].RoadBuildingRoadsRemaining != 0 {
+		t.Errorf("free roads granted out of turn")
+	}
+}
+
 func TestPlayKnightIncrementsKnightCount(t *testing.T) {
 	state := &pbb.GameState{
 		Players: []*pbb.PlayerState{{
@@ -150,6 +171,29 @@ func TestPlayYearOfPlentyGrants2Resources(t *testing.T) {
 	}
 }
 
+func TestPlayYearOfPlentyBankEmpty(t *testing.T) {
+	state := &pbb.GameState{
+		Players: []*pbb.PlayerState{{
+			Id:           "P6",
+			DevCardCount: 1,
+			DevCards:     map[int32]int32{int32(pbb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY): 1},
+			Resources:    &pbb.ResourceCount{},
+		}, {
+			Id:        "P7",
+			Resources: &pbb.ResourceCount{Ore: 18},
+		}},
+	}
+	resources := []pbb.Resource{pbb.Resource_RESOURCE_ORE, pbb.Resource_RESOURCE_ORE}
+	err := PlayDevCard(state, "P6", pbb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY, nil, resources)
+	if !errors.Is(err, ErrBankEmpty) {
+		t.Fatalf("expected ErrBankEmpty, got %v", err)
+	}
+	p := state.Players[0]
+	if p.Resources.Ore != 0 || p.DevCards[int32(pbb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY)] != 1 {
+		t.Errorf("expected no ore and the card kept, got Ore=%d cards=%v", p.Resources.Ore, p.DevCards)
+	}
+}
+
 func TestPlayMonopolyCollectsResources(t *testing.T) {
 	targetRes := pbb.Resource_RESOURCE_WHEAT
 	state := &pbb.GameState{
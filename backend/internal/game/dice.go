@@ -122,8 +122,11 @@ func countTotalResources(r *pb.ResourceCount) int {
 	return int(r.Wood + r.Brick + r.Sheep + r.Wheat + r.Ore)
 }
 
-// distributeResources gives resources to players with buildings on matching hexes
+// distributeResources gives resources to players with buildings on matching
+// hexes. When the bank cannot pay everyone owed a resource, nobody receives
+// it, unless only one player is owed, who takes what is left.
 func distributeResources(state *pb.GameState, diceTotal int, result *DiceRollResult) {
+	owed := map[pb.TileResource]map[string]int{}
 	// Find all hexes with the rolled number
 	for _, hex := range state.Board.Hexes {
 		if hex.Number != int32(diceTotal) {
@@ -165,21 +168,40 @@ func distributeResources(state *pb.GameState, diceTotal int, result *DiceRollRes
 			if vertex.Building.Type == pb.BuildingType_BUILDING_TYPE_CITY {
 				resourceCount = 2
 			}
+			if owed[hex.Resource] == nil {
+				owed[hex.Resource] = map[string]int{}
+			}
+			owed[hex.Resource][vertex.Building.OwnerId] += resourceCount
+		}
+	}
 
-			// Find the player and add resources
-			ownerID := vertex.Building.OwnerId
-			for _, player := range state.Players {
-				if player.Id == ownerID {
-					addResourceToPlayer(player.Resources, hex.Resource, resourceCount)
-
-					// Track in result
-					if result.ResourcesGained[ownerID] == nil {
-						result.ResourcesGained[ownerID] = &pb.ResourceCount{}
-					}
-					addResourceToCount(result.ResourcesGained[ownerID], hex.Resource, resourceCount)
-					break
-				}
+	for _, res := range allResources {
+		tileResource := pb.TileResource(res)
+		claims := owed[tileResource]
+		total := 0
+		for _, n := range claims {
+			total += n
+		}
+		if stock := int(bankStock(state, res)); total > stock {
+			if len(claims) > 1 {
+				continue
+			}
+			for id := range claims {
+				claims[id] = stock
+			}
+		}
+		for _, player := range state.Players {
+			n := claims[player.Id]
+			if n <= 0 {
+				continue
+			}
+			addResourceToPlayer(player.Resources, tileResource, n)
+
+			// Track in result
+			if result.ResourcesGained[player.Id] == nil {
+				result.ResourcesGained[player.Id] = &pb.ResourceCount{}
 			}
+			addResourceToCount(result.ResourcesGained[player.Id], tileResource, n)
 		}
 	}
 }
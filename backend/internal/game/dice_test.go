@@ -528,6 +528,69 @@ func TestResourceDistribution_MultiplePlayersReceive(t *testing.T) {
 	}
 }
 
+func TestResourceDistribution_BankShortage(t *testing.T) {
+	tests := []struct {
+		name       string
+		owners     []string
+		bankHas    int32
+		wantGained map[string]int32
+	}{
+		{"enough for everyone", []string{"p1", "p2"}, 2, map[string]int32{"p1": 1, "p2": 1}},
+		{"short with two players owed", []string{"p1", "p2"}, 1, map[string]int32{"p1": 0, "p2": 0}},
+		{"short with one player owed", []string{"p1", "p1"}, 1, map[string]int32{"p1": 1, "p2": 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := createPlayingGameState(3)
+			// Only the first hex numbered 9 produces.
+			var hex *pb.Hex
+			for _, h := range state.Board.Hexes {
+				if h.Number == 9 && hex == nil {
+					hex = h
+				} else if h.Number == 9 {
+					h.Number = 0
+				}
+			}
+			if hex == nil {
+				t.Fatal("no hex numbered 9")
+			}
+			var corners []*pb.Vertex
+			for _, v := range state.Board.Vertices {
+				if len(corners) == 2 {
+					break
+				}
+				for _, c := range v.AdjacentHexes {
+					if c.Q == hex.Coord.Q && c.R == hex.Coord.R && !violatesDistanceRule(state.Board, v.Id) {
+						corners = append(corners, v)
+						v.Building = &pb.Building{Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT}
+						break
+					}
+				}
+			}
+			for i, v := range corners {
+				v.Building.OwnerId = tt.owners[i%len(tt.owners)]
+			}
+			if len(corners) != 2 {
+				t.Fatalf("expected 2 settlements on the hex, got %d", len(corners))
+			}
+			res := pb.Resource(hex.Resource)
+			for _, p := range state.Players {
+				p.Resources = &pb.ResourceCount{}
+			}
+			AddResource(state.Players[2].Resources, res, 19-tt.bankHas)
+
+			if _, err := PerformDiceRollWithValues(state, "p1", 4, 5); err != nil {
+				t.Fatalf("roll: %v", err)
+			}
+			for id, want := range tt.wantGained {
+				if got := GetResourceAmount(getPlayerByID(state, id).Resources, res); got != want {
+					t.Errorf("%s got %d %s, want %d", id, got, res, want)
+				}
+			}
+		})
+	}
+}
+
 // Helper function to count total resources
 func getResourceTotal(r *pb.ResourceCount) int {
 	if r == nil {
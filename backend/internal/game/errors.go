@@ -0,0 +1,92 @@
+package game
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// RuleError is returned when a command breaks a game rule. Code names the
+// rule so clients can localize the message and react without parsing text.
+// Required and Held are set when the player (or the bank) lacked the cards.
+type RuleError struct {
+	Code     pb.ErrorCode
+	Message  string
+	Required *pb.ResourceCount
+	Held     *pb.ResourceCount
+}
+
+func newRuleError(code pb.ErrorCode, message string) *RuleError {
+	return &RuleError{Code: code, Message: message}
+}
+
+func (e *RuleError) Error() string { return e.Message }
+
+// Is reports whether target is a RuleError with the same code, so
+// errors.Is(err, ErrInsufficientResources) holds for copies that carry
+// counts.
+func (e *RuleError) Is(target error) bool {
+	t, ok := target.(*RuleError)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCodeOf returns the rule err broke, or ERROR_CODE_UNSPECIFIED if err
+// is not a RuleError.
+func ErrorCodeOf(err error) pb.ErrorCode {
+	var re *RuleError
+	if errors.As(err, &re) {
+		return re.Code
+	}
+	return pb.ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+// insufficientResources is ErrInsufficientResources with the cards the
+// command needed and the cards the player had.
+func insufficientResources(required, held *pb.ResourceCount) error {
+	return withCounts(ErrInsufficientResources, required, held)
+}
+
+// bankEmpty is ErrBankEmpty with the cards asked for and the bank's stock.
+func bankEmpty(state *pb.GameState, requested ...pb.Resource) error {
+	required, held := &pb.ResourceCount{}, &pb.ResourceCount{}
+	for _, res := range requested {
+		addResource(required, res, 1)
+		setResource(held, res, bankStock(state, res))
+	}
+	return withCounts(ErrBankEmpty, required, held)
+}
+
+func withCounts(base *RuleError, required, held *pb.ResourceCount) error {
+	return &RuleError{
+		Code:     base.Code,
+		Message:  base.Message,
+		Required: proto.Clone(required).(*pb.ResourceCount),
+		Held:     proto.Clone(held).(*pb.ResourceCount),
+	}
+}
+
+// buildingCost returns the cost of building as a ResourceCount.
+func buildingCost(building string) *pb.ResourceCount {
+	c := &pb.ResourceCount{}
+	for res, n := range GetBuildingCosts(building) {
+		addResourceToCount(c, res, n)
+	}
+	return c
+}
+
+func setResource(c *pb.ResourceCount, res pb.Resource, n int32) {
+	switch res {
+	case pb.Resource_RESOURCE_WOOD:
+		c.Wood = n
+	case pb.Resource_RESOURCE_BRICK:
+		c.Brick = n
+	case pb.Resource_RESOURCE_SHEEP:
+		c.Sheep = n
+	case pb.Resource_RESOURCE_WHEAT:
+		c.Wheat = n
+	case pb.Resource_RESOURCE_ORE:
+		c.Ore = n
+	}
+}
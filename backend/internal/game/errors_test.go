@@ -0,0 +1,215 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestRuleFailures_HaveErrorCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(state *pb.GameState)
+		run   func(state *pb.GameState) error
+		want  pb.ErrorCode
+	}{
+		{
+			name: "roll out of turn",
+			run: func(s *pb.GameState) error {
+				_, err := PerformDiceRoll(s, "p2")
+				return err
+			},
+			want: pb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN,
+		},
+		{
+			name:  "roll in trade phase",
+			setup: func(s *pb.GameState) { s.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE },
+			run: func(s *pb.GameState) error {
+				_, err := PerformDiceRoll(s, "p1")
+				return err
+			},
+			want: pb.ErrorCode_ERROR_CODE_WRONG_PHASE,
+		},
+		{
+			name:  "start game as guest",
+			setup: func(s *pb.GameState) { s.Status = pb.GameStatus_GAME_STATUS_WAITING },
+			run:   func(s *pb.GameState) error { return StartGame(s, "p2") },
+			want:  pb.ErrorCode_ERROR_CODE_NOT_HOST,
+		},
+		{
+			name: "settle unknown vertex",
+			setup: func(s *pb.GameState) {
+				s.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+				s.Players[0].Resources = &pb.ResourceCount{Wood: 1, Brick: 1, Sheep: 1, Wheat: 1}
+			},
+			run:  func(s *pb.GameState) error { return PlaceSettlement(s, "p1", "nowhere") },
+			want: pb.ErrorCode_ERROR_CODE_INVALID_VERTEX,
+		},
+		{
+			name:  "city without cards",
+			setup: func(s *pb.GameState) { s.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD },
+			run:   func(s *pb.GameState) error { return PlaceCity(s, "p1", s.Board.Vertices[0].Id) },
+			want:  pb.ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES,
+		},
+		{
+			name: "bank trade for a sold-out resource",
+			setup: func(s *pb.GameState) {
+				s.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE
+				s.Players[0].Resources = &pb.ResourceCount{Wood: 4}
+				s.Players[1].Resources = &pb.ResourceCount{Ore: 19}
+			},
+			run: func(s *pb.GameState) error {
+				return BankTrade(s, "p1", &pb.ResourceCount{Wood: 4}, pb.Resource_RESOURCE_ORE)
+			},
+			want: pb.ErrorCode_ERROR_CODE_BANK_EMPTY,
+		},
+		{
+			name: "second trade offer",
+			setup: func(s *pb.GameState) {
+				s.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE
+				s.Players[0].Resources = &pb.ResourceCount{Wood: 2}
+				if _, err := ProposeTrade(s, "p1", nil, &pb.ResourceCount{Wood: 1}, &pb.ResourceCount{Ore: 1}); err != nil {
+					t.Fatalf("first offer: %v", err)
+				}
+			},
+			run: func(s *pb.GameState) error {
+				_, err := ProposeTrade(s, "p1", nil, &pb.ResourceCount{Wood: 1}, &pb.ResourceCount{Ore: 1})
+				return err
+			},
+			want: pb.ErrorCode_ERROR_CODE_TRADE_ALREADY_PENDING,
+		},
+		{
+			name: "respond to unknown trade",
+			run:  func(s *pb.GameState) error { return RespondTrade(s, "nope", "p2", true) },
+			want: pb.ErrorCode_ERROR_CODE_TRADE_NOT_FOUND,
+		},
+		{
+			name:  "buy from empty deck",
+			setup: func(s *pb.GameState) { s.DevCardDeck = nil },
+			run: func(s *pb.GameState) error {
+				_, err := BuyDevCard(s, "p1")
+				return err
+			},
+			want: pb.ErrorCode_ERROR_CODE_DEV_DECK_EMPTY,
+		},
+		{
+			name: "play a card not held",
+			run: func(s *pb.GameState) error {
+				return PlayDevCard(s, "p1", pb.DevCardType_DEV_CARD_TYPE_KNIGHT, nil, nil)
+			},
+			want: pb.ErrorCode_ERROR_CODE_DEV_CARD_NOT_HELD,
+		},
+		{
+			name: "year of plenty with one resource",
+			setup: func(s *pb.GameState) {
+				s.TurnCounter = 3
+				s.Players[0].DevCards = map[int32]int32{int32(pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY): 1}
+			},
+			run: func(s *pb.GameState) error {
+				return PlayDevCard(s, "p1", pb.DevCardType_DEV_CARD_TYPE_YEAR_OF_PLENTY, nil, []pb.Resource{pb.Resource_RESOURCE_ORE})
+			},
+			want: pb.ErrorCode_ERROR_CODE_INVALID_DEV_CARD_CHOICE,
+		},
+		{
+			name: "move robber outside robber phase",
+			run:  func(s *pb.GameState) error { return MoveRobber(s, "p1", s.Board.RobberHex) },
+			want: pb.ErrorCode_ERROR_CODE_NO_ROBBER_PHASE,
+		},
+		{
+			name: "move robber onto its own hex",
+			setup: func(s *pb.GameState) {
+				s.RobberPhase = &pb.RobberPhase{MovePendingPlayerId: ptr("p1")}
+			},
+			run:  func(s *pb.GameState) error { return MoveRobber(s, "p1", s.Board.RobberHex) },
+			want: pb.ErrorCode_ERROR_CODE_ROBBER_ALREADY_THERE,
+		},
+		{
+			name: "move robber for another player",
+			setup: func(s *pb.GameState) {
+				s.RobberPhase = &pb.RobberPhase{MovePendingPlayerId: ptr("p1")}
+			},
+			run:  func(s *pb.GameState) error { return MoveRobber(s, "p2", s.Board.RobberHex) },
+			want: pb.ErrorCode_ERROR_CODE_NOT_ROBBER,
+		},
+		{
+			name: "vote with no takeback",
+			run: func(s *pb.GameState) error {
+				_, err := VoteTakeback(s, "p2", true)
+				return err
+			},
+			want: pb.ErrorCode_ERROR_CODE_NO_TAKEBACK_PENDING,
+		},
+		{
+			name:  "chat while muted",
+			setup: func(s *pb.GameState) { s.ChatMutedPlayerIds = []string{"p2"} },
+			run:   func(s *pb.GameState) error { return CanChat(s, "p2", "") },
+			want:  pb.ErrorCode_ERROR_CODE_CHAT_MUTED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := createPlayingGameState(2)
+			if tt.setup != nil {
+				tt.setup(state)
+			}
+			err := tt.run(state)
+			if got := ErrorCodeOf(err); got != tt.want {
+				t.Fatalf("ErrorCodeOf(%v) = %v, want %v", err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsufficientResources_CarriesCounts(t *testing.T) {
+	state := createPlayingGameState(2)
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	state.Players[0].Resources = &pb.ResourceCount{Wheat: 1, Ore: 3}
+
+	err := PlaceCity(state, "p1", state.Board.Vertices[0].Id)
+	if !errors.Is(err, ErrInsufficientResources) {
+		t.Fatalf("got %v, want ErrInsufficientResources", err)
+	}
+	var re *RuleError
+	if !errors.As(err, &re) {
+		t.Fatalf("%T is not a RuleError", err)
+	}
+	if want := (&pb.ResourceCount{Wheat: 2, Ore: 3}); !proto.Equal(re.Required, want) {
+		t.Errorf("Required = %v, want %v", re.Required, want)
+	}
+	if !proto.Equal(re.Held, state.Players[0].Resources) {
+		t.Errorf("Held = %v, want %v", re.Held, state.Players[0].Resources)
+	}
+	// The counts are a snapshot; later changes to the hand must not leak in.
+	state.Players[0].Resources.Wheat = 5
+	if re.Held.Wheat != 1 {
+		t.Errorf("Held aliases the player's hand")
+	}
+}
+
+func TestBankEmpty_CarriesStock(t *testing.T) {
+	state := createPlayingGameState(2)
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE
+	state.Players[0].Resources = &pb.ResourceCount{Wood: 4}
+	state.Players[1].Resources = &pb.ResourceCount{Ore: 19}
+
+	err := BankTrade(state, "p1", &pb.ResourceCount{Wood: 4}, pb.Resource_RESOURCE_ORE)
+	var re *RuleError
+	if !errors.As(err, &re) || re.Code != pb.ErrorCode_ERROR_CODE_BANK_EMPTY {
+		t.Fatalf("got %v, want ErrBankEmpty", err)
+	}
+	if re.Required.GetOre() != 1 || re.Held.GetOre() != 0 {
+		t.Errorf("Required = %v, Held = %v; want 1 ore asked for, 0 in the bank", re.Required, re.Held)
+	}
+}
+
+func TestErrorCodeOf_PlainError(t *testing.T) {
+	if got := ErrorCodeOf(errors.New("boom")); got != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+		t.Errorf("ErrorCodeOf(plain error) = %v, want UNSPECIFIED", got)
+	}
+	if got := ErrorCodeOf(nil); got != pb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+		t.Errorf("ErrorCodeOf(nil) = %v, want UNSPECIFIED", got)
+	}
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"slices"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// An export carries the whole state, including every player's dev cards and
+// the deck order, so an unfinished game may only be exported or forked once
+// every player has agreed. Any other command moves the game on and drops the
+// approvals.
+
+// ApproveExport records playerID's agreement to export the unfinished game.
+func ApproveExport(state *pb.GameState, playerID string) error {
+	if state.Status == pb.GameStatus_GAME_STATUS_FINISHED {
+		return ErrWrongPhase
+	}
+	if getPlayerByID(state, playerID) == nil {
+		return ErrPlayerNotFound
+	}
+	if !slices.Contains(state.ExportApprovedPlayerIds, playerID) {
+		state.ExportApprovedPlayerIds = append(state.ExportApprovedPlayerIds, playerID)
+	}
+	return nil
+}
+
+// ExportAllowed reports whether state may be exported: the game is finished
+// or every player in it has approved.
+func ExportAllowed(state *pb.GameState) bool {
+	if state.Status == pb.GameStatus_GAME_STATUS_FINISHED {
+		return true
+	}
+	for _, p := range state.Players {
+		if !slices.Contains(state.ExportApprovedPlayerIds, p.Id) {
+			return false
+		}
+	}
+	return true
+}
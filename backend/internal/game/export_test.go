@@ -0,0 +1,41 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestApproveExport(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	if ExportAllowed(state) {
+		t.Fatalf("expected an unapproved game not to be exportable")
+	}
+	if err := ApproveExport(state, "nobody"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Fatalf("expected ErrPlayerNotFound, got %v", err)
+	}
+	for _, id := range []string{"p1", "p1"} {
+		if err := ApproveExport(state, id); err != nil {
+			t.Fatalf("approve by %s: %v", id, err)
+		}
+	}
+	if len(state.ExportApprovedPlayerIds) != 1 || ExportAllowed(state) {
+		t.Fatalf("expected one approval and no export yet, got %v", state.ExportApprovedPlayerIds)
+	}
+	if err := ApproveExport(state, "p2"); err != nil {
+		t.Fatalf("approve by p2: %v", err)
+	}
+	if !ExportAllowed(state) {
+		t.Fatalf("expected export once every player approved")
+	}
+
+	finished := NewGameState("g2", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	finished.Status = pb.GameStatus_GAME_STATUS_FINISHED
+	if !ExportAllowed(finished) {
+		t.Fatalf("expected a finished game to be exportable")
+	}
+	if err := ApproveExport(finished, "p1"); !errors.Is(err, ErrWrongPhase) {
+		t.Fatalf("expected ErrWrongPhase, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// FuzzGame plays a game whose commands are decoded from the fuzzer's bytes.
+// Commands come from every player, legal or not, and after each one the
+// harness checks that nothing panicked, that a rejected command left the
+// state untouched and that Validate still passes.
+//
+// The fuzzer minimises failing inputs and writes them under
+// testdata/fuzz/FuzzGame; the failure message lists the decoded steps, and
+// go test -run=FuzzGame/<file> replays one. make fuzz runs the fuzzer; the
+// checked-in corpus holds long, mostly legal games for 2-4 players, so
+// without -fuzz this is a regression test over those.
+func FuzzGame(f *testing.F) {
+	f.Add(int64(1), uint8(0), []byte{})
+	f.Add(int64(2), uint8(1), []byte("\x00\x00\x00\x01\x00\x02\x00\x01\x00\x02\x01\x00\x03\x00\x00"))
+	f.Fuzz(func(t *testing.T, seed int64, players uint8, script []byte) {
+		runScript(t, seed, players, script)
+	})
+}
+
+// maxScriptSteps bounds a run so huge inputs stay fast.
+const maxScriptSteps = 1000
+
+func runScript(t *testing.T, seed int64, players uint8, script []byte) {
+	t.Helper()
+	g := newFuzzGame(seed, 2+int(players)%3)
+	r := &scriptReader{data: script}
+	var steps []string
+	fail := func(format string, args ...any) {
+		t.Helper()
+		t.Fatalf("%s\nseed %d, %d players, steps:\n%s", fmt.Sprintf(format, args...), seed, len(g.ids), strings.Join(steps, "\n"))
+	}
+
+	// Each step's snapshot doubles as the next step's "before".
+	snapshot := g.snapshot()
+	for n := 0; !r.done() && n < maxScriptSteps; n++ {
+		op := fuzzOps[int(r.next())%len(fuzzOps)]
+		before := snapshot
+		desc, err, panicked := g.run(op, r)
+		line := fmt.Sprintf("%4d %s", n, desc)
+		if err != nil {
+			line += " -> " + err.Error()
+		}
+		steps = append(steps, line)
+
+		if panicked != nil {
+			fail("step %d panicked: %v", n, panicked)
+		}
+		snapshot = g.snapshot()
+		if err != nil && !bytes.Equal(before, snapshot) {
+			fail("step %d was rejected but changed the state", n)
+		}
+		if vs := Validate(g.state); len(vs) > 0 {
+			fail("step %d broke invariants: %v", n, vs)
+		}
+	}
+}
+
+// scriptReader hands out the fuzzer's bytes, then zeros once they run out.
+type scriptReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *scriptReader) done() bool { return r.pos >= len(r.data) }
+
+func (r *scriptReader) next() byte {
+	if r.done() {
+		return 0
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+// count decodes a signed card count, so offers can be negative.
+func (r *scriptReader) count() int32 { return int32(int8(r.next())) % 8 }
+
+type fuzzGame struct {
+	state *pb.GameState
+	ids   []string
+	rng   *rand.Rand
+}
+
+func newFuzzGame(seed int64, players int) *fuzzGame {
+	names := []string{"Alice", "Bob", "Carol", "Dave"}[:players]
+	ids := []string{"p1", "p2", "p3", "p4"}[:players]
+	return &fuzzGame{
+		state: NewGameStateFromSeed("fuzz", "FUZZ01", names, ids, seed),
+		ids:   ids,
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// snapshot encodes the state deterministically so rejected commands can be
+// checked byte for byte.
+func (g *fuzzGame) snapshot() []byte {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(g.state)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (g *fuzzGame) run(op fuzzOp, r *scriptReader) (desc string, err error, panicked any) {
+	defer func() {
+		if p := recover(); p != nil {
+			panicked = p
+		}
+	}()
+	actor := g.actor(r.next())
+	desc, err = op(g, actor, r)
+	return actor + " " + desc, err, nil
+}
+
+// actor is usually the player whose turn it is, sometimes anyone seated
+// and occasionally nobody at all.
+func (g *fuzzGame) actor(b byte) string {
+	switch {
+	case b >= 250:
+		return "ghost"
+	case b >= 160 || len(g.state.Players) == 0:
+		return g.ids[int(b)%len(g.ids)]
+	case g.state.CurrentTurn >= 0 && int(g.state.CurrentTurn) < len(g.state.Players):
+		return g.state.Players[g.state.CurrentTurn].Id
+	}
+	return g.ids[0]
+}
+
+func (g *fuzzGame) vertex(b byte) string {
+	vs := g.state.Board.Vertices
+	return vs[int(b)%len(vs)].Id
+}
+
+// edge picks an edge touching actor's pieces for even bytes, which is
+// where legal roads are, and any edge for odd ones.
+func (g *fuzzGame) edge(actor string, b byte) string {
+	edges := g.state.Board.Edges
+	if b%2 == 1 {
+		return edges[int(b/2)%len(edges)].Id
+	}
+	var near []string
+	for _, e := range edges {
+		for _, id := range e.Vertices {
+			if owned := GetBuildingAtVertex(g.state.Board, id); owned != nil && owned.OwnerId == actor {
+				near = append(near, e.Id)
+				break
+			}
+			if edgeTouchesRoadOf(g.state.Board, id, actor) {
+				near = append(near, e.Id)
+				break
+			}
+		}
+	}
+	if len(near) == 0 {
+		return edges[int(b/2)%len(edges)].Id
+	}
+	return near[int(b/2)%len(near)]
+}
+
+func edgeTouchesRoadOf(board *pb.BoardState, vertexID, playerID string) bool {
+	for _, e := range board.Edges {
+		if e.Road != nil && e.Road.OwnerId == playerID && (e.Vertices[0] == vertexID || e.Vertices[1] == vertexID) {
+			return true
+		}
+	}
+	return false
+}
+
+// discardFor takes the cards playerID owes from their hand, one at a time
+// round the resources starting from the one start picks.
+func (g *fuzzGame) discardFor(playerID string, start byte) *pb.ResourceCount {
+	cards := &pb.ResourceCount{}
+	p := getPlayerByID(g.state, playerID)
+	if p == nil || g.state.RobberPhase == nil {
+		return cards
+	}
+	owed := g.state.RobberPhase.DiscardRequired[playerID]
+	for i, taken := int(start), int32(0); taken < owed && int(taken) < countTotalResources(p.Resources); i++ {
+		res := allResources[i%len(allResources)]
+		if GetResourceAmount(cards, res) < GetResourceAmount(p.Resources, res) {
+			AddResource(cards, res, 1)
+			taken++
+		}
+	}
+	return cards
+}
+
+func (g *fuzzGame) hex(b byte) *pb.HexCoord {
+	hexes := g.state.Board.Hexes
+	c := hexes[int(b)%len(hexes)].Coord
+	return &pb.HexCoord{Q: c.Q, R: c.R}
+}
+
+func (g *fuzzGame) player(b byte) string {
+	if int(b)%(len(g.ids)+1) == len(g.ids) {
+		return "ghost"
+	}
+	return g.ids[int(b)%(len(g.ids)+1)]
+}
+
+func (r *scriptReader) resource() pb.Resource {
+	return pb.Resource(r.next() % 7) // includes UNSPECIFIED and one past ORE
+}
+
+func (r *scriptReader) resources() *pb.ResourceCount {
+	return &pb.ResourceCount{Wood: r.count(), Brick: r.count(), Sheep: r.count(), Wheat: r.count(), Ore: r.count()}
+}
+
+type fuzzOp func(g *fuzzGame, actor string, r *scriptReader) (string, error)
+
+// fuzzOps are the commands a script can issue, indexed by its op byte.
+var fuzzOps = []fuzzOp{
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		ready := r.next()%4 != 0
+		return fmt.Sprintf("ready %t", ready), SetPlayerReady(g.state, actor, ready)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		return "start", StartGame(g.state, actor)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		v := g.vertex(r.next())
+		return "setupSettlement " + v, PlaceSetupSettlement(g.state, actor, v)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		e := g.edge(actor, r.next())
+		return "setupRoad " + e, PlaceSetupRoad(g.state, actor, e)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		d1, d2 := int(r.next()%6)+1, int(r.next()%6)+1
+		_, err := PerformDiceRollWithValues(g.state, actor, d1, d2)
+		return fmt.Sprintf("roll %d %d", d1, d2), err
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		// Usually discard for whoever owes first, and usually the right
+		// number of cards from their hand; otherwise the counts are raw.
+		who := actor
+		if rp := g.state.RobberPhase; rp != nil && len(rp.DiscardPending) > 0 && r.next()%4 != 0 {
+			who = rp.DiscardPending[0]
+		}
+		var cards *pb.ResourceCount
+		if b := r.next(); b%4 != 0 {
+			cards = g.discardFor(who, b/4)
+		} else {
+			cards = r.resources()
+		}
+		return fmt.Sprintf("discard as %s %v", who, cards), DiscardCards(g.state, who, cards)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		h := g.hex(r.next())
+		return fmt.Sprintf("moveRobber %d,%d", h.Q, h.R), MoveRobber(g.state, actor, h)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		victim := g.player(r.next())
+		_, err := StealFromPlayer(g.state, actor, victim, g.rng.Intn)
+		return "steal from " + victim, err
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		phase := pb.TurnPhase(r.next() % 5)
+		return "phase " + phase.String(), SetTurnPhase(g.state, actor, phase)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		v := g.vertex(r.next())
+		return "settlement " + v, PlaceSettlement(g.state, actor, v)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		e := g.edge(actor, r.next())
+		return "road " + e, PlaceRoad(g.state, actor, e)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		v := g.vertex(r.next())
+		return "city " + v, PlaceCity(g.state, actor, v)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		give, count, get := r.resource(), r.count(), r.resource()
+		offer := &pb.ResourceCount{}
+		AddResource(offer, give, count)
+		return fmt.Sprintf("bankTrade %d %s for %s", count, give, get), BankTrade(g.state, actor, offer, get)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		_, err := BuyDevCard(g.state, actor)
+		return "buyDevCard", err
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		card := pb.DevCardType(r.next() % 7)
+		target := r.resource()
+		picks := []pb.Resource{r.resource(), r.resource()}[:r.next()%3]
+		return fmt.Sprintf("play %s %s %v", card, target, picks), PlayDevCard(g.state, actor, card, &target, picks)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		var target *string
+		if b := r.next(); b%2 == 1 {
+			id := g.player(b / 2)
+			target = &id
+		}
+		offering, requesting := r.resources(), r.resources()
+		_, err := ProposeTrade(g.state, actor, target, offering, requesting)
+		return fmt.Sprintf("proposeTrade %v for %v", offering, requesting), err
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		id := "missing"
+		if trades := g.state.PendingTrades; len(trades) > 0 {
+			id = trades[int(r.next())%len(trades)].Id
+		}
+		responder, accept := g.player(r.next()), r.next()%3 != 0
+		return fmt.Sprintf("respondTrade as %s accept=%t", responder, accept), RespondTrade(g.state, id, responder, accept)
+	},
+	func(g *fuzzGame, actor string, r *scriptReader) (string, error) {
+		return "endTurn", EndTurn(g.state, actor)
+	},
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"sort"
+
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
 
@@ -10,121 +12,124 @@ type LongestRoadResult struct {
 	Length   int
 }
 
+// roadGraph is the road network of a single player: for every vertex touched
+// by one of their roads, the roads leaving that vertex.
+type roadGraph struct {
+	adjacent map[string][]*pb.Edge
+	blocked  map[string]bool
+}
+
 // GetLongestRoadLengths returns road lengths for all players.
 func GetLongestRoadLengths(board *pb.BoardState, vertices []*pb.Vertex) map[string]int {
 	lengths := map[string]int{}
-	roadEdges := map[string]*pb.Edge{}
+	graphs := map[string]*roadGraph{}
 	for _, e := range board.Edges {
-		if e.Road != nil {
-			roadEdges[e.Id] = e
+		if e.Road == nil {
+			continue
 		}
-	}
-	// For each player, run DFS from each road edge
-	playerRoadEdges := map[string][]*pb.Edge{}
-	for _, e := range roadEdges {
 		owner := e.Road.OwnerId
-		playerRoadEdges[owner] = append(playerRoadEdges[owner], e)
-	}
-	for playerId, edges := range playerRoadEdges {
-		maxLen := 0
-		for _, edge := range edges {
-			visited := map[string]bool{}
-			length := dfsLongestRoad(board, vertices, edge, visited, playerId)
-			if length > maxLen {
-				maxLen = length
-			}
+		g := graphs[owner]
+		if g == nil {
+			g = &roadGraph{adjacent: map[string][]*pb.Edge{}, blocked: map[string]bool{}}
+			graphs[owner] = g
 		}
-		lengths[playerId] = maxLen
-	}
-	return lengths
-}
-
-// dfsLongestRoad performs DFS and returns length of path
-func dfsLongestRoad(board *pb.BoardState, vertices []*pb.Vertex, current *pb.Edge, visited map[string]bool, playerId string) int {
-	visited[current.Id] = true
-	maxPath := 1
-	for _, vId := range current.Vertices {
-		v := getVertexById(vertices, vId)
-		if v == nil {
-			continue
+		for _, vId := range e.Vertices {
+			g.adjacent[vId] = append(g.adjacent[vId], e)
 		}
-		if isBlockedByOpponentBuilding(v, playerId) {
+	}
+	for _, v := range vertices {
+		if v.Building == nil {
 			continue
 		}
-		adjEdges := getEdgesConnectedToVertex(board, v.Id)
-		for _, adj := range adjEdges {
-			if adj.Id == current.Id || visited[adj.Id] {
-				continue
-			}
-			if adj.Road == nil || adj.Road.OwnerId != playerId {
-				continue
-			}
-			path := 1 + dfsLongestRoad(board, vertices, adj, visited, playerId)
-			if path > maxPath {
-				maxPath = path
+		for playerId, g := range graphs {
+			if v.Building.OwnerId != playerId {
+				g.blocked[v.Id] = true
 			}
 		}
 	}
-	visited[current.Id] = false // Backtrack
-	return maxPath
+	for playerId, g := range graphs {
+		lengths[playerId] = g.longestPath()
+	}
+	return lengths
 }
 
-func isBlockedByOpponentBuilding(vertex *pb.Vertex, playerId string) bool {
-	if vertex.Building != nil && vertex.Building.OwnerId != playerId {
-		return true
+// longestPath returns the number of edges in the longest edge-simple path.
+// Paths may start or end on a vertex holding an opponent building but never
+// pass through one.
+func (g *roadGraph) longestPath() int {
+	maxLen := 0
+	visited := map[string]bool{}
+	for vId := range g.adjacent {
+		if l := g.walk(vId, visited, true); l > maxLen {
+			maxLen = l
+		}
 	}
-	return false
+	return maxLen
 }
 
-func getVertexById(vertices []*pb.Vertex, id string) *pb.Vertex {
-	for _, v := range vertices {
-		if v.Id == id {
-			return v
+// walk extends the current path from vertexId and returns the number of
+// additional edges on the longest continuation.
+func (g *roadGraph) walk(vertexId string, visited map[string]bool, start bool) int {
+	if !start && g.blocked[vertexId] {
+		return 0
+	}
+	best := 0
+	for _, e := range g.adjacent[vertexId] {
+		if visited[e.Id] {
+			continue
 		}
+		visited[e.Id] = true
+		if l := 1 + g.walk(otherVertex(e, vertexId), visited, false); l > best {
+			best = l
+		}
+		visited[e.Id] = false // Backtrack
 	}
-	return nil
+	return best
 }
 
-func getEdgesConnectedToVertex(board *pb.BoardState, vertexId string) []*pb.Edge {
-	var edges []*pb.Edge
-	for _, e := range board.Edges {
-		for _, vId := range e.Vertices {
-			if vId == vertexId {
-				edges = append(edges, e)
-			}
+func otherVertex(e *pb.Edge, vertexId string) string {
+	for _, vId := range e.Vertices {
+		if vId != vertexId {
+			return vId
 		}
 	}
-	return edges
+	return vertexId
 }
 
-// GetLongestRoadPlayerId returns the player who currently has longest road (>=5), ties go to current holder.
+// GetLongestRoadPlayerId returns the player who should hold longest road
+// (>=5) given the current holder. The holder keeps the card while tied for
+// the longest road. If the holder is no longer among the longest (their road
+// was broken) and several other players tie, the card is set aside and no
+// one holds it until the tie is broken.
 func GetLongestRoadPlayerId(state *pb.GameState) string {
 	lengths := GetLongestRoadLengths(state.Board, state.Board.Vertices)
 	maxLen := 0
-	claimers := []string{}
-	for pid, l := range lengths {
+	for _, l := range lengths {
 		if l > maxLen {
 			maxLen = l
-			claimers = []string{pid}
-		} else if l == maxLen && maxLen > 0 {
-			claimers = append(claimers, pid)
 		}
 	}
-	if maxLen >= 5 {
-		// Award to sole maxLen owner or tie-break to current holder
-		current := ""
-		if state.LongestRoadPlayerId != nil {
-			current = *state.LongestRoadPlayerId
+	if !QualifiesForLongestRoad(maxLen) {
+		return "" // No one qualifies
+	}
+	claimers := []string{}
+	for pid, l := range lengths {
+		if l == maxLen {
+			claimers = append(claimers, pid)
 		}
-		for _, c := range claimers {
-			if c == current {
-				return c
-			}
+	}
+	sort.Strings(claimers)
+
+	current := state.GetLongestRoadPlayerId()
+	for _, c := range claimers {
+		if c == current {
+			return c
 		}
-		// Otherwise, first in claimers
+	}
+	if len(claimers) == 1 {
 		return claimers[0]
 	}
-	return "" // No one qualifies
+	return "" // Tie among non-holders: card is set aside
 }
 
 // UpdateLongestRoadBonus recalculates and updates the longest road bonus holder
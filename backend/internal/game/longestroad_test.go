@@ -1,7 +1,9 @@
 package game
 
 import (
+	"fmt"
 	pb "settlers_from_catan/gen/proto/catan/v1"
+	"strings"
 	"testing"
 )
 
@@ -50,8 +52,9 @@ func TestBranchingRoads_MaxBranch(t *testing.T) {
 	e3 := makeEdge("E3", "p1", "B", "D")
 	board := &pb.BoardState{Edges: []*pb.Edge{e1, e2, e3}, Vertices: []*pb.Vertex{vA, vB, vC, vD}}
 	lengths := GetLongestRoadLengths(board, board.Vertices)
-	if lengths["p1"] != 3 {
-		t.Errorf("Expected max branch of 3, got %d", lengths["p1"])
+	// A road cannot double back through a fork: A-B-C or A-B-D, never all three
+	if lengths["p1"] != 2 {
+		t.Errorf("Expected max branch of 2, got %d", lengths["p1"])
 	}
 }
 
@@ -375,3 +378,179 @@ func TestLongestRoadUpdate_TriggersVictoryCheck(t *testing.T) {
 		t.Errorf("expected p1 to have longest road, got %s", currentHolder)
 	}
 }
+
+// roadBoard builds a board from "owner:V1-V2" road specs and "owner@V" building specs.
+func roadBoard(roads []string, buildings []string) *pb.BoardState {
+	board := &pb.BoardState{}
+	seen := map[string]*pb.Vertex{}
+	vertex := func(id string) *pb.Vertex {
+		if v, ok := seen[id]; ok {
+			return v
+		}
+		v := makeVertex(id, nil)
+		seen[id] = v
+		board.Vertices = append(board.Vertices, v)
+		return v
+	}
+	for i, spec := range roads {
+		owner, ends, _ := strings.Cut(spec, ":")
+		a, b, _ := strings.Cut(ends, "-")
+		vertex(a)
+		vertex(b)
+		board.Edges = append(board.Edges, makeEdge(fmt.Sprintf("E%d", i+1), owner, a, b))
+	}
+	for _, spec := range buildings {
+		owner, id, _ := strings.Cut(spec, "@")
+		vertex(id).Building = &pb.Building{OwnerId: owner, Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT}
+	}
+	return board
+}
+
+func TestLongestRoadShapes(t *testing.T) {
+	tests := []struct {
+		name      string
+		roads     []string
+		buildings []string
+		expected  int
+	}{
+		{
+			name:     "linear road of 6",
+			roads:    []string{"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-G"},
+			expected: 6,
+		},
+		{
+			name:     "Y-shaped road 3+3+3 uses two arms",
+			roads:    []string{"p1:X-A1", "p1:A1-A2", "p1:A2-A3", "p1:X-B1", "p1:B1-B2", "p1:B2-B3", "p1:X-C1", "p1:C1-C2", "p1:C2-C3"},
+			expected: 6,
+		},
+		{
+			name:     "figure-8 of two triangles sharing a vertex",
+			roads:    []string{"p1:X-A", "p1:A-B", "p1:B-X", "p1:X-C", "p1:C-D", "p1:D-X"},
+			expected: 6,
+		},
+		{
+			name:     "hexagon ring",
+			roads:    []string{"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-A"},
+			expected: 6,
+		},
+		{
+			name:     "hexagon ring with tail of 2",
+			roads:    []string{"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-A", "p1:A-T1", "p1:T1-T2"},
+			expected: 8,
+		},
+		{
+			name: "two hexagons sharing an edge",
+			roads: []string{
+				"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-A",
+				"p1:B-G", "p1:G-H", "p1:H-I", "p1:I-J", "p1:J-C",
+			},
+			expected: 11,
+		},
+		{
+			name:      "opponent settlement splits linear road 2+4",
+			roads:     []string{"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-G"},
+			buildings: []string{"p2@C"},
+			expected:  4,
+		},
+		{
+			name:      "opponent settlement at road end does not shorten it",
+			roads:     []string{"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F"},
+			buildings: []string{"p2@A", "p2@F"},
+			expected:  5,
+		},
+		{
+			name:      "opponent settlement on fork vertex",
+			roads:     []string{"p1:X-A1", "p1:A1-A2", "p1:X-B1", "p1:B1-B2", "p1:B2-B3", "p1:X-C1"},
+			buildings: []string{"p2@X"},
+			expected:  3,
+		},
+		{
+			name:      "own city on fork vertex does not block",
+			roads:     []string{"p1:X-A1", "p1:A1-A2", "p1:X-B1", "p1:B1-B2", "p1:B2-B3", "p1:X-C1"},
+			buildings: []string{"p1@X"},
+			expected:  5,
+		},
+		{
+			name:     "opponent roads do not connect",
+			roads:    []string{"p1:A-B", "p1:B-C", "p2:C-D", "p1:D-E", "p1:E-F"},
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := roadBoard(tt.roads, tt.buildings)
+			lengths := GetLongestRoadLengths(board, board.Vertices)
+			if lengths["p1"] != tt.expected {
+				t.Errorf("expected length %d, got %d", tt.expected, lengths["p1"])
+			}
+		})
+	}
+}
+
+func TestTieAmongNonHoldersAfterBreakSetsCardAside(t *testing.T) {
+	// p1 holds with 7; p2 and p3 both have 5. p1's road is broken into 3+3.
+	board := roadBoard([]string{
+		"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-G", "p1:G-H",
+		"p2:P-Q", "p2:Q-R", "p2:R-S", "p2:S-T", "p2:T-U",
+		"p3:K-L", "p3:L-M", "p3:M-N", "p3:N-O", "p3:O-Z",
+	}, []string{"p2@E"})
+	state := &pb.GameState{Board: board, LongestRoadPlayerId: ptr("p1")}
+
+	UpdateLongestRoadBonus(state)
+
+	if state.LongestRoadPlayerId != nil {
+		t.Errorf("expected card to be set aside on tie, got %s", *state.LongestRoadPlayerId)
+	}
+}
+
+func TestSingleLeaderAfterBreakTakesCard(t *testing.T) {
+	board := roadBoard([]string{
+		"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-G", "p1:G-H",
+		"p2:P-Q", "p2:Q-R", "p2:R-S", "p2:S-T", "p2:T-U",
+		"p3:K-L", "p3:L-M", "p3:M-N", "p3:N-O",
+	}, []string{"p2@E"})
+	state := &pb.GameState{Board: board, LongestRoadPlayerId: ptr("p1")}
+
+	UpdateLongestRoadBonus(state)
+
+	if state.GetLongestRoadPlayerId() != "p2" {
+		t.Errorf("expected p2 to take longest road, got %q", state.GetLongestRoadPlayerId())
+	}
+}
+
+func TestHolderStillTiedAfterBreakKeepsCard(t *testing.T) {
+	// p1's 8-road is broken into 5+2, leaving p1 tied with p2 at 5.
+	board := roadBoard([]string{
+		"p1:A-B", "p1:B-C", "p1:C-D", "p1:D-E", "p1:E-F", "p1:F-G", "p1:G-H", "p1:H-I",
+		"p2:P-Q", "p2:Q-R", "p2:R-S", "p2:S-T", "p2:T-U",
+	}, []string{"p2@F"})
+	state := &pb.GameState{Board: board, LongestRoadPlayerId: ptr("p1")}
+
+	UpdateLongestRoadBonus(state)
+
+	if state.GetLongestRoadPlayerId() != "p1" {
+		t.Errorf("expected p1 to keep longest road, got %q", state.GetLongestRoadPlayerId())
+	}
+}
+
+func TestSetAsideCardStaysAsideUntilTieBroken(t *testing.T) {
+	roads := []string{
+		"p2:P-Q", "p2:Q-R", "p2:R-S", "p2:S-T", "p2:T-U",
+		"p3:K-L", "p3:L-M", "p3:M-N", "p3:N-O", "p3:O-Z",
+	}
+	state := &pb.GameState{Board: roadBoard(roads, nil)}
+
+	// Deterministic: repeated evaluation never picks an arbitrary claimer
+	for i := 0; i < 20; i++ {
+		if pid := GetLongestRoadPlayerId(state); pid != "" {
+			t.Fatalf("expected no holder while tied, got %s", pid)
+		}
+	}
+
+	state.Board = roadBoard(append(roads, "p3:Z-Y"), nil)
+	UpdateLongestRoadBonus(state)
+	if state.GetLongestRoadPlayerId() != "p3" {
+		t.Errorf("expected p3 to claim longest road after breaking tie, got %q", state.GetLongestRoadPlayerId())
+	}
+}
@@ -29,6 +29,12 @@ var (
 
 // GeneratePortsForBoard returns the 9 standard ports, selecting coastal vertices from the board.
 func GeneratePortsForBoard(board *catanv1.BoardState) []*catanv1.Port {
+	return generatePorts(board, rand.Perm)
+}
+
+// generatePorts lays out the ports, drawing their order from perm so seeded
+// boards get the same ports every time.
+func generatePorts(board *catanv1.BoardState, perm func(n int) []int) []*catanv1.Port {
 	// Find coastal vertices (vertices with fewer than 3 adjacent hexes)
 	coastalVertices := []string{}
 	for _, v := range board.Vertices {
@@ -65,7 +71,7 @@ func GeneratePortsForBoard(board *catanv1.BoardState) []*catanv1.Port {
 
 	// Randomize port types and resources
 	ports := make([]*catanv1.Port, len(portVertices))
-	order := rand.Perm(len(portTypes))
+	order := perm(len(portTypes))
 	for i := range portVertices {
 		if i >= len(portVertices) {
 			break
@@ -0,0 +1,94 @@
+package game
+
+import (
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// RemapPlayerIDs rewrites every player ID reference in state using mapping
+// (old ID -> new ID). IDs missing from mapping are left unchanged.
+func RemapPlayerIDs(state *pb.GameState, mapping map[string]string) {
+	if state == nil {
+		return
+	}
+	remap := func(id string) string {
+		if newID, ok := mapping[id]; ok {
+			return newID
+		}
+		return id
+	}
+	remapOptional := func(id *string) *string {
+		if id == nil {
+			return nil
+		}
+		newID := remap(*id)
+		return &newID
+	}
+
+	for _, p := range state.Players {
+		p.Id = remap(p.Id)
+	}
+	if state.Board != nil {
+		for _, v := range state.Board.Vertices {
+			if v.Building != nil {
+				v.Building.OwnerId = remap(v.Building.OwnerId)
+			}
+		}
+		for _, e := range state.Board.Edges {
+			if e.Road != nil {
+				e.Road.OwnerId = remap(e.Road.OwnerId)
+			}
+		}
+	}
+	state.LongestRoadPlayerId = remapOptional(state.LongestRoadPlayerId)
+	state.LargestArmyPlayerId = remapOptional(state.LargestArmyPlayerId)
+	for _, t := range state.PendingTrades {
+		t.ProposerId = remap(t.ProposerId)
+		t.TargetId = remapOptional(t.TargetId)
+	}
+	if rp := state.RobberPhase; rp != nil {
+		for i, id := range rp.DiscardPending {
+			rp.DiscardPending[i] = remap(id)
+		}
+		if rp.DiscardRequired != nil {
+			required := make(map[string]int32, len(rp.DiscardRequired))
+			for id, n := range rp.DiscardRequired {
+				required[remap(id)] = n
+			}
+			rp.DiscardRequired = required
+		}
+		rp.MovePendingPlayerId = remapOptional(rp.MovePendingPlayerId)
+		rp.StealPendingPlayerId = remapOptional(rp.StealPendingPlayerId)
+	}
+	if sr := state.Series; sr != nil {
+		remapCounts := func(counts map[string]int32) map[string]int32 {
+			out := make(map[string]int32, len(counts))
+			for id, n := range counts {
+				out[remap(id)] = n
+			}
+			return out
+		}
+		sr.Wins = remapCounts(sr.Wins)
+		sr.Points = remapCounts(sr.Points)
+		if sr.WinnerId != "" {
+			sr.WinnerId = remap(sr.WinnerId)
+		}
+	}
+	remapAll := func(ids []string) {
+		for i, id := range ids {
+			ids[i] = remap(id)
+		}
+	}
+	remapAll(state.ChatMutedPlayerIds)
+	remapAll(state.ExportApprovedPlayerIds)
+	if tb := state.PendingTakeback; tb != nil {
+		tb.RequesterId = remap(tb.RequesterId)
+		remapAll(tb.ApprovedPlayerIds)
+	}
+	if state.RematchPlayerIds != nil {
+		seats := make(map[string]string, len(state.RematchPlayerIds))
+		for id, seat := range state.RematchPlayerIds {
+			seats[remap(id)] = seat
+		}
+		state.RematchPlayerIds = seats
+	}
+}
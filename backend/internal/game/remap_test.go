@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestRemapPlayerIDs(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	state.Board.Vertices[0].Building = &pb.Building{OwnerId: "p1", Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT}
+	state.Board.Edges[0].Road = &pb.Road{OwnerId: "p2"}
+	state.LongestRoadPlayerId = ptr("p2")
+	state.PendingTrades = []*pb.TradeOffer{{Id: "t1", ProposerId: "p1", TargetId: ptr("p2")}}
+	state.RobberPhase = &pb.RobberPhase{
+		DiscardPending:       []string{"p2"},
+		DiscardRequired:      map[string]int32{"p2": 4},
+		MovePendingPlayerId:  ptr("p1"),
+		StealPendingPlayerId: ptr("p1"),
+	}
+	state.Series = &pb.Series{BestOf: 3, Wins: map[string]int32{"p1": 2, "p2": 0}, Points: map[string]int32{"p1": 20, "p2": 14}, WinnerId: "p1"}
+	state.ChatMutedPlayerIds = []string{"p2"}
+	state.ExportApprovedPlayerIds = []string{"p1", "p2"}
+	state.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", EventSeq: 4, ApprovedPlayerIds: []string{"p2"}}
+	state.RematchPlayerIds = map[string]string{"p1": "r1", "p2": "r2"}
+
+	RemapPlayerIDs(state, map[string]string{"p1": "a", "p2": "b"})
+
+	if state.Players[0].Id != "a" || state.Players[1].Id != "b" {
+		t.Errorf("expected players remapped, got %s/%s", state.Players[0].Id, state.Players[1].Id)
+	}
+	if state.Board.Vertices[0].Building.OwnerId != "a" || state.Board.Edges[0].Road.OwnerId != "b" {
+		t.Errorf("expected board ownership remapped")
+	}
+	if state.GetLongestRoadPlayerId() != "b" {
+		t.Errorf("expected longest road holder remapped, got %s", state.GetLongestRoadPlayerId())
+	}
+	if state.PendingTrades[0].ProposerId != "a" || state.PendingTrades[0].GetTargetId() != "b" {
+		t.Errorf("expected trade parties remapped")
+	}
+	rp := state.RobberPhase
+	if rp.DiscardPending[0] != "b" || rp.DiscardRequired["b"] != 4 || rp.GetMovePendingPlayerId() != "a" || rp.GetStealPendingPlayerId() != "a" {
+		t.Errorf("expected robber phase remapped, got %+v", rp)
+	}
+	if sr := state.Series; sr.Wins["a"] != 2 || sr.Points["b"] != 14 || sr.WinnerId != "a" {
+		t.Errorf("expected series tallies remapped, got %+v", sr)
+	}
+	if state.ChatMutedPlayerIds[0] != "b" || state.ExportApprovedPlayerIds[0] != "a" || state.ExportApprovedPlayerIds[1] != "b" {
+		t.Errorf("expected chat mutes and export approvals remapped, got %v and %v", state.ChatMutedPlayerIds, state.ExportApprovedPlayerIds)
+	}
+	if tb := state.PendingTakeback; tb.RequesterId != "a" || tb.ApprovedPlayerIds[0] != "b" {
+		t.Errorf("expected the takeback vote remapped, got %+v", tb)
+	}
+	if seats := state.RematchPlayerIds; seats["a"] != "r1" || seats["b"] != "r2" {
+		t.Errorf("expected rematch seats keyed by the new IDs, got %v", seats)
+	}
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// MaxSeriesLength is the longest best-of-N series players can ask for.
+const MaxSeriesLength = 9
+
+var (
+	ErrGameNotFinished     = errors.New("only a finished game can be rematched")
+	ErrInvalidSeriesLength = fmt.Errorf("series length must be 0 or an odd number up to %d", MaxSeriesLength)
+)
+
+// ValidSeriesLength reports whether bestOf is 0, for open-ended rematches,
+// or an odd number of games no longer than MaxSeriesLength.
+func ValidSeriesLength(bestOf int32) bool {
+	return bestOf == 0 || bestOf > 0 && bestOf <= MaxSeriesLength && bestOf%2 == 1
+}
+
+// NewRematch builds the lobby for the rematch of the finished game prev.
+// newIDs maps each of prev's players to their ID in the rematch. Seats
+// rotate so whoever went first in prev goes last; names, colours, the host
+// and chat settings carry over.
+//
+// The rematch continues prev's series while it is undecided. Otherwise it
+// starts a series of bestOf games; a standalone prev counts as its first
+// game, while a decided series leaves the new one starting from scratch.
+func NewRematch(prev *pb.GameState, gameID, code string, newIDs map[string]string, bestOf int32) (*pb.GameState, error) {
+	if prev.GetStatus() != pb.GameStatus_GAME_STATUS_FINISHED {
+		return nil, ErrGameNotFinished
+	}
+	if !ValidSeriesLength(bestOf) {
+		return nil, ErrInvalidSeriesLength
+	}
+	n := len(prev.Players)
+	seats := make([]*pb.PlayerState, n)
+	names := make([]string, n)
+	ids := make([]string, n)
+	for i := range seats {
+		seats[i] = prev.Players[(i+1)%n]
+		names[i] = seats[i].Name
+		ids[i] = newIDs[seats[i].Id]
+	}
+	state := NewGameState(gameID, code, names, ids)
+	for i, p := range state.Players {
+		p.Color = seats[i].Color
+		p.IsHost = seats[i].IsHost
+	}
+	state.SpectatorChatDisabled = prev.SpectatorChatDisabled
+	if c := prev.GetCorrespondence(); c != nil {
+		state.Correspondence = &pb.Correspondence{TurnHours: c.TurnHours}
+	}
+	for _, id := range prev.ChatMutedPlayerIds {
+		if newID, ok := newIDs[id]; ok {
+			state.ChatMutedPlayerIds = append(state.ChatMutedPlayerIds, newID)
+		}
+	}
+
+	winnerID, _ := DetermineWinner(prev)
+	series := BuildGameOverPayload(prev, winnerID).Series
+	switch {
+	case series == nil:
+		series = seriesAfter(&pb.Series{Id: uuid.New().String(), BestOf: bestOf, GameNumber: 1}, prev, winnerID)
+	case series.WinnerId != "":
+		series = &pb.Series{Id: uuid.New().String(), BestOf: bestOf}
+	}
+	state.Series = remapSeries(series, newIDs)
+	state.Series.GameNumber++
+	return state, nil
+}
+
+// seriesAfter returns s with the finished game state counted: its winner
+// gains a win and every player their victory points. It returns nil if s is.
+func seriesAfter(s *pb.Series, state *pb.GameState, winnerID string) *pb.Series {
+	if s == nil {
+		return nil
+	}
+	out := proto.Clone(s).(*pb.Series)
+	if out.Wins == nil {
+		out.Wins = make(map[string]int32)
+	}
+	if out.Points == nil {
+		out.Points = make(map[string]int32)
+	}
+	for _, p := range state.GetPlayers() {
+		if _, ok := out.Wins[p.Id]; !ok {
+			out.Wins[p.Id] = 0
+		}
+		out.Points[p.Id] += int32(CalculatePlayerVictoryPoints(state, p.Id))
+	}
+	if winnerID == "" {
+		return out
+	}
+	out.Wins[winnerID]++
+	if out.WinnerId == "" && out.BestOf > 0 && out.Wins[winnerID] > out.BestOf/2 {
+		out.WinnerId = winnerID
+	}
+	return out
+}
+
+// remapSeries returns s with its tallies keyed by the players' new IDs.
+// Players without a new ID drop out of the tallies.
+func remapSeries(s *pb.Series, newIDs map[string]string) *pb.Series {
+	out := &pb.Series{
+		Id:         s.Id,
+		BestOf:     s.BestOf,
+		GameNumber: s.GameNumber,
+		Wins:       make(map[string]int32),
+		Points:     make(map[string]int32),
+		WinnerId:   newIDs[s.WinnerId],
+	}
+	for id, wins := range s.Wins {
+		if newID, ok := newIDs[id]; ok {
+			out.Wins[newID] = wins
+		}
+	}
+	for id, points := range s.Points {
+		if newID, ok := newIDs[id]; ok {
+			out.Points[newID] = points
+		}
+	}
+	return out
+}
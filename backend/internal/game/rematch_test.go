@@ -0,0 +1,133 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// finishGame ends state with winnerID holding ten victory point cards.
+func finishGame(state *pb.GameState, winnerID string) {
+	state.Status = pb.GameStatus_GAME_STATUS_FINISHED
+	for i, p := range state.Players {
+		if p.Id == winnerID {
+			state.CurrentTurn = int32(i)
+			p.VictoryPointCards = 10
+		}
+	}
+}
+
+// rematchIDs maps each player in state to a fresh ID with suffix.
+func rematchIDs(state *pb.GameState, suffix string) map[string]string {
+	ids := make(map[string]string, len(state.Players))
+	for _, p := range state.Players {
+		ids[p.Id] = p.Name + suffix
+	}
+	return ids
+}
+
+func TestNewRematch_RotatesSeatsAndCarriesSettings(t *testing.T) {
+	prev := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+	prev.Players[1].Color = pb.PlayerColor_PLAYER_COLOR_ORANGE
+	prev.SpectatorChatDisabled = true
+	prev.ChatMutedPlayerIds = []string{"p3"}
+	prev.Correspondence = &pb.Correspondence{TurnHours: 24, TurnDeadline: 1}
+	finishGame(prev, "p2")
+
+	state, err := NewRematch(prev, "g2", "BBBBBB", map[string]string{"p1": "a", "p2": "b", "p3": "c"}, 0)
+	if err != nil {
+		t.Fatalf("NewRematch: %v", err)
+	}
+	if state.Id != "g2" || state.Code != "BBBBBB" || state.Status != pb.GameStatus_GAME_STATUS_WAITING {
+		t.Fatalf("expected a fresh lobby g2/BBBBBB, got %s/%s %v", state.Id, state.Code, state.Status)
+	}
+	var order []string
+	for _, p := range state.Players {
+		order = append(order, p.Id)
+	}
+	if !slices.Equal(order, []string{"b", "c", "a"}) {
+		t.Fatalf("expected seats rotated to b, c, a; got %v", order)
+	}
+	if p := state.Players[0]; p.Name != "Bob" || p.Color != pb.PlayerColor_PLAYER_COLOR_ORANGE || p.IsHost {
+		t.Errorf("expected Bob to keep their colour and not host, got %+v", p)
+	}
+	if p := state.Players[2]; p.Name != "Alice" || !p.IsHost {
+		t.Errorf("expected Alice to stay host, got %+v", p)
+	}
+	if !state.SpectatorChatDisabled || !slices.Equal(state.ChatMutedPlayerIds, []string{"c"}) {
+		t.Errorf("expected chat settings carried over, got %v %v", state.SpectatorChatDisabled, state.ChatMutedPlayerIds)
+	}
+	if c := state.Correspondence; c == nil || c.TurnHours != 24 || c.TurnDeadline != 0 {
+		t.Errorf("expected the turn length carried over without a clock, got %+v", c)
+	}
+	sr := state.Series
+	if sr == nil || sr.GameNumber != 2 || sr.BestOf != 0 || sr.WinnerId != "" {
+		t.Fatalf("expected an open-ended series at game 2, got %+v", sr)
+	}
+	if sr.Wins["b"] != 1 || sr.Wins["a"] != 0 || sr.Points["b"] != 10 {
+		t.Errorf("expected Bob's win counted under their new ID, got %+v", sr)
+	}
+}
+
+func TestNewRematch_BestOfSeries(t *testing.T) {
+	game1 := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	finishGame(game1, "p1")
+
+	game2, err := NewRematch(game1, "g2", "BBBBBB", rematchIDs(game1, "2"), 3)
+	if err != nil {
+		t.Fatalf("NewRematch: %v", err)
+	}
+	if sr := game2.Series; sr.BestOf != 3 || sr.GameNumber != 2 || sr.Wins["Alice2"] != 1 {
+		t.Fatalf("expected game 1 to count toward a best of 3, got %+v", sr)
+	}
+
+	// The bestOf of a rematch inside an undecided series is ignored.
+	finishGame(game2, "Bob2")
+	game3, err := NewRematch(game2, "g3", "CCCCCC", rematchIDs(game2, "3"), 5)
+	if err != nil {
+		t.Fatalf("NewRematch: %v", err)
+	}
+	if sr := game3.Series; sr.BestOf != 3 || sr.GameNumber != 3 || sr.Wins["Alice3"] != 1 || sr.Wins["Bob3"] != 1 {
+		t.Fatalf("expected the series tied at game 3, got %+v", sr)
+	}
+	if game3.Series.Id != game2.Series.Id {
+		t.Errorf("expected the series to keep its ID")
+	}
+
+	finishGame(game3, "Alice3")
+	over := BuildGameOverPayload(game3, "Alice3")
+	if sr := over.Series; sr == nil || sr.WinnerId != "Alice3" || sr.Wins["Alice3"] != 2 || sr.Points["Alice3"] != 20 {
+		t.Fatalf("expected Alice to take the series in the game over payload, got %+v", sr)
+	}
+
+	game4, err := NewRematch(game3, "g4", "DDDDDD", rematchIDs(game3, "4"), 5)
+	if err != nil {
+		t.Fatalf("NewRematch: %v", err)
+	}
+	if sr := game4.Series; sr.Id == game3.Series.Id || sr.BestOf != 5 || sr.GameNumber != 1 || len(sr.Wins) != 0 {
+		t.Fatalf("expected a decided series to be followed by a fresh best of 5, got %+v", sr)
+	}
+}
+
+func TestNewRematch_Rejects(t *testing.T) {
+	state := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	ids := rematchIDs(state, "2")
+	if _, err := NewRematch(state, "g2", "BBBBBB", ids, 0); err != ErrGameNotFinished {
+		t.Errorf("expected ErrGameNotFinished, got %v", err)
+	}
+	finishGame(state, "p1")
+	for _, bestOf := range []int32{-1, 2, MaxSeriesLength + 2} {
+		if _, err := NewRematch(state, "g2", "BBBBBB", ids, bestOf); err != ErrInvalidSeriesLength {
+			t.Errorf("best of %d: expected ErrInvalidSeriesLength, got %v", bestOf, err)
+		}
+	}
+}
+
+func TestBuildGameOverPayload_NoSeries(t *testing.T) {
+	state := NewGameState("g1", "AAAAAA", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	finishGame(state, "p1")
+	if sr := BuildGameOverPayload(state, "p1").Series; sr != nil {
+		t.Errorf("expected no series outside rematches, got %+v", sr)
+	}
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"sort"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// ErrLastPlayer is returned when removing a player would leave a game that
+// has started with nobody in it.
+var ErrLastPlayer = newRuleError(pb.ErrorCode_ERROR_CODE_LAST_PLAYER, "cannot remove the last player from a started game")
+
+// RemovePlayer takes playerID out of the game. Their pieces leave the board,
+// unplayed development cards go back under the deck, and trades and robber
+// steps waiting on them are dropped. If it was their turn, the next player
+// starts a fresh turn. Players cannot be removed during setup, whose snake
+// order depends on the player count.
+func RemovePlayer(state *pb.GameState, playerID string) error {
+	idx := -1
+	for i, p := range state.Players {
+		if p.Id == playerID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrPlayerNotFound
+	}
+	if state.Status == pb.GameStatus_GAME_STATUS_SETUP {
+		return ErrWrongPhase
+	}
+	started := state.Status != pb.GameStatus_GAME_STATUS_WAITING
+	if started && len(state.Players) == 1 {
+		return ErrLastPlayer
+	}
+
+	removed := state.Players[idx]
+	state.Players = append(state.Players[:idx], state.Players[idx+1:]...)
+	if removed.IsHost && len(state.Players) > 0 {
+		state.Players[0].IsHost = true
+	}
+
+	if state.Board != nil {
+		for _, v := range state.Board.Vertices {
+			if v.Building != nil && v.Building.OwnerId == playerID {
+				v.Building = nil
+			}
+		}
+		for _, e := range state.Board.Edges {
+			if e.Road != nil && e.Road.OwnerId == playerID {
+				e.Road = nil
+			}
+		}
+	}
+
+	cardTypes := make([]int32, 0, len(removed.DevCards))
+	for cardType := range removed.DevCards {
+		cardTypes = append(cardTypes, cardType)
+	}
+	sort.Slice(cardTypes, func(i, j int) bool { return cardTypes[i] < cardTypes[j] })
+	for _, cardType := range cardTypes {
+		for n := int32(0); n < removed.DevCards[cardType]; n++ {
+			state.DevCardDeck = append(state.DevCardDeck, pb.DevCardType(cardType))
+		}
+	}
+
+	trades := state.PendingTrades[:0]
+	for _, t := range state.PendingTrades {
+		if t.ProposerId != playerID && t.GetTargetId() != playerID {
+			trades = append(trades, t)
+		}
+	}
+	state.PendingTrades = trades
+
+	if rp := state.RobberPhase; rp != nil {
+		pending := rp.DiscardPending[:0]
+		for _, id := range rp.DiscardPending {
+			if id != playerID {
+				pending = append(pending, id)
+			}
+		}
+		rp.DiscardPending = pending
+		delete(rp.DiscardRequired, playerID)
+		if rp.GetMovePendingPlayerId() == playerID || rp.GetStealPendingPlayerId() == playerID {
+			state.RobberPhase = nil
+		}
+	}
+
+	if started && len(state.Players) > 0 {
+		switch current := int(state.CurrentTurn); {
+		case idx < current:
+			state.CurrentTurn--
+		case idx == current:
+			state.CurrentTurn = int32(current % len(state.Players))
+			if state.Status == pb.GameStatus_GAME_STATUS_PLAYING {
+				state.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
+				state.Dice = []int32{0, 0}
+				state.RobberPhase = nil
+				ExpireOldTrades(state)
+				state.TurnCounter++
+			}
+		}
+	}
+
+	if state.Board != nil {
+		UpdateLongestRoadBonus(state)
+	}
+	RecalculateLargestArmy(state)
+	return nil
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestRemovePlayer(t *testing.T) {
+	newState := func(status pb.GameStatus, currentTurn int32) *pb.GameState {
+		state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+		state.Players[0].IsHost = true
+		state.Status = status
+		state.CurrentTurn = currentTurn
+		state.TurnPhase = pb.TurnPhase_TURN_PHASE_TRADE
+		return state
+	}
+
+	tests := []struct {
+		name        string
+		status      pb.GameStatus
+		currentTurn int32
+		remove      string
+		wantErr     error
+		wantTurn    int32
+		wantPhase   pb.TurnPhase
+	}{
+		{"lobby host hands over", pb.GameStatus_GAME_STATUS_WAITING, 0, "p1", nil, 0, pb.TurnPhase_TURN_PHASE_TRADE},
+		{"before current player", pb.GameStatus_GAME_STATUS_PLAYING, 2, "p1", nil, 1, pb.TurnPhase_TURN_PHASE_TRADE},
+		{"after current player", pb.GameStatus_GAME_STATUS_PLAYING, 0, "p3", nil, 0, pb.TurnPhase_TURN_PHASE_TRADE},
+		{"current player passes turn", pb.GameStatus_GAME_STATUS_PLAYING, 1, "p2", nil, 1, pb.TurnPhase_TURN_PHASE_ROLL},
+		{"current last player wraps", pb.GameStatus_GAME_STATUS_PLAYING, 2, "p3", nil, 0, pb.TurnPhase_TURN_PHASE_ROLL},
+		{"unknown player", pb.GameStatus_GAME_STATUS_PLAYING, 0, "nobody", ErrPlayerNotFound, 0, pb.TurnPhase_TURN_PHASE_TRADE},
+		{"during setup", pb.GameStatus_GAME_STATUS_SETUP, 0, "p1", ErrWrongPhase, 0, pb.TurnPhase_TURN_PHASE_TRADE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newState(tt.status, tt.currentTurn)
+			err := RemovePlayer(state, tt.remove)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				if len(state.Players) != 3 {
+					t.Fatalf("expected a failed removal to leave players alone")
+				}
+				return
+			}
+			if len(state.Players) != 2 || getPlayerByID(state, tt.remove) != nil {
+				t.Fatalf("expected %s removed, got %d players", tt.remove, len(state.Players))
+			}
+			if state.CurrentTurn != tt.wantTurn || state.TurnPhase != tt.wantPhase {
+				t.Errorf("expected turn %d phase %v, got %d %v", tt.wantTurn, tt.wantPhase, state.CurrentTurn, state.TurnPhase)
+			}
+			if !state.Players[0].IsHost {
+				t.Errorf("expected the first remaining player to host")
+			}
+		})
+	}
+}
+
+func TestRemovePlayer_ClearsPiecesAndReferences(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	state.CurrentTurn = 0
+	state.Board.Vertices[0].Building = &pb.Building{OwnerId: "p2", Type: pb.BuildingType_BUILDING_TYPE_CITY}
+	state.Board.Vertices[1].Building = &pb.Building{OwnerId: "p1", Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT}
+	state.Board.Edges[0].Road = &pb.Road{OwnerId: "p2"}
+	state.Players[1].KnightsPlayed = 3
+	state.LargestArmyPlayerId = ptr("p2")
+	state.Players[1].DevCards = map[int32]int32{int32(pb.DevCardType_DEV_CARD_TYPE_KNIGHT): 2}
+	deck := len(state.DevCardDeck)
+	state.PendingTrades = []*pb.TradeOffer{
+		{Id: "t1", ProposerId: "p1", TargetId: ptr("p2")},
+		{Id: "t2", ProposerId: "p1", TargetId: ptr("p3")},
+	}
+	state.RobberPhase = &pb.RobberPhase{
+		DiscardPending:  []string{"p2", "p3"},
+		DiscardRequired: map[string]int32{"p2": 4, "p3": 5},
+	}
+
+	if err := RemovePlayer(state, "p2"); err != nil {
+		t.Fatalf("RemovePlayer: %v", err)
+	}
+
+	if state.Board.Vertices[0].Building != nil || state.Board.Edges[0].Road != nil {
+		t.Error("expected removed player's pieces to leave the board")
+	}
+	if state.Board.Vertices[1].Building == nil {
+		t.Error("expected other players' pieces to stay")
+	}
+	if state.LargestArmyPlayerId != nil {
+		t.Errorf("expected largest army to be cleared, got %s", state.GetLargestArmyPlayerId())
+	}
+	if len(state.DevCardDeck) != deck+2 {
+		t.Errorf("expected 2 cards returned to the deck, got %d", len(state.DevCardDeck)-deck)
+	}
+	if len(state.PendingTrades) != 1 || state.PendingTrades[0].Id != "t2" {
+		t.Errorf("expected only trades without p2 to remain, got %v", state.PendingTrades)
+	}
+	rp := state.RobberPhase
+	if rp == nil || len(rp.DiscardPending) != 1 || rp.DiscardPending[0] != "p3" || rp.DiscardRequired["p2"] != 0 {
+		t.Errorf("expected p2 dropped from pending discards, got %+v", rp)
+	}
+}
+
+func TestRemovePlayer_LastPlayer(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice"}, []string{"p1"})
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	if err := RemovePlayer(state, "p1"); !errors.Is(err, ErrLastPlayer) {
+		t.Fatalf("expected ErrLastPlayer, got %v", err)
+	}
+
+	state.Status = pb.GameStatus_GAME_STATUS_WAITING
+	if err := RemovePlayer(state, "p1"); err != nil || len(state.Players) != 0 {
+		t.Fatalf("expected the lobby to empty, got %v with %d players", err, len(state.Players))
+	}
+}
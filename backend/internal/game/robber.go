@@ -1,30 +1,43 @@
 package game
 
 import (
-	"errors"
 	"math/rand"
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
 
+// Robber errors; each step of the robber phase belongs to one player.
+var (
+	ErrNoRobberPhase      = newRuleError(pb.ErrorCode_ERROR_CODE_NO_ROBBER_PHASE, "no robber phase active")
+	ErrDiscardNotRequired = newRuleError(pb.ErrorCode_ERROR_CODE_DISCARD_NOT_REQUIRED, "player not required to discard")
+	ErrWrongDiscard       = newRuleError(pb.ErrorCode_ERROR_CODE_WRONG_DISCARD, "incorrect discard count")
+	ErrDiscardsPending    = newRuleError(pb.ErrorCode_ERROR_CODE_DISCARDS_PENDING, "pending discards must complete first")
+	ErrNotRobber          = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_ROBBER, "not player's turn to move robber")
+	ErrNotThief           = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_ROBBER, "not thief's turn")
+	ErrInvalidHex         = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_HEX, "no such hex")
+	ErrRobberAlreadyThere = newRuleError(pb.ErrorCode_ERROR_CODE_ROBBER_ALREADY_THERE, "robber already on that hex")
+	ErrInvalidVictim      = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_VICTIM, "victim not adjacent to robber")
+	ErrVictimEmptyHanded  = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_VICTIM, "victim has no resources")
+)
+
 // DiscardCards removes the specified resources from the given player's hand for robber discards.
 func DiscardCards(state *pb.GameState, playerID string, toDiscard *pb.ResourceCount) error {
 	if state == nil || state.RobberPhase == nil {
-		return errors.New("no robber phase active")
+		return ErrNoRobberPhase
 	}
 	required, ok := state.RobberPhase.DiscardRequired[playerID]
 	if !ok || required == 0 {
-		return errors.New("player not required to discard")
+		return ErrDiscardNotRequired
 	}
 	have := countTotalResources(getPlayerByID(state, playerID).Resources)
 	request := countTotalResources(toDiscard)
 	if request != int(required) {
-		return errors.New("incorrect discard count")
+		return ErrWrongDiscard
 	}
+	p := getPlayerByID(state, playerID)
 	if request > have {
-		return errors.New("cannot discard more cards than owned")
+		return insufficientResources(toDiscard, p.Resources)
 	}
 	// Remove specified cards
-	p := getPlayerByID(state, playerID)
 	if err := removePlayerResources(p.Resources, toDiscard); err != nil {
 		return err
 	}
@@ -34,9 +47,36 @@ func DiscardCards(state *pb.GameState, playerID string, toDiscard *pb.ResourceCo
 	return nil
 }
 
+// discardAtRandom removes n cards, or all of them if fewer, picked at random
+// from p's hand.
+func discardAtRandom(p *pb.PlayerState, n int) {
+	if p.Resources == nil {
+		return
+	}
+	r := p.Resources
+	for ; n > 0; n-- {
+		counts := []*int32{&r.Wood, &r.Brick, &r.Sheep, &r.Wheat, &r.Ore}
+		total := 0
+		for _, c := range counts {
+			total += int(*c)
+		}
+		if total == 0 {
+			return
+		}
+		pick := rand.Intn(total)
+		for _, c := range counts {
+			if pick < int(*c) {
+				*c--
+				break
+			}
+			pick -= int(*c)
+		}
+	}
+}
+
 func removePlayerResources(r *pb.ResourceCount, toRemove *pb.ResourceCount) error {
 	if r == nil || toRemove == nil {
-		return errors.New("nil resource count")
+		return ErrWrongDiscard
 	}
 	fields := []struct {
 		v  *int32
@@ -49,9 +89,14 @@ func removePlayerResources(r *pb.ResourceCount, toRemove *pb.ResourceCount) erro
 		{&r.Ore, toRemove.Ore},
 	}
 	for _, f := range fields {
+		if f.rm < 0 {
+			return ErrWrongDiscard
+		}
 		if *f.v < f.rm {
-			return errors.New("not enough resources to remove")
+			return insufficientResources(toRemove, r)
 		}
+	}
+	for _, f := range fields {
 		*f.v -= f.rm
 	}
 	return nil
@@ -69,23 +114,20 @@ func removeFromSlice(s *[]string, val string) {
 
 // MoveRobber moves the robber to the specified hex if valid
 func MoveRobber(state *pb.GameState, playerID string, hex *pb.HexCoord) error {
-	if state == nil || state.Board == nil {
-		return errors.New("invalid state")
-	}
-	if state.RobberPhase == nil {
-		return errors.New("not in robber phase")
+	if state == nil || state.Board == nil || state.RobberPhase == nil {
+		return ErrNoRobberPhase
 	}
 	if len(state.RobberPhase.DiscardPending) > 0 {
-		return errors.New("pending discards must complete first")
+		return ErrDiscardsPending
 	}
 	if state.RobberPhase.MovePendingPlayerId == nil || *state.RobberPhase.MovePendingPlayerId != playerID {
-		return errors.New("not player's turn to move robber")
+		return ErrNotRobber
 	}
 	if hex == nil {
-		return errors.New("no destination specified")
+		return ErrInvalidHex
 	}
 	if state.Board.RobberHex != nil && state.Board.RobberHex.Q == hex.Q && state.Board.RobberHex.R == hex.R {
-		return errors.New("robber already on that hex")
+		return ErrRobberAlreadyThere
 	}
 	// Confirm hex exists in board
 	hexValid := false
@@ -96,7 +138,7 @@ func MoveRobber(state *pb.GameState, playerID string, hex *pb.HexCoord) error {
 		}
 	}
 	if !hexValid {
-		return errors.New("no such hex")
+		return ErrInvalidHex
 	}
 	// Move robber
 	state.Board.RobberHex = hex
@@ -113,23 +155,20 @@ func MoveRobber(state *pb.GameState, playerID string, hex *pb.HexCoord) error {
 // StealFromPlayer attempts to transfer a random card from victim to thief
 // If chooser is non-nil, use chooser(poolLen) for random selection (for testing).
 func StealFromPlayer(state *pb.GameState, thiefID, victimID string, chooser ...func(n int) int) (pb.Resource, error) {
-	if state == nil || state.Board == nil {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("invalid state")
-	}
-	if state.RobberPhase == nil {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("not in robber phase")
+	if state == nil || state.Board == nil || state.RobberPhase == nil {
+		return pb.Resource_RESOURCE_UNSPECIFIED, ErrNoRobberPhase
 	}
 	if state.RobberPhase.StealPendingPlayerId == nil || *state.RobberPhase.StealPendingPlayerId != thiefID {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("not thief's turn")
+		return pb.Resource_RESOURCE_UNSPECIFIED, ErrNotThief
 	}
 	victim := getPlayerByID(state, victimID)
 	thief := getPlayerByID(state, thiefID)
 	if victim == nil || thief == nil {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("invalid player ids")
+		return pb.Resource_RESOURCE_UNSPECIFIED, ErrPlayerNotFound
 	}
 	// Victim must be adjacent to robber hex
 	if !playerIsAdjacentToRobberHex(state, victimID) {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("victim not adjacent to robber")
+		return pb.Resource_RESOURCE_UNSPECIFIED, ErrInvalidVictim
 	}
 	// Get victim's resources
 	var resourcePool []pb.Resource
@@ -149,7 +188,7 @@ func StealFromPlayer(state *pb.GameState, thiefID, victimID string, chooser ...f
 		resourcePool = append(resourcePool, pb.Resource_RESOURCE_ORE)
 	}
 	if len(resourcePool) == 0 {
-		return pb.Resource_RESOURCE_UNSPECIFIED, errors.New("victim has no resources")
+		return pb.Resource_RESOURCE_UNSPECIFIED, ErrVictimEmptyHanded
 	}
 	var randIdx int
 	if len(chooser) > 0 && chooser[0] != nil {
@@ -217,6 +256,11 @@ func getRobberAdjacentPlayers(state *pb.GameState, excludeID string) []string {
 		if v.Building == nil || v.Building.OwnerId == excludeID {
 			continue
 		}
+		// Players with empty hands cannot be stolen from, so they would
+		// leave the thief waiting on a steal that can never happen.
+		if owner := getPlayerByID(state, v.Building.OwnerId); owner == nil || countTotalResources(owner.Resources) == 0 {
+			continue
+		}
 		for _, h := range v.AdjacentHexes {
 			if h.Q == robHex.Q && h.R == robHex.R {
 				players[v.Building.OwnerId] = struct{}{}
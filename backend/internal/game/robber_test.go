@@ -136,7 +136,7 @@ func TestMoveRobber_SetsStealPending(t *testing.T) {
 				makeRobberVertex("vic", []*pb.HexCoord{newHex}),
 			},
 		},
-		Players: []*pb.PlayerState{{Id: "thief"}, {Id: "vic"}},
+		Players: []*pb.PlayerState{{Id: "thief"}, {Id: "vic", Resources: &pb.ResourceCount{Ore: 1}}},
 		RobberPhase: &pb.RobberPhase{
 			MovePendingPlayerId: ptr("thief"),
 			DiscardRequired:     map[string]int32{},
@@ -154,6 +154,32 @@ func TestMoveRobber_SetsStealPending(t *testing.T) {
 	}
 }
 
+func TestMoveRobber_NoStealFromEmptyHands(t *testing.T) {
+	robberHex := &pb.HexCoord{Q: 0, R: 0}
+	newHex := &pb.HexCoord{Q: 1, R: 1}
+	state := &pb.GameState{
+		Board: &pb.BoardState{
+			Hexes:     []*pb.Hex{{Coord: robberHex}, {Coord: newHex}},
+			RobberHex: robberHex,
+			Vertices: []*pb.Vertex{
+				makeRobberVertex("vic", []*pb.HexCoord{newHex}),
+			},
+		},
+		Players: []*pb.PlayerState{{Id: "thief"}, {Id: "vic", Resources: &pb.ResourceCount{}}},
+		RobberPhase: &pb.RobberPhase{
+			MovePendingPlayerId: ptr("thief"),
+			DiscardRequired:     map[string]int32{},
+		},
+	}
+
+	if err := MoveRobber(state, "thief", newHex); err != nil {
+		t.Fatalf("Unexpected move error: %v", err)
+	}
+	if state.RobberPhase != nil {
+		t.Fatal("Expected the robber phase to end when no neighbour has cards to steal")
+	}
+}
+
 func TestStealFromPlayer_NotAdjacent(t *testing.T) {
 	robHex := &pb.HexCoord{Q: 1, R: 1}
 	state := &pb.GameState{
@@ -247,6 +273,30 @@ func TestDiscardCards_Errors(t *testing.T) {
 	}
 }
 
+func TestDiscardCards_RejectsNegativeCounts(t *testing.T) {
+	state := &pb.GameState{
+		Players: []*pb.PlayerState{
+			{Id: "p1", Resources: &pb.ResourceCount{Wood: 4, Ore: 1}},
+		},
+		RobberPhase: &pb.RobberPhase{
+			DiscardPending:  []string{"p1"},
+			DiscardRequired: map[string]int32{"p1": 2},
+		},
+	}
+	// Adds up to 2, but would hand p1 an extra brick.
+	if err := DiscardCards(state, "p1", &pb.ResourceCount{Wood: 3, Brick: -1}); err == nil {
+		t.Fatal("Should error for a negative count")
+	}
+	// Wood is fine but ore is short; nothing may be removed.
+	state.RobberPhase.DiscardRequired["p1"] = 3
+	if err := DiscardCards(state, "p1", &pb.ResourceCount{Wood: 1, Ore: 2}); err == nil {
+		t.Fatal("Should error for discarding ore p1 does not have")
+	}
+	if got := state.Players[0].Resources; got.Wood != 4 || got.Ore != 1 || got.Brick != 0 {
+		t.Errorf("Rejected discards changed the hand: %v", got)
+	}
+}
+
 // Test helpers
 func ptr(s string) *string { return &s }
 
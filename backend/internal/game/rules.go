@@ -272,5 +272,6 @@ func BuildGameOverPayload(state *pb.GameState, winnerID string) *pb.GameOverPayl
 	return &pb.GameOverPayload{
 		WinnerId: winnerID,
 		Scores:   scores,
+		Series:   seriesAfter(state.Series, state, winnerID),
 	}
 }
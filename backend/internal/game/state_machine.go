@@ -1,32 +1,32 @@
 package game
 
 import (
-	"errors"
-
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
 
 // Error definitions for state machine operations
 var (
-	ErrNotYourTurn              = errors.New("it's not your turn")
-	ErrWrongPhase               = errors.New("cannot perform this action in current phase")
-	ErrInvalidTurnPhase         = errors.New("invalid turn phase")
-	ErrInvalidVertex            = errors.New("invalid vertex ID")
-	ErrInvalidEdge              = errors.New("invalid edge ID")
-	ErrVertexOccupied           = errors.New("vertex is already occupied")
-	ErrEdgeOccupied             = errors.New("edge is already occupied")
-	ErrDistanceRule             = errors.New("settlement violates distance rule (must be 2+ edges from other settlements)")
-	ErrMustConnectToOwned       = errors.New("structure must connect to your existing structures")
-	ErrInsufficientResources    = errors.New("insufficient resources")
-	ErrCannotUpgrade            = errors.New("can only upgrade your own settlement to city")
-	ErrMustPlaceSettlementFirst = errors.New("must place settlement before road in setup")
-	ErrRoadMustConnectToSetup   = errors.New("road must connect to just-placed settlement")
-	ErrMaxSettlementsReached    = errors.New("maximum settlements reached")
-	ErrMaxRoadsReached          = errors.New("maximum roads reached")
-	ErrPlayerNotFound           = errors.New("player not found")
-	ErrNotHost                  = errors.New("only host can start the game")
-	ErrPlayersNotReady          = errors.New("all players must be ready")
-	ErrNotEnoughPlayers         = errors.New("not enough players to start")
+	ErrNotYourTurn              = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN, "it's not your turn")
+	ErrWrongPhase               = newRuleError(pb.ErrorCode_ERROR_CODE_WRONG_PHASE, "cannot perform this action in current phase")
+	ErrInvalidTurnPhase         = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_TURN_PHASE, "invalid turn phase")
+	ErrInvalidVertex            = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_VERTEX, "invalid vertex ID")
+	ErrInvalidEdge              = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_EDGE, "invalid edge ID")
+	ErrVertexOccupied           = newRuleError(pb.ErrorCode_ERROR_CODE_VERTEX_OCCUPIED, "vertex is already occupied")
+	ErrEdgeOccupied             = newRuleError(pb.ErrorCode_ERROR_CODE_EDGE_OCCUPIED, "edge is already occupied")
+	ErrDistanceRule             = newRuleError(pb.ErrorCode_ERROR_CODE_DISTANCE_RULE, "settlement violates distance rule (must be 2+ edges from other settlements)")
+	ErrMustConnectToOwned       = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_CONNECTED, "structure must connect to your existing structures")
+	ErrInsufficientResources    = newRuleError(pb.ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES, "insufficient resources")
+	ErrCannotUpgrade            = newRuleError(pb.ErrorCode_ERROR_CODE_CANNOT_UPGRADE, "can only upgrade your own settlement to city")
+	ErrMustPlaceSettlementFirst = newRuleError(pb.ErrorCode_ERROR_CODE_SETUP_SETTLEMENT_FIRST, "must place settlement before road in setup")
+	ErrRoadMustConnectToSetup   = newRuleError(pb.ErrorCode_ERROR_CODE_SETUP_ROAD_NOT_CONNECTED, "road must connect to just-placed settlement")
+	ErrMaxSettlementsReached    = newRuleError(pb.ErrorCode_ERROR_CODE_MAX_SETTLEMENTS, "maximum settlements reached")
+	ErrMaxRoadsReached          = newRuleError(pb.ErrorCode_ERROR_CODE_MAX_ROADS, "maximum roads reached")
+	ErrMaxCitiesReached         = newRuleError(pb.ErrorCode_ERROR_CODE_MAX_CITIES, "maximum cities reached")
+	ErrBankEmpty                = newRuleError(pb.ErrorCode_ERROR_CODE_BANK_EMPTY, "the bank does not have enough of that resource")
+	ErrPlayerNotFound           = newRuleError(pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND, "player not found")
+	ErrNotHost                  = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_HOST, "only host can start the game")
+	ErrPlayersNotReady          = newRuleError(pb.ErrorCode_ERROR_CODE_PLAYERS_NOT_READY, "all players must be ready")
+	ErrNotEnoughPlayers         = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_ENOUGH_PLAYERS, "not enough players to start")
 )
 
 // GetSetupTurnIndex returns the player index for a given turn number (0-indexed)
@@ -132,13 +132,43 @@ func EndTurn(state *pb.GameState, playerID string) error {
 	if len(state.Players) == 0 {
 		return ErrNotEnoughPlayers
 	}
+	advanceTurn(state)
+	return nil
+}
+
+// ForfeitTurn passes the current player's turn, rolled or not, to the next
+// player. Correspondence games use it for turns left past their deadline;
+// it is refused during setup. A robber step still open is settled first:
+// players owing a discard lose that many cards at random, and the robber
+// stays where it is without stealing.
+func ForfeitTurn(state *pb.GameState) error {
+	if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+		return ErrWrongPhase
+	}
+	if state.CurrentTurn < 0 || int(state.CurrentTurn) >= len(state.Players) {
+		return ErrNotYourTurn
+	}
+	if rp := state.RobberPhase; rp != nil {
+		for _, id := range rp.DiscardPending {
+			if p := getPlayerByID(state, id); p != nil {
+				discardAtRandom(p, int(rp.DiscardRequired[id]))
+			}
+		}
+		state.RobberPhase = nil
+	}
+	advanceTurn(state)
+	return nil
+}
+
+func advanceTurn(state *pb.GameState) {
+	// Free roads from Road Building are lost if not placed this turn.
+	state.Players[state.CurrentTurn].RoadBuildingRoadsRemaining = 0
 	state.CurrentTurn = (state.CurrentTurn + 1) % int32(len(state.Players))
 	state.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
 	state.Dice = []int32{0, 0}
 	ExpireOldTrades(state)
 	// Increment global turn counter
 	state.TurnCounter++
-	return nil
 }
 
 // SetTurnPhase toggles between TRADE and BUILD phases during a player's turn.
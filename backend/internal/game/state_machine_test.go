@@ -114,10 +114,13 @@ func TestSetupSecondSettlement_GrantsResources(t *testing.T) {
 	state.SetupPhase = &pb.SetupPhase{Round: 1, PlacementsInTurn: 0}
 	state.CurrentTurn = 0
 
-	// Use a different vertex for first settlement
-	firstVertex := state.Board.Vertices[0]
-	if firstVertex.Id == targetVertex.Id {
-		firstVertex = state.Board.Vertices[1]
+	// Use a vertex away from the target for the first settlement
+	var firstVertex *pb.Vertex
+	for _, v := range state.Board.Vertices {
+		if v.Id != targetVertex.Id && !verticesAdjacent(state.Board, v.Id, targetVertex.Id) {
+			firstVertex = v
+			break
+		}
 	}
 	_ = PlaceSetupSettlement(state, "p1", firstVertex.Id)
 
@@ -138,6 +141,15 @@ func TestSetupSecondSettlement_GrantsResources(t *testing.T) {
 	}
 }
 
+func verticesAdjacent(board *pb.BoardState, a, b string) bool {
+	for _, e := range board.Edges {
+		if (e.Vertices[0] == a && e.Vertices[1] == b) || (e.Vertices[0] == b && e.Vertices[1] == a) {
+			return true
+		}
+	}
+	return false
+}
+
 func TestSetupToPlayingTransition(t *testing.T) {
 	state := NewGameState("g1", "CODE", []string{"Alice", "Bob"}, []string{"p1", "p2"})
 	state.Status = pb.GameStatus_GAME_STATUS_SETUP
@@ -305,6 +317,20 @@ func TestEndTurn_AdvancesTurnAndResetsPhase(t *testing.T) {
 	}
 }
 
+func TestEndTurn_DropsUnusedFreeRoads(t *testing.T) {
+	state := NewGameState("g1", "CODE", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_BUILD
+	state.Players[0].RoadBuildingRoadsRemaining = 1
+
+	if err := EndTurn(state, "p1"); err != nil {
+		t.Fatalf("unexpected error ending turn: %v", err)
+	}
+	if got := state.Players[0].RoadBuildingRoadsRemaining; got != 0 {
+		t.Errorf("expected unused free roads to be dropped, got %d", got)
+	}
+}
+
 func TestEndTurn_DisallowsRollingPhase(t *testing.T) {
 	state := NewGameState("g1", "CODE", []string{"Alice", "Bob"}, []string{"p1", "p2"})
 	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
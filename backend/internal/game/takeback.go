@@ -0,0 +1,116 @@
+package game
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// A takeback undoes the current player's most recent logged action once
+// every other player approves it. Only actions that reveal nothing hidden
+// can be taken back, so dice rolls, steals and dev card draws never are.
+var (
+	ErrTakebackNotAllowed = newRuleError(pb.ErrorCode_ERROR_CODE_TAKEBACK_NOT_ALLOWED, "only your last build or bank trade this turn can be taken back")
+	ErrTakebackPending    = newRuleError(pb.ErrorCode_ERROR_CODE_TAKEBACK_PENDING, "a takeback is already waiting for votes")
+	ErrNoTakebackPending  = newRuleError(pb.ErrorCode_ERROR_CODE_NO_TAKEBACK_PENDING, "there is no takeback to vote on")
+	ErrOwnTakeback        = newRuleError(pb.ErrorCode_ERROR_CODE_OWN_TAKEBACK, "you cannot vote on your own takeback")
+	ErrAlreadyVoted       = newRuleError(pb.ErrorCode_ERROR_CODE_ALREADY_VOTED, "you have already approved this takeback")
+	ErrTakebackStale      = newRuleError(pb.ErrorCode_ERROR_CODE_TAKEBACK_STALE, "the players have changed since that action")
+)
+
+// takebackActions lists the client message kinds that can be taken back.
+var takebackActions = []string{"buildStructure", "bankTrade"}
+
+// TakebackAction identifies the logged event a takeback would undo.
+type TakebackAction struct {
+	Seq      int64
+	Kind     string
+	PlayerID string
+}
+
+// RequestTakeback asks the other players to undo last, which must be the
+// game's most recent move and a build or bank trade by playerID during
+// their turn that has not already been taken back.
+func RequestTakeback(state *pb.GameState, playerID string, last TakebackAction) error {
+	if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+		return ErrWrongPhase
+	}
+	current := state.CurrentTurn
+	if current < 0 || int(current) >= len(state.Players) || state.Players[current].Id != playerID {
+		return ErrNotYourTurn
+	}
+	if state.PendingTakeback != nil {
+		return ErrTakebackPending
+	}
+	if last.PlayerID != playerID || !slices.Contains(takebackActions, last.Kind) || last.Seq == state.TakenBackSeq {
+		return ErrTakebackNotAllowed
+	}
+	state.PendingTakeback = &pb.TakebackRequest{
+		RequesterId: playerID,
+		EventSeq:    last.Seq,
+		Action:      last.Kind,
+	}
+	return nil
+}
+
+// VoteTakeback records playerID's vote on the pending takeback. A rejection
+// cancels it; approved reports whether every other player has now agreed,
+// in which case the request is left in place for the caller to carry out.
+func VoteTakeback(state *pb.GameState, playerID string, approve bool) (approved bool, err error) {
+	pending := state.PendingTakeback
+	if pending == nil {
+		return false, ErrNoTakebackPending
+	}
+	if getPlayerByID(state, playerID) == nil {
+		return false, ErrPlayerNotFound
+	}
+	if playerID == pending.RequesterId {
+		return false, ErrOwnTakeback
+	}
+	if slices.Contains(pending.ApprovedPlayerIds, playerID) {
+		return false, ErrAlreadyVoted
+	}
+	if !approve {
+		state.PendingTakeback = nil
+		return false, nil
+	}
+	pending.ApprovedPlayerIds = append(pending.ApprovedPlayerIds, playerID)
+	for _, p := range state.Players {
+		if p.Id != pending.RequesterId && !slices.Contains(pending.ApprovedPlayerIds, p.Id) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// RestoreTakeback rewinds state to snapshot, the state logged just before
+// the undone action. The pause flag, chat moderation and who is connected
+// are kept, since none of them are part of the move being undone.
+func RestoreTakeback(state, snapshot *pb.GameState) error {
+	if len(state.Players) != len(snapshot.Players) {
+		return ErrTakebackStale
+	}
+	connected := make(map[string]bool, len(state.Players))
+	for i, p := range state.Players {
+		if p.Id != snapshot.Players[i].Id {
+			return ErrTakebackStale
+		}
+		connected[p.Id] = p.Connected
+	}
+	pending := state.PendingTakeback
+	paused, muted, spectatorsOff := state.Paused, state.ChatMutedPlayerIds, state.SpectatorChatDisabled
+
+	proto.Reset(state)
+	proto.Merge(state, snapshot)
+	state.Paused = paused
+	state.ChatMutedPlayerIds = muted
+	state.SpectatorChatDisabled = spectatorsOff
+	state.PendingTakeback = nil
+	state.TakenBackSeq = pending.GetEventSeq()
+	for _, p := range state.Players {
+		p.Connected = connected[p.Id]
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestRequestTakeback(t *testing.T) {
+	tests := []struct {
+		name     string
+		playerID string
+		last     TakebackAction
+		pending  bool
+		wantErr  error
+	}{
+		{"build", "p1", TakebackAction{Seq: 7, Kind: "buildStructure", PlayerID: "p1"}, false, nil},
+		{"bank trade", "p1", TakebackAction{Seq: 7, Kind: "bankTrade", PlayerID: "p1"}, false, nil},
+		{"not your turn", "p2", TakebackAction{Seq: 7, Kind: "buildStructure", PlayerID: "p2"}, false, ErrNotYourTurn},
+		{"dice roll", "p1", TakebackAction{Seq: 7, Kind: "rollDice", PlayerID: "p1"}, false, ErrTakebackNotAllowed},
+		{"steal", "p1", TakebackAction{Seq: 7, Kind: "moveRobber", PlayerID: "p1"}, false, ErrTakebackNotAllowed},
+		{"dev card draw", "p1", TakebackAction{Seq: 7, Kind: "buyDevCard", PlayerID: "p1"}, false, ErrTakebackNotAllowed},
+		{"someone else's action", "p1", TakebackAction{Seq: 7, Kind: "buildStructure", PlayerID: "p2"}, false, ErrTakebackNotAllowed},
+		{"already pending", "p1", TakebackAction{Seq: 7, Kind: "buildStructure", PlayerID: "p1"}, true, ErrTakebackPending},
+		{"already taken back", "p1", TakebackAction{Seq: 3, Kind: "buildStructure", PlayerID: "p1"}, false, ErrTakebackNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+			state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+			state.TakenBackSeq = 3
+			if tt.pending {
+				state.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", EventSeq: 5}
+			}
+			err := RequestTakeback(state, tt.playerID, tt.last)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && (state.PendingTakeback.EventSeq != tt.last.Seq || state.PendingTakeback.Action != tt.last.Kind) {
+				t.Fatalf("unexpected pending takeback %v", state.PendingTakeback)
+			}
+		})
+	}
+
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	if err := RequestTakeback(state, "p1", TakebackAction{Kind: "buildStructure", PlayerID: "p1"}); !errors.Is(err, ErrWrongPhase) {
+		t.Fatalf("expected ErrWrongPhase before the game starts, got %v", err)
+	}
+}
+
+func TestVoteTakeback(t *testing.T) {
+	newState := func() *pb.GameState {
+		state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+		state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+		state.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", EventSeq: 7, Action: "bankTrade"}
+		return state
+	}
+
+	state := newState()
+	if _, err := VoteTakeback(state, "p1", true); !errors.Is(err, ErrOwnTakeback) {
+		t.Fatalf("expected ErrOwnTakeback, got %v", err)
+	}
+	if _, err := VoteTakeback(state, "watcher", true); !errors.Is(err, ErrPlayerNotFound) {
+		t.Fatalf("expected ErrPlayerNotFound, got %v", err)
+	}
+	if approved, err := VoteTakeback(state, "p2", true); err != nil || approved {
+		t.Fatalf("expected vote recorded without approval, got %v, %v", approved, err)
+	}
+	if _, err := VoteTakeback(state, "p2", true); !errors.Is(err, ErrAlreadyVoted) {
+		t.Fatalf("expected ErrAlreadyVoted, got %v", err)
+	}
+	if approved, err := VoteTakeback(state, "p3", true); err != nil || !approved {
+		t.Fatalf("expected unanimous approval, got %v, %v", approved, err)
+	}
+
+	state = newState()
+	if approved, err := VoteTakeback(state, "p2", false); err != nil || approved {
+		t.Fatalf("expected rejection, got %v, %v", approved, err)
+	}
+	if state.PendingTakeback != nil {
+		t.Fatal("expected a rejection to cancel the takeback")
+	}
+	if _, err := VoteTakeback(state, "p3", true); !errors.Is(err, ErrNoTakebackPending) {
+		t.Fatalf("expected ErrNoTakebackPending, got %v", err)
+	}
+}
+
+func TestRestoreTakeback(t *testing.T) {
+	snapshot := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	snapshot.Players[0].Resources.Wood = 1
+	snapshot.Players[0].Connected = true
+
+	state := NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	state.Paused = true
+	state.ChatMutedPlayerIds = []string{"p2"}
+	state.Players[1].Connected = true
+	state.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", EventSeq: 7}
+
+	if err := RestoreTakeback(state, snapshot); err != nil {
+		t.Fatalf("RestoreTakeback: %v", err)
+	}
+	if state.Players[0].Resources.Wood != 1 {
+		t.Fatalf("expected resources from the snapshot, got %v", state.Players[0].Resources)
+	}
+	if !state.Paused || len(state.ChatMutedPlayerIds) != 1 || state.PendingTakeback != nil {
+		t.Fatalf("expected pause and chat settings kept and takeback cleared, got %v", state)
+	}
+	if state.TakenBackSeq != 7 {
+		t.Fatalf("expected the undone event recorded, got %d", state.TakenBackSeq)
+	}
+	if state.Players[0].Connected || !state.Players[1].Connected {
+		t.Fatal("expected connection flags kept from the current state")
+	}
+	if !snapshot.Players[0].Connected {
+		t.Fatal("expected the snapshot to be left untouched")
+	}
+
+	other := NewGameState("g1", "ABCDEF", []string{"Alice", "Dave"}, []string{"p1", "p4"})
+	if err := RestoreTakeback(state, other); !errors.Is(err, ErrTakebackStale) {
+		t.Fatalf("expected ErrTakebackStale, got %v", err)
+	}
+}
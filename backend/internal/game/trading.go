@@ -1,7 +1,9 @@
 package game
 
 import (
-	"fmt"
+	"math"
+	"slices"
+
 	"github.com/google/uuid"
 	pb "settlers_from_catan/gen/proto/catan/v1"
 )
@@ -22,12 +24,15 @@ func ProposeTrade(state *pb.GameState, proposerID string, targetID *string, offe
 	// Enforce only one active trade per proposer
 	for _, trade := range state.PendingTrades {
 		if trade.ProposerId == proposerID && trade.Status == pb.TradeStatus_TRADE_STATUS_PENDING {
-			return "", fmt.Errorf("You already have a pending trade offer")
+			return "", ErrTradeAlreadyPending
 		}
 	}
 
+	if !validTradeCounts(offering) || !validTradeCounts(requesting) {
+		return "", ErrInvalidTradeOffer
+	}
 	if !canFulfillOffer(currentPlayer.Resources, offering) {
-		return "", ErrInsufficientResources
+		return "", insufficientResources(offering, currentPlayer.Resources)
 	}
 
 	trade := &pb.TradeOffer{
@@ -54,14 +59,20 @@ func RespondTrade(state *pb.GameState, tradeID, responderID string, accept bool)
 			if t.TargetId != nil && (responderID == "" || *t.TargetId != responderID) {
 				return ErrNotTradeParticipant
 			}
+			if responderID == t.ProposerId {
+				return ErrNotTradeParticipant
+			}
 
 			from := playerByID(state, t.ProposerId)
 			to := playerByID(state, responderID)
 			if from == nil || to == nil {
 				return ErrInvalidPlayer
 			}
-			if !canFulfillOffer(from.Resources, t.Offering) || !canFulfillOffer(to.Resources, t.Requesting) {
-				return ErrInsufficientResources
+			if !canFulfillOffer(from.Resources, t.Offering) {
+				return insufficientResources(t.Offering, from.Resources)
+			}
+			if !canFulfillOffer(to.Resources, t.Requesting) {
+				return insufficientResources(t.Requesting, to.Resources)
 			}
 
 			deductOffer(from.Resources, t.Offering)
@@ -95,17 +106,22 @@ func BankTrade(state *pb.GameState, playerID string, offering *pb.ResourceCount,
 
 	offerCount, offerRes := countSingleResourceOffer(offering)
 	if offerCount <= 0 {
-		return ErrInsufficientResources
+		return ErrInvalidTradeOffer
 	}
 
 	// Get best trade ratio for this resource based on player's ports
 	requiredRatio := GetBestTradeRatio(playerID, offerRes, state.Board)
 
 	if offerCount < requiredRatio {
-		return ErrInsufficientResources
+		required := &pb.ResourceCount{}
+		addResource(required, offerRes, requiredRatio)
+		return insufficientResources(required, offering)
 	}
 	if int(playerResource(currentPlayer.Resources, offerRes)) < offerCount {
-		return ErrInsufficientResources
+		return insufficientResources(offering, currentPlayer.Resources)
+	}
+	if bankStock(state, requested) < 1 {
+		return bankEmpty(state, requested)
 	}
 
 	deductResource(currentPlayer.Resources, offerRes, offerCount)
@@ -113,8 +129,89 @@ func BankTrade(state *pb.GameState, playerID string, offering *pb.ResourceCount,
 	return nil
 }
 
+// BankTradeLines makes several bank trades at once, such as 8 wood for 2
+// ore, or 4 wood for ore and 2 sheep for brick through a sheep port. Each
+// line must give exactly its requested count times the player's best ratio
+// for the resource given. Either every line goes through or none does.
+func BankTradeLines(state *pb.GameState, playerID string, lines []*pb.BankTradeLine) error {
+	if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+		return ErrWrongPhase
+	}
+	if state.TurnPhase != pb.TurnPhase_TURN_PHASE_TRADE {
+		return ErrWrongPhase
+	}
+	currentPlayer := state.Players[state.CurrentTurn]
+	if currentPlayer.Id != playerID {
+		return ErrNotYourTurn
+	}
+	if len(lines) == 0 {
+		return ErrInvalidTradeOffer
+	}
+
+	giving, receiving := map[pb.Resource]int64{}, map[pb.Resource]int64{}
+	for _, line := range lines {
+		if !slices.Contains(allResources, line.Offering) || !slices.Contains(allResources, line.Requested) ||
+			line.OfferingCount <= 0 || line.RequestedCount <= 0 {
+			return ErrInvalidTradeOffer
+		}
+		ratio := GetBestTradeRatio(playerID, line.Offering, state.Board)
+		if required := int64(line.RequestedCount) * int64(ratio); int64(line.OfferingCount) != required {
+			if int64(line.OfferingCount) > required || required > math.MaxInt32 {
+				return ErrInvalidTradeOffer
+			}
+			want, offered := &pb.ResourceCount{}, &pb.ResourceCount{}
+			addResource(want, line.Offering, int(required))
+			addResource(offered, line.Offering, int(line.OfferingCount))
+			return insufficientResources(want, offered)
+		}
+		giving[line.Offering] += int64(line.OfferingCount)
+		receiving[line.Requested] += int64(line.RequestedCount)
+	}
+	for _, res := range allResources {
+		// Trading a resource away and back in one go only churns the bank
+		if giving[res] > 0 && receiving[res] > 0 {
+			return ErrInvalidTradeOffer
+		}
+		if giving[res] > int64(playerResource(currentPlayer.Resources, res)) {
+			required := &pb.ResourceCount{}
+			addResource(required, res, int(min(giving[res], math.MaxInt32)))
+			return insufficientResources(required, currentPlayer.Resources)
+		}
+		if stock := bankStock(state, res); receiving[res] > int64(stock) {
+			required, held := &pb.ResourceCount{}, &pb.ResourceCount{}
+			addResource(required, res, int(min(receiving[res], math.MaxInt32)))
+			setResource(held, res, stock)
+			return withCounts(ErrBankEmpty, required, held)
+		}
+	}
+
+	for _, res := range allResources {
+		deductResource(currentPlayer.Resources, res, int(giving[res]))
+		addResource(currentPlayer.Resources, res, int(receiving[res]))
+	}
+	return nil
+}
+
 // ========== Helpers ==========
 
+var allResources = []pb.Resource{
+	pb.Resource_RESOURCE_WOOD,
+	pb.Resource_RESOURCE_BRICK,
+	pb.Resource_RESOURCE_SHEEP,
+	pb.Resource_RESOURCE_WHEAT,
+	pb.Resource_RESOURCE_ORE,
+}
+
+// bankStock is how many cards of res the bank holds: the game's supply
+// less what the players hold.
+func bankStock(state *pb.GameState, res pb.Resource) int32 {
+	stock := int32(GetInitialResourceBank()[pb.TileResource(res)])
+	for _, p := range state.Players {
+		stock -= GetResourceAmount(p.Resources, res)
+	}
+	return stock
+}
+
 func playerByID(state *pb.GameState, id string) *pb.PlayerState {
 	for _, p := range state.Players {
 		if p.Id == id {
@@ -124,6 +221,12 @@ func playerByID(state *pb.GameState, id string) *pb.PlayerState {
 	return nil
 }
 
+// validTradeCounts reports whether c is present and has no negative
+// counts, which would let a trade hand out cards nobody had.
+func validTradeCounts(c *pb.ResourceCount) bool {
+	return c != nil && c.Wood >= 0 && c.Brick >= 0 && c.Sheep >= 0 && c.Wheat >= 0 && c.Ore >= 0
+}
+
 func canFulfillOffer(resources *pb.ResourceCount, offer *pb.ResourceCount) bool {
 	return resources.Wood >= offer.Wood &&
 		resources.Brick >= offer.Brick &&
@@ -215,7 +318,9 @@ func addResource(c *pb.ResourceCount, res pb.Resource, n int) {
 }
 
 var (
-	ErrInvalidPlayer       = fmt.Errorf("Invalid player")
-	ErrTradeNotFound       = fmt.Errorf("Trade not found")
-	ErrNotTradeParticipant = fmt.Errorf("Not a participant in this trade")
+	ErrInvalidPlayer       = newRuleError(pb.ErrorCode_ERROR_CODE_PLAYER_NOT_FOUND, "Invalid player")
+	ErrTradeNotFound       = newRuleError(pb.ErrorCode_ERROR_CODE_TRADE_NOT_FOUND, "Trade not found")
+	ErrNotTradeParticipant = newRuleError(pb.ErrorCode_ERROR_CODE_NOT_TRADE_PARTICIPANT, "Not a participant in this trade")
+	ErrInvalidTradeOffer   = newRuleError(pb.ErrorCode_ERROR_CODE_INVALID_TRADE, "Invalid trade offer")
+	ErrTradeAlreadyPending = newRuleError(pb.ErrorCode_ERROR_CODE_TRADE_ALREADY_PENDING, "You already have a pending trade offer")
 )
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"settlers_from_catan/gen/proto/catan/v1"
 	"testing"
 )
@@ -99,6 +100,42 @@ func TestRespondTrade_AcceptDecline(t *testing.T) {
 	}
 }
 
+func TestProposeTrade_RejectsNegativeCounts(t *testing.T) {
+	state := basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 1}), makePlayer("them", &catanv1.ResourceCount{Sheep: 3}))
+	tests := []struct {
+		name                 string
+		offering, requesting *catanv1.ResourceCount
+	}{
+		{"negative offer", &catanv1.ResourceCount{Wood: 1, Ore: -5}, &catanv1.ResourceCount{Sheep: 1}},
+		{"negative request", &catanv1.ResourceCount{Wood: 1}, &catanv1.ResourceCount{Sheep: -2}},
+		{"missing request", &catanv1.ResourceCount{Wood: 1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ProposeTrade(state, "me", nil, tt.offering, tt.requesting); err != ErrInvalidTradeOffer {
+				t.Errorf("got %v, want ErrInvalidTradeOffer", err)
+			}
+		})
+	}
+	if len(state.PendingTrades) != 0 {
+		t.Errorf("rejected offers were recorded: %v", state.PendingTrades)
+	}
+}
+
+func TestRespondTrade_ProposerCannotAccept(t *testing.T) {
+	state := basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 2, Sheep: 2}), makePlayer("them", &catanv1.ResourceCount{Sheep: 3}))
+	id, err := ProposeTrade(state, "me", nil, &catanv1.ResourceCount{Wood: 2}, &catanv1.ResourceCount{Sheep: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RespondTrade(state, id, "me", true); err != ErrNotTradeParticipant {
+		t.Errorf("got %v, want ErrNotTradeParticipant", err)
+	}
+	if state.PendingTrades[0].Status != catanv1.TradeStatus_TRADE_STATUS_PENDING {
+		t.Errorf("trade status = %v, want pending", state.PendingTrades[0].Status)
+	}
+}
+
 func TestBankTrade(t *testing.T) {
 	state := basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 4}))
 	state.Board = GenerateBoard()
@@ -120,6 +157,16 @@ func TestBankTrade(t *testing.T) {
 	if err == nil {
 		t.Error("Allowed bank trade with insufficient resources")
 	}
+	// Bank has no brick left
+	state = basicGameState(
+		makePlayer("me", &catanv1.ResourceCount{Wood: 4}),
+		makePlayer("other", &catanv1.ResourceCount{Brick: 19}),
+	)
+	state.Board = GenerateBoard()
+	err = BankTrade(state, "me", &catanv1.ResourceCount{Wood: 4}, catanv1.Resource_RESOURCE_BRICK)
+	if !errors.Is(err, ErrBankEmpty) {
+		t.Errorf("expected ErrBankEmpty, got %v", err)
+	}
 }
 
 func TestBankTradeWithGenericPort(t *testing.T) {
@@ -236,6 +283,134 @@ func TestBankTradeSpecificPortWrongResource(t *testing.T) {
 	}
 }
 
+// settleOnPort gives "me" a settlement on the first port of the given type
+// and resource.
+func settleOnPort(t *testing.T, state *catanv1.GameState, portType catanv1.PortType, res catanv1.Resource) {
+	t.Helper()
+	for _, p := range state.Board.Ports {
+		if p.Type != portType || (portType == catanv1.PortType_PORT_TYPE_SPECIFIC && p.Resource != res) {
+			continue
+		}
+		for _, v := range state.Board.Vertices {
+			if v.Id == p.Location[0] {
+				v.Building = &catanv1.Building{Type: catanv1.BuildingType_BUILDING_TYPE_SETTLEMENT, OwnerId: "me"}
+				return
+			}
+		}
+	}
+	t.Fatalf("no %v %v port on board", portType, res)
+}
+
+func TestBankTradeLines(t *testing.T) {
+	// 8 wood for 2 ore at the default 4:1 ratio
+	state := basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 8}))
+	state.Board = GenerateBoard()
+	err := BankTradeLines(state, "me", []*catanv1.BankTradeLine{
+		{Offering: catanv1.Resource_RESOURCE_WOOD, OfferingCount: 8, Requested: catanv1.Resource_RESOURCE_ORE, RequestedCount: 2},
+	})
+	if err != nil {
+		t.Fatalf("BankTradeLines failed: %v", err)
+	}
+	if got := state.Players[0].Resources; got.Wood != 0 || got.Ore != 2 {
+		t.Errorf("expected 8 wood turned into 2 ore, got %v", got)
+	}
+
+	// 4 wood and 2 sheep through a sheep port for two different cards
+	state = basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 5, Sheep: 2}))
+	state.Board = GenerateBoard()
+	settleOnPort(t, state, catanv1.PortType_PORT_TYPE_SPECIFIC, catanv1.Resource_RESOURCE_SHEEP)
+	err = BankTradeLines(state, "me", []*catanv1.BankTradeLine{
+		{Offering: catanv1.Resource_RESOURCE_WOOD, OfferingCount: 4, Requested: catanv1.Resource_RESOURCE_ORE, RequestedCount: 1},
+		{Offering: catanv1.Resource_RESOURCE_SHEEP, OfferingCount: 2, Requested: catanv1.Resource_RESOURCE_BRICK, RequestedCount: 1},
+	})
+	if err != nil {
+		t.Fatalf("BankTradeLines with a sheep port failed: %v", err)
+	}
+	if got := state.Players[0].Resources; got.Wood != 1 || got.Sheep != 0 || got.Ore != 1 || got.Brick != 1 {
+		t.Errorf("expected wood and sheep turned into ore and brick, got %v", got)
+	}
+
+	// Lines may request the same resource
+	state = basicGameState(makePlayer("me", &catanv1.ResourceCount{Wood: 3, Wheat: 3}))
+	state.Board = GenerateBoard()
+	settleOnPort(t, state, catanv1.PortType_PORT_TYPE_GENERIC, catanv1.Resource_RESOURCE_UNSPECIFIED)
+	err = BankTradeLines(state, "me", []*catanv1.BankTradeLine{
+		{Offering: catanv1.Resource_RESOURCE_WOOD, OfferingCount: 3, Requested: catanv1.Resource_RESOURCE_ORE, RequestedCount: 1},
+		{Offering: catanv1.Resource_RESOURCE_WHEAT, OfferingCount: 3, Requested: catanv1.Resource_RESOURCE_ORE, RequestedCount: 1},
+	})
+	if err != nil {
+		t.Fatalf("BankTradeLines with a generic port failed: %v", err)
+	}
+	if got := state.Players[0].Resources; got.Wood != 0 || got.Wheat != 0 || got.Ore != 2 {
+		t.Errorf("expected wood and wheat turned into 2 ore, got %v", got)
+	}
+}
+
+func TestBankTradeLines_Rejections(t *testing.T) {
+	line := func(give catanv1.Resource, giveCount int32, get catanv1.Resource, getCount int32) *catanv1.BankTradeLine {
+		return &catanv1.BankTradeLine{Offering: give, OfferingCount: giveCount, Requested: get, RequestedCount: getCount}
+	}
+	wood, sheep, ore := catanv1.Resource_RESOURCE_WOOD, catanv1.Resource_RESOURCE_SHEEP, catanv1.Resource_RESOURCE_ORE
+	tests := map[string]struct {
+		lines   []*catanv1.BankTradeLine
+		ore     int32 // held by another player, draining the bank
+		wantErr error
+	}{
+		"no lines": {
+			wantErr: ErrInvalidTradeOffer,
+		},
+		"unspecified resource": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, catanv1.Resource_RESOURCE_UNSPECIFIED, 1)},
+			wantErr: ErrInvalidTradeOffer,
+		},
+		"nothing requested": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, ore, 0)},
+			wantErr: ErrInvalidTradeOffer,
+		},
+		"under the ratio": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, ore, 1), line(sheep, 3, ore, 1)},
+			wantErr: ErrInsufficientResources,
+		},
+		"over the ratio": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 5, ore, 1)},
+			wantErr: ErrInvalidTradeOffer,
+		},
+		"more than held across lines": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, ore, 1), line(wood, 4, sheep, 1)},
+			wantErr: ErrInsufficientResources,
+		},
+		"same resource given and received": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, sheep, 1), line(sheep, 4, ore, 1)},
+			wantErr: ErrInvalidTradeOffer,
+		},
+		"bank short of the total": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 4, ore, 1), line(sheep, 4, ore, 1)},
+			ore:     18,
+			wantErr: ErrBankEmpty,
+		},
+		"overflowing counts": {
+			lines:   []*catanv1.BankTradeLine{line(wood, 2147483644, ore, 536870911), line(wood, 2147483644, ore, 536870911)},
+			wantErr: ErrInsufficientResources,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := basicGameState(
+				makePlayer("me", &catanv1.ResourceCount{Wood: 6, Sheep: 4}),
+				makePlayer("other", &catanv1.ResourceCount{Ore: tt.ore}),
+			)
+			state.Board = GenerateBoard()
+			err := BankTradeLines(state, "me", tt.lines)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if got := state.Players[0].Resources; got.Wood != 6 || got.Sheep != 4 || got.Ore != 0 {
+				t.Errorf("expected a rejected trade to change nothing, got %v", got)
+			}
+		})
+	}
+}
+
 func TestExpireOldTrades(t *testing.T) {
 	tests := map[string]struct {
 		trades      []*catanv1.TradeOffer
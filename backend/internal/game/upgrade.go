@@ -0,0 +1,63 @@
+package game
+
+import (
+	"fmt"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// CurrentStateVersion is the state_version written by NewGameState. Bump it
+// together with a new entry in stateUpgrades whenever stored states need
+// fixing up to satisfy the rule functions.
+const CurrentStateVersion = 1
+
+// stateUpgrades[i] upgrades a state from version i to version i+1.
+var stateUpgrades = []func(state *pb.GameState) error{
+	upgradeToV1,
+}
+
+// UpgradeState brings a stored state up to CurrentStateVersion, applying
+// each upgrade in order. It reports whether the state was changed, and
+// refuses states written by a newer server.
+func UpgradeState(state *pb.GameState) (bool, error) {
+	if state == nil {
+		return false, fmt.Errorf("nil game state")
+	}
+	if state.StateVersion > CurrentStateVersion {
+		return false, fmt.Errorf("game state version %d is newer than supported version %d", state.StateVersion, CurrentStateVersion)
+	}
+	if state.StateVersion < 0 {
+		return false, fmt.Errorf("invalid game state version %d", state.StateVersion)
+	}
+	upgraded := false
+	for state.StateVersion < CurrentStateVersion {
+		if err := stateUpgrades[state.StateVersion](state); err != nil {
+			return upgraded, fmt.Errorf("upgrading game state from version %d: %w", state.StateVersion, err)
+		}
+		state.StateVersion++
+		upgraded = true
+	}
+	return upgraded, nil
+}
+
+// upgradeToV1 fills in what states saved before versioning may lack: player
+// resource counts, the dice pair, and a dev card deck for games that never
+// had one.
+func upgradeToV1(state *pb.GameState) error {
+	dealt := false
+	for _, p := range state.Players {
+		if p.Resources == nil {
+			p.Resources = &pb.ResourceCount{}
+		}
+		if p.KnightsPlayed > 0 || p.DevCardCount > 0 || p.VictoryPointCards > 0 || len(p.DevCards) > 0 {
+			dealt = true
+		}
+	}
+	if len(state.Dice) != 2 {
+		state.Dice = []int32{0, 0}
+	}
+	if len(state.DevCardDeck) == 0 && !dealt && state.Status != pb.GameStatus_GAME_STATUS_FINISHED {
+		state.DevCardDeck = InitDevCardDeck()
+	}
+	return nil
+}
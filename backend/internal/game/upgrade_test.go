@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func TestNewGameStateIsCurrentVersion(t *testing.T) {
+	state := NewGameState("g1", "ABCDEF", []string{"Alice"}, []string{"p1"})
+	if state.StateVersion != CurrentStateVersion {
+		t.Fatalf("expected version %d, got %d", CurrentStateVersion, state.StateVersion)
+	}
+	if upgraded, err := UpgradeState(state); err != nil || upgraded {
+		t.Fatalf("expected current state to be left alone, got upgraded=%v err=%v", upgraded, err)
+	}
+}
+
+func TestUpgradeState(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *pb.GameState
+		wantErr  bool
+		wantDeck bool
+	}{
+		{
+			name: "unversioned lobby gets resources, dice and deck",
+			state: &pb.GameState{
+				Status:  pb.GameStatus_GAME_STATUS_WAITING,
+				Players: []*pb.PlayerState{{Id: "p1"}, {Id: "p2", Resources: &pb.ResourceCount{Wood: 2}}},
+			},
+			wantDeck: true,
+		},
+		{
+			name: "unversioned game with dealt cards keeps empty deck",
+			state: &pb.GameState{
+				Status:  pb.GameStatus_GAME_STATUS_PLAYING,
+				Dice:    []int32{3, 4},
+				Players: []*pb.PlayerState{{Id: "p1", KnightsPlayed: 1}},
+			},
+		},
+		{
+			name:    "newer version is rejected",
+			state:   &pb.GameState{StateVersion: CurrentStateVersion + 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgraded, err := UpgradeState(tt.state)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil || !upgraded {
+				t.Fatalf("expected upgrade, got upgraded=%v err=%v", upgraded, err)
+			}
+			if tt.state.StateVersion != CurrentStateVersion {
+				t.Errorf("expected version %d, got %d", CurrentStateVersion, tt.state.StateVersion)
+			}
+			for _, p := range tt.state.Players {
+				if p.Resources == nil {
+					t.Errorf("expected %s to have a resource count", p.Id)
+				}
+			}
+			if len(tt.state.Dice) != 2 {
+				t.Errorf("expected two dice, got %v", tt.state.Dice)
+			}
+			if got := len(tt.state.DevCardDeck) == 25; got != tt.wantDeck {
+				t.Errorf("expected full deck %v, got %d cards", tt.wantDeck, len(tt.state.DevCardDeck))
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package game
+
+import (
+	"fmt"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// Violation is an invariant a game state breaks. Rule names the invariant
+// and stays stable so violations can be counted and filtered; Detail says
+// where it broke.
+type Violation struct {
+	Rule   string
+	Detail string
+}
+
+func (v Violation) String() string {
+	return v.Rule + ": " + v.Detail
+}
+
+// Validate checks that state is internally consistent and returns every
+// broken invariant, or nil. Commands should never produce a state that
+// fails; a violation is a bug in the game package or a corrupted save.
+func Validate(state *pb.GameState) []Violation {
+	if state == nil {
+		return []Violation{{"nil_state", "state is nil"}}
+	}
+	var out []Violation
+	add := func(rule, format string, args ...any) {
+		out = append(out, Violation{rule, fmt.Sprintf(format, args...)})
+	}
+
+	players := map[string]*pb.PlayerState{}
+	for _, p := range state.Players {
+		if players[p.Id] != nil {
+			add("duplicate_player", "player %s is seated twice", p.Id)
+		}
+		players[p.Id] = p
+	}
+	known := func(id string) bool { return players[id] != nil }
+
+	// Turn bookkeeping.
+	if len(state.Players) > 0 && (state.CurrentTurn < 0 || int(state.CurrentTurn) >= len(state.Players)) {
+		add("turn_index", "current turn %d is outside %d players", state.CurrentTurn, len(state.Players))
+	}
+	if state.TurnCounter < 0 {
+		add("turn_index", "turn counter is %d", state.TurnCounter)
+	}
+	if (state.SetupPhase != nil) != (state.Status == pb.GameStatus_GAME_STATUS_SETUP) {
+		add("setup_phase", "setup phase present=%t while status is %s", state.SetupPhase != nil, state.Status)
+	}
+	for _, d := range state.Dice {
+		if d < 0 || d > 6 {
+			add("dice", "die shows %d", d)
+		}
+	}
+
+	// Cards: no negative counts, and players cannot hold more of a resource
+	// than the game has.
+	held := map[pb.Resource]int32{}
+	for _, p := range state.Players {
+		for _, r := range allResources {
+			n := GetResourceAmount(p.GetResources(), r)
+			if n < 0 {
+				add("negative_resources", "player %s has %d %s", p.Id, n, r)
+			}
+			held[r] += n
+		}
+	}
+	for _, r := range allResources {
+		if supply := int32(GetInitialResourceBank()[pb.TileResource(r)]); held[r] > supply {
+			add("resource_supply", "players hold %d %s, more than the %d in the game", held[r], r, supply)
+		}
+	}
+
+	validateDevCards(state, add)
+
+	// Pieces and placement.
+	if state.Board != nil {
+		settlements, cities, roads := map[string]int{}, map[string]int{}, map[string]int{}
+		built := map[string]bool{}
+		for _, v := range state.Board.Vertices {
+			b := v.Building
+			if b == nil {
+				continue
+			}
+			built[v.Id] = true
+			if !known(b.OwnerId) {
+				add("unknown_owner", "vertex %s is owned by unseated player %q", v.Id, b.OwnerId)
+			}
+			switch b.Type {
+			case pb.BuildingType_BUILDING_TYPE_SETTLEMENT:
+				settlements[b.OwnerId]++
+			case pb.BuildingType_BUILDING_TYPE_CITY:
+				cities[b.OwnerId]++
+			}
+		}
+		for _, e := range state.Board.Edges {
+			if len(e.Vertices) == 2 && built[e.Vertices[0]] && built[e.Vertices[1]] {
+				add("distance_rule", "vertices %s and %s are both built on", e.Vertices[0], e.Vertices[1])
+			}
+			if e.Road == nil {
+				continue
+			}
+			roads[e.Road.OwnerId]++
+			if !known(e.Road.OwnerId) {
+				add("unknown_owner", "edge %s is owned by unseated player %q", e.Id, e.Road.OwnerId)
+			}
+		}
+		for _, p := range state.Players {
+			id := p.Id
+			if settlements[id] > GetMaxSettlements() {
+				add("piece_limit", "player %s has %d settlements", id, settlements[id])
+			}
+			if cities[id] > GetMaxCities() {
+				add("piece_limit", "player %s has %d cities", id, cities[id])
+			}
+			if roads[id] > GetMaxRoads() {
+				add("piece_limit", "player %s has %d roads", id, roads[id])
+			}
+			want := CalculateVictoryPoints(settlements[id], cities[id], false, false, 0)
+			if got := int(p.VictoryPoints); got != want {
+				add("victory_points", "player %s shows %d points but their buildings are worth %d", id, got, want)
+			}
+		}
+	}
+
+	// Bonuses and the robber.
+	if id := state.LongestRoadPlayerId; id != nil && !known(*id) {
+		add("unknown_owner", "longest road is held by unseated player %q", *id)
+	}
+	if id := state.LargestArmyPlayerId; id != nil && !known(*id) {
+		add("unknown_owner", "largest army is held by unseated player %q", *id)
+	}
+	if state.Board != nil && state.Board.RobberHex != nil && !hexExists(state.Board, state.Board.RobberHex) {
+		add("robber", "robber is on missing hex %d,%d", state.Board.RobberHex.Q, state.Board.RobberHex.R)
+	}
+	validateRobberPhase(state, known, add)
+
+	return out
+}
+
+// validateDevCards checks hand counts against each other and against the
+// deck. Played cards other than knights and victory points are not tracked,
+// so per-type totals can only be bounded, not matched.
+func validateDevCards(state *pb.GameState, add func(rule, format string, args ...any)) {
+	seen := map[pb.DevCardType]int32{}
+	for _, c := range state.DevCardDeck {
+		seen[c]++
+	}
+	current := ""
+	if len(state.Players) > 0 && state.CurrentTurn >= 0 && int(state.CurrentTurn) < len(state.Players) {
+		current = state.Players[state.CurrentTurn].Id
+	}
+	for _, p := range state.Players {
+		var total int32
+		for card, n := range p.DevCards {
+			if n < 0 {
+				add("dev_cards", "player %s has %d of card %d", p.Id, n, card)
+			}
+			total += n
+			if pb.DevCardType(card) != pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT {
+				seen[pb.DevCardType(card)] += n
+			}
+		}
+		if total != p.DevCardCount {
+			add("dev_cards", "player %s holds %d cards but the count says %d", p.Id, total, p.DevCardCount)
+		}
+		if p.VictoryPointCards < p.DevCards[int32(pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT)] {
+			add("dev_cards", "player %s holds more victory point cards than they have scored", p.Id)
+		}
+		seen[pb.DevCardType_DEV_CARD_TYPE_VICTORY_POINT] += p.VictoryPointCards
+		seen[pb.DevCardType_DEV_CARD_TYPE_KNIGHT] += p.KnightsPlayed
+		if p.RoadBuildingRoadsRemaining < 0 || p.RoadBuildingRoadsRemaining > 2 {
+			add("road_building", "player %s has %d free roads", p.Id, p.RoadBuildingRoadsRemaining)
+		} else if p.RoadBuildingRoadsRemaining > 0 && p.Id != current {
+			add("road_building", "player %s kept %d free roads past their turn", p.Id, p.RoadBuildingRoadsRemaining)
+		}
+	}
+	for _, c := range devCardCounts {
+		if n := seen[c.card]; n > int32(c.count) {
+			add("dev_cards", "%d %s cards are in play, more than the %d in the game", n, c.card, c.count)
+		}
+	}
+}
+
+// validateRobberPhase checks that a pending robber step refers to seated
+// players and happens in order: discards, then the move, then the steal.
+func validateRobberPhase(state *pb.GameState, known func(string) bool, add func(rule, format string, args ...any)) {
+	rp := state.RobberPhase
+	if rp == nil {
+		return
+	}
+	if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+		add("robber", "robber phase is active while status is %s", state.Status)
+	}
+	pending := map[string]bool{}
+	for _, id := range rp.DiscardPending {
+		pending[id] = true
+		if !known(id) {
+			add("robber", "unseated player %q must discard", id)
+		}
+		if rp.DiscardRequired[id] <= 0 {
+			add("robber", "player %s must discard but owes %d cards", id, rp.DiscardRequired[id])
+		}
+	}
+	for id := range rp.DiscardRequired {
+		if !pending[id] {
+			add("robber", "player %s owes a discard but is not pending", id)
+		}
+	}
+	move, steal := rp.MovePendingPlayerId, rp.StealPendingPlayerId
+	if move != nil && steal != nil {
+		add("robber", "robber move and steal are pending at once")
+	}
+	if steal != nil && len(rp.DiscardPending) > 0 {
+		add("robber", "steal is pending before discards finished")
+	}
+	if len(state.Players) > 0 && state.CurrentTurn >= 0 && int(state.CurrentTurn) < len(state.Players) {
+		current := state.Players[state.CurrentTurn].Id
+		for _, id := range []*string{move, steal} {
+			if id != nil && *id != current {
+				add("robber", "player %s holds the robber on %s's turn", *id, current)
+			}
+		}
+	}
+	if move == nil && steal == nil && len(rp.DiscardPending) == 0 {
+		add("robber", "robber phase has nothing pending")
+	}
+}
+
+func hexExists(board *pb.BoardState, coord *pb.HexCoord) bool {
+	for _, h := range board.Hexes {
+		if h.Coord != nil && h.Coord.Q == coord.Q && h.Coord.R == coord.R {
+			return true
+		}
+	}
+	return false
+}
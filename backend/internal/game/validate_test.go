@@ -0,0 +1,217 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+func violationRules(vs []Violation) []string {
+	var rules []string
+	for _, v := range vs {
+		if !slices.Contains(rules, v.Rule) {
+			rules = append(rules, v.Rule)
+		}
+	}
+	return rules
+}
+
+func TestValidate(t *testing.T) {
+	settle := func(state *pb.GameState, vertexID, owner string, city bool) {
+		typ := pb.BuildingType_BUILDING_TYPE_SETTLEMENT
+		if city {
+			typ = pb.BuildingType_BUILDING_TYPE_CITY
+		}
+		for _, v := range state.Board.Vertices {
+			if v.Id == vertexID {
+				v.Building = &pb.Building{Type: typ, OwnerId: owner}
+			}
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(state *pb.GameState)
+		want   []string
+	}{
+		{"fresh game", func(state *pb.GameState) {}, nil},
+		{"negative resources", func(state *pb.GameState) {
+			state.Players[0].Resources.Wood = -1
+		}, []string{"negative_resources"}},
+		{"more cards than the game has", func(state *pb.GameState) {
+			state.Players[0].Resources.Ore = 12
+			state.Players[1].Resources.Ore = 8
+		}, []string{"resource_supply"}},
+		{"turn index out of range", func(state *pb.GameState) {
+			state.CurrentTurn = 2
+		}, []string{"turn_index"}},
+		{"setup phase after setup", func(state *pb.GameState) {
+			state.SetupPhase = &pb.SetupPhase{Round: 2}
+		}, []string{"setup_phase"}},
+		{"adjacent buildings", func(state *pb.GameState) {
+			e := state.Board.Edges[0]
+			settle(state, e.Vertices[0], "p1", false)
+			settle(state, e.Vertices[1], "p2", false)
+			state.Players[0].VictoryPoints = 1
+			state.Players[1].VictoryPoints = 1
+		}, []string{"distance_rule"}},
+		{"victory points drift from buildings", func(state *pb.GameState) {
+			settle(state, state.Board.Vertices[0].Id, "p1", true)
+			state.Players[0].VictoryPoints = 1
+		}, []string{"victory_points"}},
+		{"too many cities", func(state *pb.GameState) {
+			placed := 0
+			for _, v := range state.Board.Vertices {
+				if placed < 5 && !violatesDistanceRule(state.Board, v.Id) {
+					settle(state, v.Id, "p1", true)
+					placed++
+				}
+			}
+			state.Players[0].VictoryPoints = 10
+		}, []string{"piece_limit"}},
+		{"building owned by nobody seated", func(state *pb.GameState) {
+			settle(state, state.Board.Vertices[0].Id, "ghost", false)
+		}, []string{"unknown_owner"}},
+		{"free roads kept past the turn", func(state *pb.GameState) {
+			state.Players[1].RoadBuildingRoadsRemaining = 2
+		}, []string{"road_building"}},
+		{"dev card count disagrees with hand", func(state *pb.GameState) {
+			state.Players[0].DevCards = map[int32]int32{int32(pb.DevCardType_DEV_CARD_TYPE_KNIGHT): 2}
+			state.Players[0].DevCardCount = 1
+		}, []string{"dev_cards"}},
+		{"more knights than the deck had", func(state *pb.GameState) {
+			state.Players[0].KnightsPlayed = 15
+		}, []string{"dev_cards"}},
+		{"steal before discards", func(state *pb.GameState) {
+			p1 := "p1"
+			state.RobberPhase = &pb.RobberPhase{
+				DiscardPending:       []string{"p2"},
+				DiscardRequired:      map[string]int32{"p2": 4},
+				StealPendingPlayerId: &p1,
+			}
+		}, []string{"robber"}},
+		{"robber held by another player", func(state *pb.GameState) {
+			p2 := "p2"
+			state.RobberPhase = &pb.RobberPhase{MovePendingPlayerId: &p2}
+		}, []string{"robber"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := createPlayingGameState(2)
+			for _, p := range state.Players {
+				p.Resources = &pb.ResourceCount{}
+			}
+			tt.mutate(state)
+			if got := violationRules(Validate(state)); !slices.Equal(got, tt.want) {
+				t.Errorf("got rules %v, want %v (%v)", got, tt.want, Validate(state))
+			}
+		})
+	}
+}
+
+// TestValidate_HoldsThroughSeededGames plays seeded games with simple bots
+// and checks the invariants after every command.
+func TestValidate_HoldsThroughSeededGames(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		state := NewGameStateFromSeed("g1", "CODE", []string{"A", "B", "C"}, []string{"p1", "p2", "p3"}, seed)
+		rng := rand.New(rand.NewSource(seed))
+		check := func(step string) {
+			t.Helper()
+			if vs := Validate(state); len(vs) > 0 {
+				t.Fatalf("seed %d, turn %d, after %s: %v", seed, state.TurnCounter, step, vs)
+			}
+		}
+		firstOK := func(ids []string, try func(string) error) {
+			for _, id := range ids {
+				if try(id) == nil {
+					return
+				}
+			}
+		}
+		vertexIDs := make([]string, len(state.Board.Vertices))
+		for i, v := range state.Board.Vertices {
+			vertexIDs[i] = v.Id
+		}
+		edgeIDs := make([]string, len(state.Board.Edges))
+		for i, e := range state.Board.Edges {
+			edgeIDs[i] = e.Id
+		}
+
+		for _, p := range state.Players {
+			if err := SetPlayerReady(state, p.Id, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := StartGame(state, "p1"); err != nil {
+			t.Fatal(err)
+		}
+		for state.Status == pb.GameStatus_GAME_STATUS_SETUP {
+			id := state.Players[state.CurrentTurn].Id
+			rng.Shuffle(len(vertexIDs), func(i, j int) { vertexIDs[i], vertexIDs[j] = vertexIDs[j], vertexIDs[i] })
+			firstOK(vertexIDs, func(v string) error { return PlaceSetupSettlement(state, id, v) })
+			check("setup settlement")
+			firstOK(edgeIDs, func(e string) error { return PlaceSetupRoad(state, id, e) })
+			check("setup road")
+		}
+
+		for state.Status == pb.GameStatus_GAME_STATUS_PLAYING && state.TurnCounter < 300 {
+			id := state.Players[state.CurrentTurn].Id
+			if _, err := PerformDiceRollWithValues(state, id, rng.Intn(6)+1, rng.Intn(6)+1); err != nil {
+				t.Fatalf("seed %d: roll: %v", seed, err)
+			}
+			check("roll")
+			if rp := state.RobberPhase; rp != nil {
+				for _, victim := range append([]string(nil), rp.DiscardPending...) {
+					have := getPlayerByID(state, victim).Resources
+					discard := &pb.ResourceCount{}
+					for n := rp.DiscardRequired[victim]; n > 0; n-- {
+						for _, r := range allResources {
+							if GetResourceAmount(have, r) > GetResourceAmount(discard, r) {
+								AddResource(discard, r, 1)
+								break
+							}
+						}
+					}
+					if err := DiscardCards(state, victim, discard); err != nil {
+						t.Fatalf("seed %d: discard: %v", seed, err)
+					}
+					check("discard")
+				}
+				for _, h := range state.Board.Hexes {
+					if MoveRobber(state, id, h.Coord) == nil {
+						break
+					}
+				}
+				check("move robber")
+				if state.RobberPhase != nil {
+					for _, victim := range getRobberAdjacentPlayers(state, id) {
+						if _, err := StealFromPlayer(state, id, victim, rng.Intn); err == nil {
+							break
+						}
+					}
+					check("steal")
+				}
+			}
+
+			_ = SetTurnPhase(state, id, pb.TurnPhase_TURN_PHASE_BUILD)
+			rng.Shuffle(len(vertexIDs), func(i, j int) { vertexIDs[i], vertexIDs[j] = vertexIDs[j], vertexIDs[i] })
+			rng.Shuffle(len(edgeIDs), func(i, j int) { edgeIDs[i], edgeIDs[j] = edgeIDs[j], edgeIDs[i] })
+			firstOK(vertexIDs, func(v string) error { return PlaceCity(state, id, v) })
+			firstOK(vertexIDs, func(v string) error { return PlaceSettlement(state, id, v) })
+			firstOK(edgeIDs, func(e string) error { return PlaceRoad(state, id, e) })
+			if _, err := BuyDevCard(state, id); err == nil {
+				check("buy dev card")
+			}
+			check("build")
+			if state.Status != pb.GameStatus_GAME_STATUS_PLAYING {
+				break
+			}
+			if err := EndTurn(state, id); err != nil {
+				t.Fatalf("seed %d: end turn: %v", seed, err)
+			}
+			check("end turn")
+		}
+	}
+}
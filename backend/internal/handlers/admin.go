@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+type adminPlayer struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Connected bool   `json:"connected"`
+	IsHost    bool   `json:"isHost"`
+}
+
+type adminGameSummary struct {
+	ID          string        `json:"id"`
+	Code        string        `json:"code"`
+	Status      string        `json:"status"`
+	Paused      bool          `json:"paused"`
+	TurnCounter int32         `json:"turnCounter"`
+	Players     []adminPlayer `json:"players"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+type auditEntry struct {
+	ID         int64     `json:"id" db:"id"`
+	Action     string    `json:"action" db:"action"`
+	GameID     string    `json:"gameId,omitempty" db:"game_id"`
+	Target     string    `json:"target,omitempty" db:"target"`
+	Details    string    `json:"details,omitempty" db:"details"`
+	Actor      string    `json:"actor,omitempty" db:"actor"`
+	RemoteAddr string    `json:"remoteAddr" db:"remote_addr"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+}
+
+// SetAdminToken enables the admin API for requests carrying
+// "Authorization: Bearer <token>". An empty token disables it.
+func (h *Handler) SetAdminToken(token string) {
+	h.adminToken = token
+}
+
+// HandleAdmin serves the admin API under /api/admin/:
+//
+//	GET    /api/admin/games                          list games
+//	GET    /api/admin/games/{id}                     full unredacted state
+//	POST   /api/admin/games/{id}/end                 force the game to finish, unrated
+//	POST   /api/admin/games/{id}/pause               refuse commands until resumed
+//	POST   /api/admin/games/{id}/resume
+//	POST   /api/admin/games/{id}/rollback            {"seq": N} restore the state after event N
+//	DELETE /api/admin/games/{id}/players/{playerId}  remove a player
+//	GET    /api/admin/audit?gameId=&limit=           recent admin actions
+//
+// Every change is recorded in admin_audit, attributed to the X-Admin-Actor
+// header when present.
+func (h *Handler) HandleAdmin(w http.ResponseWriter, r *http.Request) {
+	if h.adminToken == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if !h.adminAuthorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/admin"), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "audit":
+		h.adminAudit(w, r)
+	case len(parts) == 1 && parts[0] == "games":
+		h.adminListGames(w, r)
+	case len(parts) == 2 && parts[0] == "games":
+		h.adminGetGame(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "games":
+		h.adminGameAction(w, r, parts[1], parts[2])
+	case len(parts) == 4 && parts[0] == "games" && parts[2] == "players":
+		h.adminRemovePlayer(w, r, parts[1], parts[3])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) adminAuthorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+func (h *Handler) adminListGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ids, err := h.store.ListGameIDs()
+	if err != nil {
+		http.Error(w, "failed to list games", http.StatusInternalServerError)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	games := []adminGameSummary{}
+	for _, id := range ids {
+		g, err := h.loadGame(id)
+		if err != nil {
+			continue
+		}
+		summary := adminGameSummary{
+			ID:          g.ID,
+			Code:        g.Code,
+			Status:      store.StatusName(g.State.GetStatus()),
+			Paused:      g.State.GetPaused(),
+			TurnCounter: g.State.GetTurnCounter(),
+			Players:     []adminPlayer{},
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		}
+		if status != "" && summary.Status != status {
+			continue
+		}
+		for _, p := range g.State.GetPlayers() {
+			summary.Players = append(summary.Players, adminPlayer{ID: p.Id, Name: p.Name, Connected: p.Connected, IsHost: p.IsHost})
+		}
+		games = append(games, summary)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"games": games})
+}
+
+func (h *Handler) adminGetGame(w http.ResponseWriter, r *http.Request, gameID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	data, err := protojson.Marshal(state)
+	if err != nil {
+		http.Error(w, "failed to marshal state", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (h *Handler) adminGameAction(w http.ResponseWriter, r *http.Request, gameID, action string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var (
+		details string
+		change  func(state *catanv1.GameState) (int, error)
+	)
+	switch action {
+	case "end":
+		change = func(state *catanv1.GameState) (int, error) {
+			if state.Status == catanv1.GameStatus_GAME_STATUS_FINISHED {
+				return http.StatusConflict, errors.New("game is already finished")
+			}
+			state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+			state.EndedByAdmin = true
+			state.RobberPhase = nil
+			state.PendingTrades = []*catanv1.TradeOffer{}
+			return 0, nil
+		}
+	case "pause", "resume":
+		change = func(state *catanv1.GameState) (int, error) {
+			state.Paused = action == "pause"
+			return 0, nil
+		}
+	case "rollback":
+		var req struct {
+			Seq int64 `json:"seq"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil || req.Seq <= 0 {
+			http.Error(w, "invalid rollback request", http.StatusBadRequest)
+			return
+		}
+		details = fmt.Sprintf(`{"seq":%d}`, req.Seq)
+		change = func(state *catanv1.GameState) (int, error) {
+			return h.rollbackState(state, req.Seq)
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	state, code, err := h.adminUpdate(gameID, action, change)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	h.recordAdminAction(r, action, gameID, "", details)
+	if action == "end" {
+		h.finishGame(state)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rollbackState replaces state with the one logged after event seq, keeping
+// the current pause flag. Rolling back across a change of players is refused
+// because their seats no longer match.
+func (h *Handler) rollbackState(state *catanv1.GameState, seq int64) (int, error) {
+	event, err := h.store.GetEvent(state.Id, seq)
+	if errors.Is(err, store.ErrNotFound) {
+		return http.StatusNotFound, errors.New("event not found")
+	}
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("failed to load event")
+	}
+	if _, err := game.UpgradeState(event.State); err != nil {
+		return http.StatusUnprocessableEntity, err
+	}
+	if !samePlayers(state, event.State) {
+		return http.StatusConflict, errors.New("players changed since that event")
+	}
+	paused := state.Paused
+	proto.Reset(state)
+	proto.Merge(state, event.State)
+	state.Paused = paused
+	return 0, nil
+}
+
+func samePlayers(a, b *catanv1.GameState) bool {
+	if len(a.Players) != len(b.Players) {
+		return false
+	}
+	for i := range a.Players {
+		if a.Players[i].Id != b.Players[i].Id {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Handler) adminRemovePlayer(w http.ResponseWriter, r *http.Request, gameID, playerID string) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	_, code, err := h.adminUpdate(gameID, "removePlayer", func(state *catanv1.GameState) (int, error) {
+		switch err := game.RemovePlayer(state, playerID); {
+		case errors.Is(err, game.ErrPlayerNotFound):
+			return http.StatusNotFound, err
+		case err != nil:
+			return http.StatusConflict, err
+		}
+		return 0, nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	if err := h.store.DeletePlayer(playerID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		slog.Error("failed to delete removed player's seat", "game_id", gameID, "player_id", playerID, "err", err)
+	}
+	removed := errorPayload("removed", "you were removed from the game by an administrator")
+	if frame, err := errorFrame(removed); err == nil {
+		h.hub.BroadcastToPlayer(gameID, playerID, frame)
+	}
+	h.hub.DisconnectPlayer(gameID, playerID, "removed by an administrator")
+	h.recordAdminAction(r, "removePlayer", gameID, playerID, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// adminUpdate applies change to a game's state, then saves, logs and
+// broadcasts it like a player command. change returns an HTTP status with
+// its error.
+func (h *Handler) adminUpdate(gameID, action string, change func(state *catanv1.GameState) (int, error)) (*catanv1.GameState, int, error) {
+	unlock, err := h.takeGame(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, http.StatusNotFound, errors.New("game not found")
+	}
+	if err != nil {
+		slog.Warn("failed to take game for admin update", "game_id", gameID, "err", err)
+		return nil, http.StatusServiceUnavailable, errors.New("game is busy, try again")
+	}
+	defer unlock()
+	state, err := h.loadGameState(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, http.StatusNotFound, errors.New("game not found")
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load game state")
+	}
+	if code, err := change(state); err != nil {
+		return nil, code, err
+	}
+	if err := h.saveGameState(gameID, state); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to persist game state")
+	}
+	if err := h.appendGameEvent(gameID, "admin:"+action, "", nil, state); err != nil {
+		slog.Error("failed to log game event", "game_id", gameID, "message_type", "admin:"+action, "err", err)
+	}
+	h.broadcastGameStatePersonalized(gameID, state)
+	return state, 0, nil
+}
+
+func (h *Handler) recordAdminAction(r *http.Request, action, gameID, target, details string) {
+	actor := strings.TrimSpace(r.Header.Get("X-Admin-Actor"))
+	if _, err := h.db.Exec(
+		`INSERT INTO admin_audit (action, game_id, target, details, actor, remote_addr) VALUES (?, ?, ?, ?, ?, ?)`,
+		action, gameID, nullString(target), nullString(details), nullString(actor), r.RemoteAddr); err != nil {
+		slog.Error("failed to record admin action", "action", action, "game_id", gameID, "err", err)
+	}
+	slog.Info("admin action", "action", action, "game_id", gameID, "target", target, "actor", actor)
+}
+
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (h *Handler) adminAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	limit := defaultAuditLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxAuditLimit)
+	}
+
+	query := `SELECT id, action, COALESCE(game_id, '') AS game_id, COALESCE(target, '') AS target,
+		COALESCE(details, '') AS details, COALESCE(actor, '') AS actor, COALESCE(remote_addr, '') AS remote_addr, created_at
+		FROM admin_audit`
+	args := []any{}
+	if gameID := r.URL.Query().Get("gameId"); gameID != "" {
+		query += " WHERE game_id = ?"
+		args = append(args, gameID)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	entries := []auditEntry{}
+	if err := h.db.Select(&entries, query, args...); err != nil {
+		http.Error(w, "failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"entries": entries})
+}
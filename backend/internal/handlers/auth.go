@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"settlers_from_catan/internal/store"
+)
+
+const (
+	authTokenTTL      = 30 * 24 * time.Hour
+	minPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt ignores anything longer
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,32}$`)
+
+type authRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type authResponse struct {
+	UserID    string `json:"userId"`
+	Username  string `json:"username"`
+	AuthToken string `json:"authToken"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+type activeGame struct {
+	GameID       string `json:"gameId"`
+	Code         string `json:"code"`
+	Status       string `json:"status"`
+	PlayerID     string `json:"playerId"`
+	PlayerName   string `json:"playerName"`
+	SessionToken string `json:"sessionToken"`
+}
+
+// HandleRegister creates a user account: POST /api/auth/register
+func (h *Handler) HandleRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req authRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if !usernamePattern.MatchString(req.Username) {
+		http.Error(w, "username must be 3-32 letters, digits, '-' or '_'", http.StatusBadRequest)
+		return
+	}
+	if len(req.Password) < minPasswordLength || len(req.Password) > maxPasswordLength {
+		http.Error(w, "password must be 8-72 characters", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		http.Error(w, "failed to hash password", http.StatusInternalServerError)
+		return
+	}
+	userID := uuid.New().String()
+	_, err = h.db.Exec(`INSERT INTO users (id, username, password_hash) VALUES (?, ?, ?)`,
+		userID, req.Username, string(hash))
+	if err != nil {
+		var exists int
+		if h.db.Get(&exists, "SELECT COUNT(*) FROM users WHERE username = ?", req.Username) == nil && exists > 0 {
+			http.Error(w, "username taken", http.StatusConflict)
+			return
+		}
+		http.Error(w, "failed to create user", http.StatusInternalServerError)
+		return
+	}
+
+	h.writeAuthResponse(w, userID, req.Username)
+}
+
+// HandleLogin exchanges a username and password for an auth token: POST /api/auth/login
+func (h *Handler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req authRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	var user struct {
+		ID           string `db:"id"`
+		Username     string `db:"username"`
+		PasswordHash string `db:"password_hash"`
+	}
+	err := h.db.Get(&user, "SELECT id, username, password_hash FROM users WHERE username = ?", req.Username)
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+		http.Error(w, "invalid username or password", http.StatusUnauthorized)
+		return
+	}
+
+	h.writeAuthResponse(w, user.ID, user.Username)
+}
+
+// HandleLogout revokes the bearer token of the request: POST /api/auth/logout
+func (h *Handler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := bearerToken(r.Header)
+	if token == "" {
+		http.Error(w, "missing token", http.StatusUnauthorized)
+		return
+	}
+	_, _ = h.db.Exec("DELETE FROM auth_tokens WHERE token_hash = ?", hashToken(token))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleMyGames lists the unfinished games the authenticated user has a seat
+// in, including the seat's session token so they can rejoin from any device:
+// GET /api/me/games
+func (h *Handler) HandleMyGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	userID, ok := h.authenticatedUser(r)
+	if !ok {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	seats, err := h.store.ActiveSeats(userID)
+	if err != nil {
+		http.Error(w, "failed to load games", http.StatusInternalServerError)
+		return
+	}
+	games := make([]activeGame, 0, len(seats))
+	for _, seat := range seats {
+		games = append(games, activeGame{
+			GameID:       seat.Game.ID,
+			Code:         seat.Game.Code,
+			Status:       store.StatusName(seat.Game.State.Status),
+			PlayerID:     seat.Player.ID,
+			PlayerName:   seat.Player.Name,
+			SessionToken: seat.Player.SessionToken,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"games": games})
+}
+
+func (h *Handler) writeAuthResponse(w http.ResponseWriter, userID, username string) {
+	token, expiresAt, err := h.issueAuthToken(userID)
+	if err != nil {
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(authResponse{
+		UserID:    userID,
+		Username:  username,
+		AuthToken: token,
+		ExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// issueAuthToken creates a long-lived token for userID. Only its SHA-256
+// hash is stored.
+func (h *Handler) issueAuthToken(userID string) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(raw)
+	expiresAt := time.Now().Add(authTokenTTL)
+	_, err := h.db.Exec(`INSERT INTO auth_tokens (token_hash, user_id, expires_at) VALUES (?, ?, ?)`,
+		hashToken(token), userID, expiresAt.UTC())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// authenticatedUser returns the user ID for the request's bearer token, if
+// the token is valid and unexpired.
+func (h *Handler) authenticatedUser(r *http.Request) (string, bool) {
+	return h.userForToken(bearerToken(r.Header))
+}
+
+// userForToken returns the user ID for an auth token, if it is valid and
+// unexpired.
+func (h *Handler) userForToken(token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	var row struct {
+		UserID    string    `db:"user_id"`
+		ExpiresAt time.Time `db:"expires_at"`
+	}
+	err := h.db.Get(&row, "SELECT user_id, expires_at FROM auth_tokens WHERE token_hash = ?", hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && time.Now().After(row.ExpiresAt)) {
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+	_, _ = h.db.Exec("UPDATE auth_tokens SET last_used_at = CURRENT_TIMESTAMP WHERE token_hash = ?", hashToken(token))
+	return row.UserID, true
+}
+
+func bearerToken(h http.Header) string {
+	header := h.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(header[7:])
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
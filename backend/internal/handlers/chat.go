@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/store"
+)
+
+const (
+	// maxChatLength is the longest chat line accepted, in characters.
+	maxChatLength = 300
+	// chatHistoryLimit is how many chat lines a connecting client is sent.
+	chatHistoryLimit = 100
+)
+
+// chatFilter masks blocked words in chat lines. A nil filter masks nothing.
+type chatFilter struct {
+	pattern *regexp.Regexp
+}
+
+func newChatFilter(words []string) *chatFilter {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	return &chatFilter{pattern: regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`)}
+}
+
+func (f *chatFilter) apply(text string) string {
+	if f == nil {
+		return text
+	}
+	return f.pattern.ReplaceAllStringFunc(text, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	})
+}
+
+// SetChatFilter masks words out of chat messages sent from now on, matching
+// whole words without regard to case.
+func (h *Handler) SetChatFilter(words []string) {
+	h.chatFilter = newChatFilter(words)
+}
+
+// handleSendChat delivers a chat line from client, telling it if the line
+// was refused.
+func (h *Handler) handleSendChat(client *hub.Client, payload []byte) {
+	var msg catanv1.SendChatMessage
+	if err := protojson.Unmarshal(payload, &msg); err != nil {
+		h.sendError(client, "bad_request", "invalid chat payload")
+		return
+	}
+	if refused := h.sendChat(client, client.GameID, client.PlayerID, &msg); refused != nil {
+		h.sendErrorPayload(client, refused)
+	}
+}
+
+// sendChat checks a chat line against the length and rate limits and the
+// game's moderation settings, stores it, and delivers it to the whole game
+// or, for a whisper, to just the sender and recipient. limitKey picks the
+// sender's rate limit bucket. It returns why the line was refused, if it
+// was.
+func (h *Handler) sendChat(limitKey any, gameID, playerID string, msg *catanv1.SendChatMessage) *catanv1.ErrorPayload {
+	text := strings.TrimSpace(msg.Text)
+	if text == "" {
+		return errorPayload("bad_request", "chat message is empty")
+	}
+	if utf8.RuneCountInString(text) > maxChatLength {
+		return errorPayload("chat_too_long", fmt.Sprintf("chat messages are limited to %d characters", maxChatLength))
+	}
+	if !h.limiter.allowChat(limitKey, time.Now()) {
+		return errorPayload("chat_rate_limited", "you are chatting too fast")
+	}
+
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		return errorPayload("load_failed", "failed to load game state")
+	}
+	if err := game.CanChat(state, playerID, msg.GetRecipientId()); err != nil {
+		return ruleErrorPayload("chat_refused", err)
+	}
+
+	line, err := h.store.AppendChat(&store.ChatMessage{
+		GameID:      gameID,
+		SenderID:    playerID,
+		RecipientID: msg.GetRecipientId(),
+		Text:        h.chatFilter.apply(text),
+	})
+	if err != nil {
+		playerLogger(gameID, playerID, "sendChat").Error("failed to store chat message", "err", err)
+		return errorPayload("persist_failed", "failed to store chat message")
+	}
+	out, err := chatEnvelope("chatMessage", chatPayload(line))
+	if err != nil {
+		return nil
+	}
+	if line.RecipientID == "" {
+		h.hub.BroadcastToGame(gameID, out)
+		return nil
+	}
+	h.hub.BroadcastToPlayer(gameID, line.RecipientID, out)
+	h.hub.BroadcastToPlayer(gameID, line.SenderID, out)
+	return nil
+}
+
+// sendChatHistory replays the chat lines client's player can see.
+func (h *Handler) sendChatHistory(client *hub.Client) {
+	history, err := h.chatHistory(client.GameID, client.PlayerID)
+	if err != nil {
+		commandLogger(client, "chatHistory").Error("failed to load chat history", "err", err)
+		return
+	}
+	if out, err := chatEnvelope("chatHistory", history); err == nil {
+		client.Send(out)
+	}
+}
+
+// chatHistory returns the latest chat lines playerID can see in gameID.
+func (h *Handler) chatHistory(gameID, playerID string) (*catanv1.ChatHistoryPayload, error) {
+	lines, err := h.store.ListChat(gameID, playerID, chatHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	history := &catanv1.ChatHistoryPayload{}
+	for _, line := range lines {
+		history.Messages = append(history.Messages, chatPayload(line))
+	}
+	return history, nil
+}
+
+func chatPayload(line *store.ChatMessage) *catanv1.ChatMessagePayload {
+	payload := &catanv1.ChatMessagePayload{
+		Seq:      line.Seq,
+		SenderId: line.SenderID,
+		Text:     line.Text,
+		SentAt:   line.CreatedAt.UnixMilli(),
+	}
+	if line.RecipientID != "" {
+		payload.RecipientId = &line.RecipientID
+	}
+	return payload
+}
+
+// chatEnvelope wraps a chatMessage or chatHistory payload in a ServerMessage.
+func chatEnvelope(kind string, payload proto.Message) ([]byte, error) {
+	payloadJSON, err := wsMarshal.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	msg := serverMessage{OneofKind: kind}
+	switch kind {
+	case "chatMessage":
+		msg.ChatMessage = payloadJSON
+	case "chatHistory":
+		msg.ChatHistory = payloadJSON
+	}
+	return json.Marshal(serverEnvelope{Message: msg})
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/broker"
+)
+
+// DefaultLeaseTTL is how long an instance keeps a game after last renewing
+// its lease. Games of an instance that stops without releasing them wait
+// this long for another instance to take them over.
+const DefaultLeaseTTL = 30 * time.Second
+
+// forwardTimeout bounds a call to the instance holding a game.
+const forwardTimeout = 10 * time.Second
+
+// kindGameState is the broker message carrying a game's new state, which
+// every instance personalizes for the clients attached to it.
+const kindGameState = "handlers.gameState"
+
+// kindConnect is the command marking a player connected. It is run like a
+// client's command on the instance holding the game, but is no
+// ClientMessage kind, so clients cannot send it.
+const kindConnect = "handlers.connect"
+
+// SetLeaseTTL sets how long this instance keeps a game after last renewing
+// its lease.
+func (h *Handler) SetLeaseTTL(ttl time.Duration) {
+	h.leases.ttl = ttl
+}
+
+// gameLeases tracks the games this instance runs. Instances sharing a store
+// run a game's commands on whichever holds its lease there; this instance
+// runs them one at a time under the game's lock, and renews the lease once
+// half of it has run out.
+type gameLeases struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	games map[string]*gameLease
+}
+
+type gameLease struct {
+	sync.Mutex
+	// refs counts the goroutines holding or waiting on the lock.
+	refs int
+	// renewAt is when the lease is due for renewal; zero while another
+	// instance may hold it.
+	renewAt time.Time
+}
+
+func newGameLeases(ttl time.Duration) *gameLeases {
+	return &gameLeases{ttl: ttl, games: make(map[string]*gameLease)}
+}
+
+// get returns gameID's entry, counting the caller as a user until put.
+func (l *gameLeases) get(gameID string) *gameLease {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := l.games[gameID]
+	if g == nil {
+		g = &gameLease{}
+		l.games[gameID] = g
+	}
+	g.refs++
+	return g
+}
+
+// put forgets gameID once nobody uses its entry and the lease is not held.
+func (l *gameLeases) put(gameID string, g *gameLease) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g.refs--
+	if g.refs == 0 && g.renewAt.IsZero() {
+		delete(l.games, gameID)
+	}
+}
+
+func (l *gameLeases) renewDue(g *gameLease, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return g.renewAt.IsZero() || !now.Before(g.renewAt)
+}
+
+func (l *gameLeases) setRenewAt(g *gameLease, at time.Time) {
+	l.mu.Lock()
+	g.renewAt = at
+	l.mu.Unlock()
+}
+
+// forget drops what is known of gameID's lease, for a deleted game.
+func (l *gameLeases) forget(gameID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if g := l.games[gameID]; g != nil {
+		g.renewAt = time.Time{}
+		if g.refs == 0 {
+			delete(l.games, gameID)
+		}
+	}
+}
+
+// held lists the games whose lease this instance holds.
+func (l *gameLeases) held() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var ids []string
+	for id, g := range l.games {
+		if !g.renewAt.IsZero() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// lockGame locks gameID on this instance and makes sure the instance holds
+// its lease. When another instance holds it, lockGame returns that
+// instance and no unlock.
+func (h *Handler) lockGame(gameID string) (unlock func(), holder string, err error) {
+	self := h.hub.Broker().Instance()
+	g := h.leases.get(gameID)
+	g.Lock()
+	unlock = func() {
+		g.Unlock()
+		h.leases.put(gameID, g)
+	}
+	now := time.Now()
+	if !h.leases.renewDue(g, now) {
+		return unlock, self, nil
+	}
+	ttl := h.leases.ttl
+	holder, err = h.store.AcquireLease(gameID, self, ttl)
+	if err != nil || holder != self {
+		h.leases.setRenewAt(g, time.Time{})
+		unlock()
+		return nil, holder, err
+	}
+	h.leases.setRenewAt(g, now.Add(ttl/2))
+	return unlock, self, nil
+}
+
+// takeGame locks gameID for work besides a player's command, first asking
+// the instance holding its lease, if another, to hand it over.
+func (h *Handler) takeGame(gameID string) (unlock func(), err error) {
+	unlock, holder, err := h.lockGame(gameID)
+	if err != nil || unlock != nil {
+		return unlock, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	if _, err := h.call(ctx, holder, &clusterCall{Op: "release", GameID: gameID}); err != nil {
+		return nil, fmt.Errorf("instance %s holding game %s: %w", holder, gameID, err)
+	}
+	unlock, holder, err = h.lockGame(gameID)
+	if err == nil && unlock == nil {
+		err = fmt.Errorf("game %s was taken by instance %s", gameID, holder)
+	}
+	return unlock, err
+}
+
+// releaseGame gives up gameID's lease once its running command is done.
+func (h *Handler) releaseGame(gameID string) error {
+	g := h.leases.get(gameID)
+	g.Lock()
+	defer func() {
+		g.Unlock()
+		h.leases.put(gameID, g)
+	}()
+	h.leases.setRenewAt(g, time.Time{})
+	return h.store.ReleaseLease(gameID, h.hub.Broker().Instance())
+}
+
+// releaseLeases gives up every game this instance holds so the others can
+// take them over without waiting for the leases to run out.
+func (h *Handler) releaseLeases() {
+	for _, id := range h.leases.held() {
+		if err := h.releaseGame(id); err != nil {
+			slog.Error("failed to release game lease", "game_id", id, "err", err)
+		}
+	}
+}
+
+// clusterCall is what one instance asks of another: to run a command on a
+// game it holds, or to hand the game over.
+type clusterCall struct {
+	Op       string `json:"op"`
+	GameID   string `json:"gameId"`
+	PlayerID string `json:"playerId,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Payload  []byte `json:"payload,omitempty"`
+}
+
+// clusterReply answers a forwarded command with the binary GameState it
+// produced or the ErrorPayload refusing it.
+type clusterReply struct {
+	State []byte `json:"state,omitempty"`
+	Error []byte `json:"error,omitempty"`
+}
+
+func (h *Handler) call(ctx context.Context, instance string, call *clusterCall) ([]byte, error) {
+	req, err := json.Marshal(call)
+	if err != nil {
+		return nil, err
+	}
+	return h.hub.Broker().Call(ctx, instance, req)
+}
+
+// forwardCommand has instance, which holds gameID, run cmd for playerID.
+func (h *Handler) forwardCommand(instance, gameID, playerID string, cmd gameCommand) (*catanv1.GameState, *catanv1.ErrorPayload) {
+	logger := playerLogger(gameID, playerID, cmd.Kind)
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	data, err := h.call(ctx, instance, &clusterCall{Op: "command", GameID: gameID, PlayerID: playerID, Kind: cmd.Kind, Payload: cmd.Payload})
+	if err != nil {
+		logger.Warn("failed to forward command", "instance", instance, "err", err)
+		return nil, ownerUnavailable()
+	}
+	var reply clusterReply
+	if err := json.Unmarshal(data, &reply); err != nil {
+		logger.Error("invalid forwarded command reply", "instance", instance, "err", err)
+		return nil, ownerUnavailable()
+	}
+	if reply.Error != nil {
+		var refused catanv1.ErrorPayload
+		if err := proto.Unmarshal(reply.Error, &refused); err != nil {
+			return nil, ownerUnavailable()
+		}
+		return nil, &refused
+	}
+	var state catanv1.GameState
+	if err := proto.Unmarshal(reply.State, &state); err != nil {
+		logger.Error("invalid forwarded game state", "instance", instance, "err", err)
+		return nil, ownerUnavailable()
+	}
+	return &state, nil
+}
+
+func ownerUnavailable() *catanv1.ErrorPayload {
+	return errorPayload("owner_unavailable", "the server running this game is unavailable, try again")
+}
+
+// handleCall answers calls from the other instances.
+func (h *Handler) handleCall(ctx context.Context, req []byte) ([]byte, error) {
+	var call clusterCall
+	if err := json.Unmarshal(req, &call); err != nil {
+		return nil, err
+	}
+	switch call.Op {
+	case "command":
+		var reply clusterReply
+		state, refused := h.runForwardedCommand(&call)
+		var err error
+		if refused != nil {
+			reply.Error, err = proto.Marshal(refused)
+		} else {
+			reply.State, err = proto.Marshal(state)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(reply)
+	case "release":
+		return nil, h.releaseGame(call.GameID)
+	}
+	return nil, fmt.Errorf("unknown call %q", call.Op)
+}
+
+// runForwardedCommand runs a command another instance forwarded here. It
+// is refused rather than passed on if this instance no longer holds the
+// game.
+func (h *Handler) runForwardedCommand(call *clusterCall) (*catanv1.GameState, *catanv1.ErrorPayload) {
+	if !h.beginCommand() {
+		return nil, ownerUnavailable()
+	}
+	defer h.inflight.Done()
+	cmd := gameCommand{Kind: call.Kind, Payload: call.Payload}
+	var apply func(state *catanv1.GameState) error
+	if cmd.Kind != kindConnect {
+		var err error
+		if apply, err = h.decodeCommand(cmd, call.PlayerID); err != nil {
+			return nil, errorPayload("bad_request", err.Error())
+		}
+	}
+	unlock, _, err := h.lockGame(call.GameID)
+	if err != nil {
+		playerLogger(call.GameID, call.PlayerID, cmd.Kind).Error("failed to lease game", "err", err)
+		return nil, errorPayload("load_failed", "failed to load game state")
+	}
+	if unlock == nil {
+		return nil, ownerUnavailable()
+	}
+	defer unlock()
+	return h.executeCommand(call.GameID, call.PlayerID, cmd, apply)
+}
+
+// receive handles the broker messages handlers publish on any instance.
+func (h *Handler) receive(msg *broker.Message) {
+	if msg.Kind != kindGameState {
+		return
+	}
+	var state catanv1.GameState
+	if err := proto.Unmarshal(msg.Data, &state); err != nil {
+		slog.Error("invalid game state broadcast", "game_id", msg.GameID, "err", err)
+		return
+	}
+	h.sendGameState(msg.GameID, &state)
+}
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/gen/proto/catan/v1/catanv1connect"
+	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/store"
+)
+
+// maxRPCRequestBytes bounds a CatanService request; commands are tiny.
+const maxRPCRequestBytes = 64 * 1024
+
+// ConnectHandler returns the path prefix and handler serving CatanService
+// over the Connect, gRPC and gRPC-Web protocols.
+func (h *Handler) ConnectHandler() (string, http.Handler) {
+	return catanv1connect.NewCatanServiceHandler(&catanService{h: h}, connect.WithReadMaxBytes(maxRPCRequestBytes))
+}
+
+// EndSubscriptions ends every CatanService Subscribe stream, which would
+// otherwise keep an HTTP server shutting down waiting on them.
+func (h *Handler) EndSubscriptions() {
+	h.unsubscribeOnce.Do(func() { close(h.unsubscribe) })
+}
+
+// catanService implements CatanService on top of the same game logic,
+// store and hub as the HTTP routes and the WebSocket.
+type catanService struct {
+	h *Handler
+}
+
+var _ catanv1connect.CatanServiceHandler = (*catanService)(nil)
+
+// connectCodes maps the statuses the REST routes refuse requests with to
+// Connect codes.
+var connectCodes = map[int]connect.Code{
+	http.StatusBadRequest:          connect.CodeInvalidArgument,
+	http.StatusUnauthorized:        connect.CodeUnauthenticated,
+	http.StatusForbidden:           connect.CodePermissionDenied,
+	http.StatusNotFound:            connect.CodeNotFound,
+	http.StatusConflict:            connect.CodeFailedPrecondition,
+	http.StatusInternalServerError: connect.CodeInternal,
+	http.StatusServiceUnavailable:  connect.CodeUnavailable,
+}
+
+// refusalCodes maps the codes commands are refused with to Connect codes.
+var refusalCodes = map[string]connect.Code{
+	"bad_request":       connect.CodeInvalidArgument,
+	"chat_rate_limited": connect.CodeResourceExhausted,
+	"chat_refused":      connect.CodeFailedPrecondition,
+	"chat_too_long":     connect.CodeInvalidArgument,
+	"game_expired":      connect.CodeNotFound,
+	"game_paused":       connect.CodeFailedPrecondition,
+	"invalid_action":    connect.CodeFailedPrecondition,
+	"invalid_state":     connect.CodeFailedPrecondition,
+	"load_failed":       connect.CodeInternal,
+	"owner_unavailable": connect.CodeUnavailable,
+	"persist_failed":    connect.CodeInternal,
+	"rate_limited":      connect.CodeResourceExhausted,
+	"removed":           connect.CodePermissionDenied,
+	"shutting_down":     connect.CodeUnavailable,
+}
+
+// connectError converts an error from the shared request logic.
+func connectError(err error) error {
+	var re *requestError
+	if !errors.As(err, &re) {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	code, ok := connectCodes[re.status]
+	if !ok {
+		code = connect.CodeUnknown
+	}
+	return connect.NewError(code, errors.New(re.message))
+}
+
+// refusal converts a refused command, attaching the ErrorPayload a
+// WebSocket client would have been sent as an error detail.
+func (s *catanService) refusal(e *catanv1.ErrorPayload) error {
+	s.h.metrics.rejected.Inc(e.Code)
+	code, ok := refusalCodes[e.Code]
+	if !ok {
+		code = connect.CodeUnknown
+	}
+	err := connect.NewError(code, errors.New(e.Message))
+	if detail, derr := connect.NewErrorDetail(e); derr == nil {
+		err.AddDetail(detail)
+	}
+	return err
+}
+
+// seat resolves the caller's seat from the session token in header.
+func (s *catanService) seat(header http.Header) (*store.Player, error) {
+	token := header.Get("X-Session-Token")
+	if token == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing session token"))
+	}
+	seat, err := s.h.store.GetPlayerBySession(token)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid session token"))
+	}
+	return seat, nil
+}
+
+// optionalUser resolves header's bearer token like the REST routes do:
+// none is anonymous, an invalid one is refused.
+func (s *catanService) optionalUser(header http.Header) (*string, error) {
+	token := bearerToken(header)
+	if token == "" {
+		return nil, nil
+	}
+	id, ok := s.h.userForToken(token)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid auth token"))
+	}
+	return &id, nil
+}
+
+func (s *catanService) CreateGame(ctx context.Context, req *connect.Request[catanv1.CreateGameRequest]) (*connect.Response[catanv1.CreateGameResponse], error) {
+	userID, err := s.optionalUser(req.Header())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.h.createGame(req.Msg, userID)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *catanService) JoinGame(ctx context.Context, req *connect.Request[catanv1.JoinGameRequest]) (*connect.Response[catanv1.JoinGameResponse], error) {
+	userID, err := s.optionalUser(req.Header())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.h.joinGame(req.Msg, userID)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *catanService) GetGame(ctx context.Context, req *connect.Request[catanv1.GetGameRequest]) (*connect.Response[catanv1.GameInfoResponse], error) {
+	resp, err := s.h.gameInfo(strings.ToUpper(req.Msg.Code))
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *catanService) GetPendingActions(ctx context.Context, req *connect.Request[catanv1.GetPendingActionsRequest]) (*connect.Response[catanv1.PendingActionsResponse], error) {
+	seat, err := s.seat(req.Header())
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.h.loadGameState(seat.GameID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("game not found"))
+	}
+	return connect.NewResponse(pendingActionsResponse(state, seat.ID)), nil
+}
+
+// Subscribe relays the caller's game to it for as long as the call lasts,
+// through a hub client standing in for a WebSocket.
+func (s *catanService) Subscribe(ctx context.Context, req *connect.Request[catanv1.SubscribeRequest], stream *connect.ServerStream[catanv1.ServerMessage]) error {
+	h := s.h
+	seat, err := s.seat(req.Header())
+	if err != nil {
+		return err
+	}
+	logger := playerLogger(seat.GameID, seat.ID, "subscribe")
+
+	// Register before reading the state so no update falls in between
+	client := hub.NewSubscriber(h.hub, seat.ID, seat.GameID)
+	h.hub.Register(client)
+	defer h.hub.Unregister(client)
+
+	state, err := h.loadGameState(seat.GameID)
+	if err != nil {
+		return connect.NewError(connect.CodeNotFound, errors.New("game not found"))
+	}
+	first := &catanv1.ServerMessage{Message: &catanv1.ServerMessage_GameState{
+		GameState: &catanv1.GameStatePayload{State: redactedGameStateForPlayer(state, seat.ID)},
+	}}
+	if err := stream.Send(first); err != nil {
+		return err
+	}
+	if history, err := h.chatHistory(seat.GameID, seat.ID); err != nil {
+		logger.Error("failed to load chat history", "err", err)
+	} else if err := stream.Send(&catanv1.ServerMessage{Message: &catanv1.ServerMessage_ChatHistory{ChatHistory: history}}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-h.unsubscribe:
+			return connect.NewError(connect.CodeUnavailable, errors.New("server is shutting down"))
+		case frame, ok := <-client.Messages():
+			if !ok {
+				return connect.NewError(connect.CodeUnavailable, errors.New("subscription closed by the server"))
+			}
+			msg, err := serverMessageFromWire(frame)
+			if err != nil {
+				logger.Warn("failed to decode server message", "err", err)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// serverMessageFromWire decodes a WebSocket frame, in the protobuf-ts
+// envelope the hub carries, back into a ServerMessage.
+func serverMessageFromWire(frame []byte) (*catanv1.ServerMessage, error) {
+	var envelope struct {
+		Message map[string]json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(frame, &envelope); err != nil {
+		return nil, err
+	}
+	var kind string
+	if err := json.Unmarshal(envelope.Message["oneofKind"], &kind); err != nil {
+		return nil, err
+	}
+	canonical, err := json.Marshal(map[string]json.RawMessage{kind: envelope.Message[kind]})
+	if err != nil {
+		return nil, err
+	}
+	var msg catanv1.ServerMessage
+	if err := protojson.Unmarshal(canonical, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+// commandFrame renders msg as the WebSocket frame that would have sent it,
+// so the game log reads the same however a command arrived.
+func commandFrame(kind string, msg proto.Message) ([]byte, error) {
+	body, err := wsMarshal.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]any{
+		"message": map[string]any{"oneofKind": kind, kind: json.RawMessage(body)},
+	})
+}
+
+// begin admits a command of kind from the caller in header, counting it
+// and charging its rate limit. The caller must call done once the command
+// has been handled.
+func (s *catanService) begin(header http.Header, kind string) (seat *store.Player, done func(), err error) {
+	h := s.h
+	if seat, err = s.seat(header); err != nil {
+		return nil, nil, err
+	}
+	h.metrics.messages.Inc(kind)
+	if !h.beginCommand() {
+		return nil, nil, s.refusal(errorPayload("shutting_down", "server is shutting down"))
+	}
+	if ok, _ := h.limiter.allowKey(rpcCaller{playerID: seat.ID}, seat.GameID, time.Now()); !ok {
+		h.inflight.Done()
+		return nil, nil, s.refusal(errorPayload("rate_limited", "too many messages, slow down"))
+	}
+	return seat, h.inflight.Done, nil
+}
+
+// command runs a game command of kind for the caller in header and answers
+// with the caller's view of the game after it. The command goes through
+// the same decoding as a WebSocket frame, so it runs alike on any instance.
+func (s *catanService) command(header http.Header, kind string, msg proto.Message) (*connect.Response[catanv1.CommandResponse], error) {
+	seat, done, err := s.begin(header, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	frame, err := commandFrame(kind, msg)
+	if err != nil {
+		return nil, s.refusal(errorPayload("bad_request", "invalid "+kind+" payload"))
+	}
+	cmd := gameCommand{Kind: kind, Payload: frame}
+	apply, err := s.h.decodeCommand(cmd, seat.ID)
+	if err != nil {
+		return nil, s.refusal(errorPayload("bad_request", err.Error()))
+	}
+	state, refused := s.h.runCommand(seat.GameID, seat.ID, cmd, apply)
+	if refused != nil {
+		return nil, s.refusal(refused)
+	}
+	return connect.NewResponse(&catanv1.CommandResponse{State: redactedGameStateForPlayer(state, seat.ID)}), nil
+}
+
+func (s *catanService) PlayerReady(ctx context.Context, req *connect.Request[catanv1.PlayerReadyMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "playerReady", req.Msg)
+}
+
+func (s *catanService) StartGame(ctx context.Context, req *connect.Request[catanv1.StartGameMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "startGame", req.Msg)
+}
+
+func (s *catanService) RollDice(ctx context.Context, req *connect.Request[catanv1.RollDiceMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "rollDice", req.Msg)
+}
+
+func (s *catanService) BuildStructure(ctx context.Context, req *connect.Request[catanv1.BuildStructureMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "buildStructure", req.Msg)
+}
+
+func (s *catanService) ProposeTrade(ctx context.Context, req *connect.Request[catanv1.ProposeTradeMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "proposeTrade", req.Msg)
+}
+
+func (s *catanService) RespondTrade(ctx context.Context, req *connect.Request[catanv1.RespondTradeMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "respondTrade", req.Msg)
+}
+
+func (s *catanService) BankTrade(ctx context.Context, req *connect.Request[catanv1.BankTradeMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "bankTrade", req.Msg)
+}
+
+func (s *catanService) MoveRobber(ctx context.Context, req *connect.Request[catanv1.MoveRobberMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "moveRobber", req.Msg)
+}
+
+func (s *catanService) DiscardCards(ctx context.Context, req *connect.Request[catanv1.DiscardCardsMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "discardCards", req.Msg)
+}
+
+func (s *catanService) BuyDevCard(ctx context.Context, req *connect.Request[catanv1.BuyDevCardMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "buyDevCard", req.Msg)
+}
+
+func (s *catanService) PlayDevCard(ctx context.Context, req *connect.Request[catanv1.PlayDevCardMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "playDevCard", req.Msg)
+}
+
+func (s *catanService) SetTurnPhase(ctx context.Context, req *connect.Request[catanv1.SetTurnPhaseMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "setTurnPhase", req.Msg)
+}
+
+func (s *catanService) EndTurn(ctx context.Context, req *connect.Request[catanv1.EndTurnMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "endTurn", req.Msg)
+}
+
+// SendChat delivers a chat line. Chat is not a game command, so it is not
+// logged with the game's events.
+func (s *catanService) SendChat(ctx context.Context, req *connect.Request[catanv1.SendChatMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	seat, done, err := s.begin(req.Header(), "sendChat")
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	if refused := s.h.sendChat(rpcCaller{playerID: seat.ID}, seat.GameID, seat.ID, req.Msg); refused != nil {
+		return nil, s.refusal(refused)
+	}
+	state, err := s.h.loadGameState(seat.GameID)
+	if err != nil {
+		return nil, s.refusal(errorPayload("load_failed", "failed to load game state"))
+	}
+	return connect.NewResponse(&catanv1.CommandResponse{State: redactedGameStateForPlayer(state, seat.ID)}), nil
+}
+
+func (s *catanService) MuteChat(ctx context.Context, req *connect.Request[catanv1.MuteChatMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "muteChat", req.Msg)
+}
+
+func (s *catanService) SetChatSettings(ctx context.Context, req *connect.Request[catanv1.SetChatSettingsMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "setChatSettings", req.Msg)
+}
+
+func (s *catanService) RequestTakeback(ctx context.Context, req *connect.Request[catanv1.RequestTakebackMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "requestTakeback", req.Msg)
+}
+
+func (s *catanService) RespondTakeback(ctx context.Context, req *connect.Request[catanv1.RespondTakebackMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "respondTakeback", req.Msg)
+}
+
+func (s *catanService) ApproveExport(ctx context.Context, req *connect.Request[catanv1.ApproveExportMessage]) (*connect.Response[catanv1.CommandResponse], error) {
+	return s.command(req.Header(), "approveExport", req.Msg)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/notify"
+	"settlers_from_catan/internal/store"
+)
+
+// notifyTimeout bounds one notification delivery.
+const notifyTimeout = 15 * time.Second
+
+// SetNotifier sends "your turn" and deadline notifications for
+// correspondence games through n. A nil notifier sends none.
+func (h *Handler) SetNotifier(n notify.Notifier) {
+	h.notifier = n
+}
+
+// HandlePendingActions answers GET /api/games/{code}/pending with what the
+// game is waiting for the caller to do, so correspondence players can poll
+// instead of keeping a connection open. The caller proves their seat with
+// its session token.
+func (h *Handler) HandlePendingActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	gameID, state, err := h.loadGameByCode(gameCodeFromPath(r.URL.Path))
+	if err != nil {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	seat, err := h.store.GetPlayerBySession(sessionTokenFromRequest(r))
+	if err != nil || seat.GameID != gameID {
+		http.Error(w, "only players in this game have pending actions", http.StatusForbidden)
+		return
+	}
+	writeProto(w, pendingActionsResponse(state, seat.ID))
+}
+
+// pendingActionsResponse lists what state is waiting for playerID to do.
+func pendingActionsResponse(state *catanv1.GameState, playerID string) *catanv1.PendingActionsResponse {
+	resp := &catanv1.PendingActionsResponse{
+		GameId:       state.Id,
+		PlayerId:     playerID,
+		Status:       state.Status,
+		Actions:      game.PendingActions(state, playerID),
+		TurnDeadline: state.GetCorrespondence().GetTurnDeadline(),
+	}
+	if state.CurrentTurn >= 0 && int(state.CurrentTurn) < len(state.Players) {
+		resp.CurrentPlayerId = state.Players[state.CurrentTurn].Id
+	}
+	return resp
+}
+
+// notifyPending tells each player of a correspondence game about actions
+// they owe in after but did not in before.
+func (h *Handler) notifyPending(before, after *catanv1.GameState) {
+	if after.GetCorrespondence() == nil {
+		return
+	}
+	for _, p := range after.Players {
+		if actions := game.NewlyPending(before, after, p.Id); len(actions) > 0 {
+			h.notifyPlayer(notify.KindYourTurn, after, p, actions)
+		}
+	}
+}
+
+// notifyPlayer sends a notification about state to player in the
+// background, so a slow webhook never holds up the game.
+func (h *Handler) notifyPlayer(kind string, state *catanv1.GameState, player *catanv1.PlayerState, actions []*catanv1.PendingAction) {
+	if h.notifier == nil {
+		return
+	}
+	n := notify.Notification{
+		Kind:       kind,
+		GameID:     state.Id,
+		Code:       state.Code,
+		PlayerID:   player.Id,
+		PlayerName: player.Name,
+	}
+	for _, a := range actions {
+		n.Actions = append(n.Actions, pendingActionName(a.Kind))
+	}
+	if deadline := state.GetCorrespondence().GetTurnDeadline(); deadline != 0 && len(actions) > 0 {
+		t := time.UnixMilli(deadline).UTC()
+		n.Deadline = &t
+	}
+	go func() {
+		if seat, err := h.store.GetPlayer(n.PlayerID); err == nil {
+			n.UserID = seat.UserID
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		if err := h.notifier.Notify(ctx, n); err != nil {
+			slog.Warn("notification failed", "kind", n.Kind, "game_id", n.GameID, "player_id", n.PlayerID, "err", err)
+		}
+	}()
+}
+
+// pendingActionName is the lowercase name notifications use for kind, such
+// as "roll" or "respond_trade".
+func pendingActionName(kind catanv1.PendingActionKind) string {
+	return strings.ToLower(strings.TrimPrefix(kind.String(), "PENDING_ACTION_KIND_"))
+}
+
+// RunDeadlines enforces correspondence turn deadlines every interval until
+// ctx is done.
+func (h *Handler) RunDeadlines(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			forfeited, err := h.SweepDeadlines(time.Now())
+			if err != nil {
+				slog.Error("deadline sweep failed", "err", err)
+			}
+			if forfeited > 0 {
+				slog.Info("forfeited overdue turns", "turns", forfeited)
+			}
+		}
+	}
+}
+
+// SweepDeadlines handles correspondence games whose turn is overdue as of
+// now and returns how many turns it forfeited. Only the games the store
+// reports overdue are loaded. A turn the rules let pass, rolled or not and
+// with any robber step settled, goes to the next player; during setup the
+// players holding up the game are told once instead.
+func (h *Handler) SweepDeadlines(now time.Time) (int, error) {
+	ids, err := h.store.ListOverdueGameIDs(now)
+	if err != nil {
+		return 0, err
+	}
+	forfeited := 0
+	var errs []error
+	for _, id := range ids {
+		ok, err := h.sweepGame(id, now)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ok {
+			forfeited++
+		}
+	}
+	return forfeited, errors.Join(errs...)
+}
+
+// sweepGame enforces gameID's overdue turn under the game's lock. A game
+// another instance holds is left to that instance's sweep.
+func (h *Handler) sweepGame(gameID string, now time.Time) (bool, error) {
+	unlock, _, err := h.lockGame(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil || unlock == nil {
+		return false, err
+	}
+	defer unlock()
+	state, err := h.loadGameState(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if state.Paused || !game.TurnOverdue(state, now) {
+		return false, nil
+	}
+	if err := game.ForfeitTurn(proto.Clone(state).(*catanv1.GameState)); err != nil {
+		return false, h.warnOverdue(state)
+	}
+
+	// The forfeit runs as a command of the player losing their turn, so it
+	// is checked, logged and counted like any other.
+	loser := state.Players[state.CurrentTurn]
+	cmd := gameCommand{Kind: "forfeitTurn", At: now}
+	after, errPayload := h.executeCommand(gameID, loser.Id, cmd, game.ForfeitTurn)
+	if errPayload != nil {
+		return false, fmt.Errorf("forfeit turn in game %s: %s", gameID, errPayload.Message)
+	}
+	h.notifyPlayer(notify.KindTurnForfeited, after, loser, nil)
+	slog.Info("overdue turn forfeited", "game_id", gameID, "player_id", loser.Id)
+	return true, nil
+}
+
+// warnOverdue tells the players holding up state's overdue turn, once.
+func (h *Handler) warnOverdue(state *catanv1.GameState) error {
+	if state.Correspondence.OverdueNotified {
+		return nil
+	}
+	state.Correspondence.OverdueNotified = true
+	if err := h.saveGameState(state.Id, state); err != nil {
+		return err
+	}
+	for _, p := range state.Players {
+		if actions := game.PendingActions(state, p.Id); len(actions) > 0 {
+			h.notifyPlayer(notify.KindTurnOverdue, state, p, actions)
+		}
+	}
+	slog.Info("turn overdue", "game_id", state.Id)
+	return nil
+}
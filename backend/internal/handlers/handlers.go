@@ -1,23 +1,54 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jmoiron/sqlx"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"log/slog"
 	"math/rand"
 	"net/http"
 	catanv1 "settlers_from_catan/gen/proto/catan/v1"
 	"settlers_from_catan/internal/game"
 	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/notify"
+	"settlers_from_catan/internal/store"
 	"strings"
+	"sync"
+	"time"
 )
 
 type Handler struct {
-	db  *sqlx.DB
-	hub *hub.Hub
+	db    *sqlx.DB
+	store store.GameStore
+	hub   *hub.Hub
+
+	allowedOrigins map[string]bool
+	adminToken     string
+	chatFilter     *chatFilter
+	validation     StateValidation
+	limiter        *rateLimiter
+	metrics        *handlerMetrics
+	notifier       notify.Notifier
+
+	// drainMu guards draining; commands register in inflight under it so
+	// Shutdown never races a late Add.
+	drainMu  sync.RWMutex
+	draining bool
+	inflight sync.WaitGroup
+
+	// unsubscribe is closed by EndSubscriptions to end CatanService
+	// Subscribe streams.
+	unsubscribe     chan struct{}
+	unsubscribeOnce sync.Once
+
+	// leases are the games this instance runs commands for.
+	leases *gameLeases
 }
 
 var wsUpgrader = websocket.Upgrader{
@@ -49,6 +80,12 @@ type clientEnvelope struct {
 		BankTrade    json.RawMessage `json:"bankTrade,omitempty"`
 		SetTurnPhase json.RawMessage `json:"setTurnPhase,omitempty"`
 		BuyDevCard   json.RawMessage `json:"buyDevCard,omitempty"`
+		SendChat     json.RawMessage `json:"sendChat,omitempty"`
+		MuteChat     json.RawMessage `json:"muteChat,omitempty"`
+		ChatSettings json.RawMessage `json:"setChatSettings,omitempty"`
+		Takeback     json.RawMessage `json:"requestTakeback,omitempty"`
+		TakebackVote json.RawMessage `json:"respondTakeback,omitempty"`
+		Export       json.RawMessage `json:"approveExport,omitempty"`
 	} `json:"message"`
 }
 
@@ -57,34 +94,168 @@ type serverEnvelope struct {
 }
 
 type serverMessage struct {
-	OneofKind string          `json:"oneofKind"`
-	GameState *gameStateWire  `json:"gameState,omitempty"`
-	GameOver  json.RawMessage `json:"gameOver,omitempty"`
-	Error     json.RawMessage `json:"error,omitempty"`
+	OneofKind   string          `json:"oneofKind"`
+	GameState   *gameStateWire  `json:"gameState,omitempty"`
+	GameOver    json.RawMessage `json:"gameOver,omitempty"`
+	Error       json.RawMessage `json:"error,omitempty"`
+	ChatMessage json.RawMessage `json:"chatMessage,omitempty"`
+	ChatHistory json.RawMessage `json:"chatHistory,omitempty"`
+	Rematch     json.RawMessage `json:"rematch,omitempty"`
 }
 
 type gameStateWire struct {
 	State json.RawMessage `json:"state"`
 }
 
+// NewHandler keeps games in db alongside accounts and stats.
 func NewHandler(db *sqlx.DB, hub *hub.Hub) *Handler {
-	return &Handler{
-		db:  db,
-		hub: hub,
+	return NewHandlerWithStore(db, store.NewSQLite(db), hub)
+}
+
+// NewHandlerWithStore keeps games, players and event logs in games, and
+// accounts and stats in db.
+func NewHandlerWithStore(db *sqlx.DB, games store.GameStore, hub *hub.Hub) *Handler {
+	h := &Handler{
+		db:          db,
+		store:       games,
+		hub:         hub,
+		validation:  ValidationLog,
+		limiter:     newRateLimiter(DefaultRateLimits),
+		metrics:     newHandlerMetrics(games, hub),
+		unsubscribe: make(chan struct{}),
+		leases:      newGameLeases(DefaultLeaseTTL),
+	}
+	hub.Broker().Subscribe(h.receive)
+	hub.Broker().HandleCalls(h.handleCall)
+	return h
+}
+
+// SetRateLimits replaces the limits on WebSocket commands.
+func (h *Handler) SetRateLimits(limits RateLimits) {
+	h.limiter = newRateLimiter(limits)
+}
+
+// SetAllowedOrigins limits WebSocket upgrades from browsers to requests whose
+// Origin header is in origins. Requests without an Origin header come from
+// non-browser clients such as catan-cli and are always allowed. An empty list
+// allows any origin.
+func (h *Handler) SetAllowedOrigins(origins []string) {
+	h.allowedOrigins = nil
+	if len(origins) == 0 {
+		return
+	}
+	h.allowedOrigins = make(map[string]bool, len(origins))
+	for _, o := range origins {
+		h.allowedOrigins[strings.TrimSuffix(o, "/")] = true
+	}
+}
+
+func (h *Handler) originAllowed(r *http.Request) bool {
+	if h.allowedOrigins == nil {
+		return true
 	}
+	origin := r.Header.Get("Origin")
+	return origin == "" || h.allowedOrigins[origin]
+}
+
+// Shutdown stops accepting game commands, waits for those in flight to
+// save their state, hands this instance's games over to the others, then
+// disconnects every WebSocket client. It returns ctx's error if commands
+// are still running when ctx is done.
+func (h *Handler) Shutdown(ctx context.Context) error {
+	h.drainMu.Lock()
+	h.draining = true
+	h.drainMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.inflight.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	h.releaseLeases()
+	h.hub.Shutdown("server shutting down")
+	return err
+}
+
+// beginCommand registers a game command as in flight. It reports false once
+// Shutdown has started.
+func (h *Handler) beginCommand() bool {
+	h.drainMu.RLock()
+	defer h.drainMu.RUnlock()
+	if h.draining {
+		return false
+	}
+	h.inflight.Add(1)
+	return true
 }
 
 // --- HTTP Handlers ---
 
-func (h *Handler) HandleCreateGame(w http.ResponseWriter, r *http.Request) {
-	type apiRequest struct {
-		PlayerName string `json:"playerName"`
+// requestError refuses an API request with the HTTP status the REST routes
+// answer it with; CatanService maps the status to a Connect code.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string { return e.message }
+
+func refuse(status int, message string) *requestError {
+	return &requestError{status: status, message: message}
+}
+
+// writeRequestError answers a REST request refused with err.
+func writeRequestError(w http.ResponseWriter, err error) {
+	var re *requestError
+	if errors.As(err, &re) {
+		http.Error(w, re.message, re.status)
+		return
 	}
-	var req apiRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PlayerName == "" {
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// apiUnmarshal reads REST request bodies, which may carry fields this
+// server does not know.
+var apiUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+func (h *Handler) HandleCreateGame(w http.ResponseWriter, r *http.Request) {
+	var req catanv1.CreateGameRequest
+	if body, err := io.ReadAll(r.Body); err != nil || apiUnmarshal.Unmarshal(body, &req) != nil {
 		http.Error(w, "invalid request", http.StatusBadRequest)
 		return
 	}
+	userID, ok := h.optionalUser(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.createGame(&req, userID)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeProto(w, resp)
+}
+
+// createGame opens a lobby hosted by req's player, who is signed in as
+// userID if it is set. A request with correspondence settings makes a game
+// played over days with turns of turn_hours (default 48).
+func (h *Handler) createGame(req *catanv1.CreateGameRequest, userID *string) (*catanv1.CreateGameResponse, error) {
+	if req.PlayerName == "" {
+		return nil, refuse(http.StatusBadRequest, "invalid request")
+	}
+	var correspondence *catanv1.Correspondence
+	if req.Correspondence != nil {
+		var err error
+		if correspondence, err = game.NewCorrespondence(req.Correspondence.TurnHours); err != nil {
+			return nil, refuse(http.StatusBadRequest, err.Error())
+		}
+	}
 
 	gameID := uuid.New().String()
 	playerID := uuid.New().String()
@@ -95,57 +266,41 @@ func (h *Handler) HandleCreateGame(w http.ResponseWriter, r *http.Request) {
 
 	// Create game state
 	state := game.NewGameState(gameID, code, []string{req.PlayerName}, []string{playerID})
+	state.Correspondence = correspondence
 
-	// Marshal game state to JSON (protojson)
-	stateJSON, err := protojson.Marshal(state)
-	if err != nil {
-		http.Error(w, "failed to marshal game state", http.StatusInternalServerError)
-		return
-	}
-
-	// Persist game row
-	_, err = h.db.Exec(`INSERT INTO games (id, code, state, status) VALUES (?, ?, ?, ?)`,
-		gameID, code, string(stateJSON), "waiting")
-	if err != nil {
-		http.Error(w, "failed to persist game", http.StatusInternalServerError)
-		return
+	// Persist game and host seat
+	host := &store.Player{
+		ID:           playerID,
+		Name:         req.PlayerName,
+		Color:        state.Players[0].Color,
+		SessionToken: sessionToken,
+		IsHost:       true,
+		UserID:       derefString(userID),
 	}
-
-	// Persist player row
-	name := req.PlayerName
-	color := int(state.Players[0].Color)
-	_, err = h.db.Exec(`INSERT INTO players (id, game_id, name, color, session_token, is_host, connected) VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		playerID, gameID, name, color, sessionToken, 1, 0)
-	if err != nil {
-		http.Error(w, "failed to persist player", http.StatusInternalServerError)
-		return
+	if err := h.store.CreateGame(&store.Game{ID: gameID, Code: code, State: state}, host); err != nil {
+		return nil, refuse(http.StatusInternalServerError, "failed to persist game")
 	}
+	_ = h.appendGameEvent(gameID, "createGame", playerID, nil, state)
 
-	resp := &catanv1.CreateGameResponse{
+	return &catanv1.CreateGameResponse{
 		GameId:       gameID,
 		Code:         code,
 		SessionToken: sessionToken,
 		PlayerId:     playerID,
-	}
-	w.Header().Set("Content-Type", "application/json")
-	resJSON, err := protojson.Marshal(resp)
-	if err != nil {
-		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
-		return
-	}
-	w.Write(resJSON)
+	}, nil
 }
 
 // HandleJoinGame allows a player to join an existing game by code.
 func (h *Handler) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
-	type apiRequest struct {
-		PlayerName string `json:"playerName"`
-	}
-	var req apiRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PlayerName == "" {
+	var req catanv1.JoinGameRequest
+	if body, err := io.ReadAll(r.Body); err != nil || apiUnmarshal.Unmarshal(body, &req) != nil {
 		http.Error(w, "invalid request", http.StatusBadRequest)
 		return
 	}
+	userID, ok := h.optionalUser(w, r)
+	if !ok {
+		return
+	}
 
 	// Extract code from URL (expect /api/games/{code}/join)
 	parts := strings.Split(r.URL.Path, "/")
@@ -153,28 +308,44 @@ func (h *Handler) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid path", http.StatusBadRequest)
 		return
 	}
-	code := strings.ToUpper(parts[3])
+	req.Code = parts[3]
 
-	// Look up game by code
-	var (
-		gameID    string
-		stateJSON string
-	)
-	err := h.db.Get(&gameID, "SELECT id FROM games WHERE code = ?", code)
-	if err != nil || gameID == "" {
-		http.Error(w, "game not found", http.StatusNotFound)
+	resp, err := h.joinGame(&req, userID)
+	if err != nil {
+		writeRequestError(w, err)
 		return
 	}
-	err = h.db.Get(&stateJSON, "SELECT state FROM games WHERE id = ?", gameID)
-	if err != nil || stateJSON == "" {
-		http.Error(w, "game not found", http.StatusNotFound)
-		return
+	writeProto(w, resp)
+}
+
+// joinGame seats req's player in the game with req's code and tells the
+// players already there. A signed-in user who already has a seat gets it
+// back instead of a new one.
+func (h *Handler) joinGame(req *catanv1.JoinGameRequest, userID *string) (*catanv1.JoinGameResponse, error) {
+	if req.PlayerName == "" {
+		return nil, refuse(http.StatusBadRequest, "invalid request")
 	}
 
-	var state catanv1.GameState
-	if err := protojson.Unmarshal([]byte(stateJSON), &state); err != nil {
-		http.Error(w, "failed to parse game state", http.StatusInternalServerError)
-		return
+	// Look up game by code
+	gameID, _, err := h.loadGameByCode(strings.ToUpper(req.Code))
+	if err != nil {
+		return nil, refuse(http.StatusNotFound, "game not found")
+	}
+	unlock, err := h.takeGame(gameID)
+	if err != nil {
+		slog.Warn("failed to take game for join", "game_id", gameID, "err", err)
+		return nil, refuse(http.StatusServiceUnavailable, "game is busy, try again")
+	}
+	defer unlock()
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		return nil, refuse(http.StatusNotFound, "game not found")
+	}
+
+	if userID != nil {
+		if seat, err := h.store.GetPlayerByUser(gameID, *userID); err == nil {
+			return joinResponse(gameID, seat.ID, seat.SessionToken, state), nil
+		}
 	}
 
 	// Assign first unused color
@@ -198,8 +369,7 @@ func (h *Handler) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if !found {
-		http.Error(w, "game full", http.StatusBadRequest)
-		return
+		return nil, refuse(http.StatusBadRequest, "game full")
 	}
 
 	playerID := uuid.New().String()
@@ -221,27 +391,35 @@ func (h *Handler) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
 	state.Players = append(state.Players, newPlayer)
 
 	// Persist updated state
-	updatedStateJSON, err := protojson.Marshal(&state)
-	if err != nil {
-		http.Error(w, "failed to marshal state", http.StatusInternalServerError)
-		return
-	}
-	_, err = h.db.Exec(`UPDATE games SET state = ? WHERE id = ?`, string(updatedStateJSON), gameID)
-	if err != nil {
-		http.Error(w, "failed to update game", http.StatusInternalServerError)
-		return
+	if err := h.saveGameState(gameID, state); err != nil {
+		return nil, refuse(http.StatusInternalServerError, "failed to update game")
 	}
 
-	// Add player to players table
-	_, err = h.db.Exec(
-		`INSERT INTO players (id, game_id, name, color, session_token, is_host, connected) VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		playerID, gameID, req.PlayerName, int(color), sessionToken, 0, 0)
+	// Add player seat
+	err = h.store.AddPlayer(&store.Player{
+		ID:           playerID,
+		GameID:       gameID,
+		Name:         req.PlayerName,
+		Color:        color,
+		SessionToken: sessionToken,
+		UserID:       derefString(userID),
+	})
 	if err != nil {
-		http.Error(w, "failed to insert player", http.StatusInternalServerError)
-		return
+		return nil, refuse(http.StatusInternalServerError, "failed to insert player")
 	}
+	_ = h.appendGameEvent(gameID, "joinGame", playerID, nil, state)
 
-	// Construct response, listing all players
+	// Broadcast updated game state to connected clients
+	h.broadcastGameStatePersonalized(gameID, state)
+	return joinResponse(gameID, playerID, sessionToken, state), nil
+}
+
+func writeJoinResponse(w http.ResponseWriter, gameID, playerID, sessionToken string, state *catanv1.GameState) {
+	writeProto(w, joinResponse(gameID, playerID, sessionToken, state))
+}
+
+// joinResponse hands a seat in gameID to its player, listing all players.
+func joinResponse(gameID, playerID, sessionToken string, state *catanv1.GameState) *catanv1.JoinGameResponse {
 	players := make([]*catanv1.PlayerInfo, len(state.Players))
 	for i, p := range state.Players {
 		players[i] = &catanv1.PlayerInfo{
@@ -250,23 +428,26 @@ func (h *Handler) HandleJoinGame(w http.ResponseWriter, r *http.Request) {
 			Color: p.Color,
 		}
 	}
-	resp := &catanv1.JoinGameResponse{
+	return &catanv1.JoinGameResponse{
 		GameId:       gameID,
 		SessionToken: sessionToken,
 		PlayerId:     playerID,
 		Players:      players,
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	resJSON, err := protojson.Marshal(resp)
-	if err != nil {
-		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
-		return
+// optionalUser resolves the request's bearer token. Requests without one are
+// anonymous (nil user); an invalid token is rejected with 401 and ok=false.
+func (h *Handler) optionalUser(w http.ResponseWriter, r *http.Request) (userID *string, ok bool) {
+	if bearerToken(r.Header) == "" {
+		return nil, true
 	}
-	w.Write(resJSON)
-
-	// Broadcast updated game state to connected clients
-	h.broadcastGameStatePersonalized(gameID, &state)
+	id, valid := h.authenticatedUser(r)
+	if !valid {
+		http.Error(w, "invalid auth token", http.StatusUnauthorized)
+		return nil, false
+	}
+	return &id, true
 }
 
 func randomCode(n int) string {
@@ -284,7 +465,23 @@ func (h *Handler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) HandleGameRoutes(w http.ResponseWriter, r *http.Request) {
-	if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/join") {
+	switch path := strings.TrimSuffix(r.URL.Path, "/"); {
+	case path == "/api/games/import":
+		h.HandleImportGame(w, r)
+		return
+	case strings.HasSuffix(path, "/export"):
+		h.HandleExportGame(w, r)
+		return
+	case strings.HasSuffix(path, "/fork"):
+		h.HandleForkGame(w, r)
+		return
+	case strings.HasSuffix(path, "/rematch"):
+		h.HandleRematch(w, r)
+		return
+	case strings.HasSuffix(path, "/pending"):
+		h.HandlePendingActions(w, r)
+		return
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/join"):
 		h.HandleJoinGame(w, r)
 		return
 	}
@@ -299,11 +496,19 @@ func (h *Handler) HandleGameRoutes(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid path", http.StatusBadRequest)
 		return
 	}
+	resp, err := h.gameInfo(code)
+	if err != nil {
+		writeRequestError(w, err)
+		return
+	}
+	writeProto(w, resp)
+}
 
+// gameInfo describes the game with code to anyone who has the code.
+func (h *Handler) gameInfo(code string) (*catanv1.GameInfoResponse, error) {
 	_, state, err := h.loadGameByCode(code)
 	if err != nil {
-		http.Error(w, "game not found", http.StatusNotFound)
-		return
+		return nil, refuse(http.StatusNotFound, "game not found")
 	}
 
 	players := make([]*catanv1.PlayerInfo, 0, len(state.Players))
@@ -315,38 +520,38 @@ func (h *Handler) HandleGameRoutes(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	resp := &catanv1.GameInfoResponse{
+	return &catanv1.GameInfoResponse{
 		Code:        code,
 		Status:      state.Status,
 		PlayerCount: int32(len(state.Players)),
 		Players:     players,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	resJSON, err := protojson.Marshal(resp)
-	if err != nil {
-		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
-		return
-	}
-	w.Write(resJSON)
+	}, nil
 }
 
+// HandleWebSocket connects a player to their game: GET /ws?token={sessionToken}
+// Anyone with a join code may watch instead: GET /ws?spectate={code}
 func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 	if token == "" {
+		if code := r.URL.Query().Get("spectate"); code != "" {
+			h.handleSpectator(w, r, strings.ToUpper(code))
+			return
+		}
 		http.Error(w, "missing token", http.StatusUnauthorized)
 		return
 	}
 
-	var player struct {
-		ID     string `db:"id"`
-		GameID string `db:"game_id"`
-	}
-	if err := h.db.Get(&player, "SELECT id, game_id FROM players WHERE session_token = ?", token); err != nil {
+	player, err := h.store.GetPlayerBySession(token)
+	if err != nil {
 		http.Error(w, "invalid token", http.StatusUnauthorized)
 		return
 	}
 
+	if !h.originAllowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
 	conn, err := wsUpgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return
@@ -360,18 +565,112 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	h.hub.Register(client)
 
 	// Mark player connected in DB + game state, then broadcast state.
-	state, err := h.loadGameState(player.GameID)
-	if err == nil && state != nil {
-		markPlayerConnected(state, player.ID, true)
-		_ = h.saveGameState(player.GameID, state)
-		h.broadcastGameStatePersonalized(player.GameID, state)
+	h.connectPlayer(client)
+	_ = h.store.SetPlayerConnected(player.ID, true)
+	h.sendChatHistory(client)
+
+	go client.WritePump()
+	go client.ReadPump()
+}
+
+// connectPlayer marks client's player connected in the game state on the
+// instance holding the game, which broadcasts the result. If that fails,
+// the client still gets the stored state.
+func (h *Handler) connectPlayer(client *hub.Client) {
+	if _, refused := h.runCommand(client.GameID, client.PlayerID, gameCommand{Kind: kindConnect}, nil); refused != nil {
+		playerLogger(client.GameID, client.PlayerID, kindConnect).Warn("failed to mark player connected", "err", refused.Message)
+		if state, err := h.loadGameState(client.GameID); err == nil {
+			h.sendGameStateTo(client, state)
+		}
 	}
-	_, _ = h.db.Exec("UPDATE players SET connected = 1, last_seen = CURRENT_TIMESTAMP WHERE id = ?", player.ID)
+}
+
+// markConnected marks playerID connected in gameID's state, saves and
+// broadcasts it. Unlike a command it is neither logged nor refused while
+// the game is paused. The caller holds the game's lock.
+func (h *Handler) markConnected(gameID, playerID string) (*catanv1.GameState, *catanv1.ErrorPayload) {
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		return nil, errorPayload("load_failed", "failed to load game state")
+	}
+	markPlayerConnected(state, playerID, true)
+	if err := h.saveGameState(gameID, state); err != nil {
+		return nil, errorPayload("persist_failed", "failed to save game state")
+	}
+	h.broadcastGameStatePersonalized(gameID, state)
+	return state, nil
+}
+
+// handleSpectator connects a spectator to the game with code. Spectators
+// follow the game with every player's dev cards hidden and may chat unless
+// the host turned that off, but send no game commands.
+func (h *Handler) handleSpectator(w http.ResponseWriter, r *http.Request, code string) {
+	gameID, _, err := h.loadGameByCode(code)
+	if err != nil {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	if !h.originAllowed(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	client := hub.NewClient(h.hub, conn, "spectator-"+uuid.New().String(), gameID)
+	client.Spectator = true
+	client.OnMessage = func(payload []byte) {
+		h.handleClientMessage(client, payload)
+	}
+
+	// Register before reading the state so no update falls in between
+	h.hub.Register(client)
+	if state, err := h.loadGameState(gameID); err == nil {
+		h.sendGameStateTo(client, state)
+	}
+	h.sendChatHistory(client)
 
 	go client.WritePump()
 	go client.ReadPump()
 }
 
+// devUpdate applies a DEV endpoint's change to the game with code under its
+// lock, then saves and broadcasts it. change returns an HTTP status with its
+// error.
+func (h *Handler) devUpdate(code string, change func(state *catanv1.GameState) (int, error)) (*catanv1.GameState, int, error) {
+	g, err := h.store.GetGameByCode(code)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("Game not found")
+	}
+	unlock, err := h.takeGame(g.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, http.StatusNotFound, errors.New("Game not found")
+	}
+	if err != nil {
+		slog.Warn("failed to take game for dev update", "game_id", g.ID, "err", err)
+		return nil, http.StatusServiceUnavailable, errors.New("game is busy, try again")
+	}
+	defer unlock()
+	state, err := h.loadGameState(g.ID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, http.StatusNotFound, errors.New("Game not found")
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load game state")
+	}
+	if code, err := change(state); err != nil {
+		return nil, code, err
+	}
+	if err := h.saveGameState(g.ID, state); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to persist game state")
+	}
+	h.broadcastGameStatePersonalized(g.ID, state)
+	return state, 0, nil
+}
+
 func (h *Handler) HandleGrantResources(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -405,37 +704,31 @@ func (h *Handler) HandleGrantResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gameID, state, err := h.loadGameByCode(req.GameCode)
-	if err != nil || state == nil {
-		http.Error(w, "Game not found", http.StatusNotFound)
-		return
-	}
-
 	var target *catanv1.PlayerState
-	for _, player := range state.Players {
-		if player.Id == req.PlayerID {
-			target = player
-			break
+	_, code, err := h.devUpdate(req.GameCode, func(state *catanv1.GameState) (int, error) {
+		for _, player := range state.Players {
+			if player.Id == req.PlayerID {
+				target = player
+				break
+			}
 		}
-	}
-	if target == nil {
-		http.Error(w, "Player not found", http.StatusNotFound)
-		return
-	}
-	if target.Resources == nil {
-		target.Resources = &catanv1.ResourceCount{}
-	}
-	target.Resources.Wood += req.Resources.Wood
-	target.Resources.Brick += req.Resources.Brick
-	target.Resources.Sheep += req.Resources.Sheep
-	target.Resources.Wheat += req.Resources.Wheat
-	target.Resources.Ore += req.Resources.Ore
-
-	if err := h.saveGameState(gameID, state); err != nil {
-		http.Error(w, "failed to persist game state", http.StatusInternalServerError)
+		if target == nil {
+			return http.StatusNotFound, errors.New("Player not found")
+		}
+		if target.Resources == nil {
+			target.Resources = &catanv1.ResourceCount{}
+		}
+		target.Resources.Wood += req.Resources.Wood
+		target.Resources.Brick += req.Resources.Brick
+		target.Resources.Sheep += req.Resources.Sheep
+		target.Resources.Wheat += req.Resources.Wheat
+		target.Resources.Ore += req.Resources.Ore
+		return 0, nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), code)
 		return
 	}
-	h.broadcastGameStatePersonalized(gameID, state)
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]any{
@@ -454,128 +747,206 @@ func (h *Handler) handleClientMessage(client *hub.Client, payload []byte) {
 	if client == nil || client.GameID == "" || len(payload) == 0 {
 		return
 	}
+	if !h.beginCommand() {
+		h.sendError(client, "shutting_down", "server is shutting down")
+		return
+	}
+	defer h.inflight.Done()
 
 	var envelope clientEnvelope
-	if err := json.Unmarshal(payload, &envelope); err != nil {
+	err := json.Unmarshal(payload, &envelope)
+	kind := envelope.Message.OneofKind
+	if !clientMessageTypes[kind] {
+		kind = "unknown"
+	}
+	h.metrics.messages.Inc(kind)
+	logger := commandLogger(client, kind)
+
+	if ok, strikes := h.limiter.allow(client, time.Now()); !ok {
+		logger.Warn("message rate limited", "strikes", strikes)
+		h.sendError(client, "rate_limited", "too many messages, slow down")
+		h.enforceStrikes(client, strikes)
+		return
+	}
+	if err != nil || envelope.Message.OneofKind == "" {
+		logger.Warn("malformed client message", "err", err)
 		h.sendError(client, "bad_request", "invalid client message")
+		h.enforceStrikes(client, h.limiter.strike(client, time.Now()))
+		return
+	}
+	logger.Debug("client message received")
+
+	if envelope.Message.OneofKind == "sendChat" {
+		h.handleSendChat(client, envelope.Message.SendChat)
+		return
+	}
+	if client.Spectator {
+		h.sendError(client, "bad_request", "spectators can only chat")
+		return
+	}
+	cmd := gameCommand{Kind: envelope.Message.OneofKind, Payload: payload}
+	apply, err := h.decodeCommand(cmd, client.PlayerID)
+	if errors.Is(err, errUnknownCommand) {
+		logger.Warn("unknown client message type", "oneof_kind", envelope.Message.OneofKind)
+		h.sendError(client, "bad_request", err.Error())
+		h.enforceStrikes(client, h.limiter.strike(client, time.Now()))
+		return
+	}
+	if err != nil {
+		h.sendError(client, "bad_request", err.Error())
 		return
 	}
+	h.applyGameUpdate(client, cmd, apply)
+}
+
+// errUnknownCommand is returned by decodeCommand for a message that is not
+// a game command.
+var errUnknownCommand = errors.New("unknown message type")
 
-	switch envelope.Message.OneofKind {
+// decodeCommand reads the game command in cmd's frame into the change it
+// makes to a game on behalf of playerID.
+func (h *Handler) decodeCommand(cmd gameCommand, playerID string) (func(state *catanv1.GameState) error, error) {
+	var envelope clientEnvelope
+	if err := json.Unmarshal(cmd.Payload, &envelope); err != nil || envelope.Message.OneofKind != cmd.Kind {
+		return nil, errors.New("invalid client message")
+	}
+	switch cmd.Kind {
 	case "playerReady":
 		var msg catanv1.PlayerReadyMessage
 		if err := protojson.Unmarshal(envelope.Message.PlayerReady, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid ready payload")
-			return
+			return nil, errors.New("invalid ready payload")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.SetPlayerReady(state, client.PlayerID, msg.Ready)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.SetPlayerReady(state, playerID, msg.Ready)
+		}, nil
 	case "startGame":
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.StartGame(state, client.PlayerID)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.StartGame(state, playerID)
+		}, nil
 	case "buildStructure":
 		var msg catanv1.BuildStructureMessage
 		if err := protojson.Unmarshal(envelope.Message.BuildStruct, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid build payload")
-			return
+			return nil, errors.New("invalid build payload")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return applyBuildStructure(state, client.PlayerID, &msg)
-		})
+		return func(state *catanv1.GameState) error {
+			return applyBuildStructure(state, playerID, &msg)
+		}, nil
 	case "rollDice":
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			_, err := game.PerformDiceRoll(state, client.PlayerID)
+		return func(state *catanv1.GameState) error {
+			_, err := game.PerformDiceRoll(state, playerID)
 			return err
-		})
+		}, nil
 	case "endTurn":
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.EndTurn(state, client.PlayerID)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.EndTurn(state, playerID)
+		}, nil
 	case "setTurnPhase":
-		h.handleSetTurnPhase(client, envelope.Message.SetTurnPhase)
+		var msg catanv1.SetTurnPhaseMessage
+		if err := protojson.Unmarshal(envelope.Message.SetTurnPhase, &msg); err != nil {
+			return nil, errors.New("invalid turn phase payload")
+		}
+		return func(state *catanv1.GameState) error {
+			return game.SetTurnPhase(state, playerID, msg.Phase)
+		}, nil
 	case "proposeTrade":
 		var msg catanv1.ProposeTradeMessage
 		if err := protojson.Unmarshal(envelope.Message.ProposeTrade, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid trade payload")
-			return
+			return nil, errors.New("invalid trade payload")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			_, err := game.ProposeTrade(state, client.PlayerID, msg.TargetId, msg.Offering, msg.Requesting)
+		return func(state *catanv1.GameState) error {
+			_, err := game.ProposeTrade(state, playerID, msg.TargetId, msg.Offering, msg.Requesting)
 			return err
-		})
+		}, nil
 	case "respondTrade":
 		var msg catanv1.RespondTradeMessage
 		if err := protojson.Unmarshal(envelope.Message.RespondTrade, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid trade response")
-			return
+			return nil, errors.New("invalid trade response")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.RespondTrade(state, msg.TradeId, client.PlayerID, msg.Accept)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.RespondTrade(state, msg.TradeId, playerID, msg.Accept)
+		}, nil
 	case "bankTrade":
 		var msg catanv1.BankTradeMessage
 		if err := protojson.Unmarshal(envelope.Message.BankTrade, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid bank trade")
-			return
+			return nil, errors.New("invalid bank trade")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.BankTrade(state, client.PlayerID, msg.Offering, msg.ResourceRequested)
-		})
+		return func(state *catanv1.GameState) error {
+			return applyBankTrade(state, playerID, &msg)
+		}, nil
 	case "buyDevCard":
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			_, err := game.BuyDevCard(state, client.PlayerID)
+		return func(state *catanv1.GameState) error {
+			_, err := game.BuyDevCard(state, playerID)
 			return err
-		})
+		}, nil
 	case "playDevCard":
 		var msg catanv1.PlayDevCardMessage
 		if err := protojson.Unmarshal(envelope.Message.PlayDevCard, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid dev card")
-			return
+			return nil, errors.New("invalid dev card")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.PlayDevCard(state, client.PlayerID, msg.CardType, msg.TargetResource, msg.Resources)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.PlayDevCard(state, playerID, msg.CardType, msg.TargetResource, msg.Resources)
+		}, nil
 	case "discardCards":
 		var msg catanv1.DiscardCardsMessage
 		if err := protojson.Unmarshal(envelope.Message.DiscardCards, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid discard payload")
-			return
+			return nil, errors.New("invalid discard payload")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			return game.DiscardCards(state, client.PlayerID, msg.Resources)
-		})
+		return func(state *catanv1.GameState) error {
+			return game.DiscardCards(state, playerID, msg.Resources)
+		}, nil
 	case "moveRobber":
 		var msg catanv1.MoveRobberMessage
 		if err := protojson.Unmarshal(envelope.Message.MoveRobber, &msg); err != nil {
-			h.sendError(client, "bad_request", "invalid robber payload")
-			return
+			return nil, errors.New("invalid robber payload")
 		}
-		h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-			if msg.VictimId != nil && *msg.VictimId != "" {
-				_, err := game.StealFromPlayer(state, client.PlayerID, *msg.VictimId)
-				return err
-			}
-			return game.MoveRobber(state, client.PlayerID, msg.Hex)
-		})
+		return func(state *catanv1.GameState) error {
+			return applyMoveRobber(state, playerID, &msg)
+		}, nil
+	case "muteChat":
+		var msg catanv1.MuteChatMessage
+		if err := protojson.Unmarshal(envelope.Message.MuteChat, &msg); err != nil {
+			return nil, errors.New("invalid mute payload")
+		}
+		return func(state *catanv1.GameState) error {
+			return game.SetChatMuted(state, playerID, msg.PlayerId, msg.Muted)
+		}, nil
+	case "setChatSettings":
+		var msg catanv1.SetChatSettingsMessage
+		if err := protojson.Unmarshal(envelope.Message.ChatSettings, &msg); err != nil {
+			return nil, errors.New("invalid chat settings")
+		}
+		return func(state *catanv1.GameState) error {
+			return game.SetSpectatorChatDisabled(state, playerID, msg.SpectatorChatDisabled)
+		}, nil
+	case "requestTakeback":
+		return func(state *catanv1.GameState) error {
+			return h.requestTakeback(state, playerID)
+		}, nil
+	case "respondTakeback":
+		var msg catanv1.RespondTakebackMessage
+		if err := protojson.Unmarshal(envelope.Message.TakebackVote, &msg); err != nil {
+			return nil, errors.New("invalid takeback vote")
+		}
+		return func(state *catanv1.GameState) error {
+			return h.respondTakeback(state, playerID, msg.Approve)
+		}, nil
+	case "approveExport":
+		return func(state *catanv1.GameState) error {
+			return game.ApproveExport(state, playerID)
+		}, nil
 	default:
-		h.sendError(client, "bad_request", "unknown message type")
+		return nil, errUnknownCommand
 	}
 }
 
-func (h *Handler) handleSetTurnPhase(client *hub.Client, payload []byte) {
-	if client == nil || client.GameID == "" || len(payload) == 0 {
-		return
-	}
-	var msg catanv1.SetTurnPhaseMessage
-	if err := protojson.Unmarshal(payload, &msg); err != nil {
-		h.sendError(client, "bad_request", "invalid turn phase payload")
+// enforceStrikes disconnects a client once it has had too many messages
+// rejected in quick succession for flooding or being malformed.
+func (h *Handler) enforceStrikes(client *hub.Client, strikes int) {
+	if h.limiter.limits.MaxStrikes <= 0 || strikes < h.limiter.limits.MaxStrikes {
 		return
 	}
-	h.applyGameUpdate(client, func(state *catanv1.GameState) error {
-		return game.SetTurnPhase(state, client.PlayerID, msg.Phase)
-	})
+	h.limiter.forget(client)
+	h.hub.Disconnect(client, websocket.ClosePolicyViolation, "too many rejected messages")
 }
 
 func (h *Handler) HandleGrantDevCard(w http.ResponseWriter, r *http.Request) {
@@ -607,20 +978,6 @@ func (h *Handler) HandleForceDiceRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gameID, state, err := h.loadGameByCode(req.GameCode)
-	if err != nil || state == nil {
-		http.Error(w, "Game not found", http.StatusNotFound)
-		return
-	}
-	if state.Status != catanv1.GameStatus_GAME_STATUS_PLAYING {
-		http.Error(w, "game not in playing status", http.StatusBadRequest)
-		return
-	}
-	if int(state.CurrentTurn) < 0 || int(state.CurrentTurn) >= len(state.Players) {
-		http.Error(w, "invalid current turn", http.StatusBadRequest)
-		return
-	}
-
 	die1 := req.DiceValue - 1
 	if die1 > 6 {
 		die1 = 6
@@ -631,20 +988,27 @@ func (h *Handler) HandleForceDiceRoll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_ROLL
-	playerID := state.Players[state.CurrentTurn].Id
-	result, err := game.PerformDiceRollWithValues(state, playerID, die1, die2)
+	var result *game.DiceRollResult
+	_, code, err := h.devUpdate(req.GameCode, func(state *catanv1.GameState) (int, error) {
+		if state.Status != catanv1.GameStatus_GAME_STATUS_PLAYING {
+			return http.StatusBadRequest, errors.New("game not in playing status")
+		}
+		if int(state.CurrentTurn) < 0 || int(state.CurrentTurn) >= len(state.Players) {
+			return http.StatusBadRequest, errors.New("invalid current turn")
+		}
+		state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_ROLL
+		playerID := state.Players[state.CurrentTurn].Id
+		var err error
+		if result, err = game.PerformDiceRollWithValues(state, playerID, die1, die2); err != nil {
+			return http.StatusBadRequest, errors.New("failed to roll dice")
+		}
+		return 0, nil
+	})
 	if err != nil {
-		http.Error(w, "failed to roll dice", http.StatusBadRequest)
+		http.Error(w, err.Error(), code)
 		return
 	}
 
-	if err := h.saveGameState(gameID, state); err != nil {
-		http.Error(w, "failed to persist game state", http.StatusInternalServerError)
-		return
-	}
-	h.broadcastGameStatePersonalized(gameID, state)
-
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]any{
 		"gameCode":        req.GameCode,
@@ -681,42 +1045,32 @@ func (h *Handler) HandleSetGameState(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gameID, state, err := h.loadGameByCode(req.GameCode)
-	if err != nil || state == nil {
-		http.Error(w, "Game not found", http.StatusNotFound)
-		return
-	}
-	prevStatus := state.Status
-
-	if req.Status != "" {
-		status, ok := parseGameStatus(req.Status)
-		if !ok {
-			http.Error(w, "invalid status", http.StatusBadRequest)
-			return
+	var prevStatus catanv1.GameStatus
+	state, code, err := h.devUpdate(req.GameCode, func(state *catanv1.GameState) (int, error) {
+		prevStatus = state.Status
+		if req.Status != "" {
+			status, ok := parseGameStatus(req.Status)
+			if !ok {
+				return http.StatusBadRequest, errors.New("invalid status")
+			}
+			state.Status = status
 		}
-		state.Status = status
-	}
-	if req.Phase != "" {
-		phase, ok := parseTurnPhase(req.Phase)
-		if !ok {
-			http.Error(w, "invalid phase", http.StatusBadRequest)
-			return
+		if req.Phase != "" {
+			phase, ok := parseTurnPhase(req.Phase)
+			if !ok {
+				return http.StatusBadRequest, errors.New("invalid phase")
+			}
+			state.TurnPhase = phase
 		}
-		state.TurnPhase = phase
-	}
-
-	if err := h.saveGameState(gameID, state); err != nil {
-		http.Error(w, "failed to persist game state", http.StatusInternalServerError)
+		return 0, nil
+	})
+	if err != nil {
+		http.Error(w, err.Error(), code)
 		return
 	}
-	h.broadcastGameStatePersonalized(gameID, state)
 
 	if prevStatus != catanv1.GameStatus_GAME_STATUS_FINISHED && state.Status == catanv1.GameStatus_GAME_STATUS_FINISHED {
-		winnerID, ok := game.DetermineWinner(state)
-		if !ok {
-			winnerID = ""
-		}
-		h.broadcastGameOver(state, winnerID)
+		h.finishGame(state)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -764,14 +1118,9 @@ func (h *Handler) handleMoveRobber(client *hub.Client, payload []byte) {
 	if client == nil || client.GameID == "" || len(payload) == 0 {
 		return
 	}
-	// Retrieve game state from DB
-	var stateJSON string
-	err := h.db.Get(&stateJSON, "SELECT state FROM games WHERE id = ?", client.GameID)
-	if err != nil || stateJSON == "" {
-		return
-	}
-	var state catanv1.GameState
-	if err := protojson.Unmarshal([]byte(stateJSON), &state); err != nil {
+	// Retrieve game state
+	state, err := h.loadGameState(client.GameID)
+	if err != nil || state.Paused {
 		return
 	}
 	// Unmarshal move robber message
@@ -782,21 +1131,17 @@ func (h *Handler) handleMoveRobber(client *hub.Client, payload []byte) {
 	// Apply robber move or steal
 	if msg.VictimId != nil && *msg.VictimId != "" {
 		// Steal action
-		_, _ = game.StealFromPlayer(&state, client.PlayerID, *msg.VictimId)
+		_, _ = game.StealFromPlayer(state, client.PlayerID, *msg.VictimId)
 	} else if msg.Hex != nil {
 		// Move robber
-		_ = game.MoveRobber(&state, client.PlayerID, msg.Hex)
+		_ = game.MoveRobber(state, client.PlayerID, msg.Hex)
 	} else {
 		return
 	}
 	// Persist updated state
-	newStateJSON, err := protojson.Marshal(&state)
-	if err != nil {
-		return
-	}
-	_, _ = h.db.Exec("UPDATE games SET state = ? WHERE id = ?", string(newStateJSON), client.GameID)
+	_ = h.saveGameState(client.GameID, state)
 	// Broadcast updated state
-	h.broadcastGameStatePersonalized(client.GameID, &state)
+	h.broadcastGameStatePersonalized(client.GameID, state)
 }
 
 func applyBuildStructure(state *catanv1.GameState, playerID string, msg *catanv1.BuildStructureMessage) error {
@@ -822,6 +1167,25 @@ func applyBuildStructure(state *catanv1.GameState, playerID string, msg *catanv1
 	}
 }
 
+// applyBankTrade makes a single-card bank trade, or a multi-line one when
+// msg has lines.
+func applyBankTrade(state *catanv1.GameState, playerID string, msg *catanv1.BankTradeMessage) error {
+	if len(msg.Lines) > 0 {
+		return game.BankTradeLines(state, playerID, msg.Lines)
+	}
+	return game.BankTrade(state, playerID, msg.Offering, msg.ResourceRequested)
+}
+
+// applyMoveRobber steals from msg's victim when it names one, and otherwise
+// moves the robber to msg's hex.
+func applyMoveRobber(state *catanv1.GameState, playerID string, msg *catanv1.MoveRobberMessage) error {
+	if msg.VictimId != nil && *msg.VictimId != "" {
+		_, err := game.StealFromPlayer(state, playerID, *msg.VictimId)
+		return err
+	}
+	return game.MoveRobber(state, playerID, msg.Hex)
+}
+
 func markPlayerConnected(state *catanv1.GameState, playerID string, connected bool) {
 	if state == nil {
 		return
@@ -843,114 +1207,230 @@ func extractGameCode(path string) string {
 }
 
 func (h *Handler) loadGameByCode(code string) (string, *catanv1.GameState, error) {
-	var (
-		gameID    string
-		stateJSON string
-	)
-	if err := h.db.Get(&gameID, "SELECT id FROM games WHERE code = ?", code); err != nil {
-		return "", nil, err
-	}
-	if err := h.db.Get(&stateJSON, "SELECT state FROM games WHERE id = ?", gameID); err != nil {
+	g, err := h.store.GetGameByCode(code)
+	if err != nil {
 		return "", nil, err
 	}
-	var state catanv1.GameState
-	if err := protojson.Unmarshal([]byte(stateJSON), &state); err != nil {
+	if _, err := game.UpgradeState(g.State); err != nil {
 		return "", nil, err
 	}
-	return gameID, &state, nil
+	return g.ID, g.State, nil
 }
 
 func (h *Handler) loadGameState(gameID string) (*catanv1.GameState, error) {
-	var stateJSON string
-	if err := h.db.Get(&stateJSON, "SELECT state FROM games WHERE id = ?", gameID); err != nil {
+	g, err := h.loadGame(gameID)
+	if err != nil {
 		return nil, err
 	}
-	var state catanv1.GameState
-	if err := protojson.Unmarshal([]byte(stateJSON), &state); err != nil {
+	return g.State, nil
+}
+
+// loadGame reads a stored game with its state upgraded to the current
+// version.
+func (h *Handler) loadGame(gameID string) (*store.Game, error) {
+	g, err := h.store.GetGame(gameID)
+	if err != nil {
 		return nil, err
 	}
-	return &state, nil
+	if _, err := game.UpgradeState(g.State); err != nil {
+		return nil, err
+	}
+	return g, nil
 }
 
 func (h *Handler) saveGameState(gameID string, state *catanv1.GameState) error {
 	if state == nil {
 		return errors.New("nil game state")
 	}
-	stateJSON, err := protojson.Marshal(state)
-	if err != nil {
-		return err
-	}
-	status := gameStatusToString(state.Status)
-	_, err = h.db.Exec(
-		"UPDATE games SET state = ?, status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
-		string(stateJSON),
-		status,
-		gameID,
-	)
-	return err
+	defer h.metrics.saveDuration.ObserveSince(time.Now())
+	return h.store.SaveGameState(gameID, state)
 }
 
-func gameStatusToString(status catanv1.GameStatus) string {
-	switch status {
-	case catanv1.GameStatus_GAME_STATUS_WAITING:
-		return "waiting"
-	case catanv1.GameStatus_GAME_STATUS_SETUP:
-		return "setup"
-	case catanv1.GameStatus_GAME_STATUS_PLAYING:
-		return "playing"
-	case catanv1.GameStatus_GAME_STATUS_FINISHED:
-		return "finished"
-	default:
-		return "unknown"
+func derefString(s *string) string {
+	if s == nil {
+		return ""
 	}
+	return *s
+}
+
+// gameCommand identifies a client command: its ClientMessage oneof kind and
+// the raw message it arrived in. At is when the command takes effect for
+// turn deadlines; zero means now.
+type gameCommand struct {
+	Kind    string
+	Payload []byte
+	At      time.Time
 }
 
-func (h *Handler) applyGameUpdate(client *hub.Client, apply func(state *catanv1.GameState) error) {
+// applyGameUpdate loads the client's game, applies cmd, persists, logs and
+// broadcasts the result, and tells the client if the command was refused.
+func (h *Handler) applyGameUpdate(client *hub.Client, cmd gameCommand, apply func(state *catanv1.GameState) error) {
 	if client == nil || client.GameID == "" {
 		return
 	}
-	state, err := h.loadGameState(client.GameID)
+	if _, refused := h.runCommand(client.GameID, client.PlayerID, cmd, apply); refused != nil {
+		h.sendErrorPayload(client, refused)
+	}
+}
+
+// runCommand applies cmd for playerID to gameID on the instance holding
+// the game, this one if no other does. It returns the new state, or why the
+// command was refused.
+func (h *Handler) runCommand(gameID, playerID string, cmd gameCommand, apply func(state *catanv1.GameState) error) (*catanv1.GameState, *catanv1.ErrorPayload) {
+	unlock, holder, err := h.lockGame(gameID)
 	if err != nil {
-		h.sendError(client, "load_failed", "failed to load game state")
-		return
+		playerLogger(gameID, playerID, cmd.Kind).Error("failed to lease game", "err", err)
+		return nil, errorPayload("load_failed", "failed to load game state")
 	}
+	if unlock == nil {
+		return h.forwardCommand(holder, gameID, playerID, cmd)
+	}
+	defer unlock()
+	return h.executeCommand(gameID, playerID, cmd, apply)
+}
+
+// executeCommand loads gameID, applies cmd for playerID, persists, logs and
+// broadcasts the result. The caller holds the game's lock.
+func (h *Handler) executeCommand(gameID, playerID string, cmd gameCommand, apply func(state *catanv1.GameState) error) (*catanv1.GameState, *catanv1.ErrorPayload) {
+	if cmd.Kind == kindConnect {
+		return h.markConnected(gameID, playerID)
+	}
+	start := time.Now()
+	defer h.metrics.commandDuration.ObserveSince(start, cmd.Kind)
+	logger := playerLogger(gameID, playerID, cmd.Kind)
+
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		logger.Error("failed to load game state", "err", err)
+		return nil, errorPayload("load_failed", "failed to load game state")
+	}
+	if state.Paused {
+		logger.Info("command refused while paused")
+		return nil, errorPayload("game_paused", "the game is paused by an administrator")
+	}
+	before, _ := proto.Clone(state).(*catanv1.GameState)
 	prevStatus := state.Status
 	if err := apply(state); err != nil {
-		h.sendError(client, "invalid_action", err.Error())
-		return
+		logger.Info("command rejected", "err", err)
+		return nil, ruleErrorPayload("invalid_action", err)
 	}
-	if err := h.saveGameState(client.GameID, state); err != nil {
-		h.sendError(client, "persist_failed", "failed to persist game state")
-		return
+	if !keepsTakeback[cmd.Kind] {
+		state.PendingTakeback = nil
 	}
-	h.broadcastGameStatePersonalized(client.GameID, state)
+	if cmd.Kind != "approveExport" {
+		state.ExportApprovedPlayerIds = nil
+	}
+	at := cmd.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	game.UpdateTurnDeadline(before, state, at)
+	if h.checkState(logger, state) {
+		return nil, errorPayload("invalid_state", "that would leave the game in an invalid state")
+	}
+	if err := h.saveGameState(gameID, state); err != nil {
+		logger.Error("failed to persist game state", "err", err)
+		return nil, errorPayload("persist_failed", "failed to persist game state")
+	}
+	if err := h.appendGameEvent(gameID, cmd.Kind, playerID, cmd.Payload, state); err != nil {
+		logger.Error("failed to log game event", "err", err)
+	}
+	h.recordGameStats(gameID, cmd.Kind, playerID, before, state)
+	h.broadcastGameStatePersonalized(gameID, state)
+	h.notifyPending(before, state)
+	logger.Debug("command applied", "duration", time.Since(start))
 
 	if prevStatus != catanv1.GameStatus_GAME_STATUS_FINISHED && state.Status == catanv1.GameStatus_GAME_STATUS_FINISHED {
-		winnerID, ok := game.DetermineWinner(state)
-		if !ok {
-			winnerID = ""
-		}
-		h.broadcastGameOver(state, winnerID)
+		logger.Info("game finished")
+		h.finishGame(state)
+	}
+	return state, nil
+}
+
+// finishGame announces the winner, archives the finished game and reports
+// it to its tournament, if any.
+func (h *Handler) finishGame(state *catanv1.GameState) {
+	winnerID, ok := game.DetermineWinner(state)
+	if !ok {
+		winnerID = ""
+	}
+	h.broadcastGameOver(state, winnerID)
+	if err := h.archiveFinishedGame(state, winnerID); err != nil {
+		slog.Error("failed to archive game", "game_id", state.Id, "err", err)
+	}
+	if err := h.recordTournamentResult(state, winnerID); err != nil {
+		slog.Error("failed to record tournament result", "tournament_id", state.TournamentId, "game_id", state.Id, "err", err)
 	}
 }
 
+// errorCodes maps the string codes sendError is called with to the
+// ErrorCode clients switch on.
+var errorCodes = map[string]catanv1.ErrorCode{
+	"bad_request":       catanv1.ErrorCode_ERROR_CODE_BAD_REQUEST,
+	"chat_rate_limited": catanv1.ErrorCode_ERROR_CODE_CHAT_RATE_LIMITED,
+	"chat_refused":      catanv1.ErrorCode_ERROR_CODE_INVALID_ACTION,
+	"chat_too_long":     catanv1.ErrorCode_ERROR_CODE_CHAT_TOO_LONG,
+	"game_expired":      catanv1.ErrorCode_ERROR_CODE_GAME_EXPIRED,
+	"game_paused":       catanv1.ErrorCode_ERROR_CODE_GAME_PAUSED,
+	"invalid_action":    catanv1.ErrorCode_ERROR_CODE_INVALID_ACTION,
+	"invalid_state":     catanv1.ErrorCode_ERROR_CODE_INVALID_STATE,
+	"load_failed":       catanv1.ErrorCode_ERROR_CODE_SERVER_ERROR,
+	"owner_unavailable": catanv1.ErrorCode_ERROR_CODE_SERVER_ERROR,
+	"persist_failed":    catanv1.ErrorCode_ERROR_CODE_SERVER_ERROR,
+	"rate_limited":      catanv1.ErrorCode_ERROR_CODE_RATE_LIMITED,
+	"removed":           catanv1.ErrorCode_ERROR_CODE_REMOVED,
+	"shutting_down":     catanv1.ErrorCode_ERROR_CODE_SHUTTING_DOWN,
+}
+
 func (h *Handler) sendError(client *hub.Client, code, message string) {
+	h.sendErrorPayload(client, errorPayload(code, message))
+}
+
+// sendRuleError reports err, which refused a command, under code.
+func (h *Handler) sendRuleError(client *hub.Client, code string, err error) {
+	h.sendErrorPayload(client, ruleErrorPayload(code, err))
+}
+
+func errorPayload(code, message string) *catanv1.ErrorPayload {
+	return &catanv1.ErrorPayload{Code: code, Message: message, ErrorCode: errorCodes[code]}
+}
+
+// ruleErrorPayload reports err under code. When err is a game.RuleError
+// the payload names the broken rule and carries its counts; other errors
+// fall back to the code's generic ErrorCode.
+func ruleErrorPayload(code string, err error) *catanv1.ErrorPayload {
+	payload := errorPayload(code, err.Error())
+	var re *game.RuleError
+	if errors.As(err, &re) {
+		payload.ErrorCode = re.Code
+		payload.Required = re.Required
+		payload.Held = re.Held
+	}
+	return payload
+}
+
+func (h *Handler) sendErrorPayload(client *hub.Client, e *catanv1.ErrorPayload) {
 	if client == nil {
 		return
 	}
-	payload, err := wsMarshal.Marshal(&catanv1.ErrorPayload{Code: code, Message: message})
+	h.metrics.rejected.Inc(e.Code)
+	if msg, err := errorFrame(e); err == nil {
+		client.Send(msg)
+	}
+}
+
+// errorFrame renders e as the WebSocket frame reporting it.
+func errorFrame(e *catanv1.ErrorPayload) ([]byte, error) {
+	payload, err := wsMarshal.Marshal(e)
 	if err != nil {
-		return
+		return nil, err
 	}
-	envelope := serverEnvelope{
+	return json.Marshal(serverEnvelope{
 		Message: serverMessage{
 			OneofKind: "error",
 			Error:     payload,
 		},
-	}
-	if msg, err := json.Marshal(envelope); err == nil {
-		client.Send(msg)
-	}
+	})
 }
 
 func (h *Handler) broadcastGameOver(state *catanv1.GameState, winnerID string) {
@@ -2,23 +2,35 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/gorilla/websocket"
 	"github.com/jmoiron/sqlx"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/gen/proto/catan/v1/catanv1connect"
+	"settlers_from_catan/internal/broker"
 	"settlers_from_catan/internal/db"
 	"settlers_from_catan/internal/game"
 	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/notify"
+	"settlers_from_catan/internal/store"
+	"settlers_from_catan/internal/tournament"
 )
 
 type gameStateEnvelope struct {
@@ -358,12 +370,12 @@ func TestHandleSetTurnPhase_UpdatesTurnPhase(t *testing.T) {
 			client := hub.NewClient(h, &websocket.Conn{}, tt.playerID, gameID)
 			h.Register(client)
 
-			payload, err := protojson.Marshal(&catanv1.SetTurnPhaseMessage{Phase: tt.phase})
+			frame, err := commandFrame("setTurnPhase", &catanv1.SetTurnPhaseMessage{Phase: tt.phase})
 			if err != nil {
-				t.Fatalf("failed to marshal set turn phase payload: %v", err)
+				t.Fatalf("failed to marshal set turn phase message: %v", err)
 			}
 
-			handler.handleSetTurnPhase(client, payload)
+			handler.handleClientMessage(client, frame)
 
 			var updatedStateJSON string
 			if err := database.Get(&updatedStateJSON, "SELECT state FROM games WHERE id = ?", gameID); err != nil {
@@ -689,7 +701,10 @@ func buildMux(handler *Handler) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/games", handler.HandleCreateGame)
 	mux.HandleFunc("/api/games/", handler.HandleGameRoutes)
+	mux.HandleFunc("/api/tournaments", handler.HandleTournaments)
+	mux.HandleFunc("/api/tournaments/", handler.HandleTournamentRoutes)
 	mux.HandleFunc("/ws", handler.HandleWebSocket)
+	mux.Handle(handler.ConnectHandler())
 	return mux
 }
 
@@ -772,3 +787,2309 @@ func readGameState(t *testing.T, conn *websocket.Conn, expectedPlayers int) {
 	}
 	t.Fatalf("expected game state with %d players", expectedPlayers)
 }
+
+func TestApplyGameUpdate_RecordsGameStats(t *testing.T) {
+	// Stats live in the main database wherever the games are stored
+	stores := []struct {
+		name string
+		open func(database *sqlx.DB) store.GameStore
+	}{
+		{"sqlite", func(database *sqlx.DB) store.GameStore { return store.NewSQLite(database) }},
+		{"memory", func(*sqlx.DB) store.GameStore { return store.NewMemory() }},
+	}
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			database, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			h := hub.NewHub()
+			go h.Run()
+			games := tt.open(database)
+			handler := NewHandlerWithStore(database, games, h)
+
+			gameID := "game-stats"
+			state := game.NewGameState(gameID, "STA001", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+			state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+			state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_ROLL
+			if err := games.CreateGame(&store.Game{ID: gameID, Code: state.Code, State: state}); err != nil {
+				t.Fatalf("failed to create game: %v", err)
+			}
+
+			client := hub.NewClient(h, &websocket.Conn{}, "p1", gameID)
+			h.Register(client)
+
+			handler.handleClientMessage(client, []byte(`{"message":{"oneofKind":"rollDice","rollDice":{}}}`))
+
+			var rows int
+			if err := database.Get(&rows, "SELECT COUNT(*) FROM game_stats WHERE game_id = ?", gameID); err != nil || rows != 1 {
+				t.Fatalf("expected a game_stats row, got %d (%v)", rows, err)
+			}
+			gs, err := handler.loadGameStats(gameID)
+			if err != nil {
+				t.Fatalf("failed to load game stats: %v", err)
+			}
+			rolls := 0
+			for _, count := range gs.Player("p1").Rolls {
+				rolls += count
+			}
+			if rolls != 1 {
+				t.Fatalf("expected 1 recorded roll for p1, got %d", rolls)
+			}
+
+			if err := handler.expireGame(gameID, "test"); err != nil {
+				t.Fatalf("failed to expire game: %v", err)
+			}
+			if err := database.Get(&rows, "SELECT COUNT(*) FROM game_stats WHERE game_id = ?", gameID); err != nil || rows != 0 {
+				t.Fatalf("expected the expired game's stats to be deleted, got %d (%v)", rows, err)
+			}
+		})
+	}
+}
+
+func TestArchiveFinishedGame_StatsAndLeaderboard(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	handler := NewHandler(database, hub.NewHub())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/leaderboard", handler.HandleLeaderboard)
+	mux.HandleFunc("/api/players/", handler.HandlePlayerStats)
+
+	playGame := func(gameID, code, winnerID string) {
+		state := game.NewGameState(gameID, code, []string{"Alice", "Bob"}, []string{gameID + "-alice", gameID + "-bob"})
+		state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+		for i := 0; i < 2; i++ {
+			state.Board.Vertices[i*10].Building = &catanv1.Building{
+				OwnerId: winnerID,
+				Type:    catanv1.BuildingType_BUILDING_TYPE_CITY,
+			}
+		}
+		insertGameState(t, database, state)
+		if err := handler.archiveFinishedGame(state, winnerID); err != nil {
+			t.Fatalf("failed to archive game: %v", err)
+		}
+		// Archiving twice must not double count
+		if err := handler.archiveFinishedGame(state, winnerID); err != nil {
+			t.Fatalf("failed to re-archive game: %v", err)
+		}
+	}
+	playGame("g1", "HIS001", "g1-alice")
+	playGame("g2", "HIS002", "g2-alice")
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/leaderboard", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	var board struct {
+		Players []leaderboardEntry `json:"players"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &board); err != nil {
+		t.Fatalf("failed to decode leaderboard: %v", err)
+	}
+	if len(board.Players) != 2 || board.Players[0].Name != "Alice" {
+		t.Fatalf("expected Alice to lead the leaderboard, got %+v", board.Players)
+	}
+	if board.Players[0].GamesPlayed != 2 || board.Players[0].Wins != 2 || board.Players[0].Rating <= 1500 {
+		t.Fatalf("unexpected leader entry: %+v", board.Players[0])
+	}
+	if board.Players[1].Rating >= 1500 {
+		t.Fatalf("expected Bob's rating to drop, got %f", board.Players[1].Rating)
+	}
+
+	// Lookup by per-game player ID resolves to the player's name
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/players/g1-bob/stats", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	var resp playerStatsResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode player stats: %v", err)
+	}
+	if resp.Name != "Bob" || len(resp.Games) != 2 {
+		t.Fatalf("expected 2 games for Bob, got name=%q games=%d", resp.Name, len(resp.Games))
+	}
+	for _, g := range resp.Games {
+		if g.IsWinner || g.Place != 2 {
+			t.Fatalf("expected Bob to place second, got %+v", g)
+		}
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/players/Nobody/stats", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unknown player, got %d", recorder.Code)
+	}
+}
+
+func insertGameState(t *testing.T, database *sqlx.DB, state *catanv1.GameState) {
+	t.Helper()
+	if err := store.NewSQLite(database).CreateGame(&store.Game{ID: state.Id, Code: state.Code, State: state}); err != nil {
+		t.Fatalf("failed to insert game state: %v", err)
+	}
+}
+
+func TestUserAccounts_RejoinActiveGameFromAnotherDevice(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	handler := NewHandler(database, hub.NewHub())
+	mux := buildMux(handler)
+	mux.HandleFunc("/api/auth/register", handler.HandleRegister)
+	mux.HandleFunc("/api/auth/login", handler.HandleLogin)
+	mux.HandleFunc("/api/me/games", handler.HandleMyGames)
+
+	authRequestJSON := func(path, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := authRequestJSON("/api/auth/register", `{"username":"alice","password":"correct horse"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected register to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder = authRequestJSON("/api/auth/register", `{"username":"Alice","password":"other password"}`); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected duplicate username to conflict, got %d", recorder.Code)
+	}
+	if recorder = authRequestJSON("/api/auth/login", `{"username":"alice","password":"wrong password"}`); recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected bad password to be rejected, got %d", recorder.Code)
+	}
+
+	login := func() string {
+		recorder := authRequestJSON("/api/auth/login", `{"username":"alice","password":"correct horse"}`)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected login to succeed, got %d", recorder.Code)
+		}
+		var resp authResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &resp); err != nil || resp.AuthToken == "" {
+			t.Fatalf("missing auth token in login response: %v", err)
+		}
+		return resp.AuthToken
+	}
+	laptop := login()
+	phone := login()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"playerName":"Alice"}`))
+	req.Header.Set("Authorization", "Bearer "+laptop)
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+	var created catanv1.CreateGameResponse
+	if err := protojson.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/me/games", nil)
+	req.Header.Set("Authorization", "Bearer "+phone)
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+	var mine struct {
+		Games []activeGame `json:"games"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &mine); err != nil {
+		t.Fatalf("failed to decode my games: %v", err)
+	}
+	if len(mine.Games) != 1 || mine.Games[0].SessionToken != created.SessionToken {
+		t.Fatalf("expected the created game with its seat token, got %+v", mine.Games)
+	}
+
+	// Joining the same game again returns the existing seat
+	req = httptest.NewRequest(http.MethodPost, "/api/games/"+created.Code+"/join", strings.NewReader(`{"playerName":"Alice"}`))
+	req.Header.Set("Authorization", "Bearer "+phone)
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+	var joined catanv1.JoinGameResponse
+	if err := protojson.Unmarshal(recorder.Body.Bytes(), &joined); err != nil {
+		t.Fatalf("failed to decode join response: %v", err)
+	}
+	if joined.PlayerId != created.PlayerId || len(joined.Players) != 1 {
+		t.Fatalf("expected rejoin of existing seat, got player %s with %d players", joined.PlayerId, len(joined.Players))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/me/games", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected invalid token to be rejected, got %d", recorder.Code)
+	}
+}
+
+func TestSaveGame_ExportImportAndFork(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	handler := NewHandler(database, hub.NewHub())
+	mux := buildMux(handler)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return recorder
+	}
+
+	var created catanv1.CreateGameResponse
+	if err := protojson.Unmarshal(serve(http.MethodPost, "/api/games", `{"playerName":"Alice"}`).Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+	var joined catanv1.JoinGameResponse
+	if err := protojson.Unmarshal(serve(http.MethodPost, "/api/games/"+created.Code+"/join", `{"playerName":"Bob"}`).Body.Bytes(), &joined); err != nil {
+		t.Fatalf("failed to decode join response: %v", err)
+	}
+
+	send := func(playerID, kind string, msg proto.Message) {
+		t.Helper()
+		frame, err := commandFrame(kind, msg)
+		if err != nil {
+			t.Fatalf("failed to build %s: %v", kind, err)
+		}
+		cmd := gameCommand{Kind: kind, Payload: frame}
+		apply, err := handler.decodeCommand(cmd, playerID)
+		if err != nil {
+			t.Fatalf("failed to decode %s: %v", kind, err)
+		}
+		if _, refused := handler.runCommand(created.GameId, playerID, cmd, apply); refused != nil {
+			t.Fatalf("%s refused: %s", kind, refused.Message)
+		}
+	}
+
+	if recorder := serve(http.MethodGet, "/api/games/"+created.Code+"/export?token=nope", ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected export without a seat to be forbidden, got %d", recorder.Code)
+	}
+	// The unfinished game reveals hidden cards, so every player must approve
+	send(created.PlayerId, "approveExport", &catanv1.ApproveExportMessage{})
+	if recorder := serve(http.MethodGet, "/api/games/"+created.Code+"/export?token="+created.SessionToken, ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected export without every approval to be forbidden, got %d", recorder.Code)
+	}
+	send(joined.PlayerId, "approveExport", &catanv1.ApproveExportMessage{})
+	recorder := serve(http.MethodGet, "/api/games/"+created.Code+"/export?token="+created.SessionToken, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected export to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	exported := recorder.Body.String()
+	var file saveFile
+	if err := json.Unmarshal([]byte(exported), &file); err != nil {
+		t.Fatalf("failed to decode save file: %v", err)
+	}
+	if file.AtSeq != 4 || len(file.Events) != 4 || file.Events[0].Kind != "createGame" || file.Events[1].Kind != "joinGame" || file.Events[3].Kind != "approveExport" {
+		t.Fatalf("expected create, join and approval events, got seq %d %+v", file.AtSeq, file.Events)
+	}
+	if file.Seed == 0 {
+		t.Fatalf("expected board seed in save file")
+	}
+
+	recorder = serve(http.MethodPost, "/api/games/import", exported)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected import to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var imported importResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &imported); err != nil {
+		t.Fatalf("failed to decode import response: %v", err)
+	}
+	if imported.Code == created.Code || len(imported.Seats) != 2 {
+		t.Fatalf("expected new game with two seats, got %+v", imported)
+	}
+	for _, seat := range imported.Seats {
+		if seat.PlayerID == created.PlayerId || seat.PlayerID == joined.PlayerId || seat.SessionToken == "" {
+			t.Fatalf("expected fresh player IDs and session tokens, got %+v", seat)
+		}
+	}
+	importedState, err := handler.loadGameState(imported.GameID)
+	if err != nil {
+		t.Fatalf("failed to load imported game: %v", err)
+	}
+	if importedState.Seed != file.Seed || importedState.Players[0].Id != imported.Seats[0].PlayerID {
+		t.Fatalf("expected imported state to keep seed and use new player IDs")
+	}
+
+	recorder = serve(http.MethodPost, "/api/games/"+created.Code+"/fork?at=1&token="+joined.SessionToken, "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected fork to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var forked importResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &forked); err != nil {
+		t.Fatalf("failed to decode fork response: %v", err)
+	}
+	if len(forked.Seats) != 1 || forked.Seats[0].Name != "Alice" {
+		t.Fatalf("expected fork after event 1 to only contain the host, got %+v", forked.Seats)
+	}
+
+	// Moving the game on drops the approvals
+	send(joined.PlayerId, "playerReady", &catanv1.PlayerReadyMessage{Ready: true})
+	if recorder := serve(http.MethodPost, "/api/games/"+created.Code+"/fork?token="+joined.SessionToken, ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected fork after another command to be forbidden, got %d", recorder.Code)
+	}
+
+	invalid := []struct {
+		name string
+		body string
+	}{
+		{"not json", `nope`},
+		{"wrong format", `{"format":"other","version":1}`},
+		{"future version", strings.Replace(exported, `"version":1`, `"version":99`, 1)},
+		{"unknown state field", strings.Replace(exported, `"state":{`, `"state":{"bogus":1,`, 1)},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if recorder := serve(http.MethodPost, "/api/games/import", tt.body); recorder.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSaveGame_ImportRemapsEveryPlayerReference(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	handler := NewHandler(database, hub.NewHub())
+
+	state := game.NewGameState("old-game", "OLD001", []string{"Alice", "Bob"}, []string{"old-alice", "old-bob"})
+	state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	state.Board.Vertices[0].Building = &catanv1.Building{OwnerId: "old-alice", Type: catanv1.BuildingType_BUILDING_TYPE_SETTLEMENT}
+	state.Board.Edges[0].Road = &catanv1.Road{OwnerId: "old-bob"}
+	state.LongestRoadPlayerId = proto.String("old-alice")
+	state.LargestArmyPlayerId = proto.String("old-bob")
+	state.PendingTrades = []*catanv1.TradeOffer{{Id: "t1", ProposerId: "old-alice", TargetId: proto.String("old-bob")}}
+	state.RobberPhase = &catanv1.RobberPhase{
+		DiscardPending:       []string{"old-bob"},
+		DiscardRequired:      map[string]int32{"old-bob": 4},
+		MovePendingPlayerId:  proto.String("old-alice"),
+		StealPendingPlayerId: proto.String("old-alice"),
+	}
+	state.Series = &catanv1.Series{BestOf: 3, Wins: map[string]int32{"old-alice": 1}, Points: map[string]int32{"old-bob": 7}, WinnerId: "old-alice"}
+	state.ChatMutedPlayerIds = []string{"old-bob"}
+	state.ExportApprovedPlayerIds = []string{"old-alice", "old-bob"}
+	state.PendingTakeback = &catanv1.TakebackRequest{RequesterId: "old-alice", EventSeq: 9, ApprovedPlayerIds: []string{"old-bob"}}
+	state.TakenBackSeq = 6
+	state.RematchPlayerIds = map[string]string{"old-alice": "r1"}
+	state.Paused = true
+	state.EndedByAdmin = true
+
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+	parsed, err := parseSaveFile(&saveFile{Format: saveFileFormat, Version: saveFileVersion, Seed: state.Seed, State: stateJSON})
+	if err != nil {
+		t.Fatalf("parseSaveFile: %v", err)
+	}
+	resp, err := handler.createGameFromSnapshot(parsed, "importGame")
+	if err != nil {
+		t.Fatalf("createGameFromSnapshot: %v", err)
+	}
+	imported, err := handler.loadGameState(resp.GameID)
+	if err != nil {
+		t.Fatalf("failed to load imported game: %v", err)
+	}
+
+	importedJSON, err := protojson.Marshal(imported)
+	if err != nil {
+		t.Fatalf("failed to marshal imported state: %v", err)
+	}
+	if strings.Contains(string(importedJSON), "old-") {
+		t.Fatalf("expected no reference to the old game's IDs, got %s", importedJSON)
+	}
+	bob := resp.Seats[1].PlayerID
+	if len(imported.ChatMutedPlayerIds) != 1 || imported.ChatMutedPlayerIds[0] != bob {
+		t.Errorf("expected Bob to stay muted under their new ID, got %v", imported.ChatMutedPlayerIds)
+	}
+	if imported.GetRobberPhase().GetDiscardRequired()[bob] != 4 || imported.GetSeries().GetPoints()[bob] != 7 {
+		t.Errorf("expected Bob's robber discard and series points kept, got %+v and %+v", imported.RobberPhase, imported.Series)
+	}
+	if imported.Paused || imported.EndedByAdmin || imported.PendingTakeback != nil || imported.TakenBackSeq != 0 || len(imported.ExportApprovedPlayerIds) != 0 {
+		t.Errorf("expected the old game's votes and administrator flags dropped, got %v", imported)
+	}
+}
+
+func TestHandler_WithMemoryStore(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	games := store.NewMemory()
+	handler := NewHandlerWithStore(database, games, hub.NewHub())
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	created := createGameViaHTTP(t, server.URL, "Alice")
+	joined := joinGameViaHTTP(t, server.URL, created.GetCode(), "Bob")
+	if len(joined.GetPlayers()) != 2 {
+		t.Fatalf("expected 2 players after join, got %d", len(joined.GetPlayers()))
+	}
+
+	var rows int
+	if err := database.Get(&rows, "SELECT COUNT(*) FROM games"); err != nil || rows != 0 {
+		t.Fatalf("expected no games in the SQL database, got %d (%v)", rows, err)
+	}
+	g, err := games.GetGameByCode(created.GetCode())
+	if err != nil || len(g.State.Players) != 2 {
+		t.Fatalf("expected game with 2 players in the memory store, got %v", err)
+	}
+	if events, _ := games.ListEvents(g.ID, 0); len(events) != 2 {
+		t.Fatalf("expected create and join events, got %d", len(events))
+	}
+	if seat, err := games.GetPlayerBySession(joined.GetSessionToken()); err != nil || seat.Name != "Bob" {
+		t.Fatalf("expected Bob's seat in the memory store, got %+v (%v)", seat, err)
+	}
+}
+
+func TestSweepExpiredGames_ExpiresLobbiesAndArchivesFinished(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	handler := NewHandler(database, h)
+
+	newState := func(gameID, code string, status catanv1.GameStatus) *catanv1.GameState {
+		state := game.NewGameState(gameID, code, []string{"Alice", "Bob"}, []string{gameID + "-alice", gameID + "-bob"})
+		state.Status = status
+		insertGameState(t, database, state)
+		return state
+	}
+	newState("lobby", "JAN001", catanv1.GameStatus_GAME_STATUS_WAITING)
+	newState("playing", "JAN002", catanv1.GameStatus_GAME_STATUS_PLAYING)
+	table := newState("table", "JAN006", catanv1.GameStatus_GAME_STATUS_WAITING)
+	table.TournamentId = "tournament-1"
+	if err := handler.saveGameState("table", table); err != nil {
+		t.Fatalf("failed to save tournament table: %v", err)
+	}
+	finished := newState("finished", "JAN003", catanv1.GameStatus_GAME_STATUS_FINISHED)
+	finished.Board.Vertices[0].Building = &catanv1.Building{OwnerId: "finished-alice", Type: catanv1.BuildingType_BUILDING_TYPE_CITY}
+	if err := handler.saveGameState("finished", finished); err != nil {
+		t.Fatalf("failed to save finished game: %v", err)
+	}
+
+	client := hub.NewClient(h, &websocket.Conn{}, "lobby-alice", "lobby")
+	h.Register(client)
+
+	policy := RetentionPolicy{LobbyTTL: time.Hour, FinishedRetention: 48 * time.Hour}
+
+	// Nothing is old enough yet
+	report, err := handler.SweepExpiredGames(policy, time.Now())
+	if err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if report.Scanned != 0 || report.ExpiredLobbies != 0 || report.ArchivedFinished != 0 {
+		t.Fatalf("expected nothing to expire, got %+v", report)
+	}
+
+	report, err = handler.SweepExpiredGames(policy, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if report.Scanned != 2 || report.ExpiredLobbies != 1 || report.ArchivedFinished != 0 {
+		t.Fatalf("expected only the lobby to expire, got %+v", report)
+	}
+	if _, err := handler.store.GetGame("lobby"); err != store.ErrNotFound {
+		t.Fatalf("expected lobby to be deleted, got %v", err)
+	}
+	if _, err := handler.store.GetGame("table"); err != nil {
+		t.Fatalf("expected an idle tournament table to be kept, got %v", err)
+	}
+	if !client.IsClosed() || len(h.GetClientsForGame("lobby")) != 0 {
+		t.Fatal("expected lobby client to be disconnected")
+	}
+
+	report, err = handler.SweepExpiredGames(policy, time.Now().Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if report.Scanned != 2 || report.ArchivedFinished != 1 {
+		t.Fatalf("expected the finished game to be removed, got %+v", report)
+	}
+	if _, err := handler.store.GetGame("playing"); err != nil {
+		t.Fatalf("expected game in progress to be kept, got %v", err)
+	}
+
+	// The janitor checks again under the game's lock before deleting, so a
+	// game that moved on since it was listed is kept
+	if ok, err := handler.expireIdleGame("playing", catanv1.GameStatus_GAME_STATUS_WAITING, time.Now().Add(time.Hour), "test"); ok || err != nil {
+		t.Fatalf("expected a game no longer waiting to be kept, got %v, %v", ok, err)
+	}
+	if ok, err := handler.expireIdleGame("table", catanv1.GameStatus_GAME_STATUS_WAITING, time.Now().Add(-time.Hour), "test"); ok || err != nil {
+		t.Fatalf("expected a game updated since the cutoff to be kept, got %v, %v", ok, err)
+	}
+	if ok, err := handler.expireIdleGame("lobby", catanv1.GameStatus_GAME_STATUS_WAITING, time.Now().Add(time.Hour), "test"); ok || err != nil {
+		t.Fatalf("expected a game already gone to be skipped, got %v, %v", ok, err)
+	}
+	var archived int
+	if err := database.Get(&archived, "SELECT COUNT(*) FROM player_game_stats WHERE game_id = ?", "finished"); err != nil {
+		t.Fatalf("failed to count archived stats: %v", err)
+	}
+	if archived != 2 {
+		t.Fatalf("expected finished game's stats to be kept, got %d rows", archived)
+	}
+}
+
+func TestSweepExpiredGames_UpgradesStoredStates(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	handler := NewHandler(database, hub.NewHub())
+
+	old := game.NewGameState("old", "JAN004", []string{"Alice", "Bob"}, []string{"old-alice", "old-bob"})
+	old.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+	old.StateVersion = 0
+	old.Dice = nil
+	insertGameState(t, database, old)
+	newer := game.NewGameState("newer", "JAN005", []string{"Alice", "Bob"}, []string{"newer-alice", "newer-bob"})
+	newer.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+	newer.StateVersion = game.CurrentStateVersion + 1
+	insertGameState(t, database, newer)
+
+	g, err := handler.loadGame("old")
+	if err != nil || g.State.StateVersion != game.CurrentStateVersion || len(g.State.Dice) != 2 {
+		t.Fatalf("expected the old state to be upgraded on load, got %v (%v)", g, err)
+	}
+
+	report, err := handler.SweepExpiredGames(RetentionPolicy{FinishedRetention: time.Hour}, time.Now().Add(2*time.Hour))
+	if err == nil {
+		t.Fatal("expected the sweep to report the state it cannot read")
+	}
+	if report.ArchivedFinished != 1 {
+		t.Fatalf("expected only the upgraded game to be archived, got %+v", report)
+	}
+	if _, err := handler.store.GetGame("newer"); err != nil {
+		t.Fatalf("expected the newer state to be left alone, got %v", err)
+	}
+}
+
+func TestWebSocket_AllowedOrigins(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+
+	handler := NewHandler(database, h)
+	handler.SetAllowedOrigins([]string{"http://catan.example/"})
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws?token=" + createResp.GetSessionToken()
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {"http://evil.example"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected foreign origin to be refused, got %v", err)
+	}
+
+	// Non-browser clients such as catan-cli send no Origin header
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("expected a request without an origin to connect, got %v", err)
+	}
+	readGameState(t, conn, 1)
+	conn.Close()
+
+	conn, _, err = websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {"http://catan.example"}})
+	if err != nil {
+		t.Fatalf("expected a listed origin to connect, got %v", err)
+	}
+	readGameState(t, conn, 1)
+	conn.Close()
+}
+
+func TestShutdown_ClosesWebSocketsAndRefusesCommands(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+
+	handler := NewHandler(database, h)
+	handler.SetAllowedOrigins([]string{"http://catan.example"})
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws?token=" + createResp.GetSessionToken()
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {"http://evil.example"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected foreign origin to be refused, got %v", err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {"http://catan.example"}})
+	if err != nil {
+		t.Fatalf("failed to connect websocket: %v", err)
+	}
+	defer conn.Close()
+	readGameState(t, conn, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := handler.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		if _, _, err = conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Fatalf("expected a going-away close frame, got %v", err)
+	}
+
+	if handler.beginCommand() {
+		t.Fatal("expected commands to be refused after shutdown")
+	}
+}
+
+// readErrorsUntilClose collects the error codes sent to conn until the server
+// closes it, and returns them with the close error.
+func readErrorsUntilClose(t *testing.T, conn *websocket.Conn) ([]string, error) {
+	t.Helper()
+	var codes []string
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return codes, err
+		}
+		var envelope struct {
+			Message struct {
+				OneofKind string `json:"oneofKind"`
+				Error     struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			t.Fatalf("failed to unmarshal websocket message: %v", err)
+		}
+		if envelope.Message.OneofKind == "error" {
+			codes = append(codes, envelope.Message.Error.Code)
+		}
+	}
+}
+
+func TestWebSocket_AbusiveClientsAreDisconnected(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    RateLimits
+		messages  [][]byte
+		binary    bool
+		wantCode  int
+		wantError string
+	}{
+		{
+			name:      "flooding",
+			limits:    RateLimits{ClientRate: 0.001, ClientBurst: 2, GameRate: 100, GameBurst: 100, MaxStrikes: 3},
+			messages:  bytes.Split(bytes.Repeat([]byte(`{"message":{"oneofKind":"endTurn","endTurn":{}}}|`), 6), []byte("|"))[:6],
+			wantCode:  websocket.ClosePolicyViolation,
+			wantError: "rate_limited",
+		},
+		{
+			name:      "malformed messages",
+			limits:    RateLimits{ClientRate: 100, ClientBurst: 100, GameRate: 100, GameBurst: 100, MaxStrikes: 3},
+			messages:  [][]byte{[]byte(`not json`), []byte(`{}`), []byte(`{"message":{"oneofKind":"launchMissiles"}}`)},
+			wantCode:  websocket.ClosePolicyViolation,
+			wantError: "bad_request",
+		},
+		{
+			name:     "oversized message",
+			limits:   DefaultRateLimits,
+			messages: [][]byte{bytes.Repeat([]byte("x"), 8*1024)},
+			wantCode: websocket.CloseMessageTooBig,
+		},
+		{
+			name:     "binary message",
+			limits:   DefaultRateLimits,
+			messages: [][]byte{[]byte(`{"message":{"oneofKind":"endTurn","endTurn":{}}}`)},
+			binary:   true,
+			wantCode: websocket.CloseUnsupportedData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			h := hub.NewHub()
+			go h.Run()
+			handler := NewHandler(database, h)
+			handler.SetRateLimits(tt.limits)
+			server := httptest.NewServer(buildMux(handler))
+			defer server.Close()
+
+			createResp := createGameViaHTTP(t, server.URL, "Host")
+			wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws?token=" + createResp.GetSessionToken()
+			conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+			if err != nil {
+				t.Fatalf("failed to connect websocket: %v", err)
+			}
+			defer conn.Close()
+			readGameState(t, conn, 1)
+
+			messageType := websocket.TextMessage
+			if tt.binary {
+				messageType = websocket.BinaryMessage
+			}
+			for _, msg := range tt.messages {
+				if err := conn.WriteMessage(messageType, msg); err != nil {
+					t.Fatalf("failed to write message: %v", err)
+				}
+			}
+
+			codes, err := readErrorsUntilClose(t, conn)
+			if !websocket.IsCloseError(err, tt.wantCode) {
+				t.Fatalf("expected close code %d, got %v (errors %v)", tt.wantCode, err, codes)
+			}
+			if tt.wantError != "" && !slices.Contains(codes, tt.wantError) {
+				t.Fatalf("expected a %q error before disconnecting, got %v", tt.wantError, codes)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_GameBucketIsShared(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{ClientRate: 1, ClientBurst: 5, GameRate: 1, GameBurst: 3, MaxStrikes: 10})
+	alice := hub.NewClient(nil, nil, "alice", "g1")
+	bob := hub.NewClient(nil, nil, "bob", "g1")
+	carol := hub.NewClient(nil, nil, "carol", "g2")
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.allow(alice, now); !ok {
+			t.Fatalf("expected message %d to be allowed", i+1)
+		}
+	}
+	if ok, strikes := limiter.allow(bob, now); ok || strikes != 0 {
+		t.Fatalf("expected a busy game to refuse bob without a strike, got ok=%v strikes=%d", ok, strikes)
+	}
+	if ok, _ := limiter.allow(carol, now); !ok {
+		t.Fatal("expected another game to be unaffected")
+	}
+	if ok, _ := limiter.allow(bob, now.Add(time.Second)); !ok {
+		t.Fatal("expected the game bucket to refill")
+	}
+
+	// Strikes are forgiven after a quiet spell
+	for i := 0; i < 5; i++ {
+		limiter.strike(carol, now)
+	}
+	if strikes := limiter.strike(carol, now.Add(strikeWindow+time.Second)); strikes != 1 {
+		t.Fatalf("expected strikes to reset, got %d", strikes)
+	}
+}
+
+func TestHandleMetrics_CountsMessagesAndRejections(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+
+	state := game.NewGameState("game-metrics", "MET001", []string{"Host", "Guest"}, []string{"p1", "p2"})
+	insertGameState(t, database, state)
+	client := hub.NewClient(h, &websocket.Conn{}, "p1", state.Id)
+	h.Register(client)
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(previous)
+
+	handler.handleClientMessage(client, []byte(`{"message":{"oneofKind":"playerReady","playerReady":{"ready":true}}}`))
+	handler.handleClientMessage(client, []byte(`{"message":{"oneofKind":"rollDice","rollDice":{}}}`))
+	handler.handleClientMessage(client, []byte(`{"message":{"oneofKind":"launchMissiles"}}`))
+
+	recorder := httptest.NewRecorder()
+	handler.HandleMetrics(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`catan_ws_messages_total{type="playerReady"} 1`,
+		`catan_ws_messages_total{type="rollDice"} 1`,
+		`catan_ws_messages_total{type="unknown"} 1`,
+		`catan_commands_rejected_total{code="invalid_action"} 1`,
+		`catan_commands_rejected_total{code="bad_request"} 1`,
+		`catan_command_duration_seconds_count{type="playerReady"} 1`,
+		`catan_game_save_duration_seconds_count 1`,
+		`catan_games{status="waiting"} 1`,
+		`catan_games{status="finished"} 0`,
+		`catan_connected_clients 1`,
+		`catan_hub_send_drops_total 0`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("expected metrics to contain %q", want)
+		}
+	}
+
+	// Every line logged for the rejected roll carries the command's context
+	var sawRejection bool
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("expected JSON log line, got %q", line)
+		}
+		if entry["msg"] == "command rejected" {
+			sawRejection = true
+			if entry["game_id"] != state.Id || entry["player_id"] != "p1" || entry["message_type"] != "rollDice" {
+				t.Errorf("expected rejection log to carry game, player and type, got %v", entry)
+			}
+		}
+	}
+	if !sawRejection {
+		t.Errorf("expected a command rejected log line, got %s", logs.String())
+	}
+}
+
+func TestAdminAPI(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	mux := buildMux(handler)
+	mux.HandleFunc("/api/admin/", handler.HandleAdmin)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	joinResp := joinGameViaHTTP(t, server.URL, createResp.GetCode(), "Guest")
+	gameID := createResp.GetGameId()
+
+	admin := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		req.Header.Set("X-Admin-Actor", "support")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+	expectStatus := func(recorder *httptest.ResponseRecorder, want int) {
+		t.Helper()
+		if recorder.Code != want {
+			t.Fatalf("expected status %d, got %d: %s", want, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// Disabled until a token is configured, then the token is required
+	expectStatus(admin(http.MethodGet, "/api/admin/games", "", ""), http.StatusNotFound)
+	handler.SetAdminToken("s3cret")
+	expectStatus(admin(http.MethodGet, "/api/admin/games", "", ""), http.StatusUnauthorized)
+	expectStatus(admin(http.MethodGet, "/api/admin/games", "wrong", ""), http.StatusUnauthorized)
+
+	recorder := admin(http.MethodGet, "/api/admin/games", "s3cret", "")
+	expectStatus(recorder, http.StatusOK)
+	var list struct {
+		Games []adminGameSummary `json:"games"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to decode game list: %v", err)
+	}
+	if len(list.Games) != 1 || list.Games[0].ID != gameID || len(list.Games[0].Players) != 2 || list.Games[0].Status != "waiting" {
+		t.Fatalf("unexpected game list: %+v", list.Games)
+	}
+
+	// Inspection shows hidden fields such as the dev card deck
+	recorder = admin(http.MethodGet, "/api/admin/games/"+gameID, "s3cret", "")
+	expectStatus(recorder, http.StatusOK)
+	var inspected catanv1.GameState
+	if err := protojson.Unmarshal(recorder.Body.Bytes(), &inspected); err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	if len(inspected.DevCardDeck) == 0 {
+		t.Fatal("expected the unredacted state to include the dev card deck")
+	}
+	expectStatus(admin(http.MethodGet, "/api/admin/games/missing", "s3cret", ""), http.StatusNotFound)
+
+	// Paused games refuse commands
+	host := hub.NewClient(h, &websocket.Conn{}, createResp.GetPlayerId(), gameID)
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/pause", "s3cret", ""), http.StatusNoContent)
+	handler.handleClientMessage(host, []byte(`{"message":{"oneofKind":"playerReady","playerReady":{"ready":true}}}`))
+	if state, _ := handler.loadGameState(gameID); !state.Paused || state.Players[0].IsReady {
+		t.Fatal("expected the paused game to refuse the ready command")
+	}
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/resume", "s3cret", ""), http.StatusNoContent)
+	handler.handleClientMessage(host, []byte(`{"message":{"oneofKind":"playerReady","playerReady":{"ready":true}}}`))
+	if state, _ := handler.loadGameState(gameID); state.Paused || !state.Players[0].IsReady {
+		t.Fatal("expected the resumed game to accept the ready command")
+	}
+
+	// Roll back to the state before the ready command
+	events, err := handler.store.ListEvents(gameID, 0)
+	if err != nil || len(events) < 2 {
+		t.Fatalf("expected logged events, got %d: %v", len(events), err)
+	}
+	beforeReady := events[len(events)-2].Seq
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/rollback", "s3cret", fmt.Sprintf(`{"seq":%d}`, beforeReady)), http.StatusNoContent)
+	if state, _ := handler.loadGameState(gameID); state.Players[0].IsReady {
+		t.Fatal("expected the rollback to undo the ready command")
+	}
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/rollback", "s3cret", `{"seq":1}`), http.StatusConflict)
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/rollback", "s3cret", `{"seq":999}`), http.StatusNotFound)
+
+	// Removing a player frees their seat
+	expectStatus(admin(http.MethodDelete, "/api/admin/games/"+gameID+"/players/"+joinResp.GetPlayerId(), "s3cret", ""), http.StatusNoContent)
+	if state, _ := handler.loadGameState(gameID); len(state.Players) != 1 {
+		t.Fatalf("expected one player left, got %d", len(state.Players))
+	}
+	if _, err := handler.store.GetPlayerBySession(joinResp.GetSessionToken()); err != store.ErrNotFound {
+		t.Fatalf("expected the removed player's session to be revoked, got %v", err)
+	}
+	expectStatus(admin(http.MethodDelete, "/api/admin/games/"+gameID+"/players/"+joinResp.GetPlayerId(), "s3cret", ""), http.StatusNotFound)
+
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/end", "s3cret", ""), http.StatusNoContent)
+	ended, _ := handler.loadGameState(gameID)
+	if ended.Status != catanv1.GameStatus_GAME_STATUS_FINISHED || !ended.EndedByAdmin {
+		t.Fatalf("expected the game to be ended by an administrator, got %v", ended.Status)
+	}
+	// A game nobody won is neither archived nor rated, then or by the janitor
+	if err := handler.archiveFinishedGame(ended, ""); err != nil {
+		t.Fatalf("failed to archive game: %v", err)
+	}
+	var rated int
+	if err := database.Get(&rated, "SELECT (SELECT COUNT(*) FROM game_history) + (SELECT COUNT(*) FROM player_ratings)"); err != nil || rated != 0 {
+		t.Fatalf("expected no archived results or ratings, got %d (%v)", rated, err)
+	}
+	expectStatus(admin(http.MethodPost, "/api/admin/games/"+gameID+"/end", "s3cret", ""), http.StatusConflict)
+
+	recorder = admin(http.MethodGet, "/api/admin/audit?gameId="+gameID, "s3cret", "")
+	expectStatus(recorder, http.StatusOK)
+	var audit struct {
+		Entries []auditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &audit); err != nil {
+		t.Fatalf("failed to decode audit log: %v", err)
+	}
+	var actions []string
+	for _, e := range audit.Entries {
+		actions = append(actions, e.Action)
+		if e.Actor != "support" {
+			t.Errorf("expected actor to be recorded, got %+v", e)
+		}
+	}
+	if want := []string{"end", "removePlayer", "rollback", "resume", "pause"}; !slices.Equal(actions, want) {
+		t.Fatalf("expected audit %v, got %v", want, actions)
+	}
+	if audit.Entries[1].Target != joinResp.GetPlayerId() || audit.Entries[2].Details == "" {
+		t.Errorf("expected target and details recorded, got %+v", audit.Entries)
+	}
+}
+
+// readServerMessage reads from conn until a message of the given oneof kind
+// arrives and returns its payload.
+func readServerMessage(t *testing.T, conn *websocket.Conn, kind string) json.RawMessage {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for %s: %v", kind, err)
+		}
+		var envelope struct {
+			Message map[string]json.RawMessage `json:"message"`
+		}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			t.Fatalf("failed to unmarshal websocket message: %v", err)
+		}
+		if string(envelope.Message["oneofKind"]) == strconv.Quote(kind) {
+			return envelope.Message[kind]
+		}
+	}
+}
+
+func TestChat(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	limits := DefaultRateLimits
+	limits.ChatRate, limits.ChatBurst = 0.001, 4
+	handler.SetRateLimits(limits)
+	handler.SetChatFilter([]string{"darn"})
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	bob := joinGameViaHTTP(t, server.URL, createResp.GetCode(), "Bob")
+	carol := joinGameViaHTTP(t, server.URL, createResp.GetCode(), "Carol")
+	wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws?token="
+	dial := func(token string) *websocket.Conn {
+		t.Helper()
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+token, nil)
+		if err != nil {
+			t.Fatalf("failed to connect websocket: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		readServerMessage(t, conn, "chatHistory")
+		return conn
+	}
+	hostConn := dial(createResp.GetSessionToken())
+	bobConn := dial(bob.GetSessionToken())
+	carolConn := dial(carol.GetSessionToken())
+	send := func(conn *websocket.Conn, kind, payload string) {
+		t.Helper()
+		msg := fmt.Sprintf(`{"message":{"oneofKind":%q,%q:%s}}`, kind, kind, payload)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("failed to write message: %v", err)
+		}
+	}
+	readChat := func(conn *websocket.Conn) *catanv1.ChatMessagePayload {
+		t.Helper()
+		var line catanv1.ChatMessagePayload
+		if err := protojson.Unmarshal(readServerMessage(t, conn, "chatMessage"), &line); err != nil {
+			t.Fatalf("failed to decode chat message: %v", err)
+		}
+		return &line
+	}
+	readErrorCode := func(conn *websocket.Conn) string {
+		t.Helper()
+		var payload catanv1.ErrorPayload
+		if err := protojson.Unmarshal(readServerMessage(t, conn, "error"), &payload); err != nil {
+			t.Fatalf("failed to decode error: %v", err)
+		}
+		return payload.Code
+	}
+
+	send(hostConn, "sendChat", `{"text":"  well DARN it  "}`)
+	if line := readChat(bobConn); line.Text != "well **** it" || line.SenderId != createResp.GetPlayerId() || line.RecipientId != nil {
+		t.Fatalf("expected a filtered game-wide line from the host, got %+v", line)
+	}
+	readChat(carolConn)
+
+	send(hostConn, "sendChat", fmt.Sprintf(`{"text":"psst","recipientId":%q}`, carol.GetPlayerId()))
+	if line := readChat(carolConn); line.Text != "psst" || line.GetRecipientId() != carol.GetPlayerId() {
+		t.Fatalf("expected a whisper to carol, got %+v", line)
+	}
+
+	send(hostConn, "sendChat", fmt.Sprintf(`{"text":%q}`, strings.Repeat("a", maxChatLength+1)))
+	if code := readErrorCode(hostConn); code != "chat_too_long" {
+		t.Fatalf("expected chat_too_long, got %s", code)
+	}
+	send(hostConn, "sendChat", fmt.Sprintf(`{"text":"hi","recipientId":%q}`, createResp.GetPlayerId()))
+	if code := readErrorCode(hostConn); code != "chat_refused" {
+		t.Fatalf("expected a whisper to yourself to be refused, got %s", code)
+	}
+
+	send(bobConn, "muteChat", fmt.Sprintf(`{"playerId":%q,"muted":true}`, createResp.GetPlayerId()))
+	if code := readErrorCode(bobConn); code != "invalid_action" {
+		t.Fatalf("expected only the host to mute, got %s", code)
+	}
+	send(hostConn, "muteChat", fmt.Sprintf(`{"playerId":%q,"muted":true}`, bob.GetPlayerId()))
+	readServerMessage(t, bobConn, "gameState")
+	send(bobConn, "sendChat", `{"text":"let me talk"}`)
+	if code := readErrorCode(bobConn); code != "chat_refused" {
+		t.Fatalf("expected a muted player to be refused, got %s", code)
+	}
+
+	// The refused whisper still spent one of the host's four chat tokens
+	send(hostConn, "sendChat", `{"text":"three"}`)
+	send(hostConn, "sendChat", `{"text":"four"}`)
+	if code := readErrorCode(hostConn); code != "chat_rate_limited" {
+		t.Fatalf("expected chat_rate_limited, got %s", code)
+	}
+
+	// Reconnecting replays only the lines each player may see
+	for _, tt := range []struct {
+		token string
+		want  []string
+	}{
+		{bob.GetSessionToken(), []string{"well **** it", "three"}},
+		{carol.GetSessionToken(), []string{"well **** it", "psst", "three"}},
+	} {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL+tt.token, nil)
+		if err != nil {
+			t.Fatalf("failed to reconnect: %v", err)
+		}
+		var history catanv1.ChatHistoryPayload
+		if err := protojson.Unmarshal(readServerMessage(t, conn, "chatHistory"), &history); err != nil {
+			t.Fatalf("failed to decode chat history: %v", err)
+		}
+		conn.Close()
+		var texts []string
+		for _, line := range history.Messages {
+			texts = append(texts, line.Text)
+		}
+		if !slices.Equal(texts, tt.want) {
+			t.Errorf("expected history %v, got %v", tt.want, texts)
+		}
+	}
+}
+
+func TestSpectators(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	state, err := handler.loadGameState(createResp.GetGameId())
+	if err != nil {
+		t.Fatalf("failed to load game: %v", err)
+	}
+	state.Players[0].DevCards = map[int32]int32{int32(catanv1.DevCardType_DEV_CARD_TYPE_KNIGHT): 1}
+	state.Players[0].DevCardCount = 1
+	if err := handler.saveGameState(createResp.GetGameId(), state); err != nil {
+		t.Fatalf("failed to save game: %v", err)
+	}
+
+	wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws"
+	if _, resp, err := websocket.DefaultDialer.Dial(wsURL+"?spectate=NOPE00", nil); err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected an unknown game to be refused, got %v", err)
+	}
+	hostConn, _, err := websocket.DefaultDialer.Dial(wsURL+"?token="+createResp.GetSessionToken(), nil)
+	if err != nil {
+		t.Fatalf("failed to connect host: %v", err)
+	}
+	defer hostConn.Close()
+	readServerMessage(t, hostConn, "chatHistory")
+	watcher, _, err := websocket.DefaultDialer.Dial(wsURL+"?spectate="+strings.ToLower(createResp.GetCode()), nil)
+	if err != nil {
+		t.Fatalf("failed to connect spectator: %v", err)
+	}
+	defer watcher.Close()
+
+	var wire struct {
+		State json.RawMessage `json:"state"`
+	}
+	if err := json.Unmarshal(readServerMessage(t, watcher, "gameState"), &wire); err != nil {
+		t.Fatalf("failed to decode game state: %v", err)
+	}
+	var seen catanv1.GameState
+	if err := protojson.Unmarshal(wire.State, &seen); err != nil {
+		t.Fatalf("failed to decode game state: %v", err)
+	}
+	if len(seen.Players) != 1 || len(seen.Players[0].DevCards) != 0 {
+		t.Fatalf("expected the spectator to see the host without their dev cards, got %+v", seen.Players)
+	}
+	readServerMessage(t, watcher, "chatHistory")
+
+	send := func(conn *websocket.Conn, kind, payload string) {
+		t.Helper()
+		msg := fmt.Sprintf(`{"message":{"oneofKind":%q,%q:%s}}`, kind, kind, payload)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("failed to write message: %v", err)
+		}
+	}
+	readErrorCode := func(conn *websocket.Conn) string {
+		t.Helper()
+		var payload catanv1.ErrorPayload
+		if err := protojson.Unmarshal(readServerMessage(t, conn, "error"), &payload); err != nil {
+			t.Fatalf("failed to decode error: %v", err)
+		}
+		return payload.Code
+	}
+
+	send(watcher, "sendChat", `{"text":"good luck"}`)
+	var line catanv1.ChatMessagePayload
+	if err := protojson.Unmarshal(readServerMessage(t, hostConn, "chatMessage"), &line); err != nil {
+		t.Fatalf("failed to decode chat message: %v", err)
+	}
+	if line.Text != "good luck" || !strings.HasPrefix(line.SenderId, "spectator-") {
+		t.Fatalf("expected a line from the spectator, got %+v", &line)
+	}
+	send(watcher, "playerReady", `{"ready":true}`)
+	if code := readErrorCode(watcher); code != "bad_request" {
+		t.Fatalf("expected the spectator's command to be refused, got %s", code)
+	}
+
+	send(hostConn, "setChatSettings", `{"spectatorChatDisabled":true}`)
+	readServerMessage(t, watcher, "gameState")
+	send(watcher, "sendChat", `{"text":"still here"}`)
+	if code := readErrorCode(watcher); code != "chat_refused" {
+		t.Fatalf("expected spectator chat to be refused once disabled, got %s", code)
+	}
+	send(hostConn, "sendChat", `{"text":"players can still talk"}`)
+	readServerMessage(t, watcher, "chatMessage")
+}
+
+func TestTakeback(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+
+	gameID := "game-takeback"
+	state := game.NewGameState(gameID, "TAK001", []string{"Alice", "Bob", "Carol"}, []string{"p1", "p2", "p3"})
+	state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_TRADE
+	state.Players[0].Resources = &catanv1.ResourceCount{Wood: 8}
+	insertGameState(t, database, state)
+	if err := handler.appendGameEvent(gameID, "createGame", "p1", nil, state); err != nil {
+		t.Fatalf("failed to log initial event: %v", err)
+	}
+
+	clients := map[string]*hub.Client{}
+	for _, id := range []string{"p1", "p2", "p3"} {
+		clients[id] = hub.NewClient(h, &websocket.Conn{}, id, gameID)
+		h.Register(clients[id])
+	}
+	send := func(playerID, msg string) {
+		handler.handleClientMessage(clients[playerID], []byte(msg))
+	}
+	load := func() *catanv1.GameState {
+		t.Helper()
+		state, err := handler.loadGameState(gameID)
+		if err != nil {
+			t.Fatalf("failed to load game state: %v", err)
+		}
+		return state
+	}
+	const (
+		bankTrade = `{"message":{"oneofKind":"bankTrade","bankTrade":{"offering":{"wood":4},"resourceRequested":"RESOURCE_ORE"}}}`
+		request   = `{"message":{"oneofKind":"requestTakeback","requestTakeback":{}}}`
+		approve   = `{"message":{"oneofKind":"respondTakeback","respondTakeback":{"approve":true}}}`
+		reject    = `{"message":{"oneofKind":"respondTakeback","respondTakeback":{"approve":false}}}`
+	)
+
+	send("p1", bankTrade)
+	send("p2", request)
+	if load().PendingTakeback != nil {
+		t.Fatal("expected only the current player to request a takeback")
+	}
+	send("p1", request)
+	if pending := load().PendingTakeback; pending == nil || pending.Action != "bankTrade" {
+		t.Fatalf("expected a pending bank trade takeback, got %v", pending)
+	}
+	send("p2", approve)
+	if got := load(); got.PendingTakeback == nil || got.Players[0].Resources.Ore != 1 {
+		t.Fatalf("expected the takeback to wait for Carol, got %v", got.PendingTakeback)
+	}
+	send("p3", approve)
+	got := load()
+	if got.PendingTakeback != nil || got.Players[0].Resources.Wood != 8 || got.Players[0].Resources.Ore != 0 {
+		t.Fatalf("expected the bank trade undone, got %v with %v", got.Players[0].Resources, got.PendingTakeback)
+	}
+	if gs, err := handler.loadGameStats(gameID); err != nil || gs.Player("p1").BankTrades != 0 {
+		t.Fatalf("expected the undone bank trade dropped from stats, got %v", err)
+	}
+
+	send("p1", request)
+	if load().PendingTakeback != nil {
+		t.Fatal("expected an action to be taken back only once")
+	}
+
+	send("p1", bankTrade)
+	send("p1", request)
+	send("p2", reject)
+	if load().PendingTakeback != nil {
+		t.Fatal("expected a rejection to cancel the takeback")
+	}
+	send("p1", request)
+	if pending := load().PendingTakeback; pending == nil || pending.Action != "bankTrade" {
+		t.Fatalf("expected the bank trade can be asked about again after a rejection, got %v", pending)
+	}
+	send("p1", bankTrade)
+	if got := load(); got.PendingTakeback != nil || got.Players[0].Resources.Ore != 2 {
+		t.Fatalf("expected another move to cancel the takeback, got %v", got.PendingTakeback)
+	}
+}
+
+func TestStateValidation(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+
+	const toBuild = `{"message":{"oneofKind":"setTurnPhase","setTurnPhase":{"phase":"TURN_PHASE_BUILD"}}}`
+	tests := []struct {
+		mode        StateValidation
+		wantPhase   catanv1.TurnPhase
+		wantMetrics []string
+	}{
+		{ValidationOff, catanv1.TurnPhase_TURN_PHASE_BUILD, nil},
+		{ValidationLog, catanv1.TurnPhase_TURN_PHASE_BUILD, []string{
+			`catan_state_violations_total{rule="negative_resources"} 1`,
+		}},
+		{ValidationReject, catanv1.TurnPhase_TURN_PHASE_TRADE, []string{
+			`catan_state_violations_total{rule="negative_resources"} 1`,
+			`catan_commands_rejected_total{code="invalid_state"} 1`,
+		}},
+	}
+	for i, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			handler := NewHandler(database, h)
+			handler.SetStateValidation(tt.mode)
+
+			// The stored state is already broken, so any command leaves it
+			// broken.
+			state := game.NewGameState(fmt.Sprintf("game-validate-%d", i), fmt.Sprintf("VAL%03d", i), []string{"Alice", "Bob"}, []string{"p1", "p2"})
+			state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+			state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_TRADE
+			state.Players[1].Resources.Wood = -1
+			insertGameState(t, database, state)
+			client := hub.NewClient(h, &websocket.Conn{}, "p1", state.Id)
+			h.Register(client)
+
+			handler.handleClientMessage(client, []byte(toBuild))
+
+			got, err := handler.loadGameState(state.Id)
+			if err != nil {
+				t.Fatalf("failed to load game state: %v", err)
+			}
+			if got.TurnPhase != tt.wantPhase {
+				t.Errorf("expected phase %v, got %v", tt.wantPhase, got.TurnPhase)
+			}
+			recorder := httptest.NewRecorder()
+			handler.HandleMetrics(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			body := recorder.Body.String()
+			for _, want := range tt.wantMetrics {
+				if !strings.Contains(body, want+"\n") {
+					t.Errorf("expected metrics to contain %q", want)
+				}
+			}
+			if tt.mode == ValidationOff && strings.Contains(body, "catan_state_violations_total{") {
+				t.Errorf("expected no violations counted with validation off")
+			}
+		})
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+
+	createResp := createGameViaHTTP(t, server.URL, "Host")
+	joinGameViaHTTP(t, server.URL, createResp.GetCode(), "Bob")
+	state, err := handler.loadGameState(createResp.GetGameId())
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_BUILD
+	state.CurrentTurn = 0
+	state.Players[0].Resources = &catanv1.ResourceCount{Wheat: 1, Ore: 3}
+	if err := handler.saveGameState(state.Id, state); err != nil {
+		t.Fatalf("failed to save game state: %v", err)
+	}
+
+	wsURL := strings.Replace(server.URL, "http", "ws", 1) + "/ws?token=" + createResp.GetSessionToken()
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to connect websocket: %v", err)
+	}
+	defer conn.Close()
+	readServerMessage(t, conn, "chatHistory")
+	readError := func(msg string) *catanv1.ErrorPayload {
+		t.Helper()
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("failed to write message: %v", err)
+		}
+		var payload catanv1.ErrorPayload
+		if err := protojson.Unmarshal(readServerMessage(t, conn, "error"), &payload); err != nil {
+			t.Fatalf("failed to decode error: %v", err)
+		}
+		return &payload
+	}
+
+	got := readError(fmt.Sprintf(`{"message":{"oneofKind":"buildStructure","buildStructure":{"structureType":"STRUCTURE_TYPE_CITY","location":%q}}}`, state.Board.Vertices[0].Id))
+	if got.Code != "invalid_action" || got.ErrorCode != catanv1.ErrorCode_ERROR_CODE_INSUFFICIENT_RESOURCES {
+		t.Fatalf("expected invalid_action/INSUFFICIENT_RESOURCES, got %s/%v", got.Code, got.ErrorCode)
+	}
+	if !proto.Equal(got.Required, &catanv1.ResourceCount{Wheat: 2, Ore: 3}) || !proto.Equal(got.Held, &catanv1.ResourceCount{Wheat: 1, Ore: 3}) {
+		t.Fatalf("expected a city's cost against the host's hand, got required %v held %v", got.Required, got.Held)
+	}
+
+	got = readError(`{"message":{"oneofKind":"rollDice","rollDice":{}}}`)
+	if got.ErrorCode != catanv1.ErrorCode_ERROR_CODE_WRONG_PHASE || got.Required != nil {
+		t.Fatalf("expected WRONG_PHASE without counts, got %v", got)
+	}
+
+	got = readError(`not json`)
+	if got.Code != "bad_request" || got.ErrorCode != catanv1.ErrorCode_ERROR_CODE_BAD_REQUEST {
+		t.Fatalf("expected bad_request/BAD_REQUEST, got %s/%v", got.Code, got.ErrorCode)
+	}
+}
+
+func TestRematch(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	mux := buildMux(handler)
+	rematch := func(code, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/games/"+code+"/rematch", strings.NewReader(body))
+		req.Header.Set("X-Session-Token", token)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+	seat := func(recorder *httptest.ResponseRecorder) *catanv1.JoinGameResponse {
+		t.Helper()
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected rematch to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var resp catanv1.JoinGameResponse
+		if err := protojson.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode rematch response: %v", err)
+		}
+		return &resp
+	}
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	alice := createGameViaHTTP(t, server.URL, "Alice")
+	bob := joinGameViaHTTP(t, server.URL, alice.GetCode(), "Bob")
+
+	if recorder := rematch(alice.Code, alice.SessionToken, ""); recorder.Code != http.StatusConflict {
+		t.Fatalf("expected rematching an unfinished game to conflict, got %d", recorder.Code)
+	}
+	state, err := handler.loadGameState(alice.GameId)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+	state.CurrentTurn = 0
+	state.Players[0].VictoryPointCards = 10
+	if err := handler.saveGameState(state.Id, state); err != nil {
+		t.Fatalf("failed to save game state: %v", err)
+	}
+
+	if recorder := rematch(alice.Code, "nope", ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected a rematch without a seat to be forbidden, got %d", recorder.Code)
+	}
+	if recorder := rematch(alice.Code, bob.SessionToken, `{"bestOf":2}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an even series length to be refused, got %d", recorder.Code)
+	}
+	bobSeat := seat(rematch(alice.Code, bob.SessionToken, `{"bestOf":3}`))
+	aliceSeat := seat(rematch(alice.Code, alice.SessionToken, ""))
+	if bobSeat.GameId == alice.GameId || aliceSeat.GameId != bobSeat.GameId {
+		t.Fatalf("expected both players in one new game, got %s and %s", bobSeat.GameId, aliceSeat.GameId)
+	}
+	if aliceSeat.SessionToken == alice.SessionToken || aliceSeat.PlayerId == alice.PlayerId || aliceSeat.PlayerId == bobSeat.PlayerId {
+		t.Fatalf("expected Alice to get a fresh seat of their own, got %+v", aliceSeat)
+	}
+	if again := seat(rematch(alice.Code, bob.SessionToken, "")); again.PlayerId != bobSeat.PlayerId || again.SessionToken != bobSeat.SessionToken {
+		t.Fatalf("expected asking again to return the same seat, got %+v", again)
+	}
+	if player, err := handler.store.GetPlayerBySession(bobSeat.SessionToken); err != nil || player.GameID != bobSeat.GameId {
+		t.Fatalf("expected Bob's new session to belong to the rematch, got %+v, %v", player, err)
+	}
+
+	next, err := handler.loadGameState(bobSeat.GameId)
+	if err != nil {
+		t.Fatalf("failed to load rematch: %v", err)
+	}
+	if next.Status != catanv1.GameStatus_GAME_STATUS_WAITING || next.Players[0].Id != bobSeat.PlayerId || !next.Players[1].IsHost {
+		t.Fatalf("expected a lobby with Bob seated first and Alice hosting, got %v %+v", next.Status, next.Players)
+	}
+	if sr := next.Series; sr == nil || sr.BestOf != 3 || sr.GameNumber != 2 || sr.Wins[aliceSeat.PlayerId] != 1 {
+		t.Fatalf("expected game 2 of a best of 3 with Alice one up, got %+v", sr)
+	}
+}
+
+func TestTournament(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	mux := buildMux(handler)
+	call := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("X-Session-Token", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+	decode := func(recorder *httptest.ResponseRecorder, msg proto.Message) {
+		t.Helper()
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if err := protojson.Unmarshal(recorder.Body.Bytes(), msg); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	observe := func(id string) *catanv1.Tournament {
+		t.Helper()
+		var tour catanv1.Tournament
+		decode(call(http.MethodGet, "/api/tournaments/"+id, "", ""), &tour)
+		return &tour
+	}
+	// finishTable ends the table's game with its first seat winning.
+	finishTable := func(table *catanv1.TournamentTable) {
+		t.Helper()
+		state, err := handler.loadGameState(table.GameId)
+		if err != nil {
+			t.Fatalf("failed to load table: %v", err)
+		}
+		state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+		state.CurrentTurn = 0
+		state.Players[0].VictoryPointCards = 10
+		if err := handler.saveGameState(state.Id, state); err != nil {
+			t.Fatalf("failed to save table: %v", err)
+		}
+		handler.finishGame(state)
+	}
+
+	var created catanv1.CreateTournamentResponse
+	decode(call(http.MethodPost, "/api/tournaments", "", `{"name":"Office league","advancement":"placement"}`), &created)
+	id := created.Tournament.Id
+	if created.OrganizerToken == "" || created.Tournament.OrganizerToken != "" {
+		t.Fatalf("expected the organizer token once, outside the tournament, got %+v", &created)
+	}
+	if recorder := call(http.MethodPost, "/api/tournaments", "", `{"name":"x","advancePerTable":3}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected too many advancing per table to be refused, got %d", recorder.Code)
+	}
+
+	tokens := map[string]string{}
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave", "Erin", "Frank"} {
+		var entrant catanv1.RegisterTournamentResponse
+		decode(call(http.MethodPost, "/api/tournaments/"+id+"/register", "", `{"name":"`+name+`"}`), &entrant)
+		tokens[entrant.EntrantId] = entrant.Token
+	}
+	if recorder := call(http.MethodPost, "/api/tournaments/"+id+"/start", "wrong", ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected only the organizer to start, got %d", recorder.Code)
+	}
+	var started catanv1.Tournament
+	decode(call(http.MethodPost, "/api/tournaments/"+id+"/start", created.OrganizerToken, ""), &started)
+	if started.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING || len(started.Rounds[0].Tables) != 2 {
+		t.Fatalf("expected two tables running, got %v %+v", started.Status, started.Rounds)
+	}
+	if recorder := call(http.MethodPost, "/api/tournaments/"+id+"/register", "", `{"name":"Late"}`); recorder.Code != http.StatusConflict {
+		t.Errorf("expected registration to close on start, got %d", recorder.Code)
+	}
+	for _, e := range observe(id).Entrants {
+		if e.Token != "" {
+			t.Fatalf("expected entrant tokens to stay private, got %+v", e)
+		}
+	}
+
+	table := started.Rounds[0].Tables[0]
+	var seat catanv1.JoinGameResponse
+	decode(call(http.MethodGet, "/api/tournaments/"+id+"/seat", tokens[table.Seats[1].EntrantId], ""), &seat)
+	if seat.GameId != table.GameId || seat.PlayerId != table.Seats[1].PlayerId {
+		t.Fatalf("expected the entrant's seat at their table, got %+v", &seat)
+	}
+	if state, _ := handler.loadGameState(seat.GameId); state.TournamentId != id || !state.Players[0].IsHost {
+		t.Fatalf("expected a table game in the tournament hosted by its first seat, got %+v", state)
+	}
+	if player, err := handler.store.GetPlayerBySession(seat.SessionToken); err != nil || player.ID != seat.PlayerId {
+		t.Fatalf("expected the seat's session to be live, got %+v, %v", player, err)
+	}
+
+	for _, table := range started.Rounds[0].Tables {
+		finishTable(table)
+	}
+	tour := observe(id)
+	if len(tour.Rounds) != 2 || len(tour.Rounds[1].Tables) != 1 || len(tour.Rounds[1].Tables[0].Seats) != 3 {
+		t.Fatalf("expected a final of three, got %+v", tour.Rounds)
+	}
+	final := tour.Rounds[1].Tables[0]
+	if final.GameId == "" || final.Code == "" {
+		t.Fatalf("expected the final to have a game, got %+v", final)
+	}
+	var out string
+	for _, e := range tour.Entrants {
+		if e.Eliminated {
+			out = e.Id
+		}
+	}
+	if recorder := call(http.MethodGet, "/api/tournaments/"+id+"/seat", tokens[out], ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("expected an eliminated entrant to have no seat, got %d", recorder.Code)
+	}
+
+	finishTable(final)
+	tour = observe(id)
+	if tour.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_FINISHED || tour.WinnerId != final.Seats[0].EntrantId {
+		t.Fatalf("expected the final's first seat to win, got %v %q", tour.Status, tour.WinnerId)
+	}
+	if top := tour.Standings[0]; top.EntrantId != tour.WinnerId || top.Wins != 2 || top.Games != 2 {
+		t.Errorf("expected the winner on top of the standings, got %+v", top)
+	}
+
+	var list catanv1.ListTournamentsResponse
+	decode(call(http.MethodGet, "/api/tournaments", "", ""), &list)
+	if len(list.Tournaments) != 1 || list.Tournaments[0].Id != id {
+		t.Errorf("expected the tournament listed, got %+v", &list)
+	}
+}
+
+// failingTournamentStore fails every SaveTournament with saveErr while it
+// is set.
+type failingTournamentStore struct {
+	store.GameStore
+	saveErr error
+}
+
+func (s *failingTournamentStore) SaveTournament(id string, state *catanv1.Tournament, version int64) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	return s.GameStore.SaveTournament(id, state, version)
+}
+
+func TestTournament_RecordsResultsThatFailedToSave(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	games := &failingTournamentStore{GameStore: store.NewSQLite(database)}
+	handler := NewHandlerWithStore(database, games, h)
+	mux := buildMux(handler)
+	call := func(method, path, token, body string, msg proto.Message) {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("X-Session-Token", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s %s: expected 200, got %d: %s", method, path, recorder.Code, recorder.Body.String())
+		}
+		if err := protojson.Unmarshal(recorder.Body.Bytes(), msg); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+
+	var created catanv1.CreateTournamentResponse
+	call(http.MethodPost, "/api/tournaments", "", `{"name":"Office league"}`, &created)
+	id := created.Tournament.Id
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		call(http.MethodPost, "/api/tournaments/"+id+"/register", "", `{"name":"`+name+`"}`, &catanv1.RegisterTournamentResponse{})
+	}
+	var started catanv1.Tournament
+	call(http.MethodPost, "/api/tournaments/"+id+"/start", created.OrganizerToken, "", &started)
+	table := started.Rounds[0].Tables[0]
+
+	state, err := handler.loadGameState(table.GameId)
+	if err != nil {
+		t.Fatalf("failed to load table: %v", err)
+	}
+	state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+	state.Players[0].VictoryPointCards = 10
+	if err := handler.saveGameState(state.Id, state); err != nil {
+		t.Fatalf("failed to save table: %v", err)
+	}
+	games.saveErr = errors.New("database is locked")
+	handler.finishGame(state)
+	if stored, err := games.GetTournament(id); err != nil || stored.State.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING {
+		t.Fatalf("expected the result to be lost while saves fail, got %v", err)
+	}
+
+	// A result that could not be saved is recorded once someone looks
+	games.saveErr = nil
+	var read catanv1.Tournament
+	call(http.MethodGet, "/api/tournaments/"+id, "", "", &read)
+	if read.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_FINISHED || !read.Rounds[0].Tables[0].Finished {
+		t.Fatalf("expected the table recorded from its finished game, got %v %+v", read.Status, read.Rounds)
+	}
+}
+
+// racingStore runs beforeSave right before the next SaveTournament, standing
+// in for another instance saving the tournament at that moment.
+type racingStore struct {
+	store.GameStore
+	beforeSave func()
+}
+
+func (s *racingStore) SaveTournament(id string, state *catanv1.Tournament, version int64) error {
+	if before := s.beforeSave; before != nil {
+		s.beforeSave = nil
+		before()
+	}
+	return s.GameStore.SaveTournament(id, state, version)
+}
+
+func TestTournament_ConcurrentResults(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	racing := &racingStore{GameStore: store.NewSQLite(database)}
+	first := NewHandlerWithStore(database, racing, h)
+	second := NewHandler(database, h)
+
+	tour, err := tournament.New("t-race", "Race night", "organizer", catanv1.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT, 1, 7)
+	if err != nil {
+		t.Fatalf("tournament.New: %v", err)
+	}
+	if err := first.store.CreateTournament(&store.Tournament{ID: tour.Id, State: tour}); err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave", "Erin", "Frank"} {
+		err := first.updateTournament(tour.Id, func(t *catanv1.Tournament) error {
+			return tournament.Register(t, &catanv1.TournamentEntrant{Id: "e-" + name, Name: name, Token: "tok-" + name})
+		})
+		if err != nil {
+			t.Fatalf("failed to register %s: %v", name, err)
+		}
+	}
+	load := func() *catanv1.Tournament {
+		t.Helper()
+		stored, err := first.store.GetTournament(tour.Id)
+		if err != nil {
+			t.Fatalf("GetTournament: %v", err)
+		}
+		return stored.State
+	}
+
+	// Tables seated by a save that lost a race are dropped again
+	racing.beforeSave = func() {
+		stored, _ := first.store.GetTournament(tour.Id)
+		if err := first.store.SaveTournament(tour.Id, stored.State, stored.Version); err != nil {
+			t.Errorf("failed to save concurrently: %v", err)
+		}
+	}
+	err = first.updateTournament(tour.Id, func(t *catanv1.Tournament) error {
+		round, err := tournament.Start(t)
+		if err != nil {
+			return err
+		}
+		return first.createTournamentTables(t, round)
+	})
+	if err != nil {
+		t.Fatalf("failed to start: %v", err)
+	}
+	finishTable := func(handler *Handler, table *catanv1.TournamentTable) {
+		t.Helper()
+		state, err := handler.loadGameState(table.GameId)
+		if err != nil {
+			t.Fatalf("failed to load table: %v", err)
+		}
+		state.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+		state.CurrentTurn = 0
+		state.Players[0].VictoryPointCards = 10
+		if err := handler.saveGameState(state.Id, state); err != nil {
+			t.Fatalf("failed to save table: %v", err)
+		}
+		handler.finishGame(state)
+	}
+	games := func() int {
+		t.Helper()
+		ids, err := first.store.ListGameIDs()
+		if err != nil {
+			t.Fatalf("ListGameIDs: %v", err)
+		}
+		return len(ids)
+	}
+	round := load().Rounds[0]
+	if len(round.Tables) != 2 || games() != 2 {
+		t.Fatalf("expected two tables, got %d tables and %d games", len(round.Tables), games())
+	}
+
+	// Both tables finish at once on different instances
+	racing.beforeSave = func() { finishTable(second, round.Tables[1]) }
+	finishTable(first, round.Tables[0])
+	got := load()
+	if len(got.Rounds) != 2 || len(got.Rounds[1].Tables) != 1 {
+		t.Fatalf("expected both results recorded and the final seated, got %+v", got.Rounds)
+	}
+	for _, table := range got.Rounds[0].Tables {
+		if !table.Finished {
+			t.Fatalf("expected every first round table finished, got %+v", table)
+		}
+	}
+	if n := games(); n != 3 {
+		t.Fatalf("expected two tables and a final, got %d games", n)
+	}
+
+}
+
+func TestCorrespondence(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	mux := buildMux(handler)
+	call := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("X-Session-Token", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+	pending := func(code, token string) *catanv1.PendingActionsResponse {
+		t.Helper()
+		recorder := call(http.MethodGet, "/api/games/"+code+"/pending", token, "")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var resp catanv1.PendingActionsResponse
+		if err := protojson.Unmarshal(recorder.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode pending actions: %v", err)
+		}
+		return &resp
+	}
+
+	notifications := make(chan []byte, 8)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		notifications <- body
+	}))
+	defer webhook.Close()
+
+	if recorder := call(http.MethodPost, "/api/games", "", `{"playerName":"Alice","correspondence":{"turnHours":1000}}`); recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected an overlong turn to be refused, got %d", recorder.Code)
+	}
+	recorder := call(http.MethodPost, "/api/games", "", `{"playerName":"Alice","correspondence":{"turnHours":24}}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected create to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var alice catanv1.CreateGameResponse
+	if err := protojson.Unmarshal(recorder.Body.Bytes(), &alice); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	bob := joinGameViaHTTP(t, server.URL, alice.Code, "Bob")
+
+	if recorder := call(http.MethodGet, "/api/games/"+alice.Code+"/pending", "nope", ""); recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected polling without a seat to be forbidden, got %d", recorder.Code)
+	}
+	state, err := handler.loadGameState(alice.GameId)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	if state.GetCorrespondence().GetTurnHours() != 24 {
+		t.Fatalf("expected a 24 hour correspondence game, got %+v", state.Correspondence)
+	}
+	deadline := time.Now().Add(time.Hour)
+	state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_ROLL
+	state.CurrentTurn = 0
+	state.Correspondence.TurnDeadline = deadline.UnixMilli()
+	if err := handler.saveGameState(state.Id, state); err != nil {
+		t.Fatalf("failed to save game state: %v", err)
+	}
+
+	resp := pending(alice.Code, alice.SessionToken)
+	if len(resp.Actions) != 1 || resp.Actions[0].Kind != catanv1.PendingActionKind_PENDING_ACTION_KIND_ROLL || resp.TurnDeadline != deadline.UnixMilli() {
+		t.Fatalf("expected Alice to owe a roll by the deadline, got %+v", resp)
+	}
+	if resp := pending(alice.Code, bob.SessionToken); len(resp.Actions) != 0 || resp.CurrentPlayerId != alice.PlayerId {
+		t.Fatalf("expected Bob to be waiting on Alice, got %+v", resp)
+	}
+
+	handler.SetNotifier(notify.Webhooks([]string{webhook.URL}, ""))
+	if n, err := handler.SweepDeadlines(deadline.Add(-time.Minute)); err != nil || n != 0 {
+		t.Fatalf("expected nothing overdue before the deadline, got %d, %v", n, err)
+	}
+	if n, err := handler.SweepDeadlines(deadline); err != nil || n != 1 {
+		t.Fatalf("expected Alice's turn to be forfeited, got %d, %v", n, err)
+	}
+	seen := map[string]map[string]any{}
+	for range 2 {
+		select {
+		case body := <-notifications:
+			var n map[string]any
+			if err := json.Unmarshal(body, &n); err != nil {
+				t.Fatalf("failed to decode notification: %v", err)
+			}
+			seen[n["kind"].(string)] = n
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected two notifications, got %v", seen)
+		}
+	}
+	if n := seen["turn_forfeited"]; n == nil || n["playerId"] != alice.PlayerId {
+		t.Errorf("expected Alice to hear their turn was forfeited, got %v", seen)
+	}
+	if n := seen["your_turn"]; n == nil || n["playerId"] != bob.PlayerId || n["deadline"] == nil {
+		t.Errorf("expected Bob to be told it is their turn, got %v", seen)
+	}
+
+	resp = pending(alice.Code, bob.SessionToken)
+	if len(resp.Actions) != 1 || resp.Actions[0].Kind != catanv1.PendingActionKind_PENDING_ACTION_KIND_ROLL {
+		t.Fatalf("expected Bob to owe a roll, got %+v", resp)
+	}
+	if want := deadline.Add(24 * time.Hour).UnixMilli(); resp.TurnDeadline != want {
+		t.Errorf("expected a fresh 24 hour clock, got %d want %d", resp.TurnDeadline, want)
+	}
+
+	// A robber step nobody finishes is settled rather than stalling the
+	// game, and the forfeit resets export votes like any other command.
+	state, err = handler.loadGameState(alice.GameId)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	roller := bob.PlayerId
+	state.Players[0].Resources = &catanv1.ResourceCount{Wood: 8}
+	state.RobberPhase = &catanv1.RobberPhase{
+		DiscardPending:      []string{alice.PlayerId},
+		DiscardRequired:     map[string]int32{alice.PlayerId: 4},
+		MovePendingPlayerId: &roller,
+	}
+	state.ExportApprovedPlayerIds = []string{alice.PlayerId}
+	if err := handler.saveGameState(state.Id, state); err != nil {
+		t.Fatalf("failed to save game state: %v", err)
+	}
+	overdue := time.UnixMilli(state.Correspondence.TurnDeadline)
+	if n, err := handler.SweepDeadlines(overdue); err != nil || n != 1 {
+		t.Fatalf("expected the stalled robber turn to be forfeited, got %d, %v", n, err)
+	}
+	state, err = handler.loadGameState(alice.GameId)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	if state.RobberPhase != nil || state.Players[state.CurrentTurn].Id != alice.PlayerId || state.Players[0].Resources.Wood != 4 {
+		t.Errorf("expected Alice's discard taken and the turn passed to her, got %v", state)
+	}
+	if len(state.ExportApprovedPlayerIds) != 0 {
+		t.Errorf("expected the forfeit to reset export votes, got %v", state.ExportApprovedPlayerIds)
+	}
+	last, err := handler.store.LastEvent(alice.GameId)
+	if err != nil || last.Kind != "forfeitTurn" || last.PlayerID != bob.PlayerId {
+		t.Errorf("expected the forfeit logged as Bob's, got %+v, %v", last, err)
+	}
+}
+
+func TestCatanService(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	h := hub.NewHub()
+	go h.Run()
+	handler := NewHandler(database, h)
+	server := httptest.NewServer(buildMux(handler))
+	defer server.Close()
+	client := catanv1connect.NewCatanServiceClient(http.DefaultClient, server.URL)
+	ctx := context.Background()
+	created, err := client.CreateGame(ctx, connect.NewRequest(&catanv1.CreateGameRequest{PlayerName: "Host"}))
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	host := created.Msg
+	joined, err := client.JoinGame(ctx, connect.NewRequest(&catanv1.JoinGameRequest{PlayerName: "Bob", Code: strings.ToLower(host.Code)}))
+	if err != nil {
+		t.Fatalf("JoinGame: %v", err)
+	}
+	bob := joined.Msg
+	if _, err := client.JoinGame(ctx, connect.NewRequest(&catanv1.JoinGameRequest{PlayerName: "Eve", Code: "NOSUCH"})); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected NotFound joining a missing game, got %v", err)
+	}
+
+	info, err := client.GetGame(ctx, connect.NewRequest(&catanv1.GetGameRequest{Code: host.Code}))
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+	if info.Msg.PlayerCount != 2 {
+		t.Fatalf("expected 2 players, got %d", info.Msg.PlayerCount)
+	}
+
+	// Plain JSON over HTTP/1.1 works as well, as with curl
+	resp, err := http.Post(server.URL+catanv1connect.CatanServiceGetGameProcedure, "application/json", strings.NewReader(`{"code":"`+host.Code+`"}`))
+	if err != nil {
+		t.Fatalf("failed to post: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), `"playerCount":2`) {
+		t.Fatalf("expected the game info as JSON, got %d: %s", resp.StatusCode, body)
+	}
+
+	if _, err := client.PlayerReady(ctx, connect.NewRequest(&catanv1.PlayerReadyMessage{Ready: true})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected Unauthenticated without a session token, got %v", err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	subReq := connect.NewRequest(&catanv1.SubscribeRequest{})
+	subReq.Header().Set("X-Session-Token", host.SessionToken)
+	stream, err := client.Subscribe(subCtx, subReq)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer stream.Close()
+	receive := func() *catanv1.ServerMessage {
+		t.Helper()
+		if !stream.Receive() {
+			t.Fatalf("stream ended: %v", stream.Err())
+		}
+		return stream.Msg()
+	}
+	if state := receive().GetGameState().GetState(); state == nil || state.Code != host.Code {
+		t.Fatalf("expected the game's state first, got %v", state)
+	}
+	if receive().GetChatHistory() == nil {
+		t.Fatalf("expected the chat history after the state")
+	}
+
+	readyReq := connect.NewRequest(&catanv1.PlayerReadyMessage{Ready: true})
+	readyReq.Header().Set("X-Session-Token", bob.SessionToken)
+	ready, err := client.PlayerReady(ctx, readyReq)
+	if err != nil {
+		t.Fatalf("PlayerReady: %v", err)
+	}
+	if p := ready.Msg.State.Players[1]; p.Id != bob.PlayerId || !p.IsReady {
+		t.Fatalf("expected Bob ready in the response, got %v", p)
+	}
+	if p := receive().GetGameState().GetState().GetPlayers()[1]; !p.GetIsReady() {
+		t.Fatalf("expected the subscriber to see Bob ready, got %v", p)
+	}
+
+	rollReq := connect.NewRequest(&catanv1.RollDiceMessage{})
+	rollReq.Header().Set("X-Session-Token", host.SessionToken)
+	_, err = client.RollDice(ctx, rollReq)
+	if connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected FailedPrecondition rolling in the lobby, got %v", err)
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) || len(connectErr.Details()) != 1 {
+		t.Fatalf("expected one error detail, got %v", err)
+	}
+	detail, err := connectErr.Details()[0].Value()
+	if payload, ok := detail.(*catanv1.ErrorPayload); err != nil || !ok || payload.ErrorCode != catanv1.ErrorCode_ERROR_CODE_WRONG_PHASE {
+		t.Fatalf("expected a WRONG_PHASE ErrorPayload, got %v (%v)", detail, err)
+	}
+
+	events, err := handler.store.ListEvents(host.GameId, 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	last := events[len(events)-1]
+	if last.Kind != "playerReady" || last.PlayerID != bob.PlayerId || !strings.Contains(string(last.Payload), `"oneofKind":"playerReady"`) {
+		t.Fatalf("expected the command logged like a WebSocket one, got %s %s %s", last.Kind, last.PlayerID, last.Payload)
+	}
+
+	handler.EndSubscriptions()
+	if stream.Receive() || connect.CodeOf(stream.Err()) != connect.CodeUnavailable {
+		t.Fatalf("expected the stream to end as Unavailable, got %v", stream.Err())
+	}
+}
+
+// instance is one of several servers sharing a game store, as behind a load
+// balancer.
+type instance struct {
+	handler *Handler
+	server  *httptest.Server
+	client  catanv1connect.CatanServiceClient
+}
+
+// startInstances starts n servers on database that know each other.
+func startInstances(t *testing.T, database *sqlx.DB, n int) []*instance {
+	t.Helper()
+	instances := make([]*instance, n)
+	urls := make([]string, n)
+	for i := range instances {
+		server := httptest.NewUnstartedServer(nil)
+		instances[i] = &instance{server: server}
+		urls[i] = "http://" + server.Listener.Addr().String()
+	}
+	for i, inst := range instances {
+		peers := broker.NewPeers(urls[i], urls, "s3cret")
+		h := hub.NewHubWithBroker(peers)
+		go h.Run()
+		inst.handler = NewHandler(database, h)
+		inst.handler.SetLeaseTTL(time.Second)
+		mux := buildMux(inst.handler)
+		mux.Handle(broker.PathPrefix, peers)
+		inst.server.Config.Handler = mux
+		inst.server.Start()
+		inst.client = catanv1connect.NewCatanServiceClient(http.DefaultClient, inst.server.URL)
+		t.Cleanup(func() {
+			inst.server.Close()
+			_ = peers.Close()
+		})
+	}
+	return instances
+}
+
+func TestMultipleInstances(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	instances := startInstances(t, database, 3)
+	a, b, c := instances[0], instances[1], instances[2]
+
+	created := createGameViaHTTP(t, a.server.URL, "Host")
+	gameID := created.GetGameId()
+	holder := func() string {
+		t.Helper()
+		var owner string
+		if err := database.Get(&owner, "SELECT owner FROM game_leases WHERE game_id = ?", gameID); err != nil {
+			t.Fatalf("failed to read the game's lease: %v", err)
+		}
+		return owner
+	}
+	bob := joinGameViaHTTP(t, b.server.URL, created.GetCode(), "Bob")
+	if got := holder(); got != b.server.URL {
+		t.Fatalf("expected the instance Bob joined through to hold the game, got %q", got)
+	}
+
+	dial := func(inst *instance, token string) *websocket.Conn {
+		t.Helper()
+		conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(inst.server.URL, "http", "ws", 1)+"/ws?token="+token, nil)
+		if err != nil {
+			t.Fatalf("failed to connect websocket: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		readServerMessage(t, conn, "chatHistory")
+		return conn
+	}
+	waitForState := func(conn *websocket.Conn, done func(state *catanv1.GameState) bool) {
+		t.Helper()
+		for {
+			var payload gameStatePayload
+			if err := json.Unmarshal(readServerMessage(t, conn, "gameState"), &payload); err != nil {
+				t.Fatalf("failed to decode game state payload: %v", err)
+			}
+			var state catanv1.GameState
+			if err := protojson.Unmarshal(payload.State, &state); err != nil {
+				t.Fatalf("failed to decode game state: %v", err)
+			}
+			if done(&state) {
+				return
+			}
+		}
+	}
+	hostConn := dial(a, created.GetSessionToken())
+	if got := holder(); got != b.server.URL {
+		t.Fatalf("expected connecting elsewhere to be forwarded rather than take the game, got %q", got)
+	}
+	if state, err := a.handler.loadGameState(gameID); err != nil || !state.Players[0].Connected {
+		t.Fatalf("expected the instance holding the game to mark the host connected, got %v", err)
+	}
+	bobConn := dial(b, bob.GetSessionToken())
+	waitForState(hostConn, func(state *catanv1.GameState) bool { return state.Players[1].Connected })
+
+	// The host's command is run by the instance holding the game, and
+	// everyone sees the result wherever they are connected.
+	ready := `{"message":{"oneofKind":"playerReady","playerReady":{"ready":true}}}`
+	if err := hostConn.WriteMessage(websocket.TextMessage, []byte(ready)); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+	hostReady := func(state *catanv1.GameState) bool { return state.Players[0].IsReady }
+	waitForState(hostConn, hostReady)
+	waitForState(bobConn, hostReady)
+	if got := holder(); got != b.server.URL {
+		t.Fatalf("expected the command forwarded rather than the game taken, got %q", got)
+	}
+	events, err := a.handler.store.ListEvents(gameID, 0)
+	if err != nil || events[len(events)-1].Kind != "playerReady" || events[len(events)-1].PlayerID != created.GetPlayerId() {
+		t.Fatalf("expected the host's command logged once, got %v", err)
+	}
+
+	// Whispers reach the recipient on another instance
+	whisper := fmt.Sprintf(`{"message":{"oneofKind":"sendChat","sendChat":{"text":"psst","recipientId":%q}}}`, bob.GetPlayerId())
+	if err := hostConn.WriteMessage(websocket.TextMessage, []byte(whisper)); err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+	if line := readServerMessage(t, bobConn, "chatMessage"); !strings.Contains(string(line), "psst") {
+		t.Fatalf("expected Bob to get the whisper, got %s", line)
+	}
+
+	// Commands arriving at every instance at once are applied one at a time
+	state, err := a.handler.loadGameState(gameID)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	state.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = catanv1.TurnPhase_TURN_PHASE_TRADE
+	state.CurrentTurn = 0
+	const trades = 16
+	state.Players[0].Resources = &catanv1.ResourceCount{Wood: trades, Brick: trades, Sheep: trades, Wheat: trades}
+	if err := a.handler.store.SaveGameState(gameID, state); err != nil {
+		t.Fatalf("failed to save game state: %v", err)
+	}
+	offers := []*catanv1.ResourceCount{{Wood: 4}, {Brick: 4}, {Sheep: 4}, {Wheat: 4}}
+	trade := func(inst *instance, offer *catanv1.ResourceCount) error {
+		req := connect.NewRequest(&catanv1.BankTradeMessage{Offering: offer, ResourceRequested: catanv1.Resource_RESOURCE_ORE})
+		req.Header().Set("X-Session-Token", created.GetSessionToken())
+		_, err := inst.client.BankTrade(context.Background(), req)
+		return err
+	}
+	errs := make(chan error, trades)
+	for i := range trades {
+		go func() { errs <- trade(instances[i%len(instances)], offers[i%len(offers)]) }()
+	}
+	for range trades {
+		if err := <-errs; err != nil {
+			t.Errorf("BankTrade: %v", err)
+		}
+	}
+	state, err = a.handler.loadGameState(gameID)
+	if err != nil {
+		t.Fatalf("failed to load game state: %v", err)
+	}
+	if res := state.Players[0].Resources; res.Wood+res.Brick+res.Sheep+res.Wheat != 0 || res.Ore != trades {
+		t.Fatalf("expected all %d trades applied, got %v", trades, res)
+	}
+
+	// An instance shutting down hands its games over
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := b.handler.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if err := trade(c, offers[0]); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected the trade refused for lack of wood, got %v", err)
+	}
+	if got := holder(); got != c.server.URL {
+		t.Fatalf("expected the game taken over at once, got %q", got)
+	}
+
+	// One that disappears holds its games up until their lease runs out
+	c.server.Close()
+	if err := trade(a, offers[0]); connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Fatalf("expected Unavailable while the lost instance holds the game, got %v", err)
+	}
+	time.Sleep(time.Second)
+	if err := trade(a, offers[0]); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+		t.Fatalf("expected the game run again after its lease ran out, got %v", err)
+	}
+	if got := holder(); got != a.server.URL {
+		t.Fatalf("expected the game taken over after its lease ran out, got %q", got)
+	}
+}
+
+func TestDevEndpoints_TakeGameFromHolder(t *testing.T) {
+	database, cleanup := setupTestDB(t)
+	defer cleanup()
+	instances := startInstances(t, database, 2)
+	a, b := instances[0], instances[1]
+
+	created := createGameViaHTTP(t, a.server.URL, "Host")
+	gameID := created.GetGameId()
+	joinGameViaHTTP(t, b.server.URL, created.GetCode(), "Bob")
+
+	body, _ := json.Marshal(map[string]any{
+		"gameCode":  created.GetCode(),
+		"playerId":  created.GetPlayerId(),
+		"resources": map[string]int32{"ore": 3},
+	})
+	recorder := httptest.NewRecorder()
+	a.handler.HandleGrantResources(recorder, httptest.NewRequest(http.MethodPost, "/test/grant-resources", bytes.NewBuffer(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var owner string
+	if err := database.Get(&owner, "SELECT owner FROM game_leases WHERE game_id = ?", gameID); err != nil {
+		t.Fatalf("failed to read the game's lease: %v", err)
+	}
+	if owner != a.server.URL {
+		t.Fatalf("expected the DEV write to take the game from the instance holding it, got %q", owner)
+	}
+	state, err := a.handler.loadGameState(gameID)
+	if err != nil || state.Players[0].Resources.GetOre() != 3 {
+		t.Fatalf("expected the grant saved, got %v %v", state.GetPlayers(), err)
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+// RetentionPolicy says how long games are kept. A zero duration keeps those
+// games forever.
+type RetentionPolicy struct {
+	// LobbyTTL expires waiting lobbies nobody has touched for this long.
+	LobbyTTL time.Duration
+	// FinishedRetention deletes finished games this long after their last
+	// update. Their results stay in game_history and player_game_stats.
+	FinishedRetention time.Duration
+}
+
+// SweepReport counts what one janitor pass removed.
+type SweepReport struct {
+	Scanned          int
+	ExpiredLobbies   int
+	ArchivedFinished int
+}
+
+// RunJanitor sweeps expired games every interval until ctx is done.
+func (h *Handler) RunJanitor(ctx context.Context, policy RetentionPolicy, interval time.Duration) {
+	if interval <= 0 || (policy.LobbyTTL <= 0 && policy.FinishedRetention <= 0) {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := h.SweepExpiredGames(policy, time.Now())
+			if err != nil {
+				slog.Error("janitor sweep failed", "err", err)
+			}
+			if report.ExpiredLobbies > 0 || report.ArchivedFinished > 0 {
+				slog.Info("janitor removed games", "expired_lobbies", report.ExpiredLobbies,
+					"archived_finished", report.ArchivedFinished, "scanned", report.Scanned)
+			}
+		}
+	}
+}
+
+// SweepExpiredGames deletes lobbies idle past policy.LobbyTTL and finished
+// games older than policy.FinishedRetention as of now. Tournament tables
+// waiting for their players are kept, since their tournament cannot advance
+// without a result for each of them. Finished games are archived first so
+// their stats survive, and a finished table's result is given to its
+// tournament if it is still missing. Clients still attached to a removed
+// game are told why and disconnected.
+func (h *Handler) SweepExpiredGames(policy RetentionPolicy, now time.Time) (SweepReport, error) {
+	var report SweepReport
+	var errs []error
+	sweep := func(status catanv1.GameStatus, ttl time.Duration, reason string, removed *int) {
+		if ttl <= 0 {
+			return
+		}
+		cutoff := now.Add(-ttl)
+		ids, err := h.store.ListIdleGameIDs(status, cutoff)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		for _, id := range ids {
+			report.Scanned++
+			ok, err := h.expireIdleGame(id, status, cutoff, reason)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if ok {
+				*removed++
+			}
+		}
+	}
+	sweep(catanv1.GameStatus_GAME_STATUS_WAITING, policy.LobbyTTL, "lobby expired after being idle", &report.ExpiredLobbies)
+	sweep(catanv1.GameStatus_GAME_STATUS_FINISHED, policy.FinishedRetention, "finished game was archived", &report.ArchivedFinished)
+	return report, errors.Join(errs...)
+}
+
+// expireIdleGame deletes gameID if it is still in status and untouched
+// since cutoff, archiving it first if finished. It checks under the game's
+// lock, taken from whichever instance holds it, so a join or command
+// arriving meanwhile either lands first and keeps the game or finds it gone.
+func (h *Handler) expireIdleGame(gameID string, status catanv1.GameStatus, cutoff time.Time, reason string) (bool, error) {
+	unlock, err := h.takeGame(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	g, err := h.loadGame(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if g.State.GetStatus() != status || !g.UpdatedAt.Before(cutoff) {
+		return false, nil
+	}
+	switch status {
+	case catanv1.GameStatus_GAME_STATUS_WAITING:
+		if g.State.TournamentId != "" {
+			return false, nil
+		}
+	case catanv1.GameStatus_GAME_STATUS_FINISHED:
+		if id := g.State.TournamentId; id != "" {
+			// The tournament needs the table's result before it is gone
+			if err := h.catchUpTournament(id); err != nil && !errors.Is(err, store.ErrNotFound) {
+				return false, err
+			}
+		}
+		winnerID, _ := game.DetermineWinner(g.State)
+		if err := h.archiveFinishedGame(g.State, winnerID); err != nil {
+			return false, err
+		}
+	}
+	return true, h.expireGame(gameID, reason)
+}
+
+// expireGame deletes a game and disconnects anyone still attached to it,
+// on every instance. A game already gone was expired by another instance.
+// The caller holds the game's lock.
+func (h *Handler) expireGame(gameID, reason string) error {
+	err := h.store.DeleteGame(gameID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	h.leases.forget(gameID)
+	if err := h.deleteGameStats(gameID); err != nil {
+		slog.Error("failed to delete game stats", "game_id", gameID, "err", err)
+	}
+	if frame, err := errorFrame(errorPayload("game_expired", reason)); err == nil {
+		h.hub.BroadcastToGame(gameID, frame)
+	}
+	h.hub.CloseGame(gameID)
+	return nil
+}
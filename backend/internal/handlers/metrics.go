@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/hub"
+	"settlers_from_catan/internal/metrics"
+	"settlers_from_catan/internal/store"
+)
+
+// clientMessageTypes are the ClientMessage oneof kinds handleClientMessage
+// understands. Anything else is counted as "unknown" so clients cannot mint
+// label values.
+var clientMessageTypes = map[string]bool{
+	"joinGame": true, "startGame": true, "rollDice": true, "buildStructure": true,
+	"proposeTrade": true, "respondTrade": true, "moveRobber": true, "endTurn": true,
+	"playDevCard": true, "playerReady": true, "discardCards": true, "bankTrade": true,
+	"setTurnPhase": true, "buyDevCard": true, "approveExport": true, "sendChat": true,
+	"muteChat": true, "setChatSettings": true, "requestTakeback": true, "respondTakeback": true,
+}
+
+type handlerMetrics struct {
+	registry        *metrics.Registry
+	messages        *metrics.CounterVec
+	rejected        *metrics.CounterVec
+	violations      *metrics.CounterVec
+	commandDuration *metrics.HistogramVec
+	saveDuration    *metrics.HistogramVec
+}
+
+func newHandlerMetrics(games store.GameStore, clients *hub.Hub) *handlerMetrics {
+	r := metrics.NewRegistry()
+	m := &handlerMetrics{
+		registry:        r,
+		messages:        r.NewCounterVec("catan_ws_messages_total", "WebSocket messages received, by message type.", "type"),
+		rejected:        r.NewCounterVec("catan_commands_rejected_total", "Commands answered with an error, by error code.", "code"),
+		violations:      r.NewCounterVec("catan_state_violations_total", "Game state invariants broken by commands, by rule.", "rule"),
+		commandDuration: r.NewHistogramVec("catan_command_duration_seconds", "Time to load, apply, save and broadcast a game command.", metrics.DefaultBuckets, "type"),
+		saveDuration:    r.NewHistogramVec("catan_game_save_duration_seconds", "Time to persist a game state.", metrics.DefaultBuckets),
+	}
+	r.NewGaugeVecFunc("catan_games", "Stored games, by status.", "status", func() map[string]float64 {
+		return countGamesByStatus(games)
+	})
+	r.NewGaugeFunc("catan_connected_clients", "Connected WebSocket clients.", func() float64 {
+		return float64(clients.ClientCount())
+	})
+	r.NewCounterFunc("catan_hub_send_drops_total", "Messages dropped because a client's send queue was full.", func() float64 {
+		return float64(clients.Drops())
+	})
+	return m
+}
+
+func countGamesByStatus(games store.GameStore) map[string]float64 {
+	counts := map[string]float64{}
+	for _, status := range []catanv1.GameStatus{
+		catanv1.GameStatus_GAME_STATUS_WAITING,
+		catanv1.GameStatus_GAME_STATUS_SETUP,
+		catanv1.GameStatus_GAME_STATUS_PLAYING,
+		catanv1.GameStatus_GAME_STATUS_FINISHED,
+	} {
+		counts[store.StatusName(status)] = 0
+	}
+	stored, err := games.CountGamesByStatus()
+	if err != nil {
+		slog.Error("counting games for metrics", "err", err)
+		return counts
+	}
+	for status, n := range stored {
+		counts[status] = float64(n)
+	}
+	return counts
+}
+
+// HandleMetrics serves metrics in the Prometheus text format: GET /metrics
+func (h *Handler) HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	h.metrics.registry.ServeHTTP(w, r)
+}
+
+// commandLogger tags log lines with the client's game, player and message
+// type.
+func commandLogger(client *hub.Client, kind string) *slog.Logger {
+	return playerLogger(client.GameID, client.PlayerID, kind)
+}
+
+func playerLogger(gameID, playerID, kind string) *slog.Logger {
+	return slog.With("game_id", gameID, "player_id", playerID, "message_type", kind)
+}
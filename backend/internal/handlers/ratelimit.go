@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"settlers_from_catan/internal/hub"
+)
+
+// RateLimits bounds how fast WebSocket clients may send game commands. Rates
+// are in messages per second; Burst is how many may arrive at once.
+type RateLimits struct {
+	ClientRate  float64
+	ClientBurst int
+	GameRate    float64
+	GameBurst   int
+	// MaxStrikes is how many rejected messages a client may send, each
+	// within strikeWindow of the last, before it is disconnected.
+	MaxStrikes int
+	// ChatRate and ChatBurst further limit chat lines per client. A zero
+	// ChatBurst leaves chat limited only by ClientRate.
+	ChatRate  float64
+	ChatBurst int
+}
+
+// DefaultRateLimits are generous for people clicking and tight for scripts.
+var DefaultRateLimits = RateLimits{
+	ClientRate:  5,
+	ClientBurst: 20,
+	GameRate:    20,
+	GameBurst:   60,
+	MaxStrikes:  20,
+	ChatRate:    0.5,
+	ChatBurst:   5,
+}
+
+const (
+	// bucketIdle is how long an untouched bucket is kept before it is dropped.
+	bucketIdle = time.Minute
+	// strikeWindow is how long a client must stay well behaved for its
+	// strikes to be forgiven.
+	strikeWindow = 10 * time.Second
+)
+
+// tokenBucket holds up to burst tokens, refilled at rate per second.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(now time.Time, rate float64, burst int) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type clientLimit struct {
+	tokenBucket
+	chat       tokenBucket
+	strikes    int
+	lastStrike time.Time
+}
+
+func (c *clientLimit) strike(now time.Time) int {
+	if now.Sub(c.lastStrike) > strikeWindow {
+		c.strikes = 0
+	}
+	c.strikes++
+	c.lastStrike = now
+	return c.strikes
+}
+
+// rateLimiter keeps a bucket per client and per game. Clients are keyed by
+// their *hub.Client, or by an rpcCaller for CatanService calls.
+type rateLimiter struct {
+	limits    RateLimits
+	mu        sync.Mutex
+	clients   map[any]*clientLimit
+	games     map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	return &rateLimiter{
+		limits:  limits,
+		clients: map[any]*clientLimit{},
+		games:   map[string]*tokenBucket{},
+	}
+}
+
+// rpcCaller keys the buckets of a player calling CatanService, whose calls
+// share one bucket however many connections they arrive on.
+type rpcCaller struct {
+	playerID string
+}
+
+// allow reports whether client may send another message now, and its
+// strike count.
+func (l *rateLimiter) allow(client *hub.Client, now time.Time) (ok bool, strikes int) {
+	return l.allowKey(client, client.GameID, now)
+}
+
+// allowKey is allow for the client keyed by key, playing in gameID.
+func (l *rateLimiter) allowKey(key any, gameID string, now time.Time) (ok bool, strikes int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	c := l.client(key, now)
+	g := l.games[gameID]
+	if g == nil {
+		g = &tokenBucket{tokens: float64(l.limits.GameBurst), last: now}
+		l.games[gameID] = g
+	}
+	// Only charge the game once the client's own bucket has room, so one
+	// noisy client cannot drain the game for everyone else.
+	if !c.take(now, l.limits.ClientRate, l.limits.ClientBurst) {
+		return false, c.strike(now)
+	}
+	// A busy game is not this client's fault, so it earns no strike
+	if !g.take(now, l.limits.GameRate, l.limits.GameBurst) {
+		return false, c.strikes
+	}
+	return true, c.strikes
+}
+
+// allowChat reports whether the client keyed by key may send another chat
+// line now.
+func (l *rateLimiter) allowChat(key any, now time.Time) bool {
+	if l.limits.ChatBurst <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.client(key, now).chat.take(now, l.limits.ChatRate, l.limits.ChatBurst)
+}
+
+func (l *rateLimiter) client(key any, now time.Time) *clientLimit {
+	c := l.clients[key]
+	if c == nil {
+		c = &clientLimit{
+			tokenBucket: tokenBucket{tokens: float64(l.limits.ClientBurst), last: now},
+			chat:        tokenBucket{tokens: float64(l.limits.ChatBurst), last: now},
+		}
+		l.clients[key] = c
+	}
+	return c
+}
+
+// strike records a rejected message that got past the rate limit, such as
+// one that does not parse.
+func (l *rateLimiter) strike(client *hub.Client, now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.clients[client]
+	if c == nil {
+		return 0
+	}
+	return c.strike(now)
+}
+
+func (l *rateLimiter) forget(client *hub.Client) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.clients, client)
+}
+
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < bucketIdle {
+		return
+	}
+	l.lastPrune = now
+	for key, c := range l.clients {
+		if now.Sub(c.last) > bucketIdle {
+			delete(l.clients, key)
+		}
+	}
+	for gameID, g := range l.games {
+		if now.Sub(g.last) > bucketIdle {
+			delete(l.games, gameID)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+// HandleRematch answers POST /api/games/{code}/rematch. The first player to
+// ask creates a lobby for the same players, optionally as a best-of-N
+// series with {"bestOf": n}; everyone who asks gets their seat in it as a
+// join response. The caller proves their seat in the finished game with its
+// session token.
+func (h *Handler) HandleRematch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req struct {
+		BestOf int32 `json:"bestOf"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	gameID, _, err := h.loadGameByCode(gameCodeFromPath(r.URL.Path))
+	if err != nil {
+		http.Error(w, "game not found", http.StatusNotFound)
+		return
+	}
+	seat, err := h.store.GetPlayerBySession(sessionTokenFromRequest(r))
+	if err != nil || seat.GameID != gameID {
+		http.Error(w, "only players in this game can rematch it", http.StatusForbidden)
+		return
+	}
+
+	// Players tend to ask at the same moment, maybe of different instances;
+	// only one rematch is created.
+	unlock, err := h.takeGame(gameID)
+	if err != nil {
+		slog.Warn("failed to take game for rematch", "game_id", gameID, "err", err)
+		http.Error(w, "game is busy, try again", http.StatusServiceUnavailable)
+		return
+	}
+	defer unlock()
+	state, err := h.loadGameState(gameID)
+	if err != nil {
+		http.Error(w, "failed to load game state", http.StatusInternalServerError)
+		return
+	}
+	if state.RematchGameId == "" {
+		if err := h.createRematch(state, req.BestOf); err != nil {
+			switch {
+			case errors.Is(err, game.ErrGameNotFinished):
+				http.Error(w, err.Error(), http.StatusConflict)
+			case errors.Is(err, game.ErrInvalidSeriesLength):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+			default:
+				http.Error(w, "failed to create rematch", http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	rematch, err := h.loadGame(state.RematchGameId)
+	if err != nil {
+		http.Error(w, "rematch not found", http.StatusNotFound)
+		return
+	}
+	player, err := h.store.GetPlayer(state.RematchPlayerIds[seat.ID])
+	if err != nil || player.GameID != rematch.ID {
+		http.Error(w, "you no longer have a seat in the rematch", http.StatusNotFound)
+		return
+	}
+	writeJoinResponse(w, rematch.ID, player.ID, player.SessionToken, rematch.State)
+}
+
+// createRematch stores the rematch of the finished game prev, links prev to
+// it and tells prev's players. Each seat keeps its account, if any, and gets
+// a new session token.
+func (h *Handler) createRematch(prev *catanv1.GameState, bestOf int32) error {
+	gameID := uuid.New().String()
+	code := strings.ToUpper(randomCode(6))
+	newIDs := make(map[string]string, len(prev.Players))
+	for _, p := range prev.Players {
+		newIDs[p.Id] = uuid.New().String()
+	}
+	state, err := game.NewRematch(prev, gameID, code, newIDs, bestOf)
+	if err != nil {
+		return err
+	}
+
+	seats := make([]*store.Player, 0, len(state.Players))
+	for _, p := range prev.Players {
+		seat := &store.Player{
+			ID:           newIDs[p.Id],
+			Name:         p.Name,
+			Color:        p.Color,
+			SessionToken: uuid.New().String(),
+			IsHost:       p.IsHost,
+		}
+		if old, err := h.store.GetPlayer(p.Id); err == nil {
+			seat.UserID = old.UserID
+		}
+		seats = append(seats, seat)
+	}
+	if err := h.store.CreateGame(&store.Game{ID: gameID, Code: code, State: state}, seats...); err != nil {
+		return err
+	}
+	_ = h.appendGameEvent(gameID, "rematch", "", nil, state)
+
+	prev.RematchGameId = gameID
+	prev.RematchPlayerIds = newIDs
+	if err := h.saveGameState(prev.Id, prev); err != nil {
+		return err
+	}
+	h.broadcastRematch(prev.Id, &catanv1.RematchPayload{GameId: gameID, Code: code})
+	return nil
+}
+
+func (h *Handler) broadcastRematch(gameID string, payload *catanv1.RematchPayload) {
+	payloadJSON, err := wsMarshal.Marshal(payload)
+	if err != nil {
+		return
+	}
+	envelope := serverEnvelope{
+		Message: serverMessage{
+			OneofKind: "rematch",
+			Rematch:   payloadJSON,
+		},
+	}
+	if msg, err := json.Marshal(envelope); err == nil {
+		h.hub.BroadcastToGame(gameID, msg)
+	}
+}
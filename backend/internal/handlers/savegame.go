@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+const (
+	saveFileFormat  = "settlers-from-catan/game"
+	saveFileVersion = 1
+	maxSaveFileSize = 16 << 20
+)
+
+// saveFile is the self-contained, versioned export of a game.
+type saveFile struct {
+	Format       string          `json:"format"`
+	Version      int             `json:"version"`
+	ExportedAt   string          `json:"exportedAt"`
+	SourceGameID string          `json:"sourceGameId"`
+	Seed         int64           `json:"seed,string"`
+	AtSeq        int64           `json:"atSeq"`
+	State        json.RawMessage `json:"state"`
+	Events       []gameEvent     `json:"events"`
+}
+
+// gameEvent is one entry of a game's event log.
+type gameEvent struct {
+	Seq       int64           `json:"seq"`
+	Kind      string          `json:"kind"`
+	PlayerID  string          `json:"playerId,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt string          `json:"createdAt"`
+}
+
+type seatClaim struct {
+	PlayerID     string              `json:"playerId"`
+	Name         string              `json:"name"`
+	Color        catanv1.PlayerColor `json:"color"`
+	IsHost       bool                `json:"isHost"`
+	SessionToken string              `json:"sessionToken"`
+}
+
+type importResponse struct {
+	GameID string      `json:"gameId"`
+	Code   string      `json:"code"`
+	Seats  []seatClaim `json:"seats"`
+}
+
+// HandleExportGame returns a save file for the game:
+// GET /api/games/{code}/export?token={sessionToken}[&at={seq}]
+// Without at, the current state is exported; with it, the state right after
+// event seq. Only players seated in the game may export it, and only once it
+// is finished or every player has sent approveExport, since the file reveals
+// every hidden card and the deck order.
+func (h *Handler) HandleExportGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	file, status, err := h.exportGame(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="catan-%s.json"`, file.SourceGameID))
+	_ = json.NewEncoder(w).Encode(file)
+}
+
+// HandleImportGame creates a new game from a save file:
+// POST /api/games/import
+// The new game gets its own ID, join code, player IDs and seat-claim
+// session tokens.
+func (h *Handler) HandleImportGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var file saveFile
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSaveFileSize)).Decode(&file); err != nil {
+		http.Error(w, "invalid save file", http.StatusBadRequest)
+		return
+	}
+	state, err := parseSaveFile(&file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.createGameFromSnapshot(state, "importGame")
+	if err != nil {
+		http.Error(w, "failed to import game", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// HandleForkGame creates a new game from the current state of a game, or
+// from the state right after event seq, on the same terms as an export:
+// POST /api/games/{code}/fork?token={sessionToken}[&at={seq}]
+func (h *Handler) HandleForkGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	file, status, err := h.exportGame(r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	state, err := parseSaveFile(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := h.createGameFromSnapshot(state, "forkGame")
+	if err != nil {
+		http.Error(w, "failed to fork game", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// exportGame builds the save file for an export or fork request. On failure
+// it returns the HTTP status to respond with.
+func (h *Handler) exportGame(r *http.Request) (*saveFile, int, error) {
+	code := gameCodeFromPath(r.URL.Path)
+	gameID, state, err := h.loadGameByCode(code)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("game not found")
+	}
+	if !h.hasSeat(gameID, sessionTokenFromRequest(r)) {
+		return nil, http.StatusForbidden, errors.New("only players in this game can export it")
+	}
+	if !game.ExportAllowed(state) {
+		return nil, http.StatusForbidden, errors.New("every player must approve exporting an unfinished game")
+	}
+
+	var atSeq int64
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		atSeq, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || atSeq <= 0 {
+			return nil, http.StatusBadRequest, errors.New("invalid event sequence")
+		}
+		event, err := h.store.GetEvent(gameID, atSeq)
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, http.StatusNotFound, errors.New("no such event")
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.New("failed to load event")
+		}
+		state = event.State
+		if _, err := game.UpgradeState(state); err != nil {
+			return nil, http.StatusInternalServerError, errors.New("failed to upgrade event state")
+		}
+	}
+
+	logged, err := h.store.ListEvents(gameID, atSeq)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to load events")
+	}
+	events := make([]gameEvent, 0, len(logged))
+	for _, e := range logged {
+		events = append(events, gameEvent{
+			Seq:       e.Seq,
+			Kind:      e.Kind,
+			PlayerID:  e.PlayerID,
+			Payload:   json.RawMessage(e.Payload),
+			CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+		})
+		atSeq = e.Seq
+	}
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to marshal state")
+	}
+
+	return &saveFile{
+		Format:       saveFileFormat,
+		Version:      saveFileVersion,
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		SourceGameID: gameID,
+		Seed:         state.Seed,
+		AtSeq:        atSeq,
+		State:        stateJSON,
+		Events:       events,
+	}, http.StatusOK, nil
+}
+
+// parseSaveFile validates a save file against the current format and proto
+// schema and returns its game state.
+func parseSaveFile(file *saveFile) (*catanv1.GameState, error) {
+	if file.Format != saveFileFormat {
+		return nil, fmt.Errorf("unknown save file format %q", file.Format)
+	}
+	if file.Version < 1 || file.Version > saveFileVersion {
+		return nil, fmt.Errorf("unsupported save file version %d", file.Version)
+	}
+	if len(file.State) == 0 {
+		return nil, errors.New("save file has no state")
+	}
+	var state catanv1.GameState
+	if err := protojson.Unmarshal(file.State, &state); err != nil {
+		return nil, fmt.Errorf("invalid game state: %v", err)
+	}
+	if _, err := game.UpgradeState(&state); err != nil {
+		return nil, fmt.Errorf("invalid game state: %v", err)
+	}
+	if err := validateSnapshot(&state); err != nil {
+		return nil, fmt.Errorf("invalid game state: %v", err)
+	}
+	if state.Seed != file.Seed {
+		return nil, errors.New("save file seed does not match state")
+	}
+	return &state, nil
+}
+
+func validateSnapshot(state *catanv1.GameState) error {
+	if len(state.Players) == 0 || len(state.Players) > game.GetMaxPlayers() {
+		return fmt.Errorf("expected 1-%d players, got %d", game.GetMaxPlayers(), len(state.Players))
+	}
+	seen := map[string]bool{}
+	for _, p := range state.Players {
+		if p.Id == "" || seen[p.Id] {
+			return errors.New("player IDs must be unique and non-empty")
+		}
+		seen[p.Id] = true
+	}
+	if state.Board == nil || len(state.Board.Hexes) == 0 || len(state.Board.Vertices) == 0 || len(state.Board.Edges) == 0 {
+		return errors.New("missing board")
+	}
+	if state.Status == catanv1.GameStatus_GAME_STATUS_UNSPECIFIED {
+		return errors.New("missing status")
+	}
+	if state.CurrentTurn < 0 || int(state.CurrentTurn) >= len(state.Players) {
+		return errors.New("current turn out of range")
+	}
+	return nil
+}
+
+// createGameFromSnapshot persists state as a brand-new game with fresh IDs,
+// join code and session tokens, and starts its event log with kind.
+func (h *Handler) createGameFromSnapshot(state *catanv1.GameState, kind string) (*importResponse, error) {
+	gameID := uuid.New().String()
+	code := strings.ToUpper(randomCode(6))
+
+	mapping := make(map[string]string, len(state.Players))
+	for _, p := range state.Players {
+		mapping[p.Id] = uuid.New().String()
+	}
+	game.RemapPlayerIDs(state, mapping)
+	state.Id = gameID
+	state.Code = code
+	state.RematchGameId = ""
+	state.RematchPlayerIds = nil
+	state.TournamentId = ""
+	// Votes and administrator flags belong to the old game: takeback and
+	// export votes refer to its event log, a pause or forced end to its run
+	state.Paused = false
+	state.EndedByAdmin = false
+	state.ExportApprovedPlayerIds = nil
+	state.PendingTakeback = nil
+	state.TakenBackSeq = 0
+	if c := state.GetCorrespondence(); c != nil {
+		// Restart the turn clock rather than inherit one that has run down
+		c.TurnDeadline = 0
+		game.UpdateTurnDeadline(state, state, time.Now())
+	}
+	for _, p := range state.Players {
+		p.Connected = false
+	}
+
+	resp := &importResponse{GameID: gameID, Code: code, Seats: make([]seatClaim, 0, len(state.Players))}
+	seats := make([]*store.Player, 0, len(state.Players))
+	for _, p := range state.Players {
+		seat := seatClaim{
+			PlayerID:     p.Id,
+			Name:         p.Name,
+			Color:        p.Color,
+			IsHost:       p.IsHost,
+			SessionToken: uuid.New().String(),
+		}
+		resp.Seats = append(resp.Seats, seat)
+		seats = append(seats, &store.Player{
+			ID:           seat.PlayerID,
+			Name:         seat.Name,
+			Color:        seat.Color,
+			SessionToken: seat.SessionToken,
+			IsHost:       seat.IsHost,
+		})
+	}
+	if err := h.store.CreateGame(&store.Game{ID: gameID, Code: code, State: state}, seats...); err != nil {
+		return nil, err
+	}
+
+	_ = h.appendGameEvent(gameID, kind, "", nil, state)
+	return resp, nil
+}
+
+// appendGameEvent adds an entry with the resulting state snapshot to the
+// game's event log.
+func (h *Handler) appendGameEvent(gameID, kind, playerID string, payload []byte, state *catanv1.GameState) error {
+	_, err := h.store.AppendEvent(&store.Event{
+		GameID:   gameID,
+		Kind:     kind,
+		PlayerID: playerID,
+		Payload:  payload,
+		State:    state,
+	})
+	return err
+}
+
+// hasSeat reports whether sessionToken belongs to a player in gameID.
+func (h *Handler) hasSeat(gameID, sessionToken string) bool {
+	if sessionToken == "" {
+		return false
+	}
+	player, err := h.store.GetPlayerBySession(sessionToken)
+	return err == nil && player.GameID == gameID
+}
+
+func sessionTokenFromRequest(r *http.Request) string {
+	if token := r.Header.Get("X-Session-Token"); token != "" {
+		return token
+	}
+	return r.URL.Query().Get("token")
+}
+
+// gameCodeFromPath extracts {code} from /api/games/{code}/...
+func gameCodeFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.ToUpper(parts[2])
+}
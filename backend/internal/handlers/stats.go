@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/stats"
+)
+
+const (
+	defaultLeaderboardLimit = 20
+	maxLeaderboardLimit     = 100
+)
+
+type leaderboardEntry struct {
+	Name        string  `json:"name" db:"player_name"`
+	Rating      float64 `json:"rating" db:"rating"`
+	GamesPlayed int     `json:"gamesPlayed" db:"games_played"`
+	Wins        int     `json:"wins" db:"wins"`
+}
+
+type playerGameRecord struct {
+	GameID       string             `json:"gameId" db:"game_id"`
+	Code         string             `json:"code" db:"code"`
+	FinishedAt   string             `json:"finishedAt" db:"finished_at"`
+	Place        int                `json:"place" db:"place"`
+	Points       int                `json:"points" db:"points"`
+	IsWinner     bool               `json:"isWinner" db:"is_winner"`
+	RatingBefore float64            `json:"ratingBefore" db:"rating_before"`
+	RatingAfter  float64            `json:"ratingAfter" db:"rating_after"`
+	StatsJSON    string             `json:"-" db:"stats"`
+	Stats        *stats.PlayerStats `json:"stats"`
+}
+
+type playerStatsResponse struct {
+	leaderboardEntry
+	Totals *stats.PlayerStats `json:"totals"`
+	Games  []playerGameRecord `json:"games"`
+}
+
+// HandleLeaderboard lists player names by rating: GET /api/leaderboard?limit=N
+func (h *Handler) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	limit := defaultLeaderboardLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxLeaderboardLimit)
+	}
+
+	entries := []leaderboardEntry{}
+	if err := h.db.Select(&entries,
+		`SELECT player_name, rating, games_played, wins FROM player_ratings
+		 ORDER BY rating DESC, wins DESC, player_name ASC LIMIT ?`, limit); err != nil {
+		http.Error(w, "failed to load leaderboard", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"players": entries})
+}
+
+// HandlePlayerStats returns rating and archived game stats for a player:
+// GET /api/players/{id}/stats where {id} is a player ID from any archived
+// game or a player name.
+func (h *Handler) HandlePlayerStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 4 || parts[3] != "stats" {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	id, err := url.PathUnescape(parts[2])
+	if err != nil || id == "" {
+		http.Error(w, "invalid player", http.StatusBadRequest)
+		return
+	}
+
+	name := id
+	var resolved string
+	if err := h.db.Get(&resolved, "SELECT player_name FROM player_game_stats WHERE player_id = ? LIMIT 1", id); err == nil {
+		name = resolved
+	}
+
+	var resp playerStatsResponse
+	err = h.db.Get(&resp.leaderboardEntry,
+		"SELECT player_name, rating, games_played, wins FROM player_ratings WHERE player_name = ?", name)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "player not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to load player", http.StatusInternalServerError)
+		return
+	}
+
+	resp.Games = []playerGameRecord{}
+	if err := h.db.Select(&resp.Games,
+		`SELECT s.game_id, g.code, g.finished_at, s.place, s.points, s.is_winner, s.rating_before, s.rating_after, s.stats
+		 FROM player_game_stats s JOIN game_history g ON g.game_id = s.game_id
+		 WHERE s.player_name = ? ORDER BY g.finished_at DESC, s.game_id`, name); err != nil {
+		http.Error(w, "failed to load player games", http.StatusInternalServerError)
+		return
+	}
+	resp.Totals = &stats.PlayerStats{}
+	for i := range resp.Games {
+		var ps stats.PlayerStats
+		if err := json.Unmarshal([]byte(resp.Games[i].StatsJSON), &ps); err != nil {
+			continue
+		}
+		resp.Games[i].Stats = &ps
+		resp.Totals.Merge(&ps)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) loadGameStats(gameID string) (*stats.GameStats, error) {
+	var statsJSON string
+	err := h.db.Get(&statsJSON, "SELECT stats FROM game_stats WHERE game_id = ?", gameID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return stats.NewGameStats(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	gs := stats.NewGameStats()
+	if err := json.Unmarshal([]byte(statsJSON), gs); err != nil {
+		return nil, err
+	}
+	return gs, nil
+}
+
+func (h *Handler) saveGameStats(gameID string, gs *stats.GameStats) error {
+	statsJSON, err := json.Marshal(gs)
+	if err != nil {
+		return err
+	}
+	_, err = h.db.Exec(
+		`INSERT INTO game_stats (game_id, stats) VALUES (?, ?)
+		 ON CONFLICT(game_id) DO UPDATE SET stats = excluded.stats, updated_at = CURRENT_TIMESTAMP`,
+		gameID, string(statsJSON))
+	return err
+}
+
+// deleteGameStats drops the running stats of a deleted game. Finished games
+// keep theirs in player_game_stats once archived.
+func (h *Handler) deleteGameStats(gameID string) error {
+	_, err := h.db.Exec("DELETE FROM game_stats WHERE game_id = ?", gameID)
+	return err
+}
+
+// recordGameStats folds an applied command into the game's running stats.
+// Stats are best effort and never fail the command.
+func (h *Handler) recordGameStats(gameID, kind, playerID string, before, after *catanv1.GameState) {
+	if kind == "" {
+		return
+	}
+	gs, err := h.loadGameStats(gameID)
+	if err != nil {
+		return
+	}
+	gs.Record(kind, playerID, before, after)
+	_ = h.saveGameStats(gameID, gs)
+}
+
+// archiveFinishedGame records a finished game in game_history with the final
+// placements and per-player stats, and updates each player name's rating.
+// Archiving an already archived game, or one an administrator ended before
+// anyone won, is a no-op.
+func (h *Handler) archiveFinishedGame(state *catanv1.GameState, winnerID string) error {
+	if state == nil || state.Status != catanv1.GameStatus_GAME_STATUS_FINISHED || state.EndedByAdmin {
+		return nil
+	}
+	gs, err := h.loadGameStats(state.Id)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var archived int
+	if err := tx.Get(&archived, "SELECT COUNT(*) FROM game_history WHERE game_id = ?", state.Id); err != nil {
+		return err
+	}
+	if archived > 0 {
+		return nil
+	}
+
+	diceJSON, err := json.Marshal(gs.DiceDistribution)
+	if err != nil {
+		return err
+	}
+	var winner any
+	if winnerID != "" {
+		winner = winnerID
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO game_history (game_id, code, winner_id, player_count, turns, dice_distribution) VALUES (?, ?, ?, ?, ?, ?)`,
+		state.Id, state.Code, winner, len(state.Players), state.TurnCounter, string(diceJSON)); err != nil {
+		return err
+	}
+
+	names := map[string]string{}
+	for _, p := range state.Players {
+		names[p.Id] = strings.TrimSpace(p.Name)
+	}
+	placements := stats.Placements(game.BuildGameOverPayload(state, winnerID))
+	ratings := make([]float64, len(placements))
+	places := make([]int, len(placements))
+	for i, pl := range placements {
+		ratings[i] = stats.InitialRating
+		if err := tx.Get(&ratings[i], "SELECT rating FROM player_ratings WHERE player_name = ?", names[pl.PlayerID]); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		places[i] = pl.Place
+	}
+	deltas := stats.RatingChanges(ratings, places)
+
+	for i, pl := range placements {
+		playerJSON, err := json.Marshal(gs.Player(pl.PlayerID))
+		if err != nil {
+			return err
+		}
+		isWinner := 0
+		if pl.PlayerID == winnerID {
+			isWinner = 1
+		}
+		after := ratings[i] + deltas[i]
+		if _, err := tx.Exec(
+			`INSERT INTO player_game_stats (game_id, player_id, player_name, place, points, is_winner, stats, rating_before, rating_after)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			state.Id, pl.PlayerID, names[pl.PlayerID], pl.Place, pl.Points, isWinner, string(playerJSON), ratings[i], after); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO player_ratings (player_name, rating, games_played, wins) VALUES (?, ?, 1, ?)
+			 ON CONFLICT(player_name) DO UPDATE SET
+			   rating = excluded.rating,
+			   games_played = games_played + 1,
+			   wins = wins + excluded.wins,
+			   updated_at = CURRENT_TIMESTAMP`,
+			names[pl.PlayerID], after, isWinner); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
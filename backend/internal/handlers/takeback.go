@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+)
+
+// keepsTakeback lists the commands that leave a pending takeback standing.
+// Any other command moves the game on, so the takeback is dropped.
+var keepsTakeback = map[string]bool{
+	"requestTakeback": true,
+	"respondTakeback": true,
+	"muteChat":        true,
+	"setChatSettings": true,
+}
+
+// requestTakeback opens a takeback of the game's most recent logged move.
+// Commands that leave a pending takeback standing are not moves, so a vote
+// or a chat setting logged since does not hide the action to undo.
+func (h *Handler) requestTakeback(state *catanv1.GameState, playerID string) error {
+	last, err := h.store.LastEvent(state.Id, slices.Collect(maps.Keys(keepsTakeback))...)
+	if errors.Is(err, store.ErrNotFound) {
+		return game.ErrTakebackNotAllowed
+	}
+	if err != nil {
+		return errors.New("failed to load the game log")
+	}
+	return game.RequestTakeback(state, playerID, game.TakebackAction{
+		Seq:      last.Seq,
+		Kind:     last.Kind,
+		PlayerID: last.PlayerID,
+	})
+}
+
+// restoreTakeback carries out an approved takeback by restoring the state
+// logged just before the undone event.
+func (h *Handler) restoreTakeback(state *catanv1.GameState) error {
+	event, err := h.store.GetEvent(state.Id, state.PendingTakeback.EventSeq-1)
+	if err != nil {
+		return errors.New("failed to load the state before that action")
+	}
+	if _, err := game.UpgradeState(event.State); err != nil {
+		return err
+	}
+	return game.RestoreTakeback(state, event.State)
+}
+
+// respondTakeback records playerID's vote on the pending takeback and
+// carries it out once approved.
+func (h *Handler) respondTakeback(state *catanv1.GameState, playerID string, approve bool) error {
+	approved, err := game.VoteTakeback(state, playerID, approve)
+	if err != nil || !approved {
+		return err
+	}
+	return h.restoreTakeback(state)
+}
@@ -2,38 +2,62 @@ package handlers
 
 import (
 	"encoding/json"
+	"log/slog"
+
 	"google.golang.org/protobuf/proto"
 	pb "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/broker"
+	"settlers_from_catan/internal/hub"
 )
 
-// Helper to personalize state for each player during test broadcasts
+// broadcastGameStatePersonalized sends state to its game's clients on every
+// instance, each with their own view of it.
 func (h *Handler) broadcastGameStatePersonalized(gameID string, state *pb.GameState) {
+	if state == nil {
+		return
+	}
+	data, err := proto.Marshal(state)
+	if err != nil {
+		slog.Error("failed to encode game state broadcast", "game_id", gameID, "err", err)
+		return
+	}
+	if err := h.hub.Broker().Publish(&broker.Message{Kind: kindGameState, GameID: gameID, Data: data}); err != nil {
+		slog.Warn("failed to publish game state", "game_id", gameID, "err", err)
+	}
+}
+
+// sendGameState personalizes state for each client of gameID on this
+// instance.
+func (h *Handler) sendGameState(gameID string, state *pb.GameState) {
 	clients := h.hub.GetClientsForGame(gameID)
-	if len(clients) == 0 || state == nil {
+	if len(clients) == 0 {
 		return
 	}
 	for _, client := range clients {
-		if client == nil {
-			continue
-		}
-		personalized := redactedGameStateForPlayer(state, client.PlayerID)
-		stateJSON, err := wsMarshal.Marshal(personalized)
-		if err != nil {
-			continue
-		}
-		envelope := serverEnvelope{
-			Message: serverMessage{
-				OneofKind: "gameState",
-				GameState: &gameStateWire{State: stateJSON},
-			},
-		}
-		msg, err := json.Marshal(envelope)
-		if err == nil {
-			client.Send(msg)
+		if client != nil {
+			h.sendGameStateTo(client, state)
 		}
 	}
 }
 
+// sendGameStateTo sends client its own view of state.
+func (h *Handler) sendGameStateTo(client *hub.Client, state *pb.GameState) {
+	personalized := redactedGameStateForPlayer(state, client.PlayerID)
+	stateJSON, err := wsMarshal.Marshal(personalized)
+	if err != nil {
+		return
+	}
+	envelope := serverEnvelope{
+		Message: serverMessage{
+			OneofKind: "gameState",
+			GameState: &gameStateWire{State: stateJSON},
+		},
+	}
+	if msg, err := json.Marshal(envelope); err == nil {
+		client.Send(msg)
+	}
+}
+
 // Helper for deep copy and dev card redaction in tests
 func redactedGameStateForPlayer(src *pb.GameState, playerID string) *pb.GameState {
 	if src == nil {
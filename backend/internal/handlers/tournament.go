@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+	"settlers_from_catan/internal/store"
+	"settlers_from_catan/internal/tournament"
+)
+
+var tournamentAdvancements = map[string]catanv1.TournamentAdvancement{
+	"":          catanv1.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT,
+	"placement": catanv1.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT,
+	"points":    catanv1.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_POINTS,
+}
+
+// HandleTournaments serves /api/tournaments: GET lists every tournament and
+// POST creates one from {"name", "advancement": "placement"|"points",
+// "advancePerTable"}. The creator gets the organizer token that starts it.
+func (h *Handler) HandleTournaments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		stored, err := h.store.ListTournaments()
+		if err != nil {
+			http.Error(w, "failed to load tournaments", http.StatusInternalServerError)
+			return
+		}
+		resp := &catanv1.ListTournamentsResponse{}
+		for _, t := range stored {
+			resp.Tournaments = append(resp.Tournaments, publicTournament(t.State))
+		}
+		writeProto(w, resp)
+	case http.MethodPost:
+		h.createTournament(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) createTournament(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name            string `json:"name"`
+		Advancement     string `json:"advancement"`
+		AdvancePerTable int32  `json:"advancePerTable"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	advancement, ok := tournamentAdvancements[strings.ToLower(req.Advancement)]
+	if !ok {
+		http.Error(w, "advancement must be placement or points", http.StatusBadRequest)
+		return
+	}
+	organizerToken := uuid.New().String()
+	t, err := tournament.New(uuid.New().String(), strings.TrimSpace(req.Name), organizerToken, advancement, req.AdvancePerTable, rand.Int63())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.store.CreateTournament(&store.Tournament{ID: t.Id, State: t}); err != nil {
+		http.Error(w, "failed to persist tournament", http.StatusInternalServerError)
+		return
+	}
+	writeProto(w, &catanv1.CreateTournamentResponse{Tournament: publicTournament(t), OrganizerToken: organizerToken})
+}
+
+// HandleTournamentRoutes serves /api/tournaments/{id} and its actions:
+//
+//	GET  /api/tournaments/{id}           tables, results and standings
+//	POST /api/tournaments/{id}/register  enter as {"name"}; returns the entrant token
+//	POST /api/tournaments/{id}/start     organizer token; seats the first round
+//	GET  /api/tournaments/{id}/seat      entrant token; joins the current table
+//
+// Tokens go in the X-Session-Token header or the token query parameter.
+func (h *Handler) HandleTournamentRoutes(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[2] == "" {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+	id, action := parts[2], ""
+	if len(parts) == 4 {
+		action = parts[3]
+	}
+
+	method := http.MethodGet
+	if action == "register" || action == "start" {
+		method = http.MethodPost
+	}
+	if r.Method != method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if method == http.MethodGet {
+		if err := h.catchUpTournament(id); err != nil && !errors.Is(err, store.ErrNotFound) {
+			slog.Warn("failed to catch up on tournament results", "tournament_id", id, "err", err)
+		}
+	}
+	switch action {
+	case "":
+		stored, err := h.store.GetTournament(id)
+		if err != nil {
+			http.Error(w, "tournament not found", http.StatusNotFound)
+			return
+		}
+		writeProto(w, publicTournament(stored.State))
+	case "register":
+		h.registerEntrant(w, r, id)
+	case "start":
+		h.startTournament(w, r, id)
+	case "seat":
+		h.tournamentSeat(w, r, id)
+	default:
+		http.Error(w, "invalid path", http.StatusNotFound)
+	}
+}
+
+func (h *Handler) registerEntrant(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	userID, ok := h.optionalUser(w, r)
+	if !ok {
+		return
+	}
+
+	entrant := &catanv1.TournamentEntrant{
+		Id:     uuid.New().String(),
+		Name:   strings.TrimSpace(req.Name),
+		Token:  uuid.New().String(),
+		UserId: derefString(userID),
+	}
+	var refused error
+	err := h.updateTournament(id, func(t *catanv1.Tournament) error {
+		refused = tournament.Register(t, entrant)
+		return refused
+	})
+	switch {
+	case refused != nil:
+		http.Error(w, refused.Error(), http.StatusConflict)
+	case errors.Is(err, store.ErrNotFound):
+		http.Error(w, "tournament not found", http.StatusNotFound)
+	case err != nil:
+		http.Error(w, "failed to persist tournament", http.StatusInternalServerError)
+	default:
+		writeProto(w, &catanv1.RegisterTournamentResponse{EntrantId: entrant.Id, Token: entrant.Token})
+	}
+}
+
+func (h *Handler) startTournament(w http.ResponseWriter, r *http.Request, id string) {
+	stored, err := h.store.GetTournament(id)
+	if err != nil {
+		http.Error(w, "tournament not found", http.StatusNotFound)
+		return
+	}
+	if token := sessionTokenFromRequest(r); token == "" || token != stored.State.OrganizerToken {
+		http.Error(w, "only the organizer can start the tournament", http.StatusForbidden)
+		return
+	}
+	var started *catanv1.Tournament
+	var refused, tablesErr error
+	err = h.updateTournament(id, func(t *catanv1.Tournament) error {
+		round, err := tournament.Start(t)
+		if err != nil {
+			refused = err
+			return err
+		}
+		if tablesErr = h.createTournamentTables(t, round); tablesErr != nil {
+			return tablesErr
+		}
+		started = t
+		return nil
+	})
+	switch {
+	case refused != nil:
+		http.Error(w, refused.Error(), http.StatusConflict)
+	case errors.Is(err, store.ErrNotFound):
+		http.Error(w, "tournament not found", http.StatusNotFound)
+	case tablesErr != nil:
+		http.Error(w, "failed to create tables", http.StatusInternalServerError)
+	case err != nil:
+		http.Error(w, "failed to persist tournament", http.StatusInternalServerError)
+	default:
+		writeProto(w, publicTournament(started))
+	}
+}
+
+// tournamentSeat answers with the caller's seat at their table in the
+// current round, identified by their entrant token.
+func (h *Handler) tournamentSeat(w http.ResponseWriter, r *http.Request, id string) {
+	stored, err := h.store.GetTournament(id)
+	if err != nil {
+		http.Error(w, "tournament not found", http.StatusNotFound)
+		return
+	}
+	t := stored.State
+	token := sessionTokenFromRequest(r)
+	var entrantID string
+	for _, e := range t.Entrants {
+		if token != "" && e.Token == token {
+			entrantID = e.Id
+		}
+	}
+	if entrantID == "" {
+		http.Error(w, "only entrants have a seat", http.StatusForbidden)
+		return
+	}
+	if round := tournament.CurrentRound(t); round != nil {
+		for _, table := range round.Tables {
+			for _, seat := range table.Seats {
+				if seat.EntrantId != entrantID {
+					continue
+				}
+				g, err := h.loadGame(table.GameId)
+				if err != nil {
+					http.Error(w, "table not found", http.StatusNotFound)
+					return
+				}
+				player, err := h.store.GetPlayer(seat.PlayerId)
+				if err != nil {
+					http.Error(w, "seat not found", http.StatusNotFound)
+					return
+				}
+				writeJoinResponse(w, g.ID, player.ID, player.SessionToken, g.State)
+				return
+			}
+		}
+	}
+	http.Error(w, "no table for you in the current round", http.StatusNotFound)
+}
+
+// createTournamentTables creates a game for every table in round, seating
+// its entrants in order with the first as host. Each seat gets its own
+// session token, which entrants collect from the seat endpoint.
+func (h *Handler) createTournamentTables(t *catanv1.Tournament, round *catanv1.TournamentRound) error {
+	entrants := make(map[string]*catanv1.TournamentEntrant, len(t.Entrants))
+	for _, e := range t.Entrants {
+		entrants[e.Id] = e
+	}
+	for _, table := range round.Tables {
+		gameID := uuid.New().String()
+		code := strings.ToUpper(randomCode(6))
+		names := make([]string, len(table.Seats))
+		ids := make([]string, len(table.Seats))
+		for i, seat := range table.Seats {
+			seat.PlayerId = uuid.New().String()
+			names[i] = entrants[seat.EntrantId].GetName()
+			ids[i] = seat.PlayerId
+		}
+		state := game.NewGameState(gameID, code, names, ids)
+		state.TournamentId = t.Id
+
+		players := make([]*store.Player, len(table.Seats))
+		for i, seat := range table.Seats {
+			players[i] = &store.Player{
+				ID:           seat.PlayerId,
+				Name:         names[i],
+				Color:        state.Players[i].Color,
+				SessionToken: uuid.New().String(),
+				IsHost:       i == 0,
+				UserID:       entrants[seat.EntrantId].GetUserId(),
+			}
+		}
+		if err := h.store.CreateGame(&store.Game{ID: gameID, Code: code, State: state}, players...); err != nil {
+			return err
+		}
+		_ = h.appendGameEvent(gameID, "tournamentTable", "", nil, state)
+		table.GameId = gameID
+		table.Code = code
+	}
+	return nil
+}
+
+// recordTournamentResult reports a finished tournament table and seats the
+// next round once the whole round is done. A result the tournament refuses,
+// such as one already recorded, is logged; an error saving it is returned,
+// and catchUpTournament records it later from the finished game.
+func (h *Handler) recordTournamentResult(state *catanv1.GameState, winnerID string) error {
+	if state.GetTournamentId() == "" {
+		return nil
+	}
+	logger := slog.With("tournament_id", state.TournamentId, "game_id", state.Id)
+	scores := game.BuildGameOverPayload(state, winnerID).Scores
+	var next *catanv1.TournamentRound
+	var refused error
+	err := h.updateTournament(state.TournamentId, func(t *catanv1.Tournament) error {
+		var err error
+		next, err = tournament.RecordResult(t, state.Id, scores, winnerID)
+		if err != nil {
+			refused = err
+			return err
+		}
+		if next != nil {
+			return h.createTournamentTables(t, next)
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(refused, tournament.ErrTableFinished):
+	case refused != nil:
+		logger.Warn("tournament result not recorded", "err", refused)
+	case err != nil:
+		return err
+	case next != nil:
+		logger.Info("tournament round seated", "round", next.Number, "tables", len(next.Tables))
+	}
+	return nil
+}
+
+// catchUpTournament records the result of every finished table in id's
+// current round that the tournament is still missing, as when saving it
+// failed once the game ended.
+func (h *Handler) catchUpTournament(id string) error {
+	stored, err := h.store.GetTournament(id)
+	if err != nil {
+		return err
+	}
+	round := tournament.CurrentRound(stored.State)
+	if stored.State.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING || round == nil {
+		return nil
+	}
+	var errs []error
+	for _, table := range round.Tables {
+		if table.Finished {
+			continue
+		}
+		g, err := h.loadGame(table.GameId)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if g.State.Status != catanv1.GameStatus_GAME_STATUS_FINISHED {
+			continue
+		}
+		winnerID, _ := game.DetermineWinner(g.State)
+		errs = append(errs, h.recordTournamentResult(g.State, winnerID))
+	}
+	return errors.Join(errs...)
+}
+
+// tournamentSaveAttempts bounds how often updateTournament starts over
+// after another request or instance saved the tournament first.
+const tournamentSaveAttempts = 5
+
+// updateTournament applies change to the stored tournament id and saves it,
+// starting over from the newly stored state when the save finds the
+// tournament changed in between. An error from change is returned as is.
+// Tables change created for a state that was not saved are deleted again.
+func (h *Handler) updateTournament(id string, change func(t *catanv1.Tournament) error) error {
+	for attempt := 1; ; attempt++ {
+		stored, err := h.store.GetTournament(id)
+		if err != nil {
+			return err
+		}
+		seated := tournamentGameIDs(stored.State)
+		err = change(stored.State)
+		if err == nil {
+			err = h.store.SaveTournament(id, stored.State, stored.Version)
+		}
+		if err == nil {
+			return nil
+		}
+		for gameID := range tournamentGameIDs(stored.State) {
+			if !seated[gameID] {
+				h.dropTournamentTable(gameID)
+			}
+		}
+		if !errors.Is(err, store.ErrStale) || attempt == tournamentSaveAttempts {
+			return err
+		}
+	}
+}
+
+// tournamentGameIDs returns the game of every table t has seated.
+func tournamentGameIDs(t *catanv1.Tournament) map[string]bool {
+	ids := map[string]bool{}
+	for _, round := range t.Rounds {
+		for _, table := range round.Tables {
+			if table.GameId != "" {
+				ids[table.GameId] = true
+			}
+		}
+	}
+	return ids
+}
+
+// dropTournamentTable deletes a table game nobody was sent to.
+func (h *Handler) dropTournamentTable(gameID string) {
+	if err := h.store.DeleteGame(gameID); err != nil && !errors.Is(err, store.ErrNotFound) {
+		slog.Error("failed to delete unused tournament table", "game_id", gameID, "err", err)
+	}
+}
+
+// publicTournament returns t without the organizer token and the entrants'
+// tokens and accounts.
+func publicTournament(t *catanv1.Tournament) *catanv1.Tournament {
+	out := proto.Clone(t).(*catanv1.Tournament)
+	out.OrganizerToken = ""
+	for _, e := range out.Entrants {
+		e.Token = ""
+		e.UserId = ""
+	}
+	return out
+}
+
+func writeProto(w http.ResponseWriter, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
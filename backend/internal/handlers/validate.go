@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"log/slog"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+)
+
+// StateValidation is what applyGameUpdate does when a command leaves the
+// game breaking one of game.Validate's invariants.
+type StateValidation string
+
+const (
+	// ValidationOff skips the check.
+	ValidationOff StateValidation = "off"
+	// ValidationLog logs and counts violations but keeps the new state.
+	ValidationLog StateValidation = "log"
+	// ValidationReject also refuses the command, leaving the stored state
+	// as it was.
+	ValidationReject StateValidation = "reject"
+)
+
+// SetStateValidation chooses how commands that break invariants are
+// handled. The default is ValidationLog.
+func (h *Handler) SetStateValidation(mode StateValidation) {
+	h.validation = mode
+}
+
+// checkState validates state after a command, logging and counting any
+// violations, and reports whether the command must be refused.
+func (h *Handler) checkState(logger *slog.Logger, state *catanv1.GameState) (refuse bool) {
+	if h.validation == ValidationOff {
+		return false
+	}
+	violations := game.Validate(state)
+	if len(violations) == 0 {
+		return false
+	}
+	details := make([]string, len(violations))
+	for i, v := range violations {
+		h.metrics.violations.Inc(v.Rule)
+		details[i] = v.String()
+	}
+	logger.Error("command broke game state invariants", "violations", details, "mode", string(h.validation))
+	return h.validation == ValidationReject
+}
@@ -1,7 +1,7 @@
 package hub
 
 import (
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -9,10 +9,12 @@ import (
 )
 
 const (
-	writeWait      = 10 * time.Second
-	pongWait       = 60 * time.Second
-	pingPeriod     = (pongWait * 9) / 10
-	maxMessageSize = 512 * 1024
+	writeWait  = 10 * time.Second
+	pongWait   = 60 * time.Second
+	pingPeriod = (pongWait * 9) / 10
+	// maxMessageSize is far above any client command; larger frames close
+	// the connection with CloseMessageTooBig.
+	maxMessageSize = 4 * 1024
 )
 
 // Client represents a WebSocket client
@@ -24,7 +26,13 @@ type Client struct {
 	GameID    string
 	OnMessage func([]byte)
 	closed    bool
-	mu        sync.RWMutex
+	// Spectator is set for connections without a seat; PlayerID then
+	// names no player in the game.
+	Spectator bool
+	// closeFrame is sent when the hub closes the connection; empty sends a
+	// bare close frame.
+	closeFrame []byte
+	mu         sync.RWMutex
 }
 
 // NewClient creates a new client
@@ -38,6 +46,18 @@ func NewClient(hub *Hub, conn *websocket.Conn, playerID, gameID string) *Client
 	}
 }
 
+// NewSubscriber creates a client without a WebSocket, for relaying a game's
+// messages some other way. They arrive on Messages.
+func NewSubscriber(hub *Hub, playerID, gameID string) *Client {
+	return NewClient(hub, nil, playerID, gameID)
+}
+
+// Messages returns the client's queue of outgoing messages, which is closed
+// once the hub drops the client.
+func (c *Client) Messages() <-chan []byte {
+	return c.send
+}
+
 // ReadPump pumps messages from the WebSocket to the hub
 func (c *Client) ReadPump() {
 	defer func() {
@@ -53,13 +73,22 @@ func (c *Client) ReadPump() {
 	})
 
 	for {
-		_, message, err := c.conn.ReadMessage()
+		messageType, message, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("error: %v", err)
+				slog.Warn("websocket read failed", "game_id", c.GameID, "player_id", c.PlayerID, "err", err)
 			}
 			break
 		}
+		// Once disconnected, keep reading only until WritePump's close frame
+		// is answered
+		if c.IsClosed() {
+			continue
+		}
+		if messageType != websocket.TextMessage {
+			c.hub.Disconnect(c, websocket.CloseUnsupportedData, "text messages only")
+			continue
+		}
 		if c.OnMessage != nil {
 			c.OnMessage(message)
 		}
@@ -79,7 +108,10 @@ func (c *Client) WritePump() {
 		case message, ok := <-c.send:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				c.mu.RLock()
+				frame := c.closeFrame
+				c.mu.RUnlock()
+				c.conn.WriteMessage(websocket.CloseMessage, frame)
 				return
 			}
 
@@ -108,14 +140,17 @@ func (c *Client) Send(message []byte) {
 	defer c.mu.RUnlock()
 
 	if c.closed {
-		log.Printf("Attempted to send to closed client %s", c.PlayerID)
+		slog.Debug("send to closed client", "game_id", c.GameID, "player_id", c.PlayerID)
 		return
 	}
 
 	select {
 	case c.send <- message:
 	default:
-		log.Printf("Client send buffer full for player %s", c.PlayerID)
+		if c.hub != nil {
+			c.hub.drops.Add(1)
+		}
+		slog.Warn("client send queue full", "game_id", c.GameID, "player_id", c.PlayerID)
 	}
 }
 
@@ -126,6 +161,14 @@ func (c *Client) Close() {
 	c.closed = true
 }
 
+// closeWith marks the client closed and sets the close frame WritePump sends
+func (c *Client) closeWith(code int, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.closeFrame = websocket.FormatCloseMessage(code, reason)
+}
+
 // IsClosed returns whether the client is closed
 func (c *Client) IsClosed() bool {
 	c.mu.RLock()
@@ -2,8 +2,21 @@ package hub
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+
+	"settlers_from_catan/internal/broker"
+)
+
+// Kinds of broker messages hubs exchange.
+const (
+	kindGame       = "hub.game"
+	kindPlayer     = "hub.player"
+	kindClose      = "hub.close"
+	kindDisconnect = "hub.disconnect"
 )
 
 // Message represents a WebSocket message
@@ -12,92 +25,139 @@ type Message struct {
 	Payload json.RawMessage `json:"payload,omitempty"`
 }
 
-// Hub maintains active clients and broadcasts messages
+// Hub maintains active clients and broadcasts messages. Broadcasts go
+// through its broker, which delivers them to the hubs of every instance.
 type Hub struct {
+	broker     broker.Broker
 	clients    map[*Client]bool
 	games      map[string]map[*Client]bool // gameID -> clients
-	register   chan *Client
 	unregister chan *Client
-	broadcast  chan *BroadcastMessage
 	mu         sync.RWMutex
+	// drops counts messages discarded because a client's send queue was full.
+	drops atomic.Uint64
 }
 
-// BroadcastMessage is a message to broadcast to a game
-type BroadcastMessage struct {
-	GameID  string
-	Message []byte
+// NewHub creates a Hub for a single server instance.
+func NewHub() *Hub {
+	return NewHubWithBroker(broker.NewLocal())
 }
 
-// NewHub creates a new Hub
-func NewHub() *Hub {
-	return &Hub{
+// NewHubWithBroker creates a Hub that broadcasts through b.
+func NewHubWithBroker(b broker.Broker) *Hub {
+	h := &Hub{
+		broker:     b,
 		clients:    make(map[*Client]bool),
 		games:      make(map[string]map[*Client]bool),
-		register:   make(chan *Client),
 		unregister: make(chan *Client),
-		broadcast:  make(chan *BroadcastMessage),
+	}
+	b.Subscribe(h.receive)
+	return h
+}
+
+// Broker returns the broker the hub broadcasts through.
+func (h *Hub) Broker() broker.Broker {
+	return h.broker
+}
+
+// receive handles a broadcast from any instance for the clients here.
+func (h *Hub) receive(msg *broker.Message) {
+	switch msg.Kind {
+	case kindGame:
+		h.sendToGame(msg.GameID, msg.Data)
+	case kindPlayer:
+		h.SendToPlayer(msg.GameID, msg.PlayerID, msg.Data)
+	case kindClose:
+		h.closeGame(msg.GameID)
+	case kindDisconnect:
+		for _, client := range h.GetClientsForGame(msg.GameID) {
+			if client.PlayerID == msg.PlayerID {
+				h.Disconnect(client, websocket.CloseNormalClosure, string(msg.Data))
+			}
+		}
+	}
+}
+
+func (h *Hub) publish(msg *broker.Message) {
+	if err := h.broker.Publish(msg); err != nil {
+		slog.Warn("failed to publish broadcast", "kind", msg.Kind, "game_id", msg.GameID, "err", err)
 	}
 }
 
 // Run starts the hub's main loop
 func (h *Hub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client] = true
+	for client := range h.unregister {
+		h.mu.Lock()
+		if _, ok := h.clients[client]; ok {
+			delete(h.clients, client)
 			if client.GameID != "" {
-				if h.games[client.GameID] == nil {
-					h.games[client.GameID] = make(map[*Client]bool)
-				}
-				h.games[client.GameID][client] = true
-			}
-			h.mu.Unlock()
-			log.Printf("Client registered: %s for game %s", client.PlayerID, client.GameID)
-
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				if client.GameID != "" {
-					delete(h.games[client.GameID], client)
-				}
-				client.Close()
-				close(client.send)
+				delete(h.games[client.GameID], client)
 			}
-			h.mu.Unlock()
-			log.Printf("Client unregistered: %s", client.PlayerID)
-
-		case message := <-h.broadcast:
-			h.mu.RLock()
-			if clients, ok := h.games[message.GameID]; ok {
-				for client := range clients {
-					select {
-					case client.send <- message.Message:
-					default:
-						client.Close()
-						close(client.send)
-						delete(h.clients, client)
-						delete(h.games[message.GameID], client)
-					}
-				}
-			}
-			h.mu.RUnlock()
+			client.Close()
+			close(client.send)
 		}
+		h.mu.Unlock()
+		slog.Debug("client unregistered", "game_id", client.GameID, "player_id", client.PlayerID)
 	}
 }
 
-// BroadcastToGame sends a message to all clients in a game
+// sendToGame queues message on every client of gameID on this instance,
+// dropping clients too far behind to take it.
+func (h *Hub) sendToGame(gameID string, message []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.games[gameID] {
+		select {
+		case client.send <- message:
+		default:
+			h.drops.Add(1)
+			client.Close()
+			close(client.send)
+			delete(h.clients, client)
+			delete(h.games[gameID], client)
+		}
+	}
+}
+
+// BroadcastToGame sends a message to all clients in a game, on every
+// instance.
 func (h *Hub) BroadcastToGame(gameID string, message []byte) {
-	h.broadcast <- &BroadcastMessage{
-		GameID:  gameID,
-		Message: message,
+	h.publish(&broker.Message{Kind: kindGame, GameID: gameID, Data: message})
+}
+
+// BroadcastToPlayer sends a message to every connection playerID has open
+// in gameID, on every instance.
+func (h *Hub) BroadcastToPlayer(gameID, playerID string, message []byte) {
+	h.publish(&broker.Message{Kind: kindPlayer, GameID: gameID, PlayerID: playerID, Data: message})
+}
+
+// SendToPlayer queues message on every connection playerID has open in
+// gameID on this instance, and reports how many there were.
+func (h *Hub) SendToPlayer(gameID, playerID string, message []byte) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	sent := 0
+	for client := range h.games[gameID] {
+		if client.PlayerID == playerID {
+			client.Send(message)
+			sent++
+		}
 	}
+	return sent
 }
 
-// Register adds a client to the hub
+// Register adds a client to the hub. The client receives every broadcast
+// sent after Register returns.
 func (h *Hub) Register(client *Client) {
-	h.register <- client
+	h.mu.Lock()
+	h.clients[client] = true
+	if client.GameID != "" {
+		if h.games[client.GameID] == nil {
+			h.games[client.GameID] = make(map[*Client]bool)
+		}
+		h.games[client.GameID][client] = true
+	}
+	h.mu.Unlock()
+	slog.Debug("client registered", "game_id", client.GameID, "player_id", client.PlayerID)
 }
 
 // Unregister removes a client from the hub
@@ -105,6 +165,78 @@ func (h *Hub) Unregister(client *Client) {
 	h.unregister <- client
 }
 
+// CloseGame disconnects every client attached to gameID, on every
+// instance. Messages already queued are flushed before the connection
+// closes.
+func (h *Hub) CloseGame(gameID string) {
+	h.publish(&broker.Message{Kind: kindClose, GameID: gameID})
+}
+
+// DisconnectPlayer closes every connection playerID has open in gameID, on
+// every instance, with a normal close frame carrying reason.
+func (h *Hub) DisconnectPlayer(gameID, playerID, reason string) {
+	h.publish(&broker.Message{Kind: kindDisconnect, GameID: gameID, PlayerID: playerID, Data: []byte(reason)})
+}
+
+func (h *Hub) closeGame(gameID string) {
+	h.mu.Lock()
+	clients := h.games[gameID]
+	delete(h.games, gameID)
+	for client := range clients {
+		delete(h.clients, client)
+		client.Close()
+		close(client.send)
+	}
+	h.mu.Unlock()
+	slog.Info("closed game clients", "game_id", gameID, "clients", len(clients))
+}
+
+// Disconnect closes one client's connection with a close frame carrying code
+// and reason, after flushing messages already queued with Send. It reports
+// false if the client was not connected.
+func (h *Hub) Disconnect(client *Client, code int, reason string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[client]; !ok {
+		return false
+	}
+	delete(h.clients, client)
+	if client.GameID != "" {
+		delete(h.games[client.GameID], client)
+	}
+	client.closeWith(code, reason)
+	close(client.send)
+	slog.Warn("client disconnected", "game_id", client.GameID, "player_id", client.PlayerID, "reason", reason)
+	return true
+}
+
+// Shutdown disconnects every client with a going-away close frame carrying
+// reason. Messages already queued with Send are flushed first.
+func (h *Hub) Shutdown(reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		client.closeWith(websocket.CloseGoingAway, reason)
+		close(client.send)
+	}
+	slog.Info("closed all clients", "clients", len(h.clients), "reason", reason)
+	h.clients = make(map[*Client]bool)
+	h.games = make(map[string]map[*Client]bool)
+}
+
+// ClientCount returns how many clients are connected.
+func (h *Hub) ClientCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// Drops returns how many messages were discarded because a client's send
+// queue was full.
+func (h *Hub) Drops() uint64 {
+	return h.drops.Load()
+}
+
 // GetClientsForGame returns the clients registered for a given gameID
 func (h *Hub) GetClientsForGame(gameID string) []*Client {
 	h.mu.RLock()
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+	messages := r.NewCounterVec("test_messages_total", "Messages received.", "type")
+	latency := r.NewHistogramVec("test_latency_seconds", "Latency.", []float64{0.1, 1}, "type")
+	r.NewGaugeFunc("test_clients", "Connected clients.", func() float64 { return 3 })
+	r.NewGaugeVecFunc("test_games", "Games by status.", "status", func() map[string]float64 {
+		return map[string]float64{"playing": 2, "waiting": 1}
+	})
+	r.NewCounterFunc("test_drops_total", "Dropped sends.", func() float64 { return 7 })
+
+	messages.Inc("rollDice")
+	messages.Inc("rollDice")
+	messages.Add(3, `say "hi"`)
+	latency.Observe(0.05, "rollDice")
+	latency.Observe(0.5, "rollDice")
+	latency.Observe(5, "rollDice")
+
+	var b strings.Builder
+	r.WriteText(&b)
+	want := `# HELP test_clients Connected clients.
+# TYPE test_clients gauge
+test_clients 3
+# HELP test_drops_total Dropped sends.
+# TYPE test_drops_total counter
+test_drops_total 7
+# HELP test_games Games by status.
+# TYPE test_games gauge
+test_games{status="playing"} 2
+test_games{status="waiting"} 1
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{type="rollDice",le="0.1"} 1
+test_latency_seconds_bucket{type="rollDice",le="1"} 2
+test_latency_seconds_bucket{type="rollDice",le="+Inf"} 3
+test_latency_seconds_sum{type="rollDice"} 5.55
+test_latency_seconds_count{type="rollDice"} 3
+# HELP test_messages_total Messages received.
+# TYPE test_messages_total counter
+test_messages_total{type="rollDice"} 2
+test_messages_total{type="say \"hi\""} 3
+`
+	if b.String() != want {
+		t.Fatalf("unexpected exposition:\n%s\nwant:\n%s", b.String(), want)
+	}
+	if got := messages.Value("rollDice"); got != 2 {
+		t.Errorf("expected counter value 2, got %v", got)
+	}
+	if got := latency.Count("rollDice"); got != 3 {
+		t.Errorf("expected 3 observations, got %d", got)
+	}
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	r.NewGaugeFunc("test_up", "Always one.", func() float64 { return 1 })
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "test_up 1\n") {
+		t.Fatalf("unexpected response %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	recorder = httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for POST, got %d", recorder.Code)
+	}
+}
+
+func TestRegistry_DuplicateNamePanics(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounterVec("test_total", "First.")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected duplicate registration to panic")
+		}
+	}()
+	r.NewCounterVec("test_total", "Second.")
+}
@@ -0,0 +1,109 @@
+// Package notify tells players outside the game that it is waiting on them.
+// Notifiers are pluggable; the server ships a webhook notifier that POSTs
+// each notification as JSON.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// KindYourTurn is sent when a player newly owes an action: their turn,
+	// a discard, a robber move or a trade response.
+	KindYourTurn = "your_turn"
+	// KindTurnOverdue is sent once when a correspondence turn passes its
+	// deadline and cannot be forfeited, to the players it is waiting on.
+	KindTurnOverdue = "turn_overdue"
+	// KindTurnForfeited is sent to a player whose overdue turn was passed on.
+	KindTurnForfeited = "turn_forfeited"
+)
+
+// Notification is one message to one player.
+type Notification struct {
+	Kind       string     `json:"kind"`
+	GameID     string     `json:"gameId"`
+	Code       string     `json:"code"`
+	PlayerID   string     `json:"playerId"`
+	PlayerName string     `json:"playerName"`
+	UserID     string     `json:"userId,omitempty"`
+	Actions    []string   `json:"actions,omitempty"`
+	Deadline   *time.Time `json:"deadline,omitempty"` // Correspondence games only
+}
+
+// Notifier delivers notifications.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Multi sends every notification to each of its notifiers.
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook body, keyed by
+// the webhook secret, when one is set.
+const SignatureHeader = "X-Catan-Signature"
+
+// Webhook POSTs notifications as JSON to URL.
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (w *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s answered %s", w.URL, resp.Status)
+	}
+	return nil
+}
+
+// Webhooks returns a notifier posting to every URL, signing with secret.
+func Webhooks(urls []string, secret string) Notifier {
+	client := &http.Client{Timeout: 10 * time.Second}
+	m := make(Multi, 0, len(urls))
+	for _, u := range urls {
+		m = append(m, &Webhook{URL: u, Secret: secret, Client: client})
+	}
+	return m
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhook_PostsSignedJSON(t *testing.T) {
+	var got Notification
+	var body []byte
+	var signature string
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer stub.Close()
+
+	n := Notification{Kind: KindYourTurn, GameID: "g1", Code: "ABCDEF", PlayerID: "p1", PlayerName: "Alice", Actions: []string{"roll"}}
+	if err := Webhooks([]string{stub.URL}, "s3cret").Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Kind != KindYourTurn || got.PlayerID != "p1" || len(got.Actions) != 1 || got.Deadline != nil {
+		t.Errorf("expected the notification as JSON, got %+v", got)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	if want := hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("expected signature %s, got %s", want, signature)
+	}
+}
+
+func TestWebhook_ReportsFailures(t *testing.T) {
+	calls := 0
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer ok.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	err := Webhooks([]string{broken.URL, ok.URL}, "").Notify(context.Background(), Notification{Kind: KindYourTurn})
+	if err == nil {
+		t.Fatal("expected the failing webhook to be reported")
+	}
+	if calls != 1 {
+		t.Errorf("expected the other webhook to still be called, got %d calls", calls)
+	}
+}
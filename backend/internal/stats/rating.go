@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+const (
+	// InitialRating is the rating assigned to a player name on their first game.
+	InitialRating = 1500.0
+	// ratingK is the maximum rating change a player can get from one game.
+	ratingK = 32.0
+)
+
+// Placement is a player's finishing position in a game (1 = first).
+type Placement struct {
+	PlayerID string
+	Points   int32
+	Place    int
+}
+
+// Placements ranks the final scores of a game. The winner always places
+// first; remaining players are ordered by points and share a place on ties.
+func Placements(payload *pb.GameOverPayload) []Placement {
+	if payload == nil {
+		return nil
+	}
+	out := make([]Placement, 0, len(payload.Scores))
+	for _, s := range payload.Scores {
+		out = append(out, Placement{PlayerID: s.PlayerId, Points: s.Points})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		iWins := out[i].PlayerID == payload.WinnerId
+		jWins := out[j].PlayerID == payload.WinnerId
+		if iWins != jWins {
+			return iWins
+		}
+		return out[i].Points > out[j].Points
+	})
+	for i := range out {
+		switch {
+		case i == 0:
+			out[i].Place = 1
+		case out[i].Points == out[i-1].Points && out[i-1].PlayerID != payload.WinnerId:
+			out[i].Place = out[i-1].Place
+		default:
+			out[i].Place = i + 1
+		}
+	}
+	return out
+}
+
+// RatingChanges computes Elo adjustments for a multiplayer game by treating
+// it as a round robin of pairwise matches between every pair of players.
+// ratings and places are indexed identically; the result is the delta per
+// player.
+func RatingChanges(ratings []float64, places []int) []float64 {
+	n := len(ratings)
+	deltas := make([]float64, n)
+	if n < 2 || len(places) != n {
+		return deltas
+	}
+	k := ratingK / float64(n-1)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[i])/400))
+			actual := 0.5
+			if places[i] < places[j] {
+				actual = 1
+			} else if places[i] > places[j] {
+				actual = 0
+			}
+			deltas[i] += k * (actual - expected)
+		}
+	}
+	return deltas
+}
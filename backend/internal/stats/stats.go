@@ -0,0 +1,243 @@
+package stats
+
+import (
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// GameStats accumulates per-game statistics while a game is being played.
+type GameStats struct {
+	// DiceDistribution counts every observed roll total (2-12) in the game.
+	DiceDistribution map[int]int `json:"diceDistribution"`
+	// Players maps player ID -> stats for that player.
+	Players map[string]*PlayerStats `json:"players"`
+}
+
+// PlayerStats holds the statistics recorded for one player in one game.
+type PlayerStats struct {
+	Rolls                 map[int]int    `json:"rolls"`                 // Roll totals made by this player
+	ResourcesGained       int            `json:"resourcesGained"`       // Cards received from production
+	ResourcesStolen       int            `json:"resourcesStolen"`       // Cards taken from others with the robber
+	ResourcesLostToRobber int            `json:"resourcesLostToRobber"` // Cards discarded on a 7 or stolen
+	TradesMade            int            `json:"tradesMade"`            // Accepted player-to-player trades
+	BankTrades            int            `json:"bankTrades"`            // Trades with the bank or a port
+	CardsPlayed           map[string]int `json:"cardsPlayed"`           // Dev card type name -> times played
+	Builds                []Build        `json:"builds"`                // Every structure built, in order
+}
+
+// Build records a structure placement and the turn it happened on.
+type Build struct {
+	Structure string `json:"structure"`
+	Turn      int32  `json:"turn"`
+}
+
+// NewGameStats returns empty statistics for a game.
+func NewGameStats() *GameStats {
+	return &GameStats{
+		DiceDistribution: map[int]int{},
+		Players:          map[string]*PlayerStats{},
+	}
+}
+
+// Player returns the stats for playerID, creating them if needed.
+func (s *GameStats) Player(playerID string) *PlayerStats {
+	if s.DiceDistribution == nil {
+		s.DiceDistribution = map[int]int{}
+	}
+	if s.Players == nil {
+		s.Players = map[string]*PlayerStats{}
+	}
+	ps, ok := s.Players[playerID]
+	if !ok {
+		ps = &PlayerStats{}
+		s.Players[playerID] = ps
+	}
+	if ps.Rolls == nil {
+		ps.Rolls = map[int]int{}
+	}
+	if ps.CardsPlayed == nil {
+		ps.CardsPlayed = map[string]int{}
+	}
+	return ps
+}
+
+// Record updates the statistics for a successfully applied client command.
+// kind is the ClientMessage oneof kind (e.g. "rollDice"), before and after
+// are the game state around the command.
+func (s *GameStats) Record(kind, playerID string, before, after *pb.GameState) {
+	if before == nil || after == nil {
+		return
+	}
+	switch kind {
+	case "rollDice":
+		if len(after.Dice) == 2 {
+			total := int(after.Dice[0] + after.Dice[1])
+			s.Player(playerID).Rolls[total]++
+			s.DiceDistribution[total]++
+		}
+		for id, delta := range resourceDeltas(before, after) {
+			if delta > 0 {
+				s.Player(id).ResourcesGained += delta
+			}
+		}
+	case "discardCards", "moveRobber", "forfeitTurn":
+		for id, delta := range resourceDeltas(before, after) {
+			if delta < 0 {
+				s.Player(id).ResourcesLostToRobber -= delta
+			} else if delta > 0 {
+				s.Player(id).ResourcesStolen += delta
+			}
+		}
+	case "respondTrade":
+		for _, t := range after.PendingTrades {
+			if t.Status != pb.TradeStatus_TRADE_STATUS_ACCEPTED || tradeStatus(before, t.Id) == pb.TradeStatus_TRADE_STATUS_ACCEPTED {
+				continue
+			}
+			s.Player(t.ProposerId).TradesMade++
+			s.Player(playerID).TradesMade++
+		}
+	case "bankTrade":
+		s.Player(playerID).BankTrades++
+	case "playDevCard":
+		for card, count := range playedCards(before, after, playerID) {
+			s.Player(playerID).CardsPlayed[card] += count
+		}
+	case "buildStructure":
+		for _, structure := range newStructures(before, after, playerID) {
+			ps := s.Player(playerID)
+			ps.Builds = append(ps.Builds, Build{Structure: structure, Turn: after.TurnCounter})
+		}
+	case "respondTakeback":
+		s.undoTakeback(before, after)
+	}
+}
+
+// undoTakeback reverses what was recorded for an action that an approved
+// takeback undid. A rejected takeback leaves the board and hands unchanged.
+func (s *GameStats) undoTakeback(before, after *pb.GameState) {
+	takeback := before.GetPendingTakeback()
+	if takeback == nil {
+		return
+	}
+	ps := s.Player(takeback.RequesterId)
+	switch takeback.Action {
+	case "buildStructure":
+		for _, structure := range newStructures(after, before, takeback.RequesterId) {
+			for i := len(ps.Builds) - 1; i >= 0; i-- {
+				if ps.Builds[i].Structure == structure {
+					ps.Builds = append(ps.Builds[:i], ps.Builds[i+1:]...)
+					break
+				}
+			}
+		}
+	case "bankTrade":
+		if resourceDeltas(before, after)[takeback.RequesterId] != 0 && ps.BankTrades > 0 {
+			ps.BankTrades--
+		}
+	}
+}
+
+func resourceDeltas(before, after *pb.GameState) map[string]int {
+	deltas := map[string]int{}
+	for _, p := range after.Players {
+		deltas[p.Id] = resourceTotal(p.Resources)
+	}
+	for _, p := range before.Players {
+		deltas[p.Id] -= resourceTotal(p.Resources)
+	}
+	return deltas
+}
+
+func resourceTotal(rc *pb.ResourceCount) int {
+	if rc == nil {
+		return 0
+	}
+	return int(rc.Wood + rc.Brick + rc.Sheep + rc.Wheat + rc.Ore)
+}
+
+func tradeStatus(state *pb.GameState, tradeID string) pb.TradeStatus {
+	for _, t := range state.PendingTrades {
+		if t.Id == tradeID {
+			return t.Status
+		}
+	}
+	return pb.TradeStatus_TRADE_STATUS_UNSPECIFIED
+}
+
+func playedCards(before, after *pb.GameState, playerID string) map[string]int {
+	played := map[string]int{}
+	var prev, next map[int32]int32
+	for _, p := range before.Players {
+		if p.Id == playerID {
+			prev = p.DevCards
+		}
+	}
+	for _, p := range after.Players {
+		if p.Id == playerID {
+			next = p.DevCards
+		}
+	}
+	for card, count := range prev {
+		if diff := count - next[card]; diff > 0 {
+			played[pb.DevCardType(card).String()] += int(diff)
+		}
+	}
+	return played
+}
+
+func newStructures(before, after *pb.GameState, playerID string) []string {
+	var built []string
+	prevBuildings := map[string]pb.BuildingType{}
+	for _, v := range before.GetBoard().GetVertices() {
+		if v.Building != nil {
+			prevBuildings[v.Id] = v.Building.Type
+		}
+	}
+	for _, v := range after.GetBoard().GetVertices() {
+		if v.Building == nil || v.Building.OwnerId != playerID || prevBuildings[v.Id] == v.Building.Type {
+			continue
+		}
+		switch v.Building.Type {
+		case pb.BuildingType_BUILDING_TYPE_SETTLEMENT:
+			built = append(built, "settlement")
+		case pb.BuildingType_BUILDING_TYPE_CITY:
+			built = append(built, "city")
+		}
+	}
+	prevRoads := map[string]bool{}
+	for _, e := range before.GetBoard().GetEdges() {
+		if e.Road != nil {
+			prevRoads[e.Id] = true
+		}
+	}
+	for _, e := range after.GetBoard().GetEdges() {
+		if e.Road != nil && e.Road.OwnerId == playerID && !prevRoads[e.Id] {
+			built = append(built, "road")
+		}
+	}
+	return built
+}
+
+// Merge adds the counters of other into s. Builds are per game and are not
+// merged.
+func (s *PlayerStats) Merge(other *PlayerStats) {
+	if other == nil {
+		return
+	}
+	if s.Rolls == nil {
+		s.Rolls = map[int]int{}
+	}
+	if s.CardsPlayed == nil {
+		s.CardsPlayed = map[string]int{}
+	}
+	for total, count := range other.Rolls {
+		s.Rolls[total] += count
+	}
+	for card, count := range other.CardsPlayed {
+		s.CardsPlayed[card] += count
+	}
+	s.ResourcesGained += other.ResourcesGained
+	s.ResourcesStolen += other.ResourcesStolen
+	s.ResourcesLostToRobber += other.ResourcesLostToRobber
+	s.TradesMade += other.TradesMade
+	s.BankTrades += other.BankTrades
+}
@@ -0,0 +1,181 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/game"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func newPlayingState() *pb.GameState {
+	state := game.NewGameState("g1", "ABCDEF", []string{"Alice", "Bob"}, []string{"p1", "p2"})
+	state.Status = pb.GameStatus_GAME_STATUS_PLAYING
+	state.TurnPhase = pb.TurnPhase_TURN_PHASE_ROLL
+	return state
+}
+
+func TestRecord_RollDiceCountsDistributionAndProduction(t *testing.T) {
+	before := newPlayingState()
+	after := proto.Clone(before).(*pb.GameState)
+	after.Dice = []int32{3, 5}
+	after.Players[0].Resources.Wood += 2
+	after.Players[1].Resources.Ore++
+
+	gs := NewGameStats()
+	gs.Record("rollDice", "p1", before, after)
+
+	if gs.DiceDistribution[8] != 1 {
+		t.Errorf("expected one roll of 8, got %d", gs.DiceDistribution[8])
+	}
+	if gs.Player("p1").Rolls[8] != 1 {
+		t.Errorf("expected p1 to have rolled an 8")
+	}
+	if gs.Player("p2").Rolls[8] != 0 {
+		t.Errorf("expected roll attributed only to roller")
+	}
+	if gs.Player("p1").ResourcesGained != 2 || gs.Player("p2").ResourcesGained != 1 {
+		t.Errorf("expected production 2/1, got %d/%d", gs.Player("p1").ResourcesGained, gs.Player("p2").ResourcesGained)
+	}
+}
+
+func TestRecord_RobberStealAndDiscard(t *testing.T) {
+	before := newPlayingState()
+	before.Players[1].Resources = &pb.ResourceCount{Wood: 5, Brick: 4}
+
+	discarded := proto.Clone(before).(*pb.GameState)
+	discarded.Players[1].Resources = &pb.ResourceCount{Wood: 1, Brick: 3}
+	stolen := proto.Clone(discarded).(*pb.GameState)
+	stolen.Players[1].Resources.Wood--
+	stolen.Players[0].Resources.Wood++
+
+	gs := NewGameStats()
+	gs.Record("discardCards", "p2", before, discarded)
+	gs.Record("moveRobber", "p1", discarded, stolen)
+
+	if got := gs.Player("p2").ResourcesLostToRobber; got != 6 {
+		t.Errorf("expected p2 to lose 6 cards to robber, got %d", got)
+	}
+	if got := gs.Player("p1").ResourcesStolen; got != 1 {
+		t.Errorf("expected p1 to steal 1 card, got %d", got)
+	}
+}
+
+func TestRecord_TradesCardsAndBuilds(t *testing.T) {
+	before := newPlayingState()
+	before.TurnCounter = 7
+	before.Players[0].DevCards = map[int32]int32{int32(pb.DevCardType_DEV_CARD_TYPE_KNIGHT): 2}
+	before.PendingTrades = []*pb.TradeOffer{{Id: "t1", ProposerId: "p1", Status: pb.TradeStatus_TRADE_STATUS_PENDING}}
+
+	traded := proto.Clone(before).(*pb.GameState)
+	traded.PendingTrades[0].Status = pb.TradeStatus_TRADE_STATUS_ACCEPTED
+
+	played := proto.Clone(traded).(*pb.GameState)
+	played.Players[0].DevCards[int32(pb.DevCardType_DEV_CARD_TYPE_KNIGHT)] = 1
+
+	built := proto.Clone(played).(*pb.GameState)
+	built.Board.Vertices[0].Building = &pb.Building{OwnerId: "p1", Type: pb.BuildingType_BUILDING_TYPE_SETTLEMENT}
+	built.Board.Edges[0].Road = &pb.Road{OwnerId: "p1"}
+
+	gs := NewGameStats()
+	gs.Record("respondTrade", "p2", before, traded)
+	gs.Record("bankTrade", "p1", traded, traded)
+	gs.Record("playDevCard", "p1", traded, played)
+	gs.Record("buildStructure", "p1", played, built)
+
+	p1 := gs.Player("p1")
+	if p1.TradesMade != 1 || gs.Player("p2").TradesMade != 1 {
+		t.Errorf("expected both trade parties to record a trade")
+	}
+	if p1.BankTrades != 1 {
+		t.Errorf("expected 1 bank trade, got %d", p1.BankTrades)
+	}
+	if p1.CardsPlayed["DEV_CARD_TYPE_KNIGHT"] != 1 {
+		t.Errorf("expected knight played once, got %v", p1.CardsPlayed)
+	}
+	if len(p1.Builds) != 2 || p1.Builds[0].Turn != 7 {
+		t.Errorf("expected settlement and road on turn 7, got %+v", p1.Builds)
+	}
+}
+
+func TestRecord_TakebackUndoesBuildAndBankTrade(t *testing.T) {
+	before := newPlayingState()
+	before.Players[0].Resources = &pb.ResourceCount{Wood: 1, Brick: 1, Ore: 4}
+
+	built := proto.Clone(before).(*pb.GameState)
+	built.Board.Edges[0].Road = &pb.Road{OwnerId: "p1"}
+	built.Players[0].Resources.Wood, built.Players[0].Resources.Brick = 0, 0
+	traded := proto.Clone(built).(*pb.GameState)
+	traded.Players[0].Resources.Ore, traded.Players[0].Resources.Wheat = 0, 1
+
+	gs := NewGameStats()
+	gs.Record("buildStructure", "p1", before, built)
+	gs.Record("bankTrade", "p1", built, traded)
+
+	requested := proto.Clone(traded).(*pb.GameState)
+	requested.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", Action: "bankTrade"}
+	gs.Record("respondTakeback", "p2", requested, traded)
+	if gs.Player("p1").BankTrades != 1 {
+		t.Fatalf("expected a rejected takeback to keep the bank trade")
+	}
+	gs.Record("respondTakeback", "p2", requested, built)
+	if gs.Player("p1").BankTrades != 0 {
+		t.Errorf("expected the bank trade to be undone, got %d", gs.Player("p1").BankTrades)
+	}
+
+	requested = proto.Clone(built).(*pb.GameState)
+	requested.PendingTakeback = &pb.TakebackRequest{RequesterId: "p1", Action: "buildStructure"}
+	gs.Record("respondTakeback", "p2", requested, before)
+	if builds := gs.Player("p1").Builds; len(builds) != 0 {
+		t.Errorf("expected the road to be undone, got %+v", builds)
+	}
+}
+
+func TestPlacements(t *testing.T) {
+	payload := &pb.GameOverPayload{
+		WinnerId: "p3",
+		Scores: []*pb.PlayerScore{
+			{PlayerId: "p1", Points: 7},
+			{PlayerId: "p2", Points: 7},
+			{PlayerId: "p3", Points: 10},
+			{PlayerId: "p4", Points: 4},
+		},
+	}
+	want := map[string]int{"p3": 1, "p1": 2, "p2": 2, "p4": 4}
+	for _, pl := range Placements(payload) {
+		if pl.Place != want[pl.PlayerID] {
+			t.Errorf("%s: expected place %d, got %d", pl.PlayerID, want[pl.PlayerID], pl.Place)
+		}
+	}
+}
+
+func TestRatingChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		ratings []float64
+		places  []int
+		want    []float64
+	}{
+		{"equal two players", []float64{1500, 1500}, []int{1, 2}, []float64{16, -16}},
+		{"draw between equals", []float64{1500, 1500}, []int{1, 1}, []float64{0, 0}},
+		{"single player unchanged", []float64{1500}, []int{1}, []float64{0}},
+		{"four equal players", []float64{1500, 1500, 1500, 1500}, []int{1, 2, 3, 4}, []float64{16, 5.333, -5.333, -16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RatingChanges(tt.ratings, tt.places)
+			sum := 0.0
+			for i := range got {
+				sum += got[i]
+				if math.Abs(got[i]-tt.want[i]) > 0.01 {
+					t.Errorf("player %d: expected %.3f, got %.3f", i, tt.want[i], got[i])
+				}
+			}
+			if math.Abs(sum) > 1e-9 {
+				t.Errorf("rating changes should sum to zero, got %f", sum)
+			}
+		})
+	}
+}
@@ -0,0 +1,721 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+var (
+	gamesBucket    = []byte("games")
+	codesBucket    = []byte("codes")
+	playersBucket  = []byte("players")
+	sessionsBucket = []byte("sessions")
+	eventsBucket   = []byte("events")
+	chatBucket     = []byte("chat")
+
+	tournamentsBucket = []byte("tournaments")
+	leasesBucket      = []byte("leases")
+)
+
+// Bolt is a GameStore kept in a single bbolt key/value file. Records are
+// JSON with game states in protojson; each game's events and chat live in
+// nested buckets keyed by big-endian sequence number.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens or creates the bbolt file at path.
+func OpenBolt(path string) (*Bolt, error) {
+	database, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = database.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{gamesBucket, codesBucket, playersBucket, sessionsBucket, eventsBucket, chatBucket, tournamentsBucket, leasesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		database.Close()
+		return nil, err
+	}
+	return &Bolt{db: database}, nil
+}
+
+type boltGame struct {
+	ID        string          `json:"id"`
+	Code      string          `json:"code"`
+	State     json.RawMessage `json:"state"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	// Status and TurnDeadline mirror the state so games can be counted and
+	// swept without decoding it. Records written before they were added
+	// lack both.
+	Status       string `json:"status,omitempty"`
+	TurnDeadline int64  `json:"turnDeadline,omitempty"`
+}
+
+type boltTournament struct {
+	ID        string          `json:"id"`
+	State     json.RawMessage `json:"state"`
+	Version   int64           `json:"version,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+type boltEvent struct {
+	Seq       int64           `json:"seq"`
+	Kind      string          `json:"kind"`
+	PlayerID  string          `json:"playerId,omitempty"`
+	Payload   []byte          `json:"payload,omitempty"`
+	State     json.RawMessage `json:"state"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+func (b *Bolt) CreateGame(game *Game, players ...*Player) error {
+	stateJSON, err := protojson.Marshal(game.State)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(gamesBucket).Get([]byte(game.ID)) != nil || tx.Bucket(codesBucket).Get([]byte(game.Code)) != nil {
+			return ErrConflict
+		}
+		now := time.Now().UTC()
+		if err := putJSON(tx.Bucket(gamesBucket), game.ID, boltGame{
+			ID: game.ID, Code: game.Code, State: stateJSON, Status: StatusName(game.State.GetStatus()), TurnDeadline: turnDeadline(game.State),
+			CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(codesBucket).Put([]byte(game.Code), []byte(game.ID)); err != nil {
+			return err
+		}
+		for _, p := range players {
+			cp := *p
+			cp.GameID = game.ID
+			if err := putPlayer(tx, &cp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *Bolt) GetGame(id string) (*Game, error) {
+	var game *Game
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		game, err = getGame(tx, id)
+		return err
+	})
+	return game, err
+}
+
+func (b *Bolt) GetGameByCode(code string) (*Game, error) {
+	var game *Game
+	err := b.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(codesBucket).Get([]byte(code))
+		if id == nil {
+			return ErrNotFound
+		}
+		var err error
+		game, err = getGame(tx, string(id))
+		return err
+	})
+	return game, err
+}
+
+func (b *Bolt) SaveGameState(gameID string, state *catanv1.GameState) error {
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var rec boltGame
+		if err := getJSON(tx.Bucket(gamesBucket), gameID, &rec); err != nil {
+			return err
+		}
+		rec.State = stateJSON
+		rec.Status = StatusName(state.GetStatus())
+		rec.TurnDeadline = turnDeadline(state)
+		rec.UpdatedAt = time.Now().UTC()
+		return putJSON(tx.Bucket(gamesBucket), gameID, rec)
+	})
+}
+
+func (b *Bolt) ListGameIDs() ([]string, error) {
+	ids := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(k, _ []byte) error {
+			ids = append(ids, string(k))
+			return nil
+		})
+	})
+	return ids, err
+}
+
+func (b *Bolt) CountGamesByStatus() (map[string]int, error) {
+	counts := map[string]int{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(_, v []byte) error {
+			rec, err := decodeGameMirrors(v)
+			if err != nil {
+				return err
+			}
+			counts[rec.Status]++
+			return nil
+		})
+	})
+	return counts, err
+}
+
+func (b *Bolt) ListIdleGameIDs(status catanv1.GameStatus, before time.Time) ([]string, error) {
+	ids := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(k, v []byte) error {
+			rec, err := decodeGameMirrors(v)
+			if err != nil {
+				return err
+			}
+			if rec.Status == StatusName(status) && rec.UpdatedAt.Before(before) {
+				ids = append(ids, string(k))
+			}
+			return nil
+		})
+	})
+	return ids, err
+}
+
+func (b *Bolt) ListOverdueGameIDs(now time.Time) ([]string, error) {
+	ids := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(gamesBucket).ForEach(func(k, v []byte) error {
+			rec, err := decodeGameMirrors(v)
+			if err != nil {
+				return err
+			}
+			if rec.TurnDeadline != 0 && rec.TurnDeadline <= now.UnixMilli() {
+				ids = append(ids, string(k))
+			}
+			return nil
+		})
+	})
+	return ids, err
+}
+
+// decodeGameMirrors decodes a game record, filling in the fields that
+// mirror its state from the state itself for records that predate them.
+func decodeGameMirrors(data []byte) (*boltGame, error) {
+	var rec boltGame
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	if rec.Status == "" {
+		var state catanv1.GameState
+		if err := protojson.Unmarshal(rec.State, &state); err != nil {
+			return nil, err
+		}
+		rec.Status = StatusName(state.GetStatus())
+		rec.TurnDeadline = turnDeadline(&state)
+	}
+	return &rec, nil
+}
+
+func (b *Bolt) DeleteGame(id string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var rec boltGame
+		if err := getJSON(tx.Bucket(gamesBucket), id, &rec); err != nil {
+			return err
+		}
+		players := tx.Bucket(playersBucket)
+		var playerIDs [][]byte
+		err := players.ForEach(func(k, v []byte) error {
+			var p Player
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			if p.GameID == id {
+				playerIDs = append(playerIDs, append([]byte(nil), k...))
+				return tx.Bucket(sessionsBucket).Delete([]byte(p.SessionToken))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range playerIDs {
+			if err := players.Delete(k); err != nil {
+				return err
+			}
+		}
+		for _, name := range [][]byte{eventsBucket, chatBucket} {
+			if tx.Bucket(name).Bucket([]byte(id)) != nil {
+				if err := tx.Bucket(name).DeleteBucket([]byte(id)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.Bucket(leasesBucket).Delete([]byte(id)); err != nil {
+			return err
+		}
+		if err := tx.Bucket(codesBucket).Delete([]byte(rec.Code)); err != nil {
+			return err
+		}
+		return tx.Bucket(gamesBucket).Delete([]byte(id))
+	})
+}
+
+func (b *Bolt) AddPlayer(player *Player) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(gamesBucket).Get([]byte(player.GameID)) == nil {
+			return ErrNotFound
+		}
+		return putPlayer(tx, player)
+	})
+}
+
+func (b *Bolt) GetPlayer(playerID string) (*Player, error) {
+	var player Player
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return getJSON(tx.Bucket(playersBucket), playerID, &player)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+func (b *Bolt) GetPlayerBySession(sessionToken string) (*Player, error) {
+	var player Player
+	err := b.db.View(func(tx *bolt.Tx) error {
+		id := tx.Bucket(sessionsBucket).Get([]byte(sessionToken))
+		if id == nil {
+			return ErrNotFound
+		}
+		return getJSON(tx.Bucket(playersBucket), string(id), &player)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &player, nil
+}
+
+func (b *Bolt) GetPlayerByUser(gameID, userID string) (*Player, error) {
+	var found *Player
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(playersBucket).ForEach(func(_, v []byte) error {
+			var p Player
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			if found == nil && userID != "" && p.GameID == gameID && p.UserID == userID {
+				found = &p
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return found, nil
+}
+
+func (b *Bolt) SetPlayerConnected(playerID string, connected bool) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var p Player
+		if err := getJSON(tx.Bucket(playersBucket), playerID, &p); err != nil {
+			return err
+		}
+		p.Connected = connected
+		return putJSON(tx.Bucket(playersBucket), playerID, p)
+	})
+}
+
+func (b *Bolt) DeletePlayer(playerID string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var p Player
+		if err := getJSON(tx.Bucket(playersBucket), playerID, &p); err != nil {
+			return err
+		}
+		if err := tx.Bucket(sessionsBucket).Delete([]byte(p.SessionToken)); err != nil {
+			return err
+		}
+		return tx.Bucket(playersBucket).Delete([]byte(playerID))
+	})
+}
+
+func (b *Bolt) ActiveSeats(userID string) ([]Seat, error) {
+	seats := []Seat{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(playersBucket).ForEach(func(_, v []byte) error {
+			var p Player
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			if userID == "" || p.UserID != userID {
+				return nil
+			}
+			g, err := getGame(tx, p.GameID)
+			if err != nil {
+				return err
+			}
+			if !isFinished(g.State) {
+				seats = append(seats, Seat{Game: g, Player: &p})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortSeats(seats)
+	return seats, nil
+}
+
+func (b *Bolt) AppendEvent(event *Event) (int64, error) {
+	stateJSON, err := protojson.Marshal(event.State)
+	if err != nil {
+		return 0, err
+	}
+	var seq int64
+	err = b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(gamesBucket).Get([]byte(event.GameID)) == nil {
+			return ErrNotFound
+		}
+		bucket, err := tx.Bucket(eventsBucket).CreateBucketIfNotExists([]byte(event.GameID))
+		if err != nil {
+			return err
+		}
+		seq = 1
+		if k, _ := bucket.Cursor().Last(); k != nil {
+			seq = int64(binary.BigEndian.Uint64(k)) + 1
+		}
+		data, err := json.Marshal(boltEvent{
+			Seq:       seq,
+			Kind:      event.Kind,
+			PlayerID:  event.PlayerID,
+			Payload:   event.Payload,
+			State:     stateJSON,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		return bucket.Put(seqKey(seq), data)
+	})
+	return seq, err
+}
+
+func (b *Bolt) GetEvent(gameID string, seq int64) (*Event, error) {
+	var event *Event
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket).Bucket([]byte(gameID))
+		if bucket == nil || seq < 1 {
+			return ErrNotFound
+		}
+		data := bucket.Get(seqKey(seq))
+		if data == nil {
+			return ErrNotFound
+		}
+		var err error
+		event, err = decodeEvent(gameID, data)
+		return err
+	})
+	return event, err
+}
+
+func (b *Bolt) ListEvents(gameID string, throughSeq int64) ([]*Event, error) {
+	events := []*Event{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket).Bucket([]byte(gameID))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if throughSeq > 0 && int64(binary.BigEndian.Uint64(k)) > throughSeq {
+				break
+			}
+			e, err := decodeEvent(gameID, v)
+			if err != nil {
+				return err
+			}
+			events = append(events, e)
+		}
+		return nil
+	})
+	return events, err
+}
+
+func (b *Bolt) LastEvent(gameID string, skipKinds ...string) (*Event, error) {
+	var event *Event
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket).Bucket([]byte(gameID))
+		if bucket == nil {
+			return ErrNotFound
+		}
+		c := bucket.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			e, err := decodeEvent(gameID, v)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(skipKinds, e.Kind) {
+				event = e
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+	return event, err
+}
+
+func (b *Bolt) AppendChat(msg *ChatMessage) (*ChatMessage, error) {
+	stored := *msg
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(gamesBucket).Get([]byte(msg.GameID)) == nil {
+			return ErrNotFound
+		}
+		bucket, err := tx.Bucket(chatBucket).CreateBucketIfNotExists([]byte(msg.GameID))
+		if err != nil {
+			return err
+		}
+		stored.Seq = 1
+		if k, _ := bucket.Cursor().Last(); k != nil {
+			stored.Seq = int64(binary.BigEndian.Uint64(k)) + 1
+		}
+		stored.CreatedAt = time.Now().UTC()
+		data, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(seqKey(stored.Seq), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (b *Bolt) ListChat(gameID, playerID string, limit int) ([]*ChatMessage, error) {
+	lines := []*ChatMessage{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(chatBucket).Bucket([]byte(gameID))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			var msg ChatMessage
+			if err := json.Unmarshal(v, &msg); err != nil {
+				return err
+			}
+			if msg.visibleTo(playerID) {
+				lines = append(lines, &msg)
+			}
+			return nil
+		})
+	})
+	return lastN(lines, limit), err
+}
+
+func (b *Bolt) CreateTournament(t *Tournament) error {
+	stateJSON, err := protojson.Marshal(t.State)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(tournamentsBucket)
+		if bucket.Get([]byte(t.ID)) != nil {
+			return ErrConflict
+		}
+		now := time.Now().UTC()
+		return putJSON(bucket, t.ID, boltTournament{ID: t.ID, State: stateJSON, CreatedAt: now, UpdatedAt: now})
+	})
+}
+
+func (b *Bolt) GetTournament(id string) (*Tournament, error) {
+	var t *Tournament
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(tournamentsBucket).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		var err error
+		t, err = decodeTournament(data)
+		return err
+	})
+	return t, err
+}
+
+func (b *Bolt) SaveTournament(id string, state *catanv1.Tournament, version int64) error {
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var rec boltTournament
+		if err := getJSON(tx.Bucket(tournamentsBucket), id, &rec); err != nil {
+			return err
+		}
+		if rec.Version != version {
+			return ErrStale
+		}
+		rec.State = stateJSON
+		rec.Version++
+		rec.UpdatedAt = time.Now().UTC()
+		return putJSON(tx.Bucket(tournamentsBucket), id, rec)
+	})
+}
+
+func (b *Bolt) ListTournaments() ([]*Tournament, error) {
+	ts := []*Tournament{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tournamentsBucket).ForEach(func(_, v []byte) error {
+			t, err := decodeTournament(v)
+			if err != nil {
+				return err
+			}
+			ts = append(ts, t)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortTournaments(ts)
+	return ts, nil
+}
+
+type boltLease struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func (b *Bolt) AcquireLease(gameID, owner string, ttl time.Duration) (string, error) {
+	holder := owner
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(gamesBucket).Get([]byte(gameID)) == nil {
+			return ErrNotFound
+		}
+		now := time.Now()
+		var rec boltLease
+		err := getJSON(tx.Bucket(leasesBucket), gameID, &rec)
+		switch {
+		case err == nil && rec.Owner != owner && now.Before(rec.ExpiresAt):
+			holder = rec.Owner
+			return nil
+		case err != nil && !errors.Is(err, ErrNotFound):
+			return err
+		}
+		return putJSON(tx.Bucket(leasesBucket), gameID, boltLease{Owner: owner, ExpiresAt: now.Add(ttl)})
+	})
+	if err != nil {
+		return "", err
+	}
+	return holder, nil
+}
+
+func (b *Bolt) ReleaseLease(gameID, owner string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		var rec boltLease
+		if err := getJSON(tx.Bucket(leasesBucket), gameID, &rec); err != nil || rec.Owner != owner {
+			return nil
+		}
+		return tx.Bucket(leasesBucket).Delete([]byte(gameID))
+	})
+}
+
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
+func getGame(tx *bolt.Tx, id string) (*Game, error) {
+	var rec boltGame
+	if err := getJSON(tx.Bucket(gamesBucket), id, &rec); err != nil {
+		return nil, err
+	}
+	var state catanv1.GameState
+	if err := protojson.Unmarshal(rec.State, &state); err != nil {
+		return nil, err
+	}
+	return &Game{ID: rec.ID, Code: rec.Code, State: &state, CreatedAt: rec.CreatedAt, UpdatedAt: rec.UpdatedAt}, nil
+}
+
+func putPlayer(tx *bolt.Tx, p *Player) error {
+	if tx.Bucket(playersBucket).Get([]byte(p.ID)) != nil || tx.Bucket(sessionsBucket).Get([]byte(p.SessionToken)) != nil {
+		return ErrConflict
+	}
+	if err := putJSON(tx.Bucket(playersBucket), p.ID, p); err != nil {
+		return err
+	}
+	return tx.Bucket(sessionsBucket).Put([]byte(p.SessionToken), []byte(p.ID))
+}
+
+func decodeEvent(gameID string, data []byte) (*Event, error) {
+	var rec boltEvent
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	var state catanv1.GameState
+	if err := protojson.Unmarshal(rec.State, &state); err != nil {
+		return nil, err
+	}
+	return &Event{
+		GameID:    gameID,
+		Seq:       rec.Seq,
+		Kind:      rec.Kind,
+		PlayerID:  rec.PlayerID,
+		Payload:   rec.Payload,
+		State:     &state,
+		CreatedAt: rec.CreatedAt,
+	}, nil
+}
+
+func decodeTournament(data []byte) (*Tournament, error) {
+	var rec boltTournament
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	var state catanv1.Tournament
+	if err := protojson.Unmarshal(rec.State, &state); err != nil {
+		return nil, err
+	}
+	return &Tournament{ID: rec.ID, State: &state, Version: rec.Version, CreatedAt: rec.CreatedAt, UpdatedAt: rec.UpdatedAt}, nil
+}
+
+func getJSON(bucket *bolt.Bucket, key string, v any) error {
+	data := bucket.Get([]byte(key))
+	if data == nil {
+		return ErrNotFound
+	}
+	return json.Unmarshal(data, v)
+}
+
+func putJSON(bucket *bolt.Bucket, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(key), data)
+}
+
+func seqKey(seq int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(seq))
+	return key
+}
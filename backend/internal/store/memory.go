@@ -0,0 +1,467 @@
+package store
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// Memory is a GameStore that keeps everything in process memory. It is meant
+// for tests and throwaway servers.
+type Memory struct {
+	mu       sync.RWMutex
+	games    map[string]*Game
+	codes    map[string]string
+	players  map[string]*Player
+	sessions map[string]string
+	events   map[string][]*Event
+	chat     map[string][]*ChatMessage
+
+	tournaments map[string]*Tournament
+	leases      map[string]lease
+}
+
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		games:    map[string]*Game{},
+		codes:    map[string]string{},
+		players:  map[string]*Player{},
+		sessions: map[string]string{},
+		events:   map[string][]*Event{},
+		chat:     map[string][]*ChatMessage{},
+
+		tournaments: map[string]*Tournament{},
+		leases:      map[string]lease{},
+	}
+}
+
+func (m *Memory) CreateGame(game *Game, players ...*Player) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[game.ID]; ok {
+		return ErrConflict
+	}
+	if _, ok := m.codes[game.Code]; ok {
+		return ErrConflict
+	}
+	seen := map[string]bool{}
+	for _, p := range players {
+		if m.playerConflicts(p) || seen[p.ID] || seen["session:"+p.SessionToken] {
+			return ErrConflict
+		}
+		seen[p.ID] = true
+		seen["session:"+p.SessionToken] = true
+	}
+
+	now := time.Now().UTC()
+	m.games[game.ID] = &Game{ID: game.ID, Code: game.Code, State: cloneState(game.State), CreatedAt: now, UpdatedAt: now}
+	m.codes[game.Code] = game.ID
+	for _, p := range players {
+		m.putPlayer(game.ID, p)
+	}
+	return nil
+}
+
+func (m *Memory) GetGame(id string) (*Game, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	g, ok := m.games[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyGame(g), nil
+}
+
+func (m *Memory) GetGameByCode(code string) (*Game, error) {
+	m.mu.RLock()
+	id, ok := m.codes[code]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return m.GetGame(id)
+}
+
+func (m *Memory) SaveGameState(gameID string, state *catanv1.GameState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, ok := m.games[gameID]
+	if !ok {
+		return ErrNotFound
+	}
+	g.State = cloneState(state)
+	g.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (m *Memory) ListGameIDs() ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.games))
+	for id := range m.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *Memory) CountGamesByStatus() (map[string]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := map[string]int{}
+	for _, g := range m.games {
+		counts[StatusName(g.State.GetStatus())]++
+	}
+	return counts, nil
+}
+
+func (m *Memory) ListIdleGameIDs(status catanv1.GameStatus, before time.Time) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := []string{}
+	for id, g := range m.games {
+		if g.State.GetStatus() == status && g.UpdatedAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *Memory) ListOverdueGameIDs(now time.Time) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := []string{}
+	for id, g := range m.games {
+		if d := turnDeadline(g.State); d != 0 && d <= now.UnixMilli() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *Memory) DeleteGame(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g, ok := m.games[id]
+	if !ok {
+		return ErrNotFound
+	}
+	for playerID, p := range m.players {
+		if p.GameID == id {
+			delete(m.sessions, p.SessionToken)
+			delete(m.players, playerID)
+		}
+	}
+	delete(m.codes, g.Code)
+	delete(m.events, id)
+	delete(m.chat, id)
+	delete(m.leases, id)
+	delete(m.games, id)
+	return nil
+}
+
+func (m *Memory) AddPlayer(player *Player) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[player.GameID]; !ok {
+		return ErrNotFound
+	}
+	if m.playerConflicts(player) {
+		return ErrConflict
+	}
+	m.putPlayer(player.GameID, player)
+	return nil
+}
+
+func (m *Memory) GetPlayer(playerID string) (*Player, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.players[playerID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (m *Memory) GetPlayerBySession(sessionToken string) (*Player, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	id, ok := m.sessions[sessionToken]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	p := *m.players[id]
+	return &p, nil
+}
+
+func (m *Memory) GetPlayerByUser(gameID, userID string) (*Player, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, p := range m.players {
+		if p.GameID == gameID && userID != "" && p.UserID == userID {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *Memory) SetPlayerConnected(playerID string, connected bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.players[playerID]
+	if !ok {
+		return ErrNotFound
+	}
+	p.Connected = connected
+	return nil
+}
+
+func (m *Memory) DeletePlayer(playerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.players[playerID]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(m.sessions, p.SessionToken)
+	delete(m.players, playerID)
+	return nil
+}
+
+func (m *Memory) ActiveSeats(userID string) ([]Seat, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seats := []Seat{}
+	for _, p := range m.players {
+		g := m.games[p.GameID]
+		if userID == "" || p.UserID != userID || g == nil || isFinished(g.State) {
+			continue
+		}
+		cp := *p
+		seats = append(seats, Seat{Game: copyGame(g), Player: &cp})
+	}
+	sortSeats(seats)
+	return seats, nil
+}
+
+func (m *Memory) AppendEvent(event *Event) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[event.GameID]; !ok {
+		return 0, ErrNotFound
+	}
+	e := copyEvent(event)
+	e.Seq = int64(len(m.events[event.GameID])) + 1
+	e.CreatedAt = time.Now().UTC()
+	m.events[event.GameID] = append(m.events[event.GameID], e)
+	return e.Seq, nil
+}
+
+func (m *Memory) GetEvent(gameID string, seq int64) (*Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	events := m.events[gameID]
+	if seq < 1 || seq > int64(len(events)) {
+		return nil, ErrNotFound
+	}
+	return copyEvent(events[seq-1]), nil
+}
+
+func (m *Memory) ListEvents(gameID string, throughSeq int64) ([]*Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	events := []*Event{}
+	for _, e := range m.events[gameID] {
+		if throughSeq > 0 && e.Seq > throughSeq {
+			break
+		}
+		events = append(events, copyEvent(e))
+	}
+	return events, nil
+}
+
+func (m *Memory) LastEvent(gameID string, skipKinds ...string) (*Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	events := m.events[gameID]
+	for i := len(events) - 1; i >= 0; i-- {
+		if !slices.Contains(skipKinds, events[i].Kind) {
+			return copyEvent(events[i]), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *Memory) AppendChat(msg *ChatMessage) (*ChatMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[msg.GameID]; !ok {
+		return nil, ErrNotFound
+	}
+	cp := *msg
+	cp.Seq = int64(len(m.chat[msg.GameID])) + 1
+	cp.CreatedAt = time.Now().UTC()
+	m.chat[msg.GameID] = append(m.chat[msg.GameID], &cp)
+	stored := cp
+	return &stored, nil
+}
+
+func (m *Memory) ListChat(gameID, playerID string, limit int) ([]*ChatMessage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	lines := []*ChatMessage{}
+	for _, msg := range m.chat[gameID] {
+		if msg.visibleTo(playerID) {
+			cp := *msg
+			lines = append(lines, &cp)
+		}
+	}
+	return lastN(lines, limit), nil
+}
+
+func (m *Memory) CreateTournament(t *Tournament) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.tournaments[t.ID]; ok {
+		return ErrConflict
+	}
+	now := time.Now().UTC()
+	m.tournaments[t.ID] = &Tournament{ID: t.ID, State: cloneTournament(t.State), CreatedAt: now, UpdatedAt: now}
+	return nil
+}
+
+func (m *Memory) GetTournament(id string) (*Tournament, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	t, ok := m.tournaments[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyTournament(t), nil
+}
+
+func (m *Memory) SaveTournament(id string, state *catanv1.Tournament, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tournaments[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if t.Version != version {
+		return ErrStale
+	}
+	t.State = cloneTournament(state)
+	t.Version++
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (m *Memory) ListTournaments() ([]*Tournament, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ts := make([]*Tournament, 0, len(m.tournaments))
+	for _, t := range m.tournaments {
+		ts = append(ts, copyTournament(t))
+	}
+	sortTournaments(ts)
+	return ts, nil
+}
+
+func (m *Memory) AcquireLease(gameID, owner string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.games[gameID]; !ok {
+		return "", ErrNotFound
+	}
+	now := time.Now()
+	if l, ok := m.leases[gameID]; ok && l.owner != owner && now.Before(l.expires) {
+		return l.owner, nil
+	}
+	m.leases[gameID] = lease{owner: owner, expires: now.Add(ttl)}
+	return owner, nil
+}
+
+func (m *Memory) ReleaseLease(gameID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.leases[gameID]; ok && l.owner == owner {
+		delete(m.leases, gameID)
+	}
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+func (m *Memory) playerConflicts(p *Player) bool {
+	if _, ok := m.players[p.ID]; ok {
+		return true
+	}
+	_, ok := m.sessions[p.SessionToken]
+	return ok
+}
+
+func (m *Memory) putPlayer(gameID string, p *Player) {
+	cp := *p
+	cp.GameID = gameID
+	m.players[p.ID] = &cp
+	m.sessions[p.SessionToken] = p.ID
+}
+
+func cloneState(state *catanv1.GameState) *catanv1.GameState {
+	if state == nil {
+		return nil
+	}
+	return proto.Clone(state).(*catanv1.GameState)
+}
+
+func copyGame(g *Game) *Game {
+	cp := *g
+	cp.State = cloneState(g.State)
+	return &cp
+}
+
+func cloneTournament(state *catanv1.Tournament) *catanv1.Tournament {
+	if state == nil {
+		return nil
+	}
+	return proto.Clone(state).(*catanv1.Tournament)
+}
+
+func copyTournament(t *Tournament) *Tournament {
+	cp := *t
+	cp.State = cloneTournament(t.State)
+	return &cp
+}
+
+func copyEvent(e *Event) *Event {
+	cp := *e
+	cp.Payload = append([]byte(nil), e.Payload...)
+	cp.State = cloneState(e.State)
+	return &cp
+}
+
+func sortSeats(seats []Seat) {
+	sort.SliceStable(seats, func(i, j int) bool {
+		if !seats[i].Game.UpdatedAt.Equal(seats[j].Game.UpdatedAt) {
+			return seats[i].Game.UpdatedAt.After(seats[j].Game.UpdatedAt)
+		}
+		return seats[i].Game.ID < seats[j].Game.ID
+	})
+}
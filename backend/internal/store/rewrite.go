@@ -0,0 +1,53 @@
+package store
+
+import (
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// RewriteFailure is a game that could not be rewritten.
+type RewriteFailure struct {
+	GameID string
+	Err    error
+}
+
+// RewriteReport summarizes a RewriteGames run.
+type RewriteReport struct {
+	Scanned   int
+	Rewritten int
+	Failures  []RewriteFailure
+}
+
+// RewriteGames applies fn to the state of every stored game and saves the
+// states fn reports as changed. Games that fail to load, rewrite or save are
+// reported and skipped. With dryRun set nothing is saved.
+func RewriteGames(s GameStore, fn func(state *catanv1.GameState) (bool, error), dryRun bool) (*RewriteReport, error) {
+	ids, err := s.ListGameIDs()
+	if err != nil {
+		return nil, err
+	}
+	report := &RewriteReport{}
+	for _, id := range ids {
+		report.Scanned++
+		g, err := s.GetGame(id)
+		if err != nil {
+			report.Failures = append(report.Failures, RewriteFailure{GameID: id, Err: err})
+			continue
+		}
+		changed, err := fn(g.State)
+		if err != nil {
+			report.Failures = append(report.Failures, RewriteFailure{GameID: id, Err: err})
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if !dryRun {
+			if err := s.SaveGameState(id, g.State); err != nil {
+				report.Failures = append(report.Failures, RewriteFailure{GameID: id, Err: err})
+				continue
+			}
+		}
+		report.Rewritten++
+	}
+	return report, nil
+}
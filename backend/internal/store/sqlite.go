@@ -0,0 +1,554 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/db"
+)
+
+// SQLite is a GameStore backed by the games, players, game_events,
+// chat_messages and tournaments tables created by db.Initialize.
+type SQLite struct {
+	db    *sqlx.DB
+	owned bool
+}
+
+// NewSQLite wraps an initialized database. Closing the store leaves the
+// database open.
+func NewSQLite(database *sqlx.DB) *SQLite {
+	return &SQLite{db: database}
+}
+
+// OpenSQLite opens and initializes the database file at path.
+func OpenSQLite(path string) (*SQLite, error) {
+	database, err := db.Initialize(path)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLite{db: database, owned: true}, nil
+}
+
+type gameRow struct {
+	ID        string    `db:"id"`
+	Code      string    `db:"code"`
+	State     string    `db:"state"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type playerRow struct {
+	ID           string         `db:"id"`
+	GameID       string         `db:"game_id"`
+	Name         string         `db:"name"`
+	Color        sql.NullString `db:"color"`
+	SessionToken string         `db:"session_token"`
+	IsHost       bool           `db:"is_host"`
+	Connected    bool           `db:"connected"`
+	UserID       sql.NullString `db:"user_id"`
+}
+
+type eventRow struct {
+	GameID    string         `db:"game_id"`
+	Seq       int64          `db:"seq"`
+	Kind      string         `db:"kind"`
+	PlayerID  sql.NullString `db:"player_id"`
+	Payload   sql.NullString `db:"payload"`
+	State     string         `db:"state"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type chatRow struct {
+	GameID      string         `db:"game_id"`
+	Seq         int64          `db:"seq"`
+	SenderID    string         `db:"sender_id"`
+	RecipientID sql.NullString `db:"recipient_id"`
+	Text        string         `db:"text"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+type tournamentRow struct {
+	ID        string    `db:"id"`
+	State     string    `db:"state"`
+	Version   int64     `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+const (
+	gameColumns       = "id, code, state, created_at, updated_at"
+	playerColumns     = "id, game_id, name, color, session_token, is_host, connected, user_id"
+	eventColumns      = "game_id, seq, kind, player_id, payload, state, created_at"
+	chatColumns       = "game_id, seq, sender_id, recipient_id, text, created_at"
+	tournamentColumns = "id, state, version, created_at, updated_at"
+)
+
+func (s *SQLite) CreateGame(game *Game, players ...*Player) error {
+	stateJSON, err := protojson.Marshal(game.State)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO games (id, code, state, status, turn_deadline) VALUES (?, ?, ?, ?, ?)`,
+		game.ID, game.Code, string(stateJSON), StatusName(game.State.GetStatus()), nullDeadline(game.State)); err != nil {
+		return sqliteError(err)
+	}
+	for _, p := range players {
+		if err := insertPlayer(tx, game.ID, p); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *SQLite) GetGame(id string) (*Game, error) {
+	return s.getGame("SELECT "+gameColumns+" FROM games WHERE id = ?", id)
+}
+
+func (s *SQLite) GetGameByCode(code string) (*Game, error) {
+	return s.getGame("SELECT "+gameColumns+" FROM games WHERE code = ?", code)
+}
+
+func (s *SQLite) getGame(query string, arg string) (*Game, error) {
+	var row gameRow
+	if err := s.db.Get(&row, query, arg); err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.game()
+}
+
+func (s *SQLite) SaveGameState(gameID string, state *catanv1.GameState) error {
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.Exec(
+		"UPDATE games SET state = ?, status = ?, turn_deadline = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?",
+		string(stateJSON), StatusName(state.GetStatus()), nullDeadline(state), gameID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) ListGameIDs() ([]string, error) {
+	ids := []string{}
+	if err := s.db.Select(&ids, "SELECT id FROM games ORDER BY id"); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *SQLite) CountGamesByStatus() (map[string]int, error) {
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	if err := s.db.Select(&rows, "SELECT status, COUNT(*) AS count FROM games GROUP BY status"); err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, r := range rows {
+		counts[r.Status] = r.Count
+	}
+	return counts, nil
+}
+
+func (s *SQLite) ListIdleGameIDs(status catanv1.GameStatus, before time.Time) ([]string, error) {
+	ids := []string{}
+	// updated_at holds CURRENT_TIMESTAMP's UTC text, so compare in that form
+	err := s.db.Select(&ids, "SELECT id FROM games WHERE status = ? AND updated_at < ? ORDER BY id",
+		StatusName(status), before.UTC().Format(time.DateTime))
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *SQLite) ListOverdueGameIDs(now time.Time) ([]string, error) {
+	ids := []string{}
+	if err := s.db.Select(&ids, "SELECT id FROM games WHERE turn_deadline <= ? ORDER BY id", now.UnixMilli()); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteGame relies on ON DELETE CASCADE to remove the game's players,
+// events, chat and lease.
+func (s *SQLite) DeleteGame(id string) error {
+	res, err := s.db.Exec("DELETE FROM games WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) AddPlayer(player *Player) error {
+	return insertPlayer(s.db, player.GameID, player)
+}
+
+func (s *SQLite) GetPlayerBySession(sessionToken string) (*Player, error) {
+	return s.getPlayer("SELECT "+playerColumns+" FROM players WHERE session_token = ?", sessionToken)
+}
+
+func (s *SQLite) GetPlayer(playerID string) (*Player, error) {
+	return s.getPlayer("SELECT "+playerColumns+" FROM players WHERE id = ?", playerID)
+}
+
+func (s *SQLite) GetPlayerByUser(gameID, userID string) (*Player, error) {
+	return s.getPlayer("SELECT "+playerColumns+" FROM players WHERE game_id = ? AND user_id = ?", gameID, userID)
+}
+
+func (s *SQLite) getPlayer(query string, args ...any) (*Player, error) {
+	var row playerRow
+	if err := s.db.Get(&row, query, args...); err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.player(), nil
+}
+
+func (s *SQLite) SetPlayerConnected(playerID string, connected bool) error {
+	res, err := s.db.Exec("UPDATE players SET connected = ?, last_seen = CURRENT_TIMESTAMP WHERE id = ?", connected, playerID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) DeletePlayer(playerID string) error {
+	res, err := s.db.Exec("DELETE FROM players WHERE id = ?", playerID)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) ActiveSeats(userID string) ([]Seat, error) {
+	var rows []struct {
+		gameRow
+		playerRow `db:"p"`
+	}
+	err := s.db.Select(&rows,
+		`SELECT g.id, g.code, g.state, g.created_at, g.updated_at,
+		        p.id AS "p.id", p.game_id AS "p.game_id", p.name AS "p.name", p.color AS "p.color",
+		        p.session_token AS "p.session_token", p.is_host AS "p.is_host",
+		        p.connected AS "p.connected", p.user_id AS "p.user_id"
+		 FROM players p JOIN games g ON g.id = p.game_id
+		 WHERE p.user_id = ? AND g.status != 'finished'
+		 ORDER BY g.updated_at DESC, g.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	seats := make([]Seat, 0, len(rows))
+	for _, row := range rows {
+		g, err := row.gameRow.game()
+		if err != nil {
+			return nil, err
+		}
+		seats = append(seats, Seat{Game: g, Player: row.playerRow.player()})
+	}
+	return seats, nil
+}
+
+func (s *SQLite) AppendEvent(event *Event) (int64, error) {
+	stateJSON, err := protojson.Marshal(event.State)
+	if err != nil {
+		return 0, err
+	}
+	var seq int64
+	err = s.db.Get(&seq,
+		`INSERT INTO game_events (game_id, seq, kind, player_id, payload, state)
+		 SELECT ?, COALESCE(MAX(seq), 0) + 1, ?, ?, ?, ? FROM game_events WHERE game_id = ?
+		 RETURNING seq`,
+		event.GameID, event.Kind, nullString(event.PlayerID), nullString(string(event.Payload)), string(stateJSON), event.GameID)
+	if err != nil {
+		return 0, sqliteError(err)
+	}
+	return seq, nil
+}
+
+func (s *SQLite) GetEvent(gameID string, seq int64) (*Event, error) {
+	var row eventRow
+	if err := s.db.Get(&row, "SELECT "+eventColumns+" FROM game_events WHERE game_id = ? AND seq = ?", gameID, seq); err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.event()
+}
+
+func (s *SQLite) ListEvents(gameID string, throughSeq int64) ([]*Event, error) {
+	var rows []eventRow
+	query := "SELECT " + eventColumns + " FROM game_events WHERE game_id = ? AND (? = 0 OR seq <= ?) ORDER BY seq"
+	if err := s.db.Select(&rows, query, gameID, throughSeq, throughSeq); err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(rows))
+	for _, row := range rows {
+		e, err := row.event()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func (s *SQLite) LastEvent(gameID string, skipKinds ...string) (*Event, error) {
+	query := "SELECT " + eventColumns + " FROM game_events WHERE game_id = ?"
+	args := []any{gameID}
+	if len(skipKinds) > 0 {
+		query += " AND kind NOT IN (?" + strings.Repeat(", ?", len(skipKinds)-1) + ")"
+		for _, kind := range skipKinds {
+			args = append(args, kind)
+		}
+	}
+	var row eventRow
+	if err := s.db.Get(&row, query+" ORDER BY seq DESC LIMIT 1", args...); err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.event()
+}
+
+func (s *SQLite) AppendChat(msg *ChatMessage) (*ChatMessage, error) {
+	var row chatRow
+	err := s.db.Get(&row,
+		`INSERT INTO chat_messages (game_id, seq, sender_id, recipient_id, text)
+		 SELECT ?, COALESCE(MAX(seq), 0) + 1, ?, ?, ? FROM chat_messages WHERE game_id = ?
+		 RETURNING `+chatColumns,
+		msg.GameID, msg.SenderID, nullString(msg.RecipientID), msg.Text, msg.GameID)
+	if err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.chat(), nil
+}
+
+func (s *SQLite) ListChat(gameID, playerID string, limit int) ([]*ChatMessage, error) {
+	var rows []chatRow
+	query := "SELECT " + chatColumns + ` FROM (
+		SELECT ` + chatColumns + ` FROM chat_messages
+		WHERE game_id = ? AND (recipient_id IS NULL OR recipient_id = ? OR sender_id = ?)
+		ORDER BY seq DESC LIMIT ?
+	) ORDER BY seq`
+	if limit <= 0 {
+		limit = -1
+	}
+	if err := s.db.Select(&rows, query, gameID, playerID, playerID, limit); err != nil {
+		return nil, err
+	}
+	lines := make([]*ChatMessage, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, row.chat())
+	}
+	return lines, nil
+}
+
+func (s *SQLite) CreateTournament(t *Tournament) error {
+	stateJSON, err := protojson.Marshal(t.State)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO tournaments (id, name, state, status) VALUES (?, ?, ?, ?)`,
+		t.ID, t.State.GetName(), string(stateJSON), TournamentStatusName(t.State.GetStatus()))
+	return sqliteError(err)
+}
+
+func (s *SQLite) GetTournament(id string) (*Tournament, error) {
+	var row tournamentRow
+	if err := s.db.Get(&row, "SELECT "+tournamentColumns+" FROM tournaments WHERE id = ?", id); err != nil {
+		return nil, sqliteError(err)
+	}
+	return row.tournament()
+}
+
+func (s *SQLite) SaveTournament(id string, state *catanv1.Tournament, version int64) error {
+	stateJSON, err := protojson.Marshal(state)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.Exec(
+		`UPDATE tournaments SET state = ?, status = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		 WHERE id = ? AND version = ?`,
+		string(stateJSON), TournamentStatusName(state.GetStatus()), id, version)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		var exists bool
+		if err := s.db.Get(&exists, "SELECT EXISTS (SELECT 1 FROM tournaments WHERE id = ?)", id); err != nil {
+			return err
+		}
+		if exists {
+			return ErrStale
+		}
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) ListTournaments() ([]*Tournament, error) {
+	var rows []tournamentRow
+	if err := s.db.Select(&rows, "SELECT "+tournamentColumns+" FROM tournaments ORDER BY created_at DESC, id"); err != nil {
+		return nil, err
+	}
+	ts := make([]*Tournament, 0, len(rows))
+	for _, row := range rows {
+		t, err := row.tournament()
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+// AcquireLease keeps expiry in unix milliseconds so instances compare it
+// without parsing.
+func (s *SQLite) AcquireLease(gameID, owner string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	_, err := s.db.Exec(
+		`INSERT INTO game_leases (game_id, owner, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(game_id) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+		WHERE game_leases.owner = excluded.owner OR game_leases.expires_at <= ?`,
+		gameID, owner, now.Add(ttl).UnixMilli(), now.UnixMilli(),
+	)
+	if err != nil {
+		return "", sqliteError(err)
+	}
+	var holder string
+	if err := s.db.Get(&holder, "SELECT owner FROM game_leases WHERE game_id = ?", gameID); err != nil {
+		return "", sqliteError(err)
+	}
+	return holder, nil
+}
+
+func (s *SQLite) ReleaseLease(gameID, owner string) error {
+	_, err := s.db.Exec("DELETE FROM game_leases WHERE game_id = ? AND owner = ?", gameID, owner)
+	return err
+}
+
+func (s *SQLite) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func insertPlayer(exec sqlx.Execer, gameID string, p *Player) error {
+	_, err := exec.Exec(
+		`INSERT INTO players (id, game_id, name, color, session_token, is_host, connected, user_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.ID, gameID, p.Name, int(p.Color), p.SessionToken, p.IsHost, p.Connected, nullString(p.UserID))
+	return sqliteError(err)
+}
+
+func (row gameRow) game() (*Game, error) {
+	var state catanv1.GameState
+	if err := protojson.Unmarshal([]byte(row.State), &state); err != nil {
+		return nil, err
+	}
+	return &Game{ID: row.ID, Code: row.Code, State: &state, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt}, nil
+}
+
+func (row playerRow) player() *Player {
+	color, _ := strconv.Atoi(row.Color.String)
+	return &Player{
+		ID:           row.ID,
+		GameID:       row.GameID,
+		Name:         row.Name,
+		Color:        catanv1.PlayerColor(color),
+		SessionToken: row.SessionToken,
+		IsHost:       row.IsHost,
+		Connected:    row.Connected,
+		UserID:       row.UserID.String,
+	}
+}
+
+func (row eventRow) event() (*Event, error) {
+	var state catanv1.GameState
+	if err := protojson.Unmarshal([]byte(row.State), &state); err != nil {
+		return nil, err
+	}
+	e := &Event{
+		GameID:    row.GameID,
+		Seq:       row.Seq,
+		Kind:      row.Kind,
+		PlayerID:  row.PlayerID.String,
+		State:     &state,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.Payload.Valid {
+		e.Payload = []byte(row.Payload.String)
+	}
+	return e, nil
+}
+
+func (row chatRow) chat() *ChatMessage {
+	return &ChatMessage{
+		GameID:      row.GameID,
+		Seq:         row.Seq,
+		SenderID:    row.SenderID,
+		RecipientID: row.RecipientID.String,
+		Text:        row.Text,
+		CreatedAt:   row.CreatedAt,
+	}
+}
+
+func (row tournamentRow) tournament() (*Tournament, error) {
+	var state catanv1.Tournament
+	if err := protojson.Unmarshal([]byte(row.State), &state); err != nil {
+		return nil, err
+	}
+	return &Tournament{ID: row.ID, State: &state, Version: row.Version, CreatedAt: row.CreatedAt, UpdatedAt: row.UpdatedAt}, nil
+}
+
+// sqliteError maps driver errors onto the store's sentinel errors.
+func sqliteError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound
+	case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return ErrConflict
+	case strings.Contains(err.Error(), "FOREIGN KEY constraint failed"):
+		return ErrNotFound
+	default:
+		return err
+	}
+}
+
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullDeadline(state *catanv1.GameState) any {
+	if d := turnDeadline(state); d != 0 {
+		return d
+	}
+	return nil
+}
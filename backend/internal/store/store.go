@@ -0,0 +1,250 @@
+// Package store persists games, their players and seat sessions, each
+// game's event log, tournaments and game leases behind the GameStore
+// interface.
+package store
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+)
+
+var (
+	// ErrNotFound is returned when a game, player, event or tournament does
+	// not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a game ID, join code, player ID, session
+	// token or tournament ID is already taken.
+	ErrConflict = errors.New("already exists")
+	// ErrStale is returned when saving a tournament that was saved again
+	// since it was loaded.
+	ErrStale = errors.New("changed since it was loaded")
+)
+
+// Game is a stored game and its current state.
+type Game struct {
+	ID        string
+	Code      string
+	State     *catanv1.GameState
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Player is a seat in a game. SessionToken identifies the seat's session;
+// UserID is empty for anonymous players.
+type Player struct {
+	ID           string
+	GameID       string
+	Name         string
+	Color        catanv1.PlayerColor
+	SessionToken string
+	IsHost       bool
+	Connected    bool
+	UserID       string
+}
+
+// Event is one entry of a game's event log together with the state right
+// after it. Seq is assigned by AppendEvent, starting at 1.
+type Event struct {
+	GameID    string
+	Seq       int64
+	Kind      string
+	PlayerID  string
+	Payload   []byte
+	State     *catanv1.GameState
+	CreatedAt time.Time
+}
+
+// ChatMessage is one line of a game's chat. RecipientID is set for whispers.
+// Seq is assigned by AppendChat, starting at 1.
+type ChatMessage struct {
+	GameID      string
+	Seq         int64
+	SenderID    string
+	RecipientID string
+	Text        string
+	CreatedAt   time.Time
+}
+
+// Seat is a user's player in an unfinished game.
+type Seat struct {
+	Game   *Game
+	Player *Player
+}
+
+// Tournament is a stored tournament and its current state.
+type Tournament struct {
+	ID    string
+	State *catanv1.Tournament
+	// Version counts the tournament's saves; SaveTournament takes the one
+	// the state was loaded at.
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GameStore is the persistence layer for games, players, sessions, event
+// logs, tournaments and game leases. Returned values are copies; mutating them does not change the store.
+type GameStore interface {
+	// CreateGame stores a new game and its initial players atomically.
+	CreateGame(game *Game, players ...*Player) error
+	GetGame(id string) (*Game, error)
+	GetGameByCode(code string) (*Game, error)
+	// SaveGameState replaces the state of an existing game.
+	SaveGameState(gameID string, state *catanv1.GameState) error
+	// ListGameIDs returns the IDs of every stored game.
+	ListGameIDs() ([]string, error)
+	// CountGamesByStatus returns how many games are stored in each status,
+	// keyed by StatusName. Statuses without games are left out.
+	CountGamesByStatus() (map[string]int, error)
+	// ListIdleGameIDs lists the games in status last updated before before,
+	// sorted.
+	ListIdleGameIDs(status catanv1.GameStatus, before time.Time) ([]string, error)
+	// ListOverdueGameIDs lists the games whose correspondence turn deadline
+	// is at or before now, sorted.
+	ListOverdueGameIDs(now time.Time) ([]string, error)
+	// DeleteGame removes a game with its players, sessions, events and chat.
+	DeleteGame(id string) error
+
+	AddPlayer(player *Player) error
+	GetPlayer(playerID string) (*Player, error)
+	GetPlayerBySession(sessionToken string) (*Player, error)
+	GetPlayerByUser(gameID, userID string) (*Player, error)
+	SetPlayerConnected(playerID string, connected bool) error
+	// DeletePlayer removes a seat, invalidating its session token.
+	DeletePlayer(playerID string) error
+	// ActiveSeats lists the user's seats in unfinished games, most recently
+	// updated game first.
+	ActiveSeats(userID string) ([]Seat, error)
+
+	// AppendEvent adds event to the end of its game's log and returns the
+	// sequence number it was given.
+	AppendEvent(event *Event) (int64, error)
+	GetEvent(gameID string, seq int64) (*Event, error)
+	// ListEvents returns the game's events up to and including throughSeq,
+	// or all events if throughSeq is 0, in order.
+	ListEvents(gameID string, throughSeq int64) ([]*Event, error)
+	// LastEvent returns the game's most recent event whose kind is not one
+	// of skipKinds, or ErrNotFound if there is none.
+	LastEvent(gameID string, skipKinds ...string) (*Event, error)
+
+	// AppendChat adds msg to its game's chat and returns the stored line.
+	AppendChat(msg *ChatMessage) (*ChatMessage, error)
+	// ListChat returns up to the last limit chat lines playerID can see,
+	// oldest first: messages to the whole game and whispers they sent or
+	// received. A limit of 0 returns every such line.
+	ListChat(gameID, playerID string, limit int) ([]*ChatMessage, error)
+
+	CreateTournament(t *Tournament) error
+	GetTournament(id string) (*Tournament, error)
+	// SaveTournament replaces the state of an existing tournament if it is
+	// still at version, and returns ErrStale if it was saved since.
+	SaveTournament(id string, state *catanv1.Tournament, version int64) error
+	// ListTournaments returns every tournament, newest first.
+	ListTournaments() ([]*Tournament, error)
+
+	// AcquireLease makes owner the holder of gameID's lease for ttl if the
+	// lease is free, expired or already owner's, and returns who holds it
+	// afterwards. Server instances sharing the store hold a game's lease to
+	// run its commands.
+	AcquireLease(gameID, owner string, ttl time.Duration) (holder string, err error)
+	// ReleaseLease frees gameID's lease if owner holds it.
+	ReleaseLease(gameID, owner string) error
+
+	Close() error
+}
+
+// Open opens the store described by a storage URL:
+//
+//	sqlite://path/to/catan.db
+//	bolt://path/to/catan.bolt
+//	memory://
+func Open(storageURL string) (GameStore, error) {
+	scheme, path, ok := strings.Cut(storageURL, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid storage URL %q", storageURL)
+	}
+	switch scheme {
+	case "sqlite":
+		if path == "" {
+			return nil, errors.New("sqlite storage URL needs a path")
+		}
+		return OpenSQLite(path)
+	case "bolt":
+		if path == "" {
+			return nil, errors.New("bolt storage URL needs a path")
+		}
+		return OpenBolt(path)
+	case "memory":
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unsupported storage scheme %q", scheme)
+	}
+}
+
+func isFinished(state *catanv1.GameState) bool {
+	return state.GetStatus() == catanv1.GameStatus_GAME_STATUS_FINISHED
+}
+
+// turnDeadline is the state's correspondence turn deadline in Unix
+// milliseconds, or 0 while no turn clock is running.
+func turnDeadline(state *catanv1.GameState) int64 {
+	return state.GetCorrespondence().GetTurnDeadline()
+}
+
+// StatusName is the lowercase name stored and reported for a game status.
+func StatusName(status catanv1.GameStatus) string {
+	switch status {
+	case catanv1.GameStatus_GAME_STATUS_WAITING:
+		return "waiting"
+	case catanv1.GameStatus_GAME_STATUS_SETUP:
+		return "setup"
+	case catanv1.GameStatus_GAME_STATUS_PLAYING:
+		return "playing"
+	case catanv1.GameStatus_GAME_STATUS_FINISHED:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// TournamentStatusName is the lowercase name stored for a tournament status.
+func TournamentStatusName(status catanv1.TournamentStatus) string {
+	switch status {
+	case catanv1.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION:
+		return "registration"
+	case catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING:
+		return "running"
+	case catanv1.TournamentStatus_TOURNAMENT_STATUS_FINISHED:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// sortTournaments orders tournaments newest first, by ID within a tie.
+func sortTournaments(ts []*Tournament) {
+	sort.SliceStable(ts, func(i, j int) bool {
+		if !ts[i].CreatedAt.Equal(ts[j].CreatedAt) {
+			return ts[i].CreatedAt.After(ts[j].CreatedAt)
+		}
+		return ts[i].ID < ts[j].ID
+	})
+}
+
+// visibleTo reports whether playerID may read msg.
+func (msg *ChatMessage) visibleTo(playerID string) bool {
+	return msg.RecipientID == "" || msg.RecipientID == playerID || msg.SenderID == playerID
+}
+
+// lastN returns the last n lines, or all of them when n is 0.
+func lastN(lines []*ChatMessage, n int) []*ChatMessage {
+	if n > 0 && len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,612 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	catanv1 "settlers_from_catan/gen/proto/catan/v1"
+	"settlers_from_catan/internal/db"
+	"settlers_from_catan/internal/game"
+)
+
+// backends lists every GameStore implementation; each must pass the
+// conformance suite below.
+var backends = []struct {
+	name string
+	open func(t *testing.T) GameStore
+}{
+	{"sqlite", func(t *testing.T) GameStore {
+		s, err := Open("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("failed to open sqlite store: %v", err)
+		}
+		return s
+	}},
+	{"sqlite-shared", func(t *testing.T) GameStore {
+		database, err := db.Initialize(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("failed to init db: %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return NewSQLite(database)
+	}},
+	{"memory", func(t *testing.T) GameStore {
+		s, err := Open("memory://")
+		if err != nil {
+			t.Fatalf("failed to open memory store: %v", err)
+		}
+		return s
+	}},
+	{"bolt", func(t *testing.T) GameStore {
+		s, err := Open("bolt://" + filepath.Join(t.TempDir(), "test.bolt"))
+		if err != nil {
+			t.Fatalf("failed to open bolt store: %v", err)
+		}
+		return s
+	}},
+}
+
+func TestGameStoreConformance(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s GameStore)
+	}{
+		{"games", testGames},
+		{"players and sessions", testPlayers},
+		{"active seats", testActiveSeats},
+		{"events", testEvents},
+		{"chat", testChat},
+		{"delete", testDelete},
+		{"tournaments", testTournaments},
+		{"leases", testLeases},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					s := backend.open(t)
+					defer s.Close()
+					tt.run(t, s)
+				})
+			}
+		})
+	}
+}
+
+func newGame(id, code string) *Game {
+	return &Game{ID: id, Code: code, State: game.NewGameState(id, code, []string{"Alice"}, []string{id + "-p1"})}
+}
+
+func host(gameID string) *Player {
+	return &Player{
+		ID:           gameID + "-p1",
+		Name:         "Alice",
+		Color:        catanv1.PlayerColor_PLAYER_COLOR_RED,
+		SessionToken: gameID + "-s1",
+		IsHost:       true,
+	}
+}
+
+// addUser creates an account for userID where the backend keeps accounts
+// alongside seats.
+func addUser(t *testing.T, s GameStore, userID string) {
+	t.Helper()
+	if sq, ok := s.(*SQLite); ok {
+		if _, err := sq.db.Exec("INSERT INTO users (id, username, password_hash) VALUES (?, ?, 'x')", userID, userID); err != nil {
+			t.Fatalf("failed to add user: %v", err)
+		}
+	}
+}
+
+func testGames(t *testing.T, s GameStore) {
+	g := newGame("g1", "ABCDEF")
+	if err := s.CreateGame(g, host("g1")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if err := s.CreateGame(newGame("g2", "ABCDEF")); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected duplicate code to conflict, got %v", err)
+	}
+	if err := s.CreateGame(newGame("g1", "ZZZZZZ")); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected duplicate ID to conflict, got %v", err)
+	}
+
+	byCode, err := s.GetGameByCode("ABCDEF")
+	if err != nil || byCode.ID != "g1" || byCode.State.Code != "ABCDEF" {
+		t.Fatalf("GetGameByCode: %+v, %v", byCode, err)
+	}
+	if byCode.CreatedAt.IsZero() || byCode.UpdatedAt.IsZero() {
+		t.Errorf("expected timestamps to be set")
+	}
+	if _, err := s.GetGame("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing game, got %v", err)
+	}
+	if _, err := s.GetGameByCode("NOPE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing code, got %v", err)
+	}
+
+	byCode.State.Status = catanv1.GameStatus_GAME_STATUS_PLAYING
+	byCode.State.TurnCounter = 12
+	if got, _ := s.GetGame("g1"); got.State.Status != catanv1.GameStatus_GAME_STATUS_WAITING {
+		t.Errorf("mutating a returned game must not change the store")
+	}
+	if err := s.SaveGameState("g1", byCode.State); err != nil {
+		t.Fatalf("SaveGameState: %v", err)
+	}
+	got, err := s.GetGame("g1")
+	if err != nil || got.State.TurnCounter != 12 || got.State.Status != catanv1.GameStatus_GAME_STATUS_PLAYING {
+		t.Errorf("expected saved state, got %+v, %v", got, err)
+	}
+	if err := s.SaveGameState("missing", byCode.State); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound saving missing game, got %v", err)
+	}
+	if err := s.CreateGame(newGame("g0", "GHIJKL")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	ids, err := s.ListGameIDs()
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("expected 2 game IDs, got %v, %v", ids, err)
+	}
+	counts, err := s.CountGamesByStatus()
+	if err != nil || len(counts) != 2 || counts["waiting"] != 1 || counts["playing"] != 1 {
+		t.Errorf("expected one waiting and one playing game, got %v, %v", counts, err)
+	}
+
+	deadline := time.UnixMilli(1_700_000_000_000)
+	corr := newGame("g2", "MNOPQR")
+	corr.State.Correspondence = &catanv1.Correspondence{TurnHours: 24, TurnDeadline: deadline.UnixMilli()}
+	if err := s.CreateGame(corr); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	byCode.State.Correspondence = &catanv1.Correspondence{TurnHours: 24, TurnDeadline: deadline.Add(time.Hour).UnixMilli()}
+	if err := s.SaveGameState("g1", byCode.State); err != nil {
+		t.Fatalf("SaveGameState: %v", err)
+	}
+	for _, tt := range []struct {
+		now  time.Time
+		want []string
+	}{
+		{deadline.Add(-time.Millisecond), []string{}},
+		{deadline, []string{"g2"}},
+		{deadline.Add(time.Hour), []string{"g1", "g2"}},
+	} {
+		if ids, err := s.ListOverdueGameIDs(tt.now); err != nil || !slices.Equal(ids, tt.want) {
+			t.Errorf("expected %v overdue at %v, got %v, %v", tt.want, tt.now, ids, err)
+		}
+	}
+	byCode.State.Correspondence.TurnDeadline = 0
+	if err := s.SaveGameState("g1", byCode.State); err != nil {
+		t.Fatalf("SaveGameState: %v", err)
+	}
+	if ids, err := s.ListOverdueGameIDs(deadline.Add(time.Hour)); err != nil || !slices.Equal(ids, []string{"g2"}) {
+		t.Errorf("expected a stopped clock to leave only g2 overdue, got %v, %v", ids, err)
+	}
+
+	later, earlier := time.Now().Add(time.Minute), time.Now().Add(-time.Minute)
+	if ids, err := s.ListIdleGameIDs(catanv1.GameStatus_GAME_STATUS_WAITING, later); err != nil || !slices.Equal(ids, []string{"g0", "g2"}) {
+		t.Errorf("expected both waiting games idle, got %v, %v", ids, err)
+	}
+	if ids, err := s.ListIdleGameIDs(catanv1.GameStatus_GAME_STATUS_PLAYING, later); err != nil || !slices.Equal(ids, []string{"g1"}) {
+		t.Errorf("expected the playing game idle, got %v, %v", ids, err)
+	}
+	if ids, err := s.ListIdleGameIDs(catanv1.GameStatus_GAME_STATUS_WAITING, earlier); err != nil || len(ids) != 0 {
+		t.Errorf("expected no game idle since before it was created, got %v, %v", ids, err)
+	}
+}
+
+func testPlayers(t *testing.T, s GameStore) {
+	if err := s.CreateGame(newGame("g1", "ABCDEF"), host("g1")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	addUser(t, s, "u-bob")
+	bob := &Player{ID: "bob", GameID: "g1", Name: "Bob", Color: catanv1.PlayerColor_PLAYER_COLOR_BLUE, SessionToken: "s-bob", UserID: "u-bob"}
+	if err := s.AddPlayer(bob); err != nil {
+		t.Fatalf("AddPlayer: %v", err)
+	}
+	if err := s.AddPlayer(&Player{ID: "eve", GameID: "g1", Name: "Eve", SessionToken: "s-bob"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected duplicate session token to conflict, got %v", err)
+	}
+	if err := s.AddPlayer(&Player{ID: "eve", GameID: "missing", Name: "Eve", SessionToken: "s-eve"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound adding to a missing game, got %v", err)
+	}
+
+	got, err := s.GetPlayerBySession("s-bob")
+	if err != nil {
+		t.Fatalf("GetPlayerBySession: %v", err)
+	}
+	if *got != *bob {
+		t.Errorf("expected %+v, got %+v", bob, got)
+	}
+	alice, err := s.GetPlayerBySession("g1-s1")
+	if err != nil || !alice.IsHost || alice.GameID != "g1" || alice.UserID != "" {
+		t.Errorf("expected anonymous host seat, got %+v, %v", alice, err)
+	}
+	if _, err := s.GetPlayerBySession("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown session, got %v", err)
+	}
+
+	if byUser, err := s.GetPlayerByUser("g1", "u-bob"); err != nil || byUser.ID != "bob" {
+		t.Errorf("GetPlayerByUser: %+v, %v", byUser, err)
+	}
+	if _, err := s.GetPlayerByUser("g1", "u-alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for user without a seat, got %v", err)
+	}
+	if byID, err := s.GetPlayer("bob"); err != nil || *byID != *bob {
+		t.Errorf("GetPlayer: %+v, %v", byID, err)
+	}
+	if _, err := s.GetPlayer("nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown player, got %v", err)
+	}
+
+	if err := s.SetPlayerConnected("bob", true); err != nil {
+		t.Fatalf("SetPlayerConnected: %v", err)
+	}
+	if got, _ := s.GetPlayerBySession("s-bob"); !got.Connected {
+		t.Errorf("expected player to be connected")
+	}
+	if err := s.SetPlayerConnected("nobody", true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown player, got %v", err)
+	}
+
+	if err := s.DeletePlayer("bob"); err != nil {
+		t.Fatalf("DeletePlayer: %v", err)
+	}
+	if _, err := s.GetPlayerBySession("s-bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleted player's session to be gone, got %v", err)
+	}
+	if err := s.DeletePlayer("bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+	if err := s.AddPlayer(&Player{ID: "eve", GameID: "g1", Name: "Eve", SessionToken: "s-bob"}); err != nil {
+		t.Errorf("expected deleted player's session token to be free, got %v", err)
+	}
+}
+
+func testActiveSeats(t *testing.T, s GameStore) {
+	addUser(t, s, "u1")
+	for _, id := range []string{"g1", "g2", "g3"} {
+		if err := s.CreateGame(newGame(id, "CODE"+id)); err != nil {
+			t.Fatalf("CreateGame: %v", err)
+		}
+		if err := s.AddPlayer(&Player{ID: id + "-u", GameID: id, Name: "Alice", SessionToken: id + "-s", UserID: "u1"}); err != nil {
+			t.Fatalf("AddPlayer: %v", err)
+		}
+	}
+	finished, _ := s.GetGame("g2")
+	finished.State.Status = catanv1.GameStatus_GAME_STATUS_FINISHED
+	if err := s.SaveGameState("g2", finished.State); err != nil {
+		t.Fatalf("SaveGameState: %v", err)
+	}
+
+	seats, err := s.ActiveSeats("u1")
+	if err != nil {
+		t.Fatalf("ActiveSeats: %v", err)
+	}
+	if len(seats) != 2 {
+		t.Fatalf("expected 2 unfinished seats, got %d", len(seats))
+	}
+	for _, seat := range seats {
+		if seat.Game.ID == "g2" || seat.Player.GameID != seat.Game.ID || seat.Player.SessionToken == "" {
+			t.Errorf("unexpected seat %+v", seat.Player)
+		}
+	}
+	if none, err := s.ActiveSeats("u2"); err != nil || len(none) != 0 {
+		t.Errorf("expected no seats for another user, got %d, %v", len(none), err)
+	}
+}
+
+func testEvents(t *testing.T, s GameStore) {
+	g := newGame("g1", "ABCDEF")
+	if err := s.CreateGame(g); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if _, err := s.AppendEvent(&Event{GameID: "missing", Kind: "createGame", State: g.State}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound appending to a missing game, got %v", err)
+	}
+
+	for i, kind := range []string{"createGame", "joinGame", "startGame"} {
+		g.State.TurnCounter = int32(i)
+		e := &Event{GameID: "g1", Kind: kind, State: g.State}
+		if kind == "startGame" {
+			e.PlayerID = "g1-p1"
+			e.Payload = []byte(`{"message":{"oneofKind":"startGame","startGame":{}}}`)
+		}
+		seq, err := s.AppendEvent(e)
+		if err != nil {
+			t.Fatalf("AppendEvent: %v", err)
+		}
+		if seq != int64(i+1) {
+			t.Errorf("expected seq %d, got %d", i+1, seq)
+		}
+	}
+
+	e, err := s.GetEvent("g1", 3)
+	if err != nil {
+		t.Fatalf("GetEvent: %v", err)
+	}
+	if e.Kind != "startGame" || e.PlayerID != "g1-p1" || string(e.Payload) == "" || e.State.TurnCounter != 2 || e.CreatedAt.IsZero() {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if first, _ := s.GetEvent("g1", 1); first.PlayerID != "" || first.Payload != nil {
+		t.Errorf("expected event without player or payload, got %+v", first)
+	}
+	if _, err := s.GetEvent("g1", 4); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing event, got %v", err)
+	}
+
+	all, err := s.ListEvents("g1", 0)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected 3 events, got %d, %v", len(all), err)
+	}
+	for i, e := range all {
+		if e.Seq != int64(i+1) || e.GameID != "g1" {
+			t.Errorf("event %d out of order: %+v", i, e)
+		}
+	}
+	if some, _ := s.ListEvents("g1", 2); len(some) != 2 || some[1].Kind != "joinGame" {
+		t.Errorf("expected events through seq 2, got %d", len(some))
+	}
+	if none, err := s.ListEvents("other", 0); err != nil || len(none) != 0 {
+		t.Errorf("expected no events for unknown game, got %d, %v", len(none), err)
+	}
+
+	if last, err := s.LastEvent("g1"); err != nil || last.Seq != 3 || last.Kind != "startGame" || last.State.TurnCounter != 2 {
+		t.Errorf("expected the last event, got %+v, %v", last, err)
+	}
+	if last, err := s.LastEvent("g1", "startGame", "createGame"); err != nil || last.Seq != 2 {
+		t.Errorf("expected the last joinGame event, got %+v, %v", last, err)
+	}
+	if _, err := s.LastEvent("g1", "createGame", "joinGame", "startGame"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound when every event is skipped, got %v", err)
+	}
+	if _, err := s.LastEvent("other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown game, got %v", err)
+	}
+}
+
+func testChat(t *testing.T, s GameStore) {
+	if err := s.CreateGame(newGame("g1", "ABCDEF")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if _, err := s.AppendChat(&ChatMessage{GameID: "missing", SenderID: "p1", Text: "hi"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound chatting in a missing game, got %v", err)
+	}
+
+	lines := []ChatMessage{
+		{SenderID: "p1", Text: "hello all"},
+		{SenderID: "p1", RecipientID: "p2", Text: "psst"},
+		{SenderID: "p3", RecipientID: "p1", Text: "trade?"},
+		{SenderID: "p2", Text: "gl"},
+	}
+	for i, line := range lines {
+		line.GameID = "g1"
+		stored, err := s.AppendChat(&line)
+		if err != nil {
+			t.Fatalf("AppendChat: %v", err)
+		}
+		if stored.Seq != int64(i+1) || stored.Text != line.Text || stored.CreatedAt.IsZero() {
+			t.Errorf("unexpected stored line %+v", stored)
+		}
+	}
+
+	tests := []struct {
+		player string
+		limit  int
+		want   []int64
+	}{
+		{"p1", 0, []int64{1, 2, 3, 4}},
+		{"p2", 0, []int64{1, 2, 4}},
+		{"p3", 0, []int64{1, 3, 4}},
+		{"p4", 0, []int64{1, 4}},
+		{"p1", 2, []int64{3, 4}},
+	}
+	for _, tt := range tests {
+		got, err := s.ListChat("g1", tt.player, tt.limit)
+		if err != nil {
+			t.Fatalf("ListChat: %v", err)
+		}
+		seqs := make([]int64, len(got))
+		for i, line := range got {
+			seqs[i] = line.Seq
+		}
+		if !slices.Equal(seqs, tt.want) {
+			t.Errorf("ListChat(%s, %d) = %v, want %v", tt.player, tt.limit, seqs, tt.want)
+		}
+	}
+	if got, _ := s.ListChat("g1", "p2", 0); got[1].RecipientID != "p2" || got[1].SenderID != "p1" {
+		t.Errorf("expected whisper fields to round-trip, got %+v", got[1])
+	}
+
+	if err := s.DeleteGame("g1"); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if got, err := s.ListChat("g1", "p1", 0); err != nil || len(got) != 0 {
+		t.Errorf("expected deleted game's chat to be gone, got %d, %v", len(got), err)
+	}
+}
+
+func testDelete(t *testing.T, s GameStore) {
+	g := newGame("g1", "ABCDEF")
+	if err := s.CreateGame(g, host("g1")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if err := s.CreateGame(newGame("g2", "GHIJKL"), host("g2")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if _, err := s.AppendEvent(&Event{GameID: "g1", Kind: "createGame", State: g.State}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+
+	if err := s.DeleteGame("g1"); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if _, err := s.GetGame("g1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleted game to be gone, got %v", err)
+	}
+	if _, err := s.GetPlayerBySession("g1-s1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleted game's session to be gone, got %v", err)
+	}
+	if events, _ := s.ListEvents("g1", 0); len(events) != 0 {
+		t.Errorf("expected deleted game's events to be gone, got %d", len(events))
+	}
+	if err := s.DeleteGame("g1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+	if _, err := s.GetPlayerBySession("g2-s1"); err != nil {
+		t.Errorf("expected other game's seat to remain, got %v", err)
+	}
+	// The code is free again
+	if err := s.CreateGame(newGame("g3", "ABCDEF")); err != nil {
+		t.Errorf("expected deleted game's code to be reusable, got %v", err)
+	}
+}
+
+func testTournaments(t *testing.T, s GameStore) {
+	state := &catanv1.Tournament{
+		Id:       "t1",
+		Name:     "League night",
+		Status:   catanv1.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION,
+		Entrants: []*catanv1.TournamentEntrant{{Id: "e1", Name: "Alice", Token: "tok1"}},
+	}
+	if err := s.CreateTournament(&Tournament{ID: "t1", State: state}); err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	if err := s.CreateTournament(&Tournament{ID: "t1", State: state}); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected duplicate ID to conflict, got %v", err)
+	}
+	got, err := s.GetTournament("t1")
+	if err != nil || got.State.Name != "League night" || len(got.State.Entrants) != 1 || got.State.Entrants[0].Token != "tok1" {
+		t.Fatalf("GetTournament: %+v, %v", got, err)
+	}
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected timestamps to be set")
+	}
+	if _, err := s.GetTournament("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing tournament, got %v", err)
+	}
+
+	got.State.Status = catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING
+	if again, _ := s.GetTournament("t1"); again.State.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION {
+		t.Errorf("mutating a returned tournament must not change the store")
+	}
+	got.State.Rounds = []*catanv1.TournamentRound{{Number: 1, Tables: []*catanv1.TournamentTable{{GameId: "g1", Code: "ABCDEF"}}}}
+	if err := s.SaveTournament("t1", got.State, got.Version); err != nil {
+		t.Fatalf("SaveTournament: %v", err)
+	}
+	saved, err := s.GetTournament("t1")
+	if err != nil || saved.State.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING || saved.State.Rounds[0].Tables[0].GameId != "g1" {
+		t.Errorf("expected saved state, got %+v, %v", saved, err)
+	}
+	if saved.Version != got.Version+1 {
+		t.Errorf("expected the save to bump the version from %d, got %d", got.Version, saved.Version)
+	}
+	got.State.Status = catanv1.TournamentStatus_TOURNAMENT_STATUS_FINISHED
+	if err := s.SaveTournament("t1", got.State, got.Version); !errors.Is(err, ErrStale) {
+		t.Errorf("expected ErrStale saving over a newer version, got %v", err)
+	}
+	if again, _ := s.GetTournament("t1"); again.State.Status != catanv1.TournamentStatus_TOURNAMENT_STATUS_RUNNING {
+		t.Errorf("expected a stale save to leave the tournament alone, got %v", again.State.Status)
+	}
+	if err := s.SaveTournament("missing", got.State, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound saving missing tournament, got %v", err)
+	}
+
+	if err := s.CreateTournament(&Tournament{ID: "t2", State: &catanv1.Tournament{Id: "t2", Name: "Cup"}}); err != nil {
+		t.Fatalf("CreateTournament: %v", err)
+	}
+	list, err := s.ListTournaments()
+	if err != nil || len(list) != 2 {
+		t.Fatalf("expected 2 tournaments, got %v, %v", list, err)
+	}
+	var ids []string
+	for _, tour := range list {
+		ids = append(ids, tour.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"t1", "t2"}) {
+		t.Errorf("expected t1 and t2, got %v", ids)
+	}
+}
+
+func testLeases(t *testing.T, s GameStore) {
+	if err := s.CreateGame(newGame("g1", "ABCDEF"), host("g1")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if _, err := s.AcquireLease("missing", "a", time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound leasing a missing game, got %v", err)
+	}
+	if holder, err := s.AcquireLease("g1", "a", time.Minute); err != nil || holder != "a" {
+		t.Fatalf("expected a to take the free lease, got %q, %v", holder, err)
+	}
+	if holder, err := s.AcquireLease("g1", "b", time.Minute); err != nil || holder != "a" {
+		t.Errorf("expected a to keep the lease, got %q, %v", holder, err)
+	}
+	if holder, err := s.AcquireLease("g1", "a", time.Minute); err != nil || holder != "a" {
+		t.Errorf("expected a to renew the lease, got %q, %v", holder, err)
+	}
+	if err := s.ReleaseLease("g1", "b"); err != nil {
+		t.Fatalf("ReleaseLease: %v", err)
+	}
+	if holder, _ := s.AcquireLease("g1", "b", time.Minute); holder != "a" {
+		t.Errorf("expected a release by another owner to be ignored, got %q", holder)
+	}
+	if err := s.ReleaseLease("g1", "a"); err != nil {
+		t.Fatalf("ReleaseLease: %v", err)
+	}
+	if holder, err := s.AcquireLease("g1", "b", time.Millisecond); err != nil || holder != "b" {
+		t.Fatalf("expected b to take the released lease, got %q, %v", holder, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if holder, err := s.AcquireLease("g1", "a", time.Minute); err != nil || holder != "a" {
+		t.Errorf("expected a to take the expired lease, got %q, %v", holder, err)
+	}
+
+	if err := s.DeleteGame("g1"); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if err := s.CreateGame(newGame("g1", "ABCDEF"), host("g1")); err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if holder, _ := s.AcquireLease("g1", "b", time.Minute); holder != "b" {
+		t.Errorf("expected the deleted game's lease to be gone, got %q", holder)
+	}
+}
+
+func TestRewriteGames(t *testing.T) {
+	s := NewMemory()
+	for _, id := range []string{"g1", "g2", "g3"} {
+		if err := s.CreateGame(newGame(id, "CODE"+id)); err != nil {
+			t.Fatalf("CreateGame: %v", err)
+		}
+	}
+	bump := func(state *catanv1.GameState) (bool, error) {
+		switch state.Id {
+		case "g2":
+			return false, nil
+		case "g3":
+			return false, errors.New("corrupt")
+		}
+		state.TurnCounter = 99
+		return true, nil
+	}
+
+	report, err := RewriteGames(s, bump, true)
+	if err != nil {
+		t.Fatalf("RewriteGames: %v", err)
+	}
+	if report.Scanned != 3 || report.Rewritten != 1 || len(report.Failures) != 1 || report.Failures[0].GameID != "g3" {
+		t.Fatalf("unexpected dry run report %+v", report)
+	}
+	if g, _ := s.GetGame("g1"); g.State.TurnCounter != 0 {
+		t.Fatalf("dry run must not save")
+	}
+
+	if _, err := RewriteGames(s, bump, false); err != nil {
+		t.Fatalf("RewriteGames: %v", err)
+	}
+	if g, _ := s.GetGame("g1"); g.State.TurnCounter != 99 {
+		t.Fatalf("expected rewritten state to be saved")
+	}
+}
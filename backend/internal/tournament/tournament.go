@@ -0,0 +1,361 @@
+// Package tournament runs multi-table tournaments. It seats entrants at
+// tables of three or four, records each table's final scores, cuts the field
+// between rounds and keeps the standings. Games themselves live elsewhere:
+// callers create a game for every table this package seats and report back
+// when it finishes.
+package tournament
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+const (
+	// MinTableSize and MaxTableSize bound the players at one table.
+	MinTableSize = 3
+	MaxTableSize = 4
+	// MaxAdvancePerTable keeps every round smaller than the one before it.
+	MaxAdvancePerTable = MinTableSize - 1
+)
+
+var (
+	ErrInvalidAdvancement = errors.New("advancePerTable must be 1 or 2")
+	ErrRegistrationClosed = errors.New("registration is closed")
+	ErrDuplicateEntrant   = errors.New("already registered")
+	ErrCannotSeat         = errors.New("entrants cannot be seated at tables of 3-4 players")
+	ErrNotRunning         = errors.New("tournament is not running")
+	ErrUnknownTable       = errors.New("game is not a table in the current round")
+	ErrTableFinished      = errors.New("table has already finished")
+)
+
+// New returns a tournament open for registration. Advancement defaults to
+// placement and advancePerTable to 1. seed fixes the first round's seating.
+func New(id, name, organizerToken string, advancement pb.TournamentAdvancement, advancePerTable int32, seed int64) (*pb.Tournament, error) {
+	if advancement == pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_UNSPECIFIED {
+		advancement = pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT
+	}
+	if advancePerTable == 0 {
+		advancePerTable = 1
+	}
+	if advancePerTable < 1 || advancePerTable > MaxAdvancePerTable {
+		return nil, ErrInvalidAdvancement
+	}
+	return &pb.Tournament{
+		Id:              id,
+		Name:            name,
+		Status:          pb.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION,
+		Advancement:     advancement,
+		AdvancePerTable: advancePerTable,
+		Seed:            seed,
+		OrganizerToken:  organizerToken,
+	}, nil
+}
+
+// Register adds an entrant while registration is open. A signed-in user can
+// only enter once.
+func Register(t *pb.Tournament, entrant *pb.TournamentEntrant) error {
+	if t.Status != pb.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION {
+		return ErrRegistrationClosed
+	}
+	for _, e := range t.Entrants {
+		if e.Id == entrant.Id || entrant.UserId != "" && e.UserId == entrant.UserId {
+			return ErrDuplicateEntrant
+		}
+	}
+	t.Entrants = append(t.Entrants, entrant)
+	t.Standings = Standings(t)
+	return nil
+}
+
+// Start closes registration and seats the first round in an order shuffled
+// by the tournament's seed. Callers create a game for each of its tables.
+func Start(t *pb.Tournament) (*pb.TournamentRound, error) {
+	if t.Status != pb.TournamentStatus_TOURNAMENT_STATUS_REGISTRATION {
+		return nil, ErrRegistrationClosed
+	}
+	if !Seatable(len(t.Entrants)) {
+		return nil, ErrCannotSeat
+	}
+	field := make([]string, len(t.Entrants))
+	for i, e := range t.Entrants {
+		field[i] = e.Id
+	}
+	rand.New(rand.NewSource(t.Seed)).Shuffle(len(field), func(i, j int) {
+		field[i], field[j] = field[j], field[i]
+	})
+	t.Status = pb.TournamentStatus_TOURNAMENT_STATUS_RUNNING
+	return seatRound(t, deal(field, tableCount(len(field)), false)), nil
+}
+
+// Seatable reports whether n entrants fill tables of 3-4 players.
+func Seatable(n int) bool {
+	return n >= MinTableSize && n != 5
+}
+
+// CurrentRound returns the round being played, or the final round once the
+// tournament finishes. It returns nil before the tournament starts.
+func CurrentRound(t *pb.Tournament) *pb.TournamentRound {
+	if len(t.Rounds) == 0 {
+		return nil
+	}
+	return t.Rounds[len(t.Rounds)-1]
+}
+
+// TableOf returns the current round's table for gameID.
+func TableOf(t *pb.Tournament, gameID string) *pb.TournamentTable {
+	round := CurrentRound(t)
+	if round == nil || gameID == "" {
+		return nil
+	}
+	for _, table := range round.Tables {
+		if table.GameId == gameID {
+			return table
+		}
+	}
+	return nil
+}
+
+// RecordResult records the final scores of the table playing gameID; scores
+// and winnerID use the game's player IDs. Once every table in the round has
+// finished the field is cut and next is the newly seated round, or nil if
+// that table was the final and the tournament is over.
+func RecordResult(t *pb.Tournament, gameID string, scores []*pb.PlayerScore, winnerID string) (next *pb.TournamentRound, err error) {
+	if t.Status != pb.TournamentStatus_TOURNAMENT_STATUS_RUNNING {
+		return nil, ErrNotRunning
+	}
+	table := TableOf(t, gameID)
+	if table == nil {
+		return nil, ErrUnknownTable
+	}
+	if table.Finished {
+		return nil, ErrTableFinished
+	}
+	points := make(map[string]int32, len(scores))
+	for _, s := range scores {
+		points[s.PlayerId] = s.Points
+	}
+	for _, seat := range table.Seats {
+		seat.Points = points[seat.PlayerId]
+		if seat.PlayerId == winnerID {
+			table.WinnerId = seat.EntrantId
+		}
+	}
+	table.Finished = true
+	t.Standings = Standings(t)
+
+	round := CurrentRound(t)
+	for _, table := range round.Tables {
+		if !table.Finished {
+			return nil, nil
+		}
+	}
+	return advance(t, round), nil
+}
+
+// advance cuts the field after the finished round and seats the next one.
+// A one-table round is the final and its winner wins the tournament.
+func advance(t *pb.Tournament, round *pb.TournamentRound) *pb.TournamentRound {
+	if len(round.Tables) == 1 {
+		t.Status = pb.TournamentStatus_TOURNAMENT_STATUS_FINISHED
+		t.WinnerId = placement(round.Tables[0])[0]
+		for _, e := range t.Entrants {
+			e.Eliminated = e.Id != t.WinnerId
+		}
+		t.Standings = Standings(t)
+		return nil
+	}
+
+	ranked := fieldByStanding(t, round)
+	size := advancingCount(int(t.AdvancePerTable) * len(round.Tables))
+	qualified := map[string]bool{}
+	if t.Advancement == pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT {
+		for _, table := range round.Tables {
+			for _, id := range placement(table)[:t.AdvancePerTable] {
+				qualified[id] = true
+			}
+		}
+	}
+	// Points advancement, and any wildcards a placement cut needs to leave a
+	// seatable field, go to the best of the rest by standing.
+	for _, id := range ranked {
+		if len(qualified) == size {
+			break
+		}
+		qualified[id] = true
+	}
+
+	field := make([]string, 0, size)
+	for _, id := range ranked {
+		if qualified[id] {
+			field = append(field, id)
+		}
+	}
+	for _, e := range t.Entrants {
+		if !qualified[e.Id] {
+			e.Eliminated = true
+		}
+	}
+	next := seatRound(t, deal(field, tableCount(len(field)), true))
+	t.Standings = Standings(t)
+	return next
+}
+
+// advancingCount rounds the number of entrants going through up to the
+// nearest field that can be seated.
+func advancingCount(n int) int {
+	for !Seatable(n) {
+		n++
+	}
+	return n
+}
+
+// seatRound appends a round with the given tables of entrant IDs.
+func seatRound(t *pb.Tournament, tables [][]string) *pb.TournamentRound {
+	round := &pb.TournamentRound{Number: int32(len(t.Rounds) + 1)}
+	for _, ids := range tables {
+		table := &pb.TournamentTable{}
+		for _, id := range ids {
+			table.Seats = append(table.Seats, &pb.TournamentSeat{EntrantId: id})
+		}
+		round.Tables = append(round.Tables, table)
+	}
+	t.Rounds = append(t.Rounds, round)
+	return round
+}
+
+func tableCount(n int) int {
+	return (n + MaxTableSize - 1) / MaxTableSize
+}
+
+// deal splits field into tables whose sizes differ by at most one. With
+// snake set, entrants are dealt out and back so each table gets a similar
+// mix of strong and weak records; otherwise tables are filled in order.
+func deal(field []string, tables int, snake bool) [][]string {
+	capacity := make([]int, tables)
+	for i := range capacity {
+		capacity[i] = len(field) / tables
+		if i < len(field)%tables {
+			capacity[i]++
+		}
+	}
+	out := make([][]string, tables)
+	if !snake {
+		next := 0
+		for i := range out {
+			out[i] = field[next : next+capacity[i]]
+			next += capacity[i]
+		}
+		return out
+	}
+	i, step := 0, 1
+	for _, id := range field {
+		for len(out[i]) == capacity[i] {
+			i, step = nextSnake(i, step, tables)
+		}
+		out[i] = append(out[i], id)
+		i, step = nextSnake(i, step, tables)
+	}
+	return out
+}
+
+func nextSnake(i, step, tables int) (int, int) {
+	if i+step < 0 || i+step >= tables {
+		return i, -step
+	}
+	return i + step, step
+}
+
+// placement returns a finished table's entrant IDs in finishing order: the
+// winner, then everyone else by points, ties broken by seat order.
+func placement(table *pb.TournamentTable) []string {
+	seats := append([]*pb.TournamentSeat(nil), table.Seats...)
+	sort.SliceStable(seats, func(i, j int) bool {
+		if (seats[i].EntrantId == table.WinnerId) != (seats[j].EntrantId == table.WinnerId) {
+			return seats[i].EntrantId == table.WinnerId
+		}
+		return seats[i].Points > seats[j].Points
+	})
+	ids := make([]string, len(seats))
+	for i, s := range seats {
+		ids[i] = s.EntrantId
+	}
+	return ids
+}
+
+// fieldByStanding returns the entrants seated in round, best standing first.
+func fieldByStanding(t *pb.Tournament, round *pb.TournamentRound) []string {
+	seated := map[string]bool{}
+	for _, table := range round.Tables {
+		for _, s := range table.Seats {
+			seated[s.EntrantId] = true
+		}
+	}
+	var ids []string
+	for _, s := range t.Standings {
+		if seated[s.EntrantId] {
+			ids = append(ids, s.EntrantId)
+		}
+	}
+	return ids
+}
+
+// Standings ranks every entrant from the finished tables: the tournament
+// winner first, then by the furthest round reached, victory points over all
+// games, wins, and finally registration order.
+func Standings(t *pb.Tournament) []*pb.TournamentStanding {
+	type record struct {
+		standing *pb.TournamentStanding
+		reached  int32
+		order    int
+	}
+	records := make(map[string]*record, len(t.Entrants))
+	list := make([]*record, len(t.Entrants))
+	for i, e := range t.Entrants {
+		list[i] = &record{standing: &pb.TournamentStanding{EntrantId: e.Id}, order: i}
+		records[e.Id] = list[i]
+	}
+	for _, round := range t.Rounds {
+		for _, table := range round.Tables {
+			for _, seat := range table.Seats {
+				r, ok := records[seat.EntrantId]
+				if !ok {
+					continue
+				}
+				r.reached = round.Number
+				if !table.Finished {
+					continue
+				}
+				r.standing.Games++
+				r.standing.Points += seat.Points
+				if seat.EntrantId == table.WinnerId {
+					r.standing.Wins++
+				}
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if (a.standing.EntrantId == t.WinnerId) != (b.standing.EntrantId == t.WinnerId) {
+			return a.standing.EntrantId == t.WinnerId
+		}
+		if a.reached != b.reached {
+			return a.reached > b.reached
+		}
+		if a.standing.Points != b.standing.Points {
+			return a.standing.Points > b.standing.Points
+		}
+		if a.standing.Wins != b.standing.Wins {
+			return a.standing.Wins > b.standing.Wins
+		}
+		return a.order < b.order
+	})
+	standings := make([]*pb.TournamentStanding, len(list))
+	for i, r := range list {
+		r.standing.Rank = int32(i + 1)
+		standings[i] = r.standing
+	}
+	return standings
+}
@@ -0,0 +1,270 @@
+package tournament
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	pb "settlers_from_catan/gen/proto/catan/v1"
+)
+
+// newTournament registers n entrants, e1..en, and starts the tournament.
+func newTournament(t *testing.T, n int, advancement pb.TournamentAdvancement, advancePerTable int32) *pb.Tournament {
+	t.Helper()
+	tour, err := New("t1", "League night", "secret", advancement, advancePerTable, 42)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("e%d", i)
+		if err := Register(tour, &pb.TournamentEntrant{Id: id, Name: id}); err != nil {
+			t.Fatalf("Register %s: %v", id, err)
+		}
+	}
+	round, err := Start(tour)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	for i, table := range round.Tables {
+		table.GameId = fmt.Sprintf("r%dg%d", round.Number, i)
+		for _, s := range table.Seats {
+			s.PlayerId = "p-" + s.EntrantId
+		}
+	}
+	return tour
+}
+
+// finishTable reports the table's result with points per entrant ID; the
+// entrant with the most points wins.
+func finishTable(t *testing.T, tour *pb.Tournament, table *pb.TournamentTable, points map[string]int32) *pb.TournamentRound {
+	t.Helper()
+	var scores []*pb.PlayerScore
+	winner, best := "", int32(-1)
+	for _, s := range table.Seats {
+		scores = append(scores, &pb.PlayerScore{PlayerId: s.PlayerId, Points: points[s.EntrantId]})
+		if points[s.EntrantId] > best {
+			winner, best = s.PlayerId, points[s.EntrantId]
+		}
+	}
+	next, err := RecordResult(tour, table.GameId, scores, winner)
+	if err != nil {
+		t.Fatalf("RecordResult %s: %v", table.GameId, err)
+	}
+	if next != nil {
+		for i, table := range next.Tables {
+			table.GameId = fmt.Sprintf("r%dg%d", next.Number, i)
+			for _, s := range table.Seats {
+				s.PlayerId = "p-" + s.EntrantId
+			}
+		}
+	}
+	return next
+}
+
+func tableSizes(round *pb.TournamentRound) []int {
+	var sizes []int
+	for _, table := range round.Tables {
+		sizes = append(sizes, len(table.Seats))
+	}
+	return sizes
+}
+
+func TestStart_SeatsTablesOfThreeOrFour(t *testing.T) {
+	for n, want := range map[int][]int{
+		3:  {3},
+		4:  {4},
+		6:  {3, 3},
+		7:  {4, 3},
+		9:  {3, 3, 3},
+		10: {4, 3, 3},
+		13: {4, 3, 3, 3},
+	} {
+		tour := newTournament(t, n, 0, 0)
+		if got := tableSizes(CurrentRound(tour)); !slices.Equal(got, want) {
+			t.Errorf("%d entrants: expected tables %v, got %v", n, want, got)
+		}
+		if tour.Status != pb.TournamentStatus_TOURNAMENT_STATUS_RUNNING {
+			t.Errorf("%d entrants: expected running, got %v", n, tour.Status)
+		}
+	}
+}
+
+func TestStart_SameSeedSameSeating(t *testing.T) {
+	a := newTournament(t, 8, 0, 0)
+	b := newTournament(t, 8, 0, 0)
+	for i := range a.Rounds[0].Tables {
+		for j, seat := range a.Rounds[0].Tables[i].Seats {
+			if other := b.Rounds[0].Tables[i].Seats[j]; other.EntrantId != seat.EntrantId {
+				t.Fatalf("expected identical seating from the same seed")
+			}
+		}
+	}
+}
+
+func TestStart_Rejects(t *testing.T) {
+	for _, n := range []int{0, 2, 5} {
+		tour, _ := New("t1", "x", "secret", 0, 0, 1)
+		for i := 0; i < n; i++ {
+			Register(tour, &pb.TournamentEntrant{Id: fmt.Sprintf("e%d", i)})
+		}
+		if _, err := Start(tour); err != ErrCannotSeat {
+			t.Errorf("%d entrants: expected ErrCannotSeat, got %v", n, err)
+		}
+	}
+	tour := newTournament(t, 3, 0, 0)
+	if err := Register(tour, &pb.TournamentEntrant{Id: "late"}); err != ErrRegistrationClosed {
+		t.Errorf("expected ErrRegistrationClosed, got %v", err)
+	}
+	if _, err := Start(tour); err != ErrRegistrationClosed {
+		t.Errorf("expected a second start to fail, got %v", err)
+	}
+	if _, err := New("t2", "x", "secret", 0, 3, 1); err != ErrInvalidAdvancement {
+		t.Errorf("expected ErrInvalidAdvancement, got %v", err)
+	}
+}
+
+func TestRegister_RejectsSameUserTwice(t *testing.T) {
+	tour, _ := New("t1", "x", "secret", 0, 0, 1)
+	if err := Register(tour, &pb.TournamentEntrant{Id: "e1", UserId: "u1"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := Register(tour, &pb.TournamentEntrant{Id: "e2", UserId: "u1"}); err != ErrDuplicateEntrant {
+		t.Errorf("expected ErrDuplicateEntrant, got %v", err)
+	}
+}
+
+func TestPlacementAdvancement_RunsToAFinal(t *testing.T) {
+	tour := newTournament(t, 8, pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT, 2)
+	round1 := CurrentRound(tour)
+	var advancing []string
+	for _, table := range round1.Tables {
+		points := map[string]int32{}
+		for i, s := range table.Seats {
+			points[s.EntrantId] = int32(10 - 2*i)
+		}
+		advancing = append(advancing, table.Seats[0].EntrantId, table.Seats[1].EntrantId)
+		if next := finishTable(t, tour, table, points); next != nil && table != round1.Tables[len(round1.Tables)-1] {
+			t.Fatalf("expected no new round before every table finishes")
+		}
+	}
+
+	final := CurrentRound(tour)
+	if final.Number != 2 || len(final.Tables) != 1 {
+		t.Fatalf("expected a single-table final, got round %d with %v", final.Number, tableSizes(final))
+	}
+	var seated []string
+	for _, s := range final.Tables[0].Seats {
+		seated = append(seated, s.EntrantId)
+	}
+	slices.Sort(seated)
+	slices.Sort(advancing)
+	if !slices.Equal(seated, advancing) {
+		t.Fatalf("expected the top two of each table in the final, got %v want %v", seated, advancing)
+	}
+
+	champion := round1.Tables[0].Seats[0].EntrantId
+	points := map[string]int32{}
+	for i, s := range final.Tables[0].Seats {
+		points[s.EntrantId] = int32(4 + i)
+	}
+	points[champion] = 10
+	if next := finishTable(t, tour, final.Tables[0], points); next != nil {
+		t.Fatalf("expected no round after the final")
+	}
+	if tour.Status != pb.TournamentStatus_TOURNAMENT_STATUS_FINISHED || tour.WinnerId != champion {
+		t.Fatalf("expected %s to win, got %v %q", champion, tour.Status, tour.WinnerId)
+	}
+	top := tour.Standings[0]
+	if top.EntrantId != champion || top.Rank != 1 || top.Wins != 2 || top.Games != 2 || top.Points != 20 {
+		t.Errorf("expected the winner on top with 2 wins and 20 points, got %+v", top)
+	}
+	for _, s := range tour.Standings[1:4] {
+		if !slices.Contains(seated, s.EntrantId) {
+			t.Errorf("expected finalists to rank above everyone else, got %+v", tour.Standings)
+		}
+	}
+	for _, e := range tour.Entrants {
+		if e.Eliminated != (e.Id != tour.WinnerId) {
+			t.Errorf("expected only the winner left in, got %s eliminated=%v", e.Id, e.Eliminated)
+		}
+	}
+	if _, err := RecordResult(tour, final.Tables[0].GameId, nil, ""); err != ErrNotRunning {
+		t.Errorf("expected ErrNotRunning after the final, got %v", err)
+	}
+}
+
+func TestPointsAdvancement_TakesTheBestTotals(t *testing.T) {
+	tour := newTournament(t, 9, pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_POINTS, 1)
+	round1 := CurrentRound(tour)
+	// Hand out points so the three best totals all sit at the first table.
+	for i, table := range round1.Tables {
+		points := map[string]int32{}
+		for j, s := range table.Seats {
+			if i == 0 {
+				points[s.EntrantId] = int32(10 - j)
+			} else {
+				points[s.EntrantId] = int32(5 - j)
+			}
+		}
+		finishTable(t, tour, table, points)
+	}
+
+	final := CurrentRound(tour)
+	if final.Number != 2 || !slices.Equal(tableSizes(final), []int{3}) {
+		t.Fatalf("expected a final of three, got %v", tableSizes(final))
+	}
+	for _, s := range final.Tables[0].Seats {
+		if !slices.ContainsFunc(round1.Tables[0].Seats, func(seat *pb.TournamentSeat) bool { return seat.EntrantId == s.EntrantId }) {
+			t.Errorf("expected only the first table's players to advance on points, got %s", s.EntrantId)
+		}
+	}
+}
+
+func TestPlacementAdvancement_AddsWildcardsToSeatTheField(t *testing.T) {
+	// Two tables sending one each would leave a field of two; the best
+	// runner-up by points goes through too.
+	tour := newTournament(t, 6, pb.TournamentAdvancement_TOURNAMENT_ADVANCEMENT_PLACEMENT, 1)
+	round1 := CurrentRound(tour)
+	finishTable(t, tour, round1.Tables[0], map[string]int32{
+		round1.Tables[0].Seats[0].EntrantId: 10,
+		round1.Tables[0].Seats[1].EntrantId: 9,
+		round1.Tables[0].Seats[2].EntrantId: 3,
+	})
+	finishTable(t, tour, round1.Tables[1], map[string]int32{
+		round1.Tables[1].Seats[0].EntrantId: 10,
+		round1.Tables[1].Seats[1].EntrantId: 4,
+		round1.Tables[1].Seats[2].EntrantId: 2,
+	})
+
+	final := CurrentRound(tour)
+	if len(final.Tables) != 1 || len(final.Tables[0].Seats) != 3 {
+		t.Fatalf("expected a final of three, got %v", tableSizes(final))
+	}
+	wildcard := round1.Tables[0].Seats[1].EntrantId
+	if !slices.ContainsFunc(final.Tables[0].Seats, func(s *pb.TournamentSeat) bool { return s.EntrantId == wildcard }) {
+		t.Errorf("expected %s through as the wildcard", wildcard)
+	}
+}
+
+func TestRecordResult_Rejects(t *testing.T) {
+	tour := newTournament(t, 6, 0, 0)
+	table := CurrentRound(tour).Tables[0]
+	if _, err := RecordResult(tour, "nope", nil, ""); err != ErrUnknownTable {
+		t.Errorf("expected ErrUnknownTable, got %v", err)
+	}
+	finishTable(t, tour, table, map[string]int32{table.Seats[0].EntrantId: 10})
+	if _, err := RecordResult(tour, table.GameId, nil, ""); err != ErrTableFinished {
+		t.Errorf("expected ErrTableFinished, got %v", err)
+	}
+}
+
+func TestDeal_SnakesByStanding(t *testing.T) {
+	got := deal([]string{"a", "b", "c", "d", "e", "f", "g", "h"}, 2, true)
+	if !slices.Equal(got[0], []string{"a", "d", "e", "h"}) || !slices.Equal(got[1], []string{"b", "c", "f", "g"}) {
+		t.Errorf("expected snake seating, got %v", got)
+	}
+	got = deal([]string{"a", "b", "c", "d", "e", "f", "g"}, 2, true)
+	if len(got[0]) != 4 || len(got[1]) != 3 {
+		t.Errorf("expected tables of 4 and 3, got %v", got)
+	}
+}
@@ -10,6 +10,8 @@ import { UnknownFieldHandler } from "@protobuf-ts/runtime";
 import type { PartialMessage } from "@protobuf-ts/runtime";
 import { reflectionMergePartial } from "@protobuf-ts/runtime";
 import { MessageType } from "@protobuf-ts/runtime";
+import { ErrorCode } from "./types";
+import { Series } from "./types";
 import { TradeOffer } from "./types";
 import { BuildingType } from "./types";
 import { PlayerState } from "./types";
@@ -34,6 +36,37 @@ export interface BankTradeMessage {
      * @generated from protobuf field: catan.v1.Resource resource_requested = 2
      */
     resourceRequested: Resource;
+    /**
+     * When set, the trade is made of these lines instead of offering and
+     * resource_requested, and is applied all or nothing.
+     *
+     * @generated from protobuf field: repeated catan.v1.BankTradeLine lines = 3
+     */
+    lines: BankTradeLine[];
+}
+/**
+ * BankTradeLine gives offering_count cards of offering for requested_count
+ * cards of requested, at the player's best ratio for offering.
+ *
+ * @generated from protobuf message catan.v1.BankTradeLine
+ */
+export interface BankTradeLine {
+    /**
+     * @generated from protobuf field: catan.v1.Resource offering = 1
+     */
+    offering: Resource;
+    /**
+     * @generated from protobuf field: int32 offering_count = 2
+     */
+    offeringCount: number;
+    /**
+     * @generated from protobuf field: catan.v1.Resource requested = 3
+     */
+    requested: Resource;
+    /**
+     * @generated from protobuf field: int32 requested_count = 4
+     */
+    requestedCount: number;
 }
 /**
  * @generated from protobuf message catan.v1.SetTurnPhaseMessage
@@ -168,6 +201,73 @@ export interface DiscardCardsMessage {
      */
     resources?: ResourceCount;
 }
+/**
+ * Agrees to export or fork the unfinished game, which reveals every hidden
+ * card and the deck order.
+ *
+ * @generated from protobuf message catan.v1.ApproveExportMessage
+ */
+export interface ApproveExportMessage {
+}
+/**
+ * Sends a chat line to the whole game, or only to recipient_id as a whisper.
+ *
+ * @generated from protobuf message catan.v1.SendChatMessage
+ */
+export interface SendChatMessage {
+    /**
+     * @generated from protobuf field: string text = 1
+     */
+    text: string;
+    /**
+     * @generated from protobuf field: optional string recipient_id = 2
+     */
+    recipientId?: string;
+}
+/**
+ * Host only: mutes or unmutes a player in chat.
+ *
+ * @generated from protobuf message catan.v1.MuteChatMessage
+ */
+export interface MuteChatMessage {
+    /**
+     * @generated from protobuf field: string player_id = 1
+     */
+    playerId: string;
+    /**
+     * @generated from protobuf field: bool muted = 2
+     */
+    muted: boolean;
+}
+/**
+ * Host only: changes the game's chat settings.
+ *
+ * @generated from protobuf message catan.v1.SetChatSettingsMessage
+ */
+export interface SetChatSettingsMessage {
+    /**
+     * @generated from protobuf field: bool spectator_chat_disabled = 1
+     */
+    spectatorChatDisabled: boolean;
+}
+/**
+ * Asks the other players to undo your last build or bank trade this turn.
+ *
+ * @generated from protobuf message catan.v1.RequestTakebackMessage
+ */
+export interface RequestTakebackMessage {
+}
+/**
+ * Votes on the pending takeback; a single rejection cancels it.
+ *
+ * @generated from protobuf message catan.v1.RespondTakebackMessage
+ */
+export interface RespondTakebackMessage {
+    /**
+     * @generated from protobuf field: bool approve = 1
+     */
+    approve: boolean;
+}
 /**
  * Wrapper for all client messages
  *
@@ -261,6 +361,42 @@ export interface ClientMessage {
          * @generated from protobuf field: catan.v1.BuyDevCardMessage buy_dev_card = 14
          */
         buyDevCard: BuyDevCardMessage;
+    } | {
+        oneofKind: "approveExport";
+        /**
+         * @generated from protobuf field: catan.v1.ApproveExportMessage approve_export = 15
+         */
+        approveExport: ApproveExportMessage;
+    } | {
+        oneofKind: "sendChat";
+        /**
+         * @generated from protobuf field: catan.v1.SendChatMessage send_chat = 16
+         */
+        sendChat: SendChatMessage;
+    } | {
+        oneofKind: "muteChat";
+        /**
+         * @generated from protobuf field: catan.v1.MuteChatMessage mute_chat = 17
+         */
+        muteChat: MuteChatMessage;
+    } | {
+        oneofKind: "setChatSettings";
+        /**
+         * @generated from protobuf field: catan.v1.SetChatSettingsMessage set_chat_settings = 18
+         */
+        setChatSettings: SetChatSettingsMessage;
+    } | {
+        oneofKind: "requestTakeback";
+        /**
+         * @generated from protobuf field: catan.v1.RequestTakebackMessage request_takeback = 19
+         */
+        requestTakeback: RequestTakebackMessage;
+    } | {
+        oneofKind: "respondTakeback";
+        /**
+         * @generated from protobuf field: catan.v1.RespondTakebackMessage respond_takeback = 20
+         */
+        respondTakeback: RespondTakebackMessage;
     } | {
         oneofKind: undefined;
     };
@@ -461,6 +597,26 @@ export interface GameOverPayload {
      * @generated from protobuf field: repeated catan.v1.PlayerScore scores = 2
      */
     scores: PlayerScore[];
+    /**
+     * @generated from protobuf field: catan.v1.Series series = 3
+     */
+    series?: Series; // Present for rematches, counting this game
+}
+/**
+ * Sent to a finished game's players once someone starts its rematch. Each
+ * player claims their new seat with POST /api/games/{code}/rematch.
+ *
+ * @generated from protobuf message catan.v1.RematchPayload
+ */
+export interface RematchPayload {
+    /**
+     * @generated from protobuf field: string game_id = 1
+     */
+    gameId: string;
+    /**
+     * @generated from protobuf field: string code = 2
+     */
+    code: string;
 }
 /**
  * @generated from protobuf message catan.v1.ErrorPayload
@@ -474,6 +630,22 @@ export interface ErrorPayload {
      * @generated from protobuf field: string message = 2
      */
     message: string;
+    /**
+     * @generated from protobuf field: catan.v1.ErrorCode error_code = 3
+     */
+    errorCode: ErrorCode;
+    /**
+     * Set for ERROR_CODE_INSUFFICIENT_RESOURCES: the cards the command needed
+     * and the cards the player had. For ERROR_CODE_BANK_EMPTY, held is what
+     * the bank had.
+     *
+     * @generated from protobuf field: catan.v1.ResourceCount required = 4
+     */
+    required?: ResourceCount;
+    /**
+     * @generated from protobuf field: catan.v1.ResourceCount held = 5
+     */
+    held?: ResourceCount;
 }
 /**
  * Server confirms that a player has discarded cards (could be broadcasted).
@@ -505,6 +677,44 @@ export interface DevCardBoughtPayload {
      */
     cardType: DevCardType; // Only visible to buying player
 }
+/**
+ * A chat line as delivered to clients.
+ *
+ * @generated from protobuf message catan.v1.ChatMessagePayload
+ */
+export interface ChatMessagePayload {
+    /**
+     * @generated from protobuf field: int64 seq = 1
+     */
+    seq: bigint;
+    /**
+     * @generated from protobuf field: string sender_id = 2
+     */
+    senderId: string;
+    /**
+     * @generated from protobuf field: optional string recipient_id = 3
+     */
+    recipientId?: string; // Set for whispers
+    /**
+     * @generated from protobuf field: string text = 4
+     */
+    text: string;
+    /**
+     * @generated from protobuf field: int64 sent_at = 5
+     */
+    sentAt: bigint; // Unix milliseconds
+}
+/**
+ * The chat lines a player can see, oldest first. Sent on connect.
+ *
+ * @generated from protobuf message catan.v1.ChatHistoryPayload
+ */
+export interface ChatHistoryPayload {
+    /**
+     * @generated from protobuf field: repeated catan.v1.ChatMessagePayload messages = 1
+     */
+    messages: ChatMessagePayload[];
+}
 /**
  * Wrapper for all server messages
  *
@@ -610,6 +820,24 @@ export interface ServerMessage {
          * @generated from protobuf field: catan.v1.DevCardBoughtPayload dev_card_bought = 16
          */
         devCardBought: DevCardBoughtPayload;
+    } | {
+        oneofKind: "chatMessage";
+        /**
+         * @generated from protobuf field: catan.v1.ChatMessagePayload chat_message = 17
+         */
+        chatMessage: ChatMessagePayload;
+    } | {
+        oneofKind: "chatHistory";
+        /**
+         * @generated from protobuf field: catan.v1.ChatHistoryPayload chat_history = 18
+         */
+        chatHistory: ChatHistoryPayload;
+    } | {
+        oneofKind: "rematch";
+        /**
+         * @generated from protobuf field: catan.v1.RematchPayload rematch = 19
+         */
+        rematch: RematchPayload;
     } | {
         oneofKind: undefined;
     };
@@ -619,12 +847,14 @@ class BankTradeMessage$Type extends MessageType<BankTradeMessage> {
     constructor() {
         super("catan.v1.BankTradeMessage", [
             { no: 1, name: "offering", kind: "message", T: () => ResourceCount },
-            { no: 2, name: "resource_requested", kind: "enum", T: () => ["catan.v1.Resource", Resource, "RESOURCE_"] }
+            { no: 2, name: "resource_requested", kind: "enum", T: () => ["catan.v1.Resource", Resource, "RESOURCE_"] },
+            { no: 3, name: "lines", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => BankTradeLine }
         ]);
     }
     create(value?: PartialMessage<BankTradeMessage>): BankTradeMessage {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.resourceRequested = 0;
+        message.lines = [];
         if (value !== undefined)
             reflectionMergePartial<BankTradeMessage>(this, message, value);
         return message;
@@ -640,6 +870,9 @@ class BankTradeMessage$Type extends MessageType<BankTradeMessage> {
                 case /* catan.v1.Resource resource_requested */ 2:
                     message.resourceRequested = reader.int32();
                     break;
+                case /* repeated catan.v1.BankTradeLine lines */ 3:
+                    message.lines.push(BankTradeLine.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -658,6 +891,9 @@ class BankTradeMessage$Type extends MessageType<BankTradeMessage> {
         /* catan.v1.Resource resource_requested = 2; */
         if (message.resourceRequested !== 0)
             writer.tag(2, WireType.Varint).int32(message.resourceRequested);
+        /* repeated catan.v1.BankTradeLine lines = 3; */
+        for (let i = 0; i < message.lines.length; i++)
+            BankTradeLine.internalBinaryWrite(message.lines[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -669,6 +905,77 @@ class BankTradeMessage$Type extends MessageType<BankTradeMessage> {
  */
 export const BankTradeMessage = new BankTradeMessage$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class BankTradeLine$Type extends MessageType<BankTradeLine> {
+    constructor() {
+        super("catan.v1.BankTradeLine", [
+            { no: 1, name: "offering", kind: "enum", T: () => ["catan.v1.Resource", Resource, "RESOURCE_"] },
+            { no: 2, name: "offering_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "requested", kind: "enum", T: () => ["catan.v1.Resource", Resource, "RESOURCE_"] },
+            { no: 4, name: "requested_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<BankTradeLine>): BankTradeLine {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.offering = 0;
+        message.offeringCount = 0;
+        message.requested = 0;
+        message.requestedCount = 0;
+        if (value !== undefined)
+            reflectionMergePartial<BankTradeLine>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: BankTradeLine): BankTradeLine {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* catan.v1.Resource offering */ 1:
+                    message.offering = reader.int32();
+                    break;
+                case /* int32 offering_count */ 2:
+                    message.offeringCount = reader.int32();
+                    break;
+                case /* catan.v1.Resource requested */ 3:
+                    message.requested = reader.int32();
+                    break;
+                case /* int32 requested_count */ 4:
+                    message.requestedCount = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: BankTradeLine, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* catan.v1.Resource offering = 1; */
+        if (message.offering !== 0)
+            writer.tag(1, WireType.Varint).int32(message.offering);
+        /* int32 offering_count = 2; */
+        if (message.offeringCount !== 0)
+            writer.tag(2, WireType.Varint).int32(message.offeringCount);
+        /* catan.v1.Resource requested = 3; */
+        if (message.requested !== 0)
+            writer.tag(3, WireType.Varint).int32(message.requested);
+        /* int32 requested_count = 4; */
+        if (message.requestedCount !== 0)
+            writer.tag(4, WireType.Varint).int32(message.requestedCount);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.BankTradeLine
+ */
+export const BankTradeLine = new BankTradeLine$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class SetTurnPhaseMessage$Type extends MessageType<SetTurnPhaseMessage> {
     constructor() {
         super("catan.v1.SetTurnPhaseMessage", [
@@ -1301,6 +1608,285 @@ class DiscardCardsMessage$Type extends MessageType<DiscardCardsMessage> {
  */
 export const DiscardCardsMessage = new DiscardCardsMessage$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class ApproveExportMessage$Type extends MessageType<ApproveExportMessage> {
+    constructor() {
+        super("catan.v1.ApproveExportMessage", []);
+    }
+    create(value?: PartialMessage<ApproveExportMessage>): ApproveExportMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<ApproveExportMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ApproveExportMessage): ApproveExportMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ApproveExportMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.ApproveExportMessage
+ */
+export const ApproveExportMessage = new ApproveExportMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SendChatMessage$Type extends MessageType<SendChatMessage> {
+    constructor() {
+        super("catan.v1.SendChatMessage", [
+            { no: 1, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "recipient_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SendChatMessage>): SendChatMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.text = "";
+        if (value !== undefined)
+            reflectionMergePartial<SendChatMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SendChatMessage): SendChatMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string text */ 1:
+                    message.text = reader.string();
+                    break;
+                case /* optional string recipient_id */ 2:
+                    message.recipientId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SendChatMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string text = 1; */
+        if (message.text !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.text);
+        /* optional string recipient_id = 2; */
+        if (message.recipientId !== undefined)
+            writer.tag(2, WireType.LengthDelimited).string(message.recipientId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.SendChatMessage
+ */
+export const SendChatMessage = new SendChatMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class MuteChatMessage$Type extends MessageType<MuteChatMessage> {
+    constructor() {
+        super("catan.v1.MuteChatMessage", [
+            { no: 1, name: "player_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "muted", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<MuteChatMessage>): MuteChatMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.playerId = "";
+        message.muted = false;
+        if (value !== undefined)
+            reflectionMergePartial<MuteChatMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: MuteChatMessage): MuteChatMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string player_id */ 1:
+                    message.playerId = reader.string();
+                    break;
+                case /* bool muted */ 2:
+                    message.muted = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: MuteChatMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string player_id = 1; */
+        if (message.playerId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.playerId);
+        /* bool muted = 2; */
+        if (message.muted !== false)
+            writer.tag(2, WireType.Varint).bool(message.muted);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.MuteChatMessage
+ */
+export const MuteChatMessage = new MuteChatMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class SetChatSettingsMessage$Type extends MessageType<SetChatSettingsMessage> {
+    constructor() {
+        super("catan.v1.SetChatSettingsMessage", [
+            { no: 1, name: "spectator_chat_disabled", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<SetChatSettingsMessage>): SetChatSettingsMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.spectatorChatDisabled = false;
+        if (value !== undefined)
+            reflectionMergePartial<SetChatSettingsMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: SetChatSettingsMessage): SetChatSettingsMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* bool spectator_chat_disabled */ 1:
+                    message.spectatorChatDisabled = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: SetChatSettingsMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bool spectator_chat_disabled = 1; */
+        if (message.spectatorChatDisabled !== false)
+            writer.tag(1, WireType.Varint).bool(message.spectatorChatDisabled);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.SetChatSettingsMessage
+ */
+export const SetChatSettingsMessage = new SetChatSettingsMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RequestTakebackMessage$Type extends MessageType<RequestTakebackMessage> {
+    constructor() {
+        super("catan.v1.RequestTakebackMessage", []);
+    }
+    create(value?: PartialMessage<RequestTakebackMessage>): RequestTakebackMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        if (value !== undefined)
+            reflectionMergePartial<RequestTakebackMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RequestTakebackMessage): RequestTakebackMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RequestTakebackMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.RequestTakebackMessage
+ */
+export const RequestTakebackMessage = new RequestTakebackMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RespondTakebackMessage$Type extends MessageType<RespondTakebackMessage> {
+    constructor() {
+        super("catan.v1.RespondTakebackMessage", [
+            { no: 1, name: "approve", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<RespondTakebackMessage>): RespondTakebackMessage {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.approve = false;
+        if (value !== undefined)
+            reflectionMergePartial<RespondTakebackMessage>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RespondTakebackMessage): RespondTakebackMessage {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* bool approve */ 1:
+                    message.approve = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RespondTakebackMessage, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* bool approve = 1; */
+        if (message.approve !== false)
+            writer.tag(1, WireType.Varint).bool(message.approve);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.RespondTakebackMessage
+ */
+export const RespondTakebackMessage = new RespondTakebackMessage$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class ClientMessage$Type extends MessageType<ClientMessage> {
     constructor() {
         super("catan.v1.ClientMessage", [
@@ -1317,7 +1903,13 @@ class ClientMessage$Type extends MessageType<ClientMessage> {
             { no: 11, name: "discard_cards", kind: "message", oneof: "message", T: () => DiscardCardsMessage },
             { no: 12, name: "bank_trade", kind: "message", oneof: "message", T: () => BankTradeMessage },
             { no: 13, name: "set_turn_phase", kind: "message", oneof: "message", T: () => SetTurnPhaseMessage },
-            { no: 14, name: "buy_dev_card", kind: "message", oneof: "message", T: () => BuyDevCardMessage }
+            { no: 14, name: "buy_dev_card", kind: "message", oneof: "message", T: () => BuyDevCardMessage },
+            { no: 15, name: "approve_export", kind: "message", oneof: "message", T: () => ApproveExportMessage },
+            { no: 16, name: "send_chat", kind: "message", oneof: "message", T: () => SendChatMessage },
+            { no: 17, name: "mute_chat", kind: "message", oneof: "message", T: () => MuteChatMessage },
+            { no: 18, name: "set_chat_settings", kind: "message", oneof: "message", T: () => SetChatSettingsMessage },
+            { no: 19, name: "request_takeback", kind: "message", oneof: "message", T: () => RequestTakebackMessage },
+            { no: 20, name: "respond_takeback", kind: "message", oneof: "message", T: () => RespondTakebackMessage }
         ]);
     }
     create(value?: PartialMessage<ClientMessage>): ClientMessage {
@@ -1416,6 +2008,42 @@ class ClientMessage$Type extends MessageType<ClientMessage> {
                         buyDevCard: BuyDevCardMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).buyDevCard)
                     };
                     break;
+                case /* catan.v1.ApproveExportMessage approve_export */ 15:
+                    message.message = {
+                        oneofKind: "approveExport",
+                        approveExport: ApproveExportMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).approveExport)
+                    };
+                    break;
+                case /* catan.v1.SendChatMessage send_chat */ 16:
+                    message.message = {
+                        oneofKind: "sendChat",
+                        sendChat: SendChatMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).sendChat)
+                    };
+                    break;
+                case /* catan.v1.MuteChatMessage mute_chat */ 17:
+                    message.message = {
+                        oneofKind: "muteChat",
+                        muteChat: MuteChatMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).muteChat)
+                    };
+                    break;
+                case /* catan.v1.SetChatSettingsMessage set_chat_settings */ 18:
+                    message.message = {
+                        oneofKind: "setChatSettings",
+                        setChatSettings: SetChatSettingsMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).setChatSettings)
+                    };
+                    break;
+                case /* catan.v1.RequestTakebackMessage request_takeback */ 19:
+                    message.message = {
+                        oneofKind: "requestTakeback",
+                        requestTakeback: RequestTakebackMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).requestTakeback)
+                    };
+                    break;
+                case /* catan.v1.RespondTakebackMessage respond_takeback */ 20:
+                    message.message = {
+                        oneofKind: "respondTakeback",
+                        respondTakeback: RespondTakebackMessage.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).respondTakeback)
+                    };
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1470,6 +2098,24 @@ class ClientMessage$Type extends MessageType<ClientMessage> {
         /* catan.v1.BuyDevCardMessage buy_dev_card = 14; */
         if (message.message.oneofKind === "buyDevCard")
             BuyDevCardMessage.internalBinaryWrite(message.message.buyDevCard, writer.tag(14, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.ApproveExportMessage approve_export = 15; */
+        if (message.message.oneofKind === "approveExport")
+            ApproveExportMessage.internalBinaryWrite(message.message.approveExport, writer.tag(15, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.SendChatMessage send_chat = 16; */
+        if (message.message.oneofKind === "sendChat")
+            SendChatMessage.internalBinaryWrite(message.message.sendChat, writer.tag(16, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.MuteChatMessage mute_chat = 17; */
+        if (message.message.oneofKind === "muteChat")
+            MuteChatMessage.internalBinaryWrite(message.message.muteChat, writer.tag(17, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.SetChatSettingsMessage set_chat_settings = 18; */
+        if (message.message.oneofKind === "setChatSettings")
+            SetChatSettingsMessage.internalBinaryWrite(message.message.setChatSettings, writer.tag(18, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.RequestTakebackMessage request_takeback = 19; */
+        if (message.message.oneofKind === "requestTakeback")
+            RequestTakebackMessage.internalBinaryWrite(message.message.requestTakeback, writer.tag(19, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.RespondTakebackMessage respond_takeback = 20; */
+        if (message.message.oneofKind === "respondTakeback")
+            RespondTakebackMessage.internalBinaryWrite(message.message.respondTakeback, writer.tag(20, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2254,7 +2900,8 @@ class GameOverPayload$Type extends MessageType<GameOverPayload> {
     constructor() {
         super("catan.v1.GameOverPayload", [
             { no: 1, name: "winner_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "scores", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => PlayerScore }
+            { no: 2, name: "scores", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => PlayerScore },
+            { no: 3, name: "series", kind: "message", T: () => Series }
         ]);
     }
     create(value?: PartialMessage<GameOverPayload>): GameOverPayload {
@@ -2276,6 +2923,9 @@ class GameOverPayload$Type extends MessageType<GameOverPayload> {
                 case /* repeated catan.v1.PlayerScore scores */ 2:
                     message.scores.push(PlayerScore.internalBinaryRead(reader, reader.uint32(), options));
                     break;
+                case /* catan.v1.Series series */ 3:
+                    message.series = Series.internalBinaryRead(reader, reader.uint32(), options, message.series);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2294,6 +2944,9 @@ class GameOverPayload$Type extends MessageType<GameOverPayload> {
         /* repeated catan.v1.PlayerScore scores = 2; */
         for (let i = 0; i < message.scores.length; i++)
             PlayerScore.internalBinaryWrite(message.scores[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.Series series = 3; */
+        if (message.series)
+            Series.internalBinaryWrite(message.series, writer.tag(3, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2305,17 +2958,76 @@ class GameOverPayload$Type extends MessageType<GameOverPayload> {
  */
 export const GameOverPayload = new GameOverPayload$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class RematchPayload$Type extends MessageType<RematchPayload> {
+    constructor() {
+        super("catan.v1.RematchPayload", [
+            { no: 1, name: "game_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "code", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<RematchPayload>): RematchPayload {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.gameId = "";
+        message.code = "";
+        if (value !== undefined)
+            reflectionMergePartial<RematchPayload>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RematchPayload): RematchPayload {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string game_id */ 1:
+                    message.gameId = reader.string();
+                    break;
+                case /* string code */ 2:
+                    message.code = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RematchPayload, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string game_id = 1; */
+        if (message.gameId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.gameId);
+        /* string code = 2; */
+        if (message.code !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.code);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.RematchPayload
+ */
+export const RematchPayload = new RematchPayload$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class ErrorPayload$Type extends MessageType<ErrorPayload> {
     constructor() {
         super("catan.v1.ErrorPayload", [
             { no: 1, name: "code", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 2, name: "message", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "error_code", kind: "enum", T: () => ["catan.v1.ErrorCode", ErrorCode, "ERROR_CODE_"] },
+            { no: 4, name: "required", kind: "message", T: () => ResourceCount },
+            { no: 5, name: "held", kind: "message", T: () => ResourceCount }
         ]);
     }
     create(value?: PartialMessage<ErrorPayload>): ErrorPayload {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.code = "";
         message.message = "";
+        message.errorCode = 0;
         if (value !== undefined)
             reflectionMergePartial<ErrorPayload>(this, message, value);
         return message;
@@ -2331,6 +3043,15 @@ class ErrorPayload$Type extends MessageType<ErrorPayload> {
                 case /* string message */ 2:
                     message.message = reader.string();
                     break;
+                case /* catan.v1.ErrorCode error_code */ 3:
+                    message.errorCode = reader.int32();
+                    break;
+                case /* catan.v1.ResourceCount required */ 4:
+                    message.required = ResourceCount.internalBinaryRead(reader, reader.uint32(), options, message.required);
+                    break;
+                case /* catan.v1.ResourceCount held */ 5:
+                    message.held = ResourceCount.internalBinaryRead(reader, reader.uint32(), options, message.held);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2349,6 +3070,15 @@ class ErrorPayload$Type extends MessageType<ErrorPayload> {
         /* string message = 2; */
         if (message.message !== "")
             writer.tag(2, WireType.LengthDelimited).string(message.message);
+        /* catan.v1.ErrorCode error_code = 3; */
+        if (message.errorCode !== 0)
+            writer.tag(3, WireType.Varint).int32(message.errorCode);
+        /* catan.v1.ResourceCount required = 4; */
+        if (message.required)
+            ResourceCount.internalBinaryWrite(message.required, writer.tag(4, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.ResourceCount held = 5; */
+        if (message.held)
+            ResourceCount.internalBinaryWrite(message.held, writer.tag(5, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2469,6 +3199,131 @@ class DevCardBoughtPayload$Type extends MessageType<DevCardBoughtPayload> {
  */
 export const DevCardBoughtPayload = new DevCardBoughtPayload$Type();
 // @generated message type with reflection information, may provide speed optimized methods
+class ChatMessagePayload$Type extends MessageType<ChatMessagePayload> {
+    constructor() {
+        super("catan.v1.ChatMessagePayload", [
+            { no: 1, name: "seq", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 2, name: "sender_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "recipient_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "text", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "sent_at", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        ]);
+    }
+    create(value?: PartialMessage<ChatMessagePayload>): ChatMessagePayload {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.seq = 0n;
+        message.senderId = "";
+        message.text = "";
+        message.sentAt = 0n;
+        if (value !== undefined)
+            reflectionMergePartial<ChatMessagePayload>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ChatMessagePayload): ChatMessagePayload {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* int64 seq */ 1:
+                    message.seq = reader.int64().toBigInt();
+                    break;
+                case /* string sender_id */ 2:
+                    message.senderId = reader.string();
+                    break;
+                case /* optional string recipient_id */ 3:
+                    message.recipientId = reader.string();
+                    break;
+                case /* string text */ 4:
+                    message.text = reader.string();
+                    break;
+                case /* int64 sent_at */ 5:
+                    message.sentAt = reader.int64().toBigInt();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ChatMessagePayload, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int64 seq = 1; */
+        if (message.seq !== 0n)
+            writer.tag(1, WireType.Varint).int64(message.seq);
+        /* string sender_id = 2; */
+        if (message.senderId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.senderId);
+        /* optional string recipient_id = 3; */
+        if (message.recipientId !== undefined)
+            writer.tag(3, WireType.LengthDelimited).string(message.recipientId);
+        /* string text = 4; */
+        if (message.text !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.text);
+        /* int64 sent_at = 5; */
+        if (message.sentAt !== 0n)
+            writer.tag(5, WireType.Varint).int64(message.sentAt);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.ChatMessagePayload
+ */
+export const ChatMessagePayload = new ChatMessagePayload$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ChatHistoryPayload$Type extends MessageType<ChatHistoryPayload> {
+    constructor() {
+        super("catan.v1.ChatHistoryPayload", [
+            { no: 1, name: "messages", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => ChatMessagePayload }
+        ]);
+    }
+    create(value?: PartialMessage<ChatHistoryPayload>): ChatHistoryPayload {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.messages = [];
+        if (value !== undefined)
+            reflectionMergePartial<ChatHistoryPayload>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ChatHistoryPayload): ChatHistoryPayload {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated catan.v1.ChatMessagePayload messages */ 1:
+                    message.messages.push(ChatMessagePayload.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ChatHistoryPayload, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated catan.v1.ChatMessagePayload messages = 1; */
+        for (let i = 0; i < message.messages.length; i++)
+            ChatMessagePayload.internalBinaryWrite(message.messages[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.ChatHistoryPayload
+ */
+export const ChatHistoryPayload = new ChatHistoryPayload$Type();
+// @generated message type with reflection information, may provide speed optimized methods
 class ServerMessage$Type extends MessageType<ServerMessage> {
     constructor() {
         super("catan.v1.ServerMessage", [
@@ -2487,7 +3342,10 @@ class ServerMessage$Type extends MessageType<ServerMessage> {
             { no: 13, name: "error", kind: "message", oneof: "message", T: () => ErrorPayload },
             { no: 14, name: "player_ready_changed", kind: "message", oneof: "message", T: () => PlayerReadyChangedPayload },
             { no: 15, name: "discarded_cards", kind: "message", oneof: "message", T: () => DiscardedCardsPayload },
-            { no: 16, name: "dev_card_bought", kind: "message", oneof: "message", T: () => DevCardBoughtPayload }
+            { no: 16, name: "dev_card_bought", kind: "message", oneof: "message", T: () => DevCardBoughtPayload },
+            { no: 17, name: "chat_message", kind: "message", oneof: "message", T: () => ChatMessagePayload },
+            { no: 18, name: "chat_history", kind: "message", oneof: "message", T: () => ChatHistoryPayload },
+            { no: 19, name: "rematch", kind: "message", oneof: "message", T: () => RematchPayload }
         ]);
     }
     create(value?: PartialMessage<ServerMessage>): ServerMessage {
@@ -2598,6 +3456,24 @@ class ServerMessage$Type extends MessageType<ServerMessage> {
                         devCardBought: DevCardBoughtPayload.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).devCardBought)
                     };
                     break;
+                case /* catan.v1.ChatMessagePayload chat_message */ 17:
+                    message.message = {
+                        oneofKind: "chatMessage",
+                        chatMessage: ChatMessagePayload.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).chatMessage)
+                    };
+                    break;
+                case /* catan.v1.ChatHistoryPayload chat_history */ 18:
+                    message.message = {
+                        oneofKind: "chatHistory",
+                        chatHistory: ChatHistoryPayload.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).chatHistory)
+                    };
+                    break;
+                case /* catan.v1.RematchPayload rematch */ 19:
+                    message.message = {
+                        oneofKind: "rematch",
+                        rematch: RematchPayload.internalBinaryRead(reader, reader.uint32(), options, (message.message as any).rematch)
+                    };
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2658,6 +3534,15 @@ class ServerMessage$Type extends MessageType<ServerMessage> {
         /* catan.v1.DevCardBoughtPayload dev_card_bought = 16; */
         if (message.message.oneofKind === "devCardBought")
             DevCardBoughtPayload.internalBinaryWrite(message.message.devCardBought, writer.tag(16, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.ChatMessagePayload chat_message = 17; */
+        if (message.message.oneofKind === "chatMessage")
+            ChatMessagePayload.internalBinaryWrite(message.message.chatMessage, writer.tag(17, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.ChatHistoryPayload chat_history = 18; */
+        if (message.message.oneofKind === "chatHistory")
+            ChatHistoryPayload.internalBinaryWrite(message.message.chatHistory, writer.tag(18, WireType.LengthDelimited).fork(), options).join();
+        /* catan.v1.RematchPayload rematch = 19; */
+        if (message.message.oneofKind === "rematch")
+            RematchPayload.internalBinaryWrite(message.message.rematch, writer.tag(19, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
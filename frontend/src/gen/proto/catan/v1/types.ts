@@ -318,6 +318,124 @@ export interface GameState {
      * @generated from protobuf field: int32 turn_counter = 15
      */
     turnCounter: number; // Global turn counter (incremented each turn)
+    /**
+     * @generated from protobuf field: int64 seed = 16
+     */
+    seed: bigint; // Seed the board and dev card deck were generated from
+    /**
+     * @generated from protobuf field: repeated string export_approved_player_ids = 17
+     */
+    exportApprovedPlayerIds: string[]; // Players agreeing to export this unfinished game; cleared by the next command
+    /**
+     * @generated from protobuf field: int32 state_version = 18
+     */
+    stateVersion: number; // Stored schema version, see game.CurrentStateVersion
+    /**
+     * @generated from protobuf field: bool paused = 19
+     */
+    paused: boolean; // Set by an administrator; commands are refused while paused
+    /**
+     * @generated from protobuf field: bool ended_by_admin = 20
+     */
+    endedByAdmin: boolean; // Finished by an administrator rather than won; never archived or rated
+    /**
+     * @generated from protobuf field: repeated string chat_muted_player_ids = 21
+     */
+    chatMutedPlayerIds: string[]; // Players the host has muted in chat
+    /**
+     * @generated from protobuf field: bool spectator_chat_disabled = 22
+     */
+    spectatorChatDisabled: boolean; // Set by the host; connections without a seat may not chat
+    /**
+     * @generated from protobuf field: catan.v1.TakebackRequest pending_takeback = 23
+     */
+    pendingTakeback?: TakebackRequest; // Present while a takeback awaits votes
+    /**
+     * @generated from protobuf field: int64 taken_back_seq = 24
+     */
+    takenBackSeq: bigint; // Logged event the last approved takeback undid
+    /**
+     * @generated from protobuf field: catan.v1.Series series = 25
+     */
+    series?: Series; // Present in rematches: the tally of the games before this one
+    /**
+     * @generated from protobuf field: string rematch_game_id = 26
+     */
+    rematchGameId: string; // Set on a finished game once its rematch was created
+    /**
+     * @generated from protobuf field: map<string, string> rematch_player_ids = 27
+     */
+    rematchPlayerIds: {
+        [key: string]: string;
+    }; // Player ID here -> their seat in the rematch
+    /**
+     * @generated from protobuf field: string tournament_id = 28
+     */
+    tournamentId: string; // Set on tournament tables
+    /**
+     * @generated from protobuf field: catan.v1.Correspondence correspondence = 29
+     */
+    correspondence?: Correspondence; // Present for correspondence games
+}
+/**
+ * Rematches between the same players, optionally as a best-of-N series.
+ * Tallies are keyed by player ID in the game that carries them.
+ *
+ * @generated from protobuf message catan.v1.Series
+ */
+export interface Series {
+    /**
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+    /**
+     * @generated from protobuf field: int32 best_of = 2
+     */
+    bestOf: number; // 0 for open-ended rematches
+    /**
+     * @generated from protobuf field: int32 game_number = 3
+     */
+    gameNumber: number; // 1 for the first game of the series
+    /**
+     * @generated from protobuf field: map<string, int32> wins = 4
+     */
+    wins: {
+        [key: string]: number;
+    }; // Games won
+    /**
+     * @generated from protobuf field: map<string, int32> points = 5
+     */
+    points: {
+        [key: string]: number;
+    }; // Victory points summed over finished games
+    /**
+     * @generated from protobuf field: string winner_id = 6
+     */
+    winnerId: string; // Set once a player has won a majority of best_of games
+}
+/**
+ * A request by the current player to undo their last action, awaiting the
+ * other players' votes.
+ *
+ * @generated from protobuf message catan.v1.TakebackRequest
+ */
+export interface TakebackRequest {
+    /**
+     * @generated from protobuf field: string requester_id = 1
+     */
+    requesterId: string;
+    /**
+     * @generated from protobuf field: int64 event_seq = 2
+     */
+    eventSeq: bigint; // Logged event the takeback would undo
+    /**
+     * @generated from protobuf field: string action = 3
+     */
+    action: string; // Client message kind of that event, e.g. "buildStructure"
+    /**
+     * @generated from protobuf field: repeated string approved_player_ids = 4
+     */
+    approvedPlayerIds: string[];
 }
 /**
  * Tracks the Robber phase state, including pending discards and steps.
@@ -408,6 +526,10 @@ export interface CreateGameRequest {
      * @generated from protobuf field: string player_name = 1
      */
     playerName: string;
+    /**
+     * @generated from protobuf field: catan.v1.Correspondence correspondence = 2
+     */
+    correspondence?: Correspondence; // Only turn_hours is read
 }
 /**
  * @generated from protobuf message catan.v1.CreateGameResponse
@@ -438,6 +560,10 @@ export interface JoinGameRequest {
      * @generated from protobuf field: string player_name = 1
      */
     playerName: string;
+    /**
+     * @generated from protobuf field: string code = 2
+     */
+    code: string; // CatanService only; the HTTP route takes it from the path
 }
 /**
  * @generated from protobuf message catan.v1.JoinGameResponse
@@ -498,6 +624,269 @@ export interface GameInfoResponse {
      */
     players: PlayerInfo[];
 }
+/**
+ * A tournament seats its entrants at tables of 3-4 players, one game per
+ * table, and cuts the field after each round until one table remains. The
+ * winner of that final table wins the tournament.
+ *
+ * @generated from protobuf message catan.v1.Tournament
+ */
+export interface Tournament {
+    /**
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+    /**
+     * @generated from protobuf field: string name = 2
+     */
+    name: string;
+    /**
+     * @generated from protobuf field: catan.v1.TournamentStatus status = 3
+     */
+    status: TournamentStatus;
+    /**
+     * @generated from protobuf field: catan.v1.TournamentAdvancement advancement = 4
+     */
+    advancement: TournamentAdvancement;
+    /**
+     * @generated from protobuf field: int32 advance_per_table = 5
+     */
+    advancePerTable: number;
+    /**
+     * @generated from protobuf field: int64 seed = 6
+     */
+    seed: bigint; // Seeds the first round's seating
+    /**
+     * @generated from protobuf field: string organizer_token = 7
+     */
+    organizerToken: string; // Secret; stripped from API responses
+    /**
+     * @generated from protobuf field: repeated catan.v1.TournamentEntrant entrants = 8
+     */
+    entrants: TournamentEntrant[];
+    /**
+     * @generated from protobuf field: repeated catan.v1.TournamentRound rounds = 9
+     */
+    rounds: TournamentRound[];
+    /**
+     * @generated from protobuf field: repeated catan.v1.TournamentStanding standings = 10
+     */
+    standings: TournamentStanding[]; // Best first
+    /**
+     * @generated from protobuf field: string winner_id = 11
+     */
+    winnerId: string; // Entrant ID, set when the tournament finishes
+}
+/**
+ * @generated from protobuf message catan.v1.TournamentEntrant
+ */
+export interface TournamentEntrant {
+    /**
+     * @generated from protobuf field: string id = 1
+     */
+    id: string;
+    /**
+     * @generated from protobuf field: string name = 2
+     */
+    name: string;
+    /**
+     * @generated from protobuf field: string token = 3
+     */
+    token: string; // Secret; stripped from API responses
+    /**
+     * @generated from protobuf field: string user_id = 4
+     */
+    userId: string; // Empty for anonymous entrants; stripped from API responses
+    /**
+     * @generated from protobuf field: bool eliminated = 5
+     */
+    eliminated: boolean;
+}
+/**
+ * @generated from protobuf message catan.v1.TournamentRound
+ */
+export interface TournamentRound {
+    /**
+     * @generated from protobuf field: int32 number = 1
+     */
+    number: number; // 1 for the first round
+    /**
+     * @generated from protobuf field: repeated catan.v1.TournamentTable tables = 2
+     */
+    tables: TournamentTable[];
+}
+/**
+ * @generated from protobuf message catan.v1.TournamentTable
+ */
+export interface TournamentTable {
+    /**
+     * @generated from protobuf field: string game_id = 1
+     */
+    gameId: string;
+    /**
+     * @generated from protobuf field: string code = 2
+     */
+    code: string;
+    /**
+     * @generated from protobuf field: repeated catan.v1.TournamentSeat seats = 3
+     */
+    seats: TournamentSeat[]; // In seat order
+    /**
+     * @generated from protobuf field: bool finished = 4
+     */
+    finished: boolean;
+    /**
+     * @generated from protobuf field: string winner_id = 5
+     */
+    winnerId: string; // Entrant ID
+}
+/**
+ * @generated from protobuf message catan.v1.TournamentSeat
+ */
+export interface TournamentSeat {
+    /**
+     * @generated from protobuf field: string entrant_id = 1
+     */
+    entrantId: string;
+    /**
+     * @generated from protobuf field: string player_id = 2
+     */
+    playerId: string;
+    /**
+     * @generated from protobuf field: int32 points = 3
+     */
+    points: number; // Final victory points, set once the table finishes
+}
+/**
+ * @generated from protobuf message catan.v1.TournamentStanding
+ */
+export interface TournamentStanding {
+    /**
+     * @generated from protobuf field: string entrant_id = 1
+     */
+    entrantId: string;
+    /**
+     * @generated from protobuf field: int32 rank = 2
+     */
+    rank: number;
+    /**
+     * @generated from protobuf field: int32 points = 3
+     */
+    points: number; // Victory points over all finished tables
+    /**
+     * @generated from protobuf field: int32 wins = 4
+     */
+    wins: number;
+    /**
+     * @generated from protobuf field: int32 games = 5
+     */
+    games: number;
+}
+/**
+ * @generated from protobuf message catan.v1.CreateTournamentResponse
+ */
+export interface CreateTournamentResponse {
+    /**
+     * @generated from protobuf field: catan.v1.Tournament tournament = 1
+     */
+    tournament?: Tournament;
+    /**
+     * @generated from protobuf field: string organizer_token = 2
+     */
+    organizerToken: string; // Starts the tournament; keep it private
+}
+/**
+ * @generated from protobuf message catan.v1.RegisterTournamentResponse
+ */
+export interface RegisterTournamentResponse {
+    /**
+     * @generated from protobuf field: string entrant_id = 1
+     */
+    entrantId: string;
+    /**
+     * @generated from protobuf field: string token = 2
+     */
+    token: string; // Claims the entrant's seat each round
+}
+/**
+ * @generated from protobuf message catan.v1.ListTournamentsResponse
+ */
+export interface ListTournamentsResponse {
+    /**
+     * @generated from protobuf field: repeated catan.v1.Tournament tournaments = 1
+     */
+    tournaments: Tournament[];
+}
+/**
+ * Correspondence games are played over days: each turn has a long deadline,
+ * players are notified when the game is waiting on them, and a turn left
+ * past its deadline is forfeited where the rules allow it.
+ *
+ * @generated from protobuf message catan.v1.Correspondence
+ */
+export interface Correspondence {
+    /**
+     * @generated from protobuf field: int32 turn_hours = 1
+     */
+    turnHours: number;
+    /**
+     * @generated from protobuf field: int64 turn_deadline = 2
+     */
+    turnDeadline: bigint; // Unix milliseconds; 0 before the game starts
+    /**
+     * @generated from protobuf field: bool overdue_notified = 3
+     */
+    overdueNotified: boolean; // Set once the current turn's players were told it is overdue
+}
+/**
+ * Something the game is waiting for a player to do. PLAY_TURN means the
+ * player may build, trade or end their turn.
+ *
+ * @generated from protobuf message catan.v1.PendingAction
+ */
+export interface PendingAction {
+    /**
+     * @generated from protobuf field: catan.v1.PendingActionKind kind = 1
+     */
+    kind: PendingActionKind;
+    /**
+     * @generated from protobuf field: string trade_id = 2
+     */
+    tradeId: string; // RESPOND_TRADE only
+    /**
+     * @generated from protobuf field: int32 discard_count = 3
+     */
+    discardCount: number; // DISCARD only
+}
+/**
+ * @generated from protobuf message catan.v1.PendingActionsResponse
+ */
+export interface PendingActionsResponse {
+    /**
+     * @generated from protobuf field: string game_id = 1
+     */
+    gameId: string;
+    /**
+     * @generated from protobuf field: string player_id = 2
+     */
+    playerId: string;
+    /**
+     * @generated from protobuf field: catan.v1.GameStatus status = 3
+     */
+    status: GameStatus;
+    /**
+     * @generated from protobuf field: string current_player_id = 4
+     */
+    currentPlayerId: string;
+    /**
+     * @generated from protobuf field: repeated catan.v1.PendingAction actions = 5
+     */
+    actions: PendingAction[];
+    /**
+     * @generated from protobuf field: int64 turn_deadline = 6
+     */
+    turnDeadline: bigint; // Unix milliseconds; 0 outside correspondence games
+}
 // ==================== Enums ====================
 
 /**
@@ -742,45 +1131,377 @@ export enum TradeStatus {
      */
     CANCELLED = 4
 }
-// @generated message type with reflection information, may provide speed optimized methods
-class HexCoord$Type extends MessageType<HexCoord> {
-    constructor() {
-        super("catan.v1.HexCoord", [
-            { no: 1, name: "q", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
-            { no: 2, name: "r", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
-        ]);
-    }
-    create(value?: PartialMessage<HexCoord>): HexCoord {
-        const message = globalThis.Object.create((this.messagePrototype!));
-        message.q = 0;
-        message.r = 0;
-        if (value !== undefined)
-            reflectionMergePartial<HexCoord>(this, message, value);
-        return message;
-    }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: HexCoord): HexCoord {
-        let message = target ?? this.create(), end = reader.pos + length;
-        while (reader.pos < end) {
-            let [fieldNo, wireType] = reader.tag();
-            switch (fieldNo) {
-                case /* int32 q */ 1:
-                    message.q = reader.int32();
-                    break;
-                case /* int32 r */ 2:
-                    message.r = reader.int32();
-                    break;
-                default:
-                    let u = options.readUnknownField;
-                    if (u === "throw")
-                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
-                    let d = reader.skip(wireType);
-                    if (u !== false)
-                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
-            }
-        }
-        return message;
-    }
-    internalBinaryWrite(message: HexCoord, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+/**
+ * Why the server refused a command. The string code on ErrorPayload names
+ * the broad kind; this names the rule, so clients can localize the message
+ * and react without parsing text.
+ *
+ * @generated from protobuf enum catan.v1.ErrorCode
+ */
+export enum ErrorCode {
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_ACTION = 1;
+     */
+    INVALID_ACTION = 1,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_BAD_REQUEST = 2;
+     */
+    BAD_REQUEST = 2,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_RATE_LIMITED = 3;
+     */
+    RATE_LIMITED = 3,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_GAME_PAUSED = 4;
+     */
+    GAME_PAUSED = 4,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_GAME_EXPIRED = 5;
+     */
+    GAME_EXPIRED = 5,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_STATE = 6;
+     */
+    INVALID_STATE = 6,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_SERVER_ERROR = 7;
+     */
+    SERVER_ERROR = 7,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_SHUTTING_DOWN = 8;
+     */
+    SHUTTING_DOWN = 8,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_REMOVED = 9;
+     */
+    REMOVED = 9,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_YOUR_TURN = 20;
+     */
+    NOT_YOUR_TURN = 20,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_WRONG_PHASE = 21;
+     */
+    WRONG_PHASE = 21,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_TURN_PHASE = 22;
+     */
+    INVALID_TURN_PHASE = 22,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_PLAYER_NOT_FOUND = 23;
+     */
+    PLAYER_NOT_FOUND = 23,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_HOST = 24;
+     */
+    NOT_HOST = 24,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_PLAYERS_NOT_READY = 25;
+     */
+    PLAYERS_NOT_READY = 25,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_ENOUGH_PLAYERS = 26;
+     */
+    NOT_ENOUGH_PLAYERS = 26,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_LAST_PLAYER = 27;
+     */
+    LAST_PLAYER = 27,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_VERTEX = 40;
+     */
+    INVALID_VERTEX = 40,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_EDGE = 41;
+     */
+    INVALID_EDGE = 41,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_VERTEX_OCCUPIED = 42;
+     */
+    VERTEX_OCCUPIED = 42,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_EDGE_OCCUPIED = 43;
+     */
+    EDGE_OCCUPIED = 43,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DISTANCE_RULE = 44;
+     */
+    DISTANCE_RULE = 44,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_CONNECTED = 45;
+     */
+    NOT_CONNECTED = 45,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_CANNOT_UPGRADE = 46;
+     */
+    CANNOT_UPGRADE = 46,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_SETUP_SETTLEMENT_FIRST = 47;
+     */
+    SETUP_SETTLEMENT_FIRST = 47,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_SETUP_ROAD_NOT_CONNECTED = 48;
+     */
+    SETUP_ROAD_NOT_CONNECTED = 48,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_MAX_SETTLEMENTS = 49;
+     */
+    MAX_SETTLEMENTS = 49,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_MAX_ROADS = 50;
+     */
+    MAX_ROADS = 50,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_MAX_CITIES = 51;
+     */
+    MAX_CITIES = 51,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INSUFFICIENT_RESOURCES = 60;
+     */
+    INSUFFICIENT_RESOURCES = 60,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_BANK_EMPTY = 61;
+     */
+    BANK_EMPTY = 61,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_TRADE = 62;
+     */
+    INVALID_TRADE = 62,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_TRADE_ALREADY_PENDING = 63;
+     */
+    TRADE_ALREADY_PENDING = 63,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_TRADE_NOT_FOUND = 64;
+     */
+    TRADE_NOT_FOUND = 64,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_TRADE_PARTICIPANT = 65;
+     */
+    NOT_TRADE_PARTICIPANT = 65,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DEV_DECK_EMPTY = 80;
+     */
+    DEV_DECK_EMPTY = 80,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DEV_CARD_NOT_HELD = 81;
+     */
+    DEV_CARD_NOT_HELD = 81,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DEV_CARD_TOO_NEW = 82;
+     */
+    DEV_CARD_TOO_NEW = 82,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_DEV_CARD_CHOICE = 83;
+     */
+    INVALID_DEV_CARD_CHOICE = 83,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NO_ROBBER_PHASE = 100;
+     */
+    NO_ROBBER_PHASE = 100,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DISCARD_NOT_REQUIRED = 101;
+     */
+    DISCARD_NOT_REQUIRED = 101,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_WRONG_DISCARD = 102;
+     */
+    WRONG_DISCARD = 102,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_DISCARDS_PENDING = 103;
+     */
+    DISCARDS_PENDING = 103,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_ROBBER = 104;
+     */
+    NOT_ROBBER = 104,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_HEX = 105;
+     */
+    INVALID_HEX = 105,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_ROBBER_ALREADY_THERE = 106;
+     */
+    ROBBER_ALREADY_THERE = 106,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_VICTIM = 107;
+     */
+    INVALID_VICTIM = 107,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_TAKEBACK_NOT_ALLOWED = 120;
+     */
+    TAKEBACK_NOT_ALLOWED = 120,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_TAKEBACK_PENDING = 121;
+     */
+    TAKEBACK_PENDING = 121,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NO_TAKEBACK_PENDING = 122;
+     */
+    NO_TAKEBACK_PENDING = 122,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_OWN_TAKEBACK = 123;
+     */
+    OWN_TAKEBACK = 123,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_ALREADY_VOTED = 124;
+     */
+    ALREADY_VOTED = 124,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_TAKEBACK_STALE = 125;
+     */
+    TAKEBACK_STALE = 125,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_CHAT_TOO_LONG = 140;
+     */
+    CHAT_TOO_LONG = 140,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_CHAT_RATE_LIMITED = 141;
+     */
+    CHAT_RATE_LIMITED = 141,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_NOT_CHAT_MODERATOR = 142;
+     */
+    NOT_CHAT_MODERATOR = 142,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_CHAT_MUTED = 143;
+     */
+    CHAT_MUTED = 143,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_SPECTATOR_CHAT_DISABLED = 144;
+     */
+    SPECTATOR_CHAT_DISABLED = 144,
+    /**
+     * @generated from protobuf enum value: ERROR_CODE_INVALID_WHISPER = 145;
+     */
+    INVALID_WHISPER = 145
+}
+/**
+ * @generated from protobuf enum catan.v1.TournamentStatus
+ */
+export enum TournamentStatus {
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_STATUS_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_STATUS_REGISTRATION = 1;
+     */
+    REGISTRATION = 1,
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_STATUS_RUNNING = 2;
+     */
+    RUNNING = 2,
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_STATUS_FINISHED = 3;
+     */
+    FINISHED = 3
+}
+/**
+ * How entrants go through to the next round. PLACEMENT takes the top
+ * advance_per_table finishers of each table; POINTS takes as many entrants
+ * in total from the top of the tournament points standings.
+ *
+ * @generated from protobuf enum catan.v1.TournamentAdvancement
+ */
+export enum TournamentAdvancement {
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_ADVANCEMENT_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_ADVANCEMENT_PLACEMENT = 1;
+     */
+    PLACEMENT = 1,
+    /**
+     * @generated from protobuf enum value: TOURNAMENT_ADVANCEMENT_POINTS = 2;
+     */
+    POINTS = 2
+}
+/**
+ * @generated from protobuf enum catan.v1.PendingActionKind
+ */
+export enum PendingActionKind {
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_UNSPECIFIED = 0;
+     */
+    UNSPECIFIED = 0,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_PLACE_SETTLEMENT = 1;
+     */
+    PLACE_SETTLEMENT = 1,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_PLACE_ROAD = 2;
+     */
+    PLACE_ROAD = 2,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_ROLL = 3;
+     */
+    ROLL = 3,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_PLAY_TURN = 4;
+     */
+    PLAY_TURN = 4,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_DISCARD = 5;
+     */
+    DISCARD = 5,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_MOVE_ROBBER = 6;
+     */
+    MOVE_ROBBER = 6,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_STEAL = 7;
+     */
+    STEAL = 7,
+    /**
+     * @generated from protobuf enum value: PENDING_ACTION_KIND_RESPOND_TRADE = 8;
+     */
+    RESPOND_TRADE = 8
+}
+// @generated message type with reflection information, may provide speed optimized methods
+class HexCoord$Type extends MessageType<HexCoord> {
+    constructor() {
+        super("catan.v1.HexCoord", [
+            { no: 1, name: "q", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 2, name: "r", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<HexCoord>): HexCoord {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.q = 0;
+        message.r = 0;
+        if (value !== undefined)
+            reflectionMergePartial<HexCoord>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: HexCoord): HexCoord {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* int32 q */ 1:
+                    message.q = reader.int32();
+                    break;
+                case /* int32 r */ 2:
+                    message.r = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: HexCoord, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* int32 q = 1; */
         if (message.q !== 0)
             writer.tag(1, WireType.Varint).int32(message.q);
@@ -1505,7 +2226,21 @@ class GameState$Type extends MessageType<GameState> {
             { no: 12, name: "robber_phase", kind: "message", T: () => RobberPhase },
             { no: 13, name: "pending_trades", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TradeOffer },
             { no: 14, name: "dev_card_deck", kind: "enum", repeat: 1 /*RepeatType.PACKED*/, T: () => ["catan.v1.DevCardType", DevCardType, "DEV_CARD_TYPE_"] },
-            { no: 15, name: "turn_counter", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+            { no: 15, name: "turn_counter", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 16, name: "seed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 17, name: "export_approved_player_ids", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 18, name: "state_version", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 19, name: "paused", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 20, name: "ended_by_admin", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 21, name: "chat_muted_player_ids", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 22, name: "spectator_chat_disabled", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 23, name: "pending_takeback", kind: "message", T: () => TakebackRequest },
+            { no: 24, name: "taken_back_seq", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 25, name: "series", kind: "message", T: () => Series },
+            { no: 26, name: "rematch_game_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 27, name: "rematch_player_ids", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 9 /*ScalarType.STRING*/ } },
+            { no: 28, name: "tournament_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 29, name: "correspondence", kind: "message", T: () => Correspondence }
         ]);
     }
     create(value?: PartialMessage<GameState>): GameState {
@@ -1520,6 +2255,17 @@ class GameState$Type extends MessageType<GameState> {
         message.pendingTrades = [];
         message.devCardDeck = [];
         message.turnCounter = 0;
+        message.seed = 0n;
+        message.exportApprovedPlayerIds = [];
+        message.stateVersion = 0;
+        message.paused = false;
+        message.endedByAdmin = false;
+        message.chatMutedPlayerIds = [];
+        message.spectatorChatDisabled = false;
+        message.takenBackSeq = 0n;
+        message.rematchGameId = "";
+        message.rematchPlayerIds = {};
+        message.tournamentId = "";
         if (value !== undefined)
             reflectionMergePartial<GameState>(this, message, value);
         return message;
@@ -1582,6 +2328,48 @@ class GameState$Type extends MessageType<GameState> {
                 case /* int32 turn_counter */ 15:
                     message.turnCounter = reader.int32();
                     break;
+                case /* int64 seed */ 16:
+                    message.seed = reader.int64().toBigInt();
+                    break;
+                case /* repeated string export_approved_player_ids */ 17:
+                    message.exportApprovedPlayerIds.push(reader.string());
+                    break;
+                case /* int32 state_version */ 18:
+                    message.stateVersion = reader.int32();
+                    break;
+                case /* bool paused */ 19:
+                    message.paused = reader.bool();
+                    break;
+                case /* bool ended_by_admin */ 20:
+                    message.endedByAdmin = reader.bool();
+                    break;
+                case /* repeated string chat_muted_player_ids */ 21:
+                    message.chatMutedPlayerIds.push(reader.string());
+                    break;
+                case /* bool spectator_chat_disabled */ 22:
+                    message.spectatorChatDisabled = reader.bool();
+                    break;
+                case /* catan.v1.TakebackRequest pending_takeback */ 23:
+                    message.pendingTakeback = TakebackRequest.internalBinaryRead(reader, reader.uint32(), options, message.pendingTakeback);
+                    break;
+                case /* int64 taken_back_seq */ 24:
+                    message.takenBackSeq = reader.int64().toBigInt();
+                    break;
+                case /* catan.v1.Series series */ 25:
+                    message.series = Series.internalBinaryRead(reader, reader.uint32(), options, message.series);
+                    break;
+                case /* string rematch_game_id */ 26:
+                    message.rematchGameId = reader.string();
+                    break;
+                case /* map<string, string> rematch_player_ids */ 27:
+                    this.binaryReadMap27(message.rematchPlayerIds, reader, options);
+                    break;
+                case /* string tournament_id */ 28:
+                    message.tournamentId = reader.string();
+                    break;
+                case /* catan.v1.Correspondence correspondence */ 29:
+                    message.correspondence = Correspondence.internalBinaryRead(reader, reader.uint32(), options, message.correspondence);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1593,6 +2381,22 @@ class GameState$Type extends MessageType<GameState> {
         }
         return message;
     }
+    private binaryReadMap27(map: GameState["rematchPlayerIds"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof GameState["rematchPlayerIds"] | undefined, val: GameState["rematchPlayerIds"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.string();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for catan.v1.GameState.rematch_player_ids");
+            }
+        }
+        map[key ?? ""] = val ?? "";
+    }
     internalBinaryWrite(message: GameState, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
         /* string id = 1; */
         if (message.id !== "")
@@ -1647,6 +2451,48 @@ class GameState$Type extends MessageType<GameState> {
         /* int32 turn_counter = 15; */
         if (message.turnCounter !== 0)
             writer.tag(15, WireType.Varint).int32(message.turnCounter);
+        /* int64 seed = 16; */
+        if (message.seed !== 0n)
+            writer.tag(16, WireType.Varint).int64(message.seed);
+        /* repeated string export_approved_player_ids = 17; */
+        for (let i = 0; i < message.exportApprovedPlayerIds.length; i++)
+            writer.tag(17, WireType.LengthDelimited).string(message.exportApprovedPlayerIds[i]);
+        /* int32 state_version = 18; */
+        if (message.stateVersion !== 0)
+            writer.tag(18, WireType.Varint).int32(message.stateVersion);
+        /* bool paused = 19; */
+        if (message.paused !== false)
+            writer.tag(19, WireType.Varint).bool(message.paused);
+        /* bool ended_by_admin = 20; */
+        if (message.endedByAdmin !== false)
+            writer.tag(20, WireType.Varint).bool(message.endedByAdmin);
+        /* repeated string chat_muted_player_ids = 21; */
+        for (let i = 0; i < message.chatMutedPlayerIds.length; i++)
+            writer.tag(21, WireType.LengthDelimited).string(message.chatMutedPlayerIds[i]);
+        /* bool spectator_chat_disabled = 22; */
+        if (message.spectatorChatDisabled !== false)
+            writer.tag(22, WireType.Varint).bool(message.spectatorChatDisabled);
+        /* catan.v1.TakebackRequest pending_takeback = 23; */
+        if (message.pendingTakeback)
+            TakebackRequest.internalBinaryWrite(message.pendingTakeback, writer.tag(23, WireType.LengthDelimited).fork(), options).join();
+        /* int64 taken_back_seq = 24; */
+        if (message.takenBackSeq !== 0n)
+            writer.tag(24, WireType.Varint).int64(message.takenBackSeq);
+        /* catan.v1.Series series = 25; */
+        if (message.series)
+            Series.internalBinaryWrite(message.series, writer.tag(25, WireType.LengthDelimited).fork(), options).join();
+        /* string rematch_game_id = 26; */
+        if (message.rematchGameId !== "")
+            writer.tag(26, WireType.LengthDelimited).string(message.rematchGameId);
+        /* map<string, string> rematch_player_ids = 27; */
+        for (let k of globalThis.Object.keys(message.rematchPlayerIds))
+            writer.tag(27, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.LengthDelimited).string(message.rematchPlayerIds[k]).join();
+        /* string tournament_id = 28; */
+        if (message.tournamentId !== "")
+            writer.tag(28, WireType.LengthDelimited).string(message.tournamentId);
+        /* catan.v1.Correspondence correspondence = 29; */
+        if (message.correspondence)
+            Correspondence.internalBinaryWrite(message.correspondence, writer.tag(29, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -1658,38 +2504,228 @@ class GameState$Type extends MessageType<GameState> {
  */
 export const GameState = new GameState$Type();
 // @generated message type with reflection information, may provide speed optimized methods
-class RobberPhase$Type extends MessageType<RobberPhase> {
+class Series$Type extends MessageType<Series> {
     constructor() {
-        super("catan.v1.RobberPhase", [
-            { no: 1, name: "discard_pending", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
-            { no: 2, name: "discard_required", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 5 /*ScalarType.INT32*/ } },
-            { no: 3, name: "move_pending_player_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
-            { no: 4, name: "steal_pending_player_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        super("catan.v1.Series", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "best_of", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "game_number", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "wins", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 5 /*ScalarType.INT32*/ } },
+            { no: 5, name: "points", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 5 /*ScalarType.INT32*/ } },
+            { no: 6, name: "winner_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
-    create(value?: PartialMessage<RobberPhase>): RobberPhase {
+    create(value?: PartialMessage<Series>): Series {
         const message = globalThis.Object.create((this.messagePrototype!));
-        message.discardPending = [];
-        message.discardRequired = {};
+        message.id = "";
+        message.bestOf = 0;
+        message.gameNumber = 0;
+        message.wins = {};
+        message.points = {};
+        message.winnerId = "";
         if (value !== undefined)
-            reflectionMergePartial<RobberPhase>(this, message, value);
+            reflectionMergePartial<Series>(this, message, value);
         return message;
     }
-    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RobberPhase): RobberPhase {
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Series): Series {
         let message = target ?? this.create(), end = reader.pos + length;
         while (reader.pos < end) {
             let [fieldNo, wireType] = reader.tag();
             switch (fieldNo) {
-                case /* repeated string discard_pending */ 1:
-                    message.discardPending.push(reader.string());
+                case /* string id */ 1:
+                    message.id = reader.string();
                     break;
-                case /* map<string, int32> discard_required */ 2:
-                    this.binaryReadMap2(message.discardRequired, reader, options);
+                case /* int32 best_of */ 2:
+                    message.bestOf = reader.int32();
                     break;
-                case /* optional string move_pending_player_id */ 3:
-                    message.movePendingPlayerId = reader.string();
+                case /* int32 game_number */ 3:
+                    message.gameNumber = reader.int32();
                     break;
-                case /* optional string steal_pending_player_id */ 4:
+                case /* map<string, int32> wins */ 4:
+                    this.binaryReadMap4(message.wins, reader, options);
+                    break;
+                case /* map<string, int32> points */ 5:
+                    this.binaryReadMap5(message.points, reader, options);
+                    break;
+                case /* string winner_id */ 6:
+                    message.winnerId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    private binaryReadMap4(map: Series["wins"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof Series["wins"] | undefined, val: Series["wins"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.int32();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for catan.v1.Series.wins");
+            }
+        }
+        map[key ?? ""] = val ?? 0;
+    }
+    private binaryReadMap5(map: Series["points"], reader: IBinaryReader, options: BinaryReadOptions): void {
+        let len = reader.uint32(), end = reader.pos + len, key: keyof Series["points"] | undefined, val: Series["points"][any] | undefined;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case 1:
+                    key = reader.string();
+                    break;
+                case 2:
+                    val = reader.int32();
+                    break;
+                default: throw new globalThis.Error("unknown map entry field for catan.v1.Series.points");
+            }
+        }
+        map[key ?? ""] = val ?? 0;
+    }
+    internalBinaryWrite(message: Series, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* int32 best_of = 2; */
+        if (message.bestOf !== 0)
+            writer.tag(2, WireType.Varint).int32(message.bestOf);
+        /* int32 game_number = 3; */
+        if (message.gameNumber !== 0)
+            writer.tag(3, WireType.Varint).int32(message.gameNumber);
+        /* map<string, int32> wins = 4; */
+        for (let k of globalThis.Object.keys(message.wins))
+            writer.tag(4, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.Varint).int32(message.wins[k]).join();
+        /* map<string, int32> points = 5; */
+        for (let k of globalThis.Object.keys(message.points))
+            writer.tag(5, WireType.LengthDelimited).fork().tag(1, WireType.LengthDelimited).string(k).tag(2, WireType.Varint).int32(message.points[k]).join();
+        /* string winner_id = 6; */
+        if (message.winnerId !== "")
+            writer.tag(6, WireType.LengthDelimited).string(message.winnerId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.Series
+ */
+export const Series = new Series$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TakebackRequest$Type extends MessageType<TakebackRequest> {
+    constructor() {
+        super("catan.v1.TakebackRequest", [
+            { no: 1, name: "requester_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "event_seq", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "action", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "approved_player_ids", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TakebackRequest>): TakebackRequest {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.requesterId = "";
+        message.eventSeq = 0n;
+        message.action = "";
+        message.approvedPlayerIds = [];
+        if (value !== undefined)
+            reflectionMergePartial<TakebackRequest>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TakebackRequest): TakebackRequest {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string requester_id */ 1:
+                    message.requesterId = reader.string();
+                    break;
+                case /* int64 event_seq */ 2:
+                    message.eventSeq = reader.int64().toBigInt();
+                    break;
+                case /* string action */ 3:
+                    message.action = reader.string();
+                    break;
+                case /* repeated string approved_player_ids */ 4:
+                    message.approvedPlayerIds.push(reader.string());
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TakebackRequest, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string requester_id = 1; */
+        if (message.requesterId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.requesterId);
+        /* int64 event_seq = 2; */
+        if (message.eventSeq !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.eventSeq);
+        /* string action = 3; */
+        if (message.action !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.action);
+        /* repeated string approved_player_ids = 4; */
+        for (let i = 0; i < message.approvedPlayerIds.length; i++)
+            writer.tag(4, WireType.LengthDelimited).string(message.approvedPlayerIds[i]);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TakebackRequest
+ */
+export const TakebackRequest = new TakebackRequest$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RobberPhase$Type extends MessageType<RobberPhase> {
+    constructor() {
+        super("catan.v1.RobberPhase", [
+            { no: 1, name: "discard_pending", kind: "scalar", repeat: 2 /*RepeatType.UNPACKED*/, T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "discard_required", kind: "map", K: 9 /*ScalarType.STRING*/, V: { kind: "scalar", T: 5 /*ScalarType.INT32*/ } },
+            { no: 3, name: "move_pending_player_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "steal_pending_player_id", kind: "scalar", opt: true, T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<RobberPhase>): RobberPhase {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.discardPending = [];
+        message.discardRequired = {};
+        if (value !== undefined)
+            reflectionMergePartial<RobberPhase>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RobberPhase): RobberPhase {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated string discard_pending */ 1:
+                    message.discardPending.push(reader.string());
+                    break;
+                case /* map<string, int32> discard_required */ 2:
+                    this.binaryReadMap2(message.discardRequired, reader, options);
+                    break;
+                case /* optional string move_pending_player_id */ 3:
+                    message.movePendingPlayerId = reader.string();
+                    break;
+                case /* optional string steal_pending_player_id */ 4:
                     message.stealPendingPlayerId = reader.string();
                     break;
                 default:
@@ -1885,7 +2921,8 @@ export const SetupPhase = new SetupPhase$Type();
 class CreateGameRequest$Type extends MessageType<CreateGameRequest> {
     constructor() {
         super("catan.v1.CreateGameRequest", [
-            { no: 1, name: "player_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 1, name: "player_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "correspondence", kind: "message", T: () => Correspondence }
         ]);
     }
     create(value?: PartialMessage<CreateGameRequest>): CreateGameRequest {
@@ -1903,6 +2940,9 @@ class CreateGameRequest$Type extends MessageType<CreateGameRequest> {
                 case /* string player_name */ 1:
                     message.playerName = reader.string();
                     break;
+                case /* catan.v1.Correspondence correspondence */ 2:
+                    message.correspondence = Correspondence.internalBinaryRead(reader, reader.uint32(), options, message.correspondence);
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -1918,6 +2958,9 @@ class CreateGameRequest$Type extends MessageType<CreateGameRequest> {
         /* string player_name = 1; */
         if (message.playerName !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.playerName);
+        /* catan.v1.Correspondence correspondence = 2; */
+        if (message.correspondence)
+            Correspondence.internalBinaryWrite(message.correspondence, writer.tag(2, WireType.LengthDelimited).fork(), options).join();
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2003,12 +3046,14 @@ export const CreateGameResponse = new CreateGameResponse$Type();
 class JoinGameRequest$Type extends MessageType<JoinGameRequest> {
     constructor() {
         super("catan.v1.JoinGameRequest", [
-            { no: 1, name: "player_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+            { no: 1, name: "player_name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "code", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
         ]);
     }
     create(value?: PartialMessage<JoinGameRequest>): JoinGameRequest {
         const message = globalThis.Object.create((this.messagePrototype!));
         message.playerName = "";
+        message.code = "";
         if (value !== undefined)
             reflectionMergePartial<JoinGameRequest>(this, message, value);
         return message;
@@ -2021,6 +3066,9 @@ class JoinGameRequest$Type extends MessageType<JoinGameRequest> {
                 case /* string player_name */ 1:
                     message.playerName = reader.string();
                     break;
+                case /* string code */ 2:
+                    message.code = reader.string();
+                    break;
                 default:
                     let u = options.readUnknownField;
                     if (u === "throw")
@@ -2036,6 +3084,9 @@ class JoinGameRequest$Type extends MessageType<JoinGameRequest> {
         /* string player_name = 1; */
         if (message.playerName !== "")
             writer.tag(1, WireType.LengthDelimited).string(message.playerName);
+        /* string code = 2; */
+        if (message.code !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.code);
         let u = options.writeUnknownFields;
         if (u !== false)
             (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
@@ -2251,3 +3302,854 @@ class GameInfoResponse$Type extends MessageType<GameInfoResponse> {
  * @generated MessageType for protobuf message catan.v1.GameInfoResponse
  */
 export const GameInfoResponse = new GameInfoResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class Tournament$Type extends MessageType<Tournament> {
+    constructor() {
+        super("catan.v1.Tournament", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "status", kind: "enum", T: () => ["catan.v1.TournamentStatus", TournamentStatus, "TOURNAMENT_STATUS_"] },
+            { no: 4, name: "advancement", kind: "enum", T: () => ["catan.v1.TournamentAdvancement", TournamentAdvancement, "TOURNAMENT_ADVANCEMENT_"] },
+            { no: 5, name: "advance_per_table", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 6, name: "seed", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 7, name: "organizer_token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 8, name: "entrants", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TournamentEntrant },
+            { no: 9, name: "rounds", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TournamentRound },
+            { no: 10, name: "standings", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TournamentStanding },
+            { no: 11, name: "winner_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<Tournament>): Tournament {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.name = "";
+        message.status = 0;
+        message.advancement = 0;
+        message.advancePerTable = 0;
+        message.seed = 0n;
+        message.organizerToken = "";
+        message.entrants = [];
+        message.rounds = [];
+        message.standings = [];
+        message.winnerId = "";
+        if (value !== undefined)
+            reflectionMergePartial<Tournament>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Tournament): Tournament {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* string name */ 2:
+                    message.name = reader.string();
+                    break;
+                case /* catan.v1.TournamentStatus status */ 3:
+                    message.status = reader.int32();
+                    break;
+                case /* catan.v1.TournamentAdvancement advancement */ 4:
+                    message.advancement = reader.int32();
+                    break;
+                case /* int32 advance_per_table */ 5:
+                    message.advancePerTable = reader.int32();
+                    break;
+                case /* int64 seed */ 6:
+                    message.seed = reader.int64().toBigInt();
+                    break;
+                case /* string organizer_token */ 7:
+                    message.organizerToken = reader.string();
+                    break;
+                case /* repeated catan.v1.TournamentEntrant entrants */ 8:
+                    message.entrants.push(TournamentEntrant.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated catan.v1.TournamentRound rounds */ 9:
+                    message.rounds.push(TournamentRound.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* repeated catan.v1.TournamentStanding standings */ 10:
+                    message.standings.push(TournamentStanding.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* string winner_id */ 11:
+                    message.winnerId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Tournament, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string name = 2; */
+        if (message.name !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.name);
+        /* catan.v1.TournamentStatus status = 3; */
+        if (message.status !== 0)
+            writer.tag(3, WireType.Varint).int32(message.status);
+        /* catan.v1.TournamentAdvancement advancement = 4; */
+        if (message.advancement !== 0)
+            writer.tag(4, WireType.Varint).int32(message.advancement);
+        /* int32 advance_per_table = 5; */
+        if (message.advancePerTable !== 0)
+            writer.tag(5, WireType.Varint).int32(message.advancePerTable);
+        /* int64 seed = 6; */
+        if (message.seed !== 0n)
+            writer.tag(6, WireType.Varint).int64(message.seed);
+        /* string organizer_token = 7; */
+        if (message.organizerToken !== "")
+            writer.tag(7, WireType.LengthDelimited).string(message.organizerToken);
+        /* repeated catan.v1.TournamentEntrant entrants = 8; */
+        for (let i = 0; i < message.entrants.length; i++)
+            TournamentEntrant.internalBinaryWrite(message.entrants[i], writer.tag(8, WireType.LengthDelimited).fork(), options).join();
+        /* repeated catan.v1.TournamentRound rounds = 9; */
+        for (let i = 0; i < message.rounds.length; i++)
+            TournamentRound.internalBinaryWrite(message.rounds[i], writer.tag(9, WireType.LengthDelimited).fork(), options).join();
+        /* repeated catan.v1.TournamentStanding standings = 10; */
+        for (let i = 0; i < message.standings.length; i++)
+            TournamentStanding.internalBinaryWrite(message.standings[i], writer.tag(10, WireType.LengthDelimited).fork(), options).join();
+        /* string winner_id = 11; */
+        if (message.winnerId !== "")
+            writer.tag(11, WireType.LengthDelimited).string(message.winnerId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.Tournament
+ */
+export const Tournament = new Tournament$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TournamentEntrant$Type extends MessageType<TournamentEntrant> {
+    constructor() {
+        super("catan.v1.TournamentEntrant", [
+            { no: 1, name: "id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "name", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 4, name: "user_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "eliminated", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TournamentEntrant>): TournamentEntrant {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.id = "";
+        message.name = "";
+        message.token = "";
+        message.userId = "";
+        message.eliminated = false;
+        if (value !== undefined)
+            reflectionMergePartial<TournamentEntrant>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TournamentEntrant): TournamentEntrant {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string id */ 1:
+                    message.id = reader.string();
+                    break;
+                case /* string name */ 2:
+                    message.name = reader.string();
+                    break;
+                case /* string token */ 3:
+                    message.token = reader.string();
+                    break;
+                case /* string user_id */ 4:
+                    message.userId = reader.string();
+                    break;
+                case /* bool eliminated */ 5:
+                    message.eliminated = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TournamentEntrant, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string id = 1; */
+        if (message.id !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.id);
+        /* string name = 2; */
+        if (message.name !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.name);
+        /* string token = 3; */
+        if (message.token !== "")
+            writer.tag(3, WireType.LengthDelimited).string(message.token);
+        /* string user_id = 4; */
+        if (message.userId !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.userId);
+        /* bool eliminated = 5; */
+        if (message.eliminated !== false)
+            writer.tag(5, WireType.Varint).bool(message.eliminated);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TournamentEntrant
+ */
+export const TournamentEntrant = new TournamentEntrant$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TournamentRound$Type extends MessageType<TournamentRound> {
+    constructor() {
+        super("catan.v1.TournamentRound", [
+            { no: 1, name: "number", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 2, name: "tables", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TournamentTable }
+        ]);
+    }
+    create(value?: PartialMessage<TournamentRound>): TournamentRound {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.number = 0;
+        message.tables = [];
+        if (value !== undefined)
+            reflectionMergePartial<TournamentRound>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TournamentRound): TournamentRound {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* int32 number */ 1:
+                    message.number = reader.int32();
+                    break;
+                case /* repeated catan.v1.TournamentTable tables */ 2:
+                    message.tables.push(TournamentTable.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TournamentRound, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int32 number = 1; */
+        if (message.number !== 0)
+            writer.tag(1, WireType.Varint).int32(message.number);
+        /* repeated catan.v1.TournamentTable tables = 2; */
+        for (let i = 0; i < message.tables.length; i++)
+            TournamentTable.internalBinaryWrite(message.tables[i], writer.tag(2, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TournamentRound
+ */
+export const TournamentRound = new TournamentRound$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TournamentTable$Type extends MessageType<TournamentTable> {
+    constructor() {
+        super("catan.v1.TournamentTable", [
+            { no: 1, name: "game_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "code", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "seats", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => TournamentSeat },
+            { no: 4, name: "finished", kind: "scalar", T: 8 /*ScalarType.BOOL*/ },
+            { no: 5, name: "winner_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TournamentTable>): TournamentTable {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.gameId = "";
+        message.code = "";
+        message.seats = [];
+        message.finished = false;
+        message.winnerId = "";
+        if (value !== undefined)
+            reflectionMergePartial<TournamentTable>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TournamentTable): TournamentTable {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string game_id */ 1:
+                    message.gameId = reader.string();
+                    break;
+                case /* string code */ 2:
+                    message.code = reader.string();
+                    break;
+                case /* repeated catan.v1.TournamentSeat seats */ 3:
+                    message.seats.push(TournamentSeat.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* bool finished */ 4:
+                    message.finished = reader.bool();
+                    break;
+                case /* string winner_id */ 5:
+                    message.winnerId = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TournamentTable, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string game_id = 1; */
+        if (message.gameId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.gameId);
+        /* string code = 2; */
+        if (message.code !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.code);
+        /* repeated catan.v1.TournamentSeat seats = 3; */
+        for (let i = 0; i < message.seats.length; i++)
+            TournamentSeat.internalBinaryWrite(message.seats[i], writer.tag(3, WireType.LengthDelimited).fork(), options).join();
+        /* bool finished = 4; */
+        if (message.finished !== false)
+            writer.tag(4, WireType.Varint).bool(message.finished);
+        /* string winner_id = 5; */
+        if (message.winnerId !== "")
+            writer.tag(5, WireType.LengthDelimited).string(message.winnerId);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TournamentTable
+ */
+export const TournamentTable = new TournamentTable$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TournamentSeat$Type extends MessageType<TournamentSeat> {
+    constructor() {
+        super("catan.v1.TournamentSeat", [
+            { no: 1, name: "entrant_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "player_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "points", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TournamentSeat>): TournamentSeat {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.entrantId = "";
+        message.playerId = "";
+        message.points = 0;
+        if (value !== undefined)
+            reflectionMergePartial<TournamentSeat>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TournamentSeat): TournamentSeat {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string entrant_id */ 1:
+                    message.entrantId = reader.string();
+                    break;
+                case /* string player_id */ 2:
+                    message.playerId = reader.string();
+                    break;
+                case /* int32 points */ 3:
+                    message.points = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TournamentSeat, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string entrant_id = 1; */
+        if (message.entrantId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.entrantId);
+        /* string player_id = 2; */
+        if (message.playerId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.playerId);
+        /* int32 points = 3; */
+        if (message.points !== 0)
+            writer.tag(3, WireType.Varint).int32(message.points);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TournamentSeat
+ */
+export const TournamentSeat = new TournamentSeat$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class TournamentStanding$Type extends MessageType<TournamentStanding> {
+    constructor() {
+        super("catan.v1.TournamentStanding", [
+            { no: 1, name: "entrant_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "rank", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 3, name: "points", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 4, name: "wins", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 5, name: "games", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<TournamentStanding>): TournamentStanding {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.entrantId = "";
+        message.rank = 0;
+        message.points = 0;
+        message.wins = 0;
+        message.games = 0;
+        if (value !== undefined)
+            reflectionMergePartial<TournamentStanding>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: TournamentStanding): TournamentStanding {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string entrant_id */ 1:
+                    message.entrantId = reader.string();
+                    break;
+                case /* int32 rank */ 2:
+                    message.rank = reader.int32();
+                    break;
+                case /* int32 points */ 3:
+                    message.points = reader.int32();
+                    break;
+                case /* int32 wins */ 4:
+                    message.wins = reader.int32();
+                    break;
+                case /* int32 games */ 5:
+                    message.games = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: TournamentStanding, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string entrant_id = 1; */
+        if (message.entrantId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.entrantId);
+        /* int32 rank = 2; */
+        if (message.rank !== 0)
+            writer.tag(2, WireType.Varint).int32(message.rank);
+        /* int32 points = 3; */
+        if (message.points !== 0)
+            writer.tag(3, WireType.Varint).int32(message.points);
+        /* int32 wins = 4; */
+        if (message.wins !== 0)
+            writer.tag(4, WireType.Varint).int32(message.wins);
+        /* int32 games = 5; */
+        if (message.games !== 0)
+            writer.tag(5, WireType.Varint).int32(message.games);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.TournamentStanding
+ */
+export const TournamentStanding = new TournamentStanding$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class CreateTournamentResponse$Type extends MessageType<CreateTournamentResponse> {
+    constructor() {
+        super("catan.v1.CreateTournamentResponse", [
+            { no: 1, name: "tournament", kind: "message", T: () => Tournament },
+            { no: 2, name: "organizer_token", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<CreateTournamentResponse>): CreateTournamentResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.organizerToken = "";
+        if (value !== undefined)
+            reflectionMergePartial<CreateTournamentResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: CreateTournamentResponse): CreateTournamentResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* catan.v1.Tournament tournament */ 1:
+                    message.tournament = Tournament.internalBinaryRead(reader, reader.uint32(), options, message.tournament);
+                    break;
+                case /* string organizer_token */ 2:
+                    message.organizerToken = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: CreateTournamentResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* catan.v1.Tournament tournament = 1; */
+        if (message.tournament)
+            Tournament.internalBinaryWrite(message.tournament, writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        /* string organizer_token = 2; */
+        if (message.organizerToken !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.organizerToken);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.CreateTournamentResponse
+ */
+export const CreateTournamentResponse = new CreateTournamentResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class RegisterTournamentResponse$Type extends MessageType<RegisterTournamentResponse> {
+    constructor() {
+        super("catan.v1.RegisterTournamentResponse", [
+            { no: 1, name: "entrant_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "token", kind: "scalar", T: 9 /*ScalarType.STRING*/ }
+        ]);
+    }
+    create(value?: PartialMessage<RegisterTournamentResponse>): RegisterTournamentResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.entrantId = "";
+        message.token = "";
+        if (value !== undefined)
+            reflectionMergePartial<RegisterTournamentResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: RegisterTournamentResponse): RegisterTournamentResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string entrant_id */ 1:
+                    message.entrantId = reader.string();
+                    break;
+                case /* string token */ 2:
+                    message.token = reader.string();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: RegisterTournamentResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string entrant_id = 1; */
+        if (message.entrantId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.entrantId);
+        /* string token = 2; */
+        if (message.token !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.token);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.RegisterTournamentResponse
+ */
+export const RegisterTournamentResponse = new RegisterTournamentResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class ListTournamentsResponse$Type extends MessageType<ListTournamentsResponse> {
+    constructor() {
+        super("catan.v1.ListTournamentsResponse", [
+            { no: 1, name: "tournaments", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => Tournament }
+        ]);
+    }
+    create(value?: PartialMessage<ListTournamentsResponse>): ListTournamentsResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.tournaments = [];
+        if (value !== undefined)
+            reflectionMergePartial<ListTournamentsResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: ListTournamentsResponse): ListTournamentsResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* repeated catan.v1.Tournament tournaments */ 1:
+                    message.tournaments.push(Tournament.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: ListTournamentsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* repeated catan.v1.Tournament tournaments = 1; */
+        for (let i = 0; i < message.tournaments.length; i++)
+            Tournament.internalBinaryWrite(message.tournaments[i], writer.tag(1, WireType.LengthDelimited).fork(), options).join();
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.ListTournamentsResponse
+ */
+export const ListTournamentsResponse = new ListTournamentsResponse$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class Correspondence$Type extends MessageType<Correspondence> {
+    constructor() {
+        super("catan.v1.Correspondence", [
+            { no: 1, name: "turn_hours", kind: "scalar", T: 5 /*ScalarType.INT32*/ },
+            { no: 2, name: "turn_deadline", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ },
+            { no: 3, name: "overdue_notified", kind: "scalar", T: 8 /*ScalarType.BOOL*/ }
+        ]);
+    }
+    create(value?: PartialMessage<Correspondence>): Correspondence {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.turnHours = 0;
+        message.turnDeadline = 0n;
+        message.overdueNotified = false;
+        if (value !== undefined)
+            reflectionMergePartial<Correspondence>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: Correspondence): Correspondence {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* int32 turn_hours */ 1:
+                    message.turnHours = reader.int32();
+                    break;
+                case /* int64 turn_deadline */ 2:
+                    message.turnDeadline = reader.int64().toBigInt();
+                    break;
+                case /* bool overdue_notified */ 3:
+                    message.overdueNotified = reader.bool();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: Correspondence, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* int32 turn_hours = 1; */
+        if (message.turnHours !== 0)
+            writer.tag(1, WireType.Varint).int32(message.turnHours);
+        /* int64 turn_deadline = 2; */
+        if (message.turnDeadline !== 0n)
+            writer.tag(2, WireType.Varint).int64(message.turnDeadline);
+        /* bool overdue_notified = 3; */
+        if (message.overdueNotified !== false)
+            writer.tag(3, WireType.Varint).bool(message.overdueNotified);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.Correspondence
+ */
+export const Correspondence = new Correspondence$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class PendingAction$Type extends MessageType<PendingAction> {
+    constructor() {
+        super("catan.v1.PendingAction", [
+            { no: 1, name: "kind", kind: "enum", T: () => ["catan.v1.PendingActionKind", PendingActionKind, "PENDING_ACTION_KIND_"] },
+            { no: 2, name: "trade_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "discard_count", kind: "scalar", T: 5 /*ScalarType.INT32*/ }
+        ]);
+    }
+    create(value?: PartialMessage<PendingAction>): PendingAction {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.kind = 0;
+        message.tradeId = "";
+        message.discardCount = 0;
+        if (value !== undefined)
+            reflectionMergePartial<PendingAction>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: PendingAction): PendingAction {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* catan.v1.PendingActionKind kind */ 1:
+                    message.kind = reader.int32();
+                    break;
+                case /* string trade_id */ 2:
+                    message.tradeId = reader.string();
+                    break;
+                case /* int32 discard_count */ 3:
+                    message.discardCount = reader.int32();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: PendingAction, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* catan.v1.PendingActionKind kind = 1; */
+        if (message.kind !== 0)
+            writer.tag(1, WireType.Varint).int32(message.kind);
+        /* string trade_id = 2; */
+        if (message.tradeId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.tradeId);
+        /* int32 discard_count = 3; */
+        if (message.discardCount !== 0)
+            writer.tag(3, WireType.Varint).int32(message.discardCount);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.PendingAction
+ */
+export const PendingAction = new PendingAction$Type();
+// @generated message type with reflection information, may provide speed optimized methods
+class PendingActionsResponse$Type extends MessageType<PendingActionsResponse> {
+    constructor() {
+        super("catan.v1.PendingActionsResponse", [
+            { no: 1, name: "game_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 2, name: "player_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 3, name: "status", kind: "enum", T: () => ["catan.v1.GameStatus", GameStatus, "GAME_STATUS_"] },
+            { no: 4, name: "current_player_id", kind: "scalar", T: 9 /*ScalarType.STRING*/ },
+            { no: 5, name: "actions", kind: "message", repeat: 2 /*RepeatType.UNPACKED*/, T: () => PendingAction },
+            { no: 6, name: "turn_deadline", kind: "scalar", T: 3 /*ScalarType.INT64*/, L: 0 /*LongType.BIGINT*/ }
+        ]);
+    }
+    create(value?: PartialMessage<PendingActionsResponse>): PendingActionsResponse {
+        const message = globalThis.Object.create((this.messagePrototype!));
+        message.gameId = "";
+        message.playerId = "";
+        message.status = 0;
+        message.currentPlayerId = "";
+        message.actions = [];
+        message.turnDeadline = 0n;
+        if (value !== undefined)
+            reflectionMergePartial<PendingActionsResponse>(this, message, value);
+        return message;
+    }
+    internalBinaryRead(reader: IBinaryReader, length: number, options: BinaryReadOptions, target?: PendingActionsResponse): PendingActionsResponse {
+        let message = target ?? this.create(), end = reader.pos + length;
+        while (reader.pos < end) {
+            let [fieldNo, wireType] = reader.tag();
+            switch (fieldNo) {
+                case /* string game_id */ 1:
+                    message.gameId = reader.string();
+                    break;
+                case /* string player_id */ 2:
+                    message.playerId = reader.string();
+                    break;
+                case /* catan.v1.GameStatus status */ 3:
+                    message.status = reader.int32();
+                    break;
+                case /* string current_player_id */ 4:
+                    message.currentPlayerId = reader.string();
+                    break;
+                case /* repeated catan.v1.PendingAction actions */ 5:
+                    message.actions.push(PendingAction.internalBinaryRead(reader, reader.uint32(), options));
+                    break;
+                case /* int64 turn_deadline */ 6:
+                    message.turnDeadline = reader.int64().toBigInt();
+                    break;
+                default:
+                    let u = options.readUnknownField;
+                    if (u === "throw")
+                        throw new globalThis.Error(`Unknown field ${fieldNo} (wire type ${wireType}) for ${this.typeName}`);
+                    let d = reader.skip(wireType);
+                    if (u !== false)
+                        (u === true ? UnknownFieldHandler.onRead : u)(this.typeName, message, fieldNo, wireType, d);
+            }
+        }
+        return message;
+    }
+    internalBinaryWrite(message: PendingActionsResponse, writer: IBinaryWriter, options: BinaryWriteOptions): IBinaryWriter {
+        /* string game_id = 1; */
+        if (message.gameId !== "")
+            writer.tag(1, WireType.LengthDelimited).string(message.gameId);
+        /* string player_id = 2; */
+        if (message.playerId !== "")
+            writer.tag(2, WireType.LengthDelimited).string(message.playerId);
+        /* catan.v1.GameStatus status = 3; */
+        if (message.status !== 0)
+            writer.tag(3, WireType.Varint).int32(message.status);
+        /* string current_player_id = 4; */
+        if (message.currentPlayerId !== "")
+            writer.tag(4, WireType.LengthDelimited).string(message.currentPlayerId);
+        /* repeated catan.v1.PendingAction actions = 5; */
+        for (let i = 0; i < message.actions.length; i++)
+            PendingAction.internalBinaryWrite(message.actions[i], writer.tag(5, WireType.LengthDelimited).fork(), options).join();
+        /* int64 turn_deadline = 6; */
+        if (message.turnDeadline !== 0n)
+            writer.tag(6, WireType.Varint).int64(message.turnDeadline);
+        let u = options.writeUnknownFields;
+        if (u !== false)
+            (u == true ? UnknownFieldHandler.onWrite : u)(this.typeName, message, writer);
+        return writer;
+    }
+}
+/**
+ * @generated MessageType for protobuf message catan.v1.PendingActionsResponse
+ */
+export const PendingActionsResponse = new PendingActionsResponse$Type();